@@ -748,6 +748,68 @@ const docTemplate = `{
                 ]
             }
         },
+        "/admin/compare": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Run one prompt against several models side by side",
+                "parameters": [
+                    {
+                        "description": "Prompt and models to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.compareModelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/admin.compareModelsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
         "/admin/failover": {
             "get": {
                 "produces": [
@@ -1466,6 +1528,12 @@ const docTemplate = `{
                         "description": "Filter by model category",
                         "name": "category",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by provider name or type",
+                        "name": "provider",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1531,6 +1599,109 @@ const docTemplate = `{
                 ]
             }
         },
+        "/admin/providers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List registered providers with their live circuit state",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/admin.providerListItemResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/providers/{name}/reset-circuit": {
+            "post": {
+                "description": "Closes the named provider's circuit breaker immediately, instead of waiting for the open timeout and half-open probes. Use it when the provider is known to have recovered. Requires the master key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Force-close a provider's circuit breaker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Configured provider name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/admin.providerCircuitResetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/core.GatewayError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
         "/admin/rate-limits": {
             "get": {
                 "produces": [
@@ -5813,6 +5984,26 @@ const docTemplate = `{
                     }
                 ]
             }
+        },
+        "/version": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Build information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -6031,6 +6222,63 @@ const docTemplate = `{
                 }
             }
         },
+        "admin.compareModelResult": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/core.Usage"
+                }
+            }
+        },
+        "admin.compareModelsRequest": {
+            "type": "object",
+            "properties": {
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "system": {
+                    "type": "string"
+                },
+                "temperature": {
+                    "type": "number"
+                }
+            }
+        },
+        "admin.compareModelsResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.compareModelResult"
+                    }
+                }
+            }
+        },
         "admin.deleteBudgetRequest": {
             "type": "object",
             "properties": {
@@ -6205,6 +6453,41 @@ const docTemplate = `{
                 },
                 "selector": {
                     "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "unqualified_owner": {
+                    "description": "UnqualifiedOwner reports whether this provider serves the bare model ID\nafter first-provider-wins dedup.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "admin.providerCircuitResetResponse": {
+            "type": "object",
+            "properties": {
+                "circuit_state": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "admin.providerListItemResponse": {
+            "type": "object",
+            "properties": {
+                "circuit_state": {
+                    "type": "string"
+                },
+                "model_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
@@ -9437,6 +9720,9 @@ const docTemplate = `{
         "usage.RequestUsageSummary": {
             "type": "object",
             "properties": {
+                "cache_type": {
+                    "type": "string"
+                },
                 "cache_write_input_tokens": {
                     "type": "integer"
                 },
@@ -9452,6 +9738,9 @@ const docTemplate = `{
                 "estimated_cached_characters": {
                     "type": "integer"
                 },
+                "estimated_cost_usd": {
+                    "type": "number"
+                },
                 "input_tokens": {
                     "type": "integer"
                 },
@@ -9464,6 +9753,9 @@ const docTemplate = `{
                 "rewrite_tokens_saved": {
                     "type": "integer"
                 },
+                "served_from_cache": {
+                    "type": "boolean"
+                },
                 "total_tokens": {
                     "type": "integer"
                 },
@@ -9876,7 +10168,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{"http"},
 	Title:            "GoModel API",
-	Description:      "AI gateway routing requests to multiple LLM providers (OpenAI, Anthropic, Gemini, Groq, Fireworks AI, Meta, OpenRouter, Kilo AI, DeepSeek, Z.ai, xAI, MiniMax, Xiaomi MiMo, OpenCode Go, Oracle, Ollama, Bailian). Drop-in OpenAI-compatible API.",
+	Description:      "AI gateway routing requests to multiple LLM providers (OpenAI, Anthropic, Gemini, Groq, Mistral, Fireworks AI, Meta, OpenRouter, Kilo AI, DeepSeek, Z.ai, xAI, MiniMax, Xiaomi MiMo, OpenCode Go, Oracle, Ollama, Bailian). Drop-in OpenAI-compatible API.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
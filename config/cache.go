@@ -15,21 +15,47 @@ type CacheConfig struct {
 }
 
 // ModelCacheConfig holds cache configuration for model registry.
-// Exactly one of Local or Redis must be non-nil.
+// Exactly one of Local, Redis, Memory, or Memcached must be non-nil.
 type ModelCacheConfig struct {
 	RefreshInterval int `yaml:"refresh_interval" env:"CACHE_REFRESH_INTERVAL"`
 	// RecheckInterval is how often (seconds) providers whose latest refresh
 	// failed are re-checked, so outage recovery is detected without waiting
 	// for the next full refresh. Zero or negative disables the fast recheck.
-	RecheckInterval int               `yaml:"recheck_interval" env:"PROVIDER_RECHECK_INTERVAL"`
-	ModelList       ModelListConfig   `yaml:"model_list"`
-	Local           *LocalCacheConfig `yaml:"local"`
-	Redis           *RedisModelConfig `yaml:"redis"`
+	RecheckInterval int `yaml:"recheck_interval" env:"PROVIDER_RECHECK_INTERVAL"`
+	// FallbackToLocal makes startup degrade to the local file cache, with a
+	// warning, when the configured Redis or Memcached cannot be reached. When
+	// false an unreachable backend fails startup. The exact response cache has
+	// its own switch, SimpleCacheConfig.FallbackToMemory.
+	FallbackToLocal bool                  `yaml:"fallback_to_local" env:"CACHE_FALLBACK_TO_LOCAL"`
+	ModelList       ModelListConfig       `yaml:"model_list"`
+	Local           *LocalCacheConfig     `yaml:"local"`
+	Redis           *RedisModelConfig     `yaml:"redis"`
+	Memory          *MemoryCacheConfig    `yaml:"memory"`
+	Memcached       *MemcachedModelConfig `yaml:"memcached"`
+}
+
+// MemoryCacheConfig holds in-process model cache configuration. The memory
+// backend never writes to disk, for read-only or ephemeral deployments.
+type MemoryCacheConfig struct {
+	// TTL expires the cached model list this many seconds after it was
+	// stored. 0 keeps it for the life of the process.
+	TTL int `yaml:"ttl"`
 }
 
 // LocalCacheConfig holds local file cache configuration.
 type LocalCacheConfig struct {
 	CacheDir string `yaml:"cache_dir" env:"GOMODEL_CACHE_DIR"`
+	// MaxAge is the oldest cache file, in seconds since its updated_at, that
+	// is still served at startup. Zero (default) loads the file at any age.
+	MaxAge int `yaml:"max_age" env:"GOMODEL_CACHE_MAX_AGE"`
+}
+
+// MemcachedModelConfig holds Memcached configuration for the model registry
+// cache. It mirrors RedisModelConfig with a server list in place of a URL.
+type MemcachedModelConfig struct {
+	Servers []string `yaml:"servers" env:"MEMCACHED_SERVERS"`
+	Key     string   `yaml:"key" env:"MEMCACHED_KEY_MODELS"`
+	TTL     int      `yaml:"ttl" env:"MEMCACHED_TTL_MODELS"`
 }
 
 // ModelListConfig holds configuration for fetching the external model metadata registry.
@@ -61,15 +87,40 @@ type RedisResponseConfig struct {
 type ResponseCacheConfig struct {
 	Simple   *SimpleCacheConfig   `yaml:"simple"`
 	Semantic *SemanticCacheConfig `yaml:"semantic"`
+	// DeterministicOnly limits response caching to requests whose output is
+	// reproducible: chat and Responses requests are cached only when they do
+	// not stream and set temperature to 0. Embeddings are always cached. It
+	// gates the exact and semantic layers alike.
+	DeterministicOnly bool `yaml:"deterministic_only"`
 }
 
 // SimpleCacheConfig holds configuration for exact-match response caching.
 // When the simple block is omitted from config.yaml, this layer stays off unless
 // RESPONSE_CACHE_SIMPLE_ENABLED=true is set (e.g. Helm without a response-cache YAML fragment).
 // Omitted enabled (nil) means true whenever the simple block exists.
+// Responses are stored in Redis, or in process memory when Memory is set.
 type SimpleCacheConfig struct {
-	Enabled *bool                `yaml:"enabled"`
-	Redis   *RedisResponseConfig `yaml:"redis"`
+	Enabled *bool                 `yaml:"enabled"`
+	Redis   *RedisResponseConfig  `yaml:"redis"`
+	Memory  *MemoryResponseConfig `yaml:"memory"`
+	// FallbackToMemory keeps the exact cache running in process memory, with
+	// a warning, when its Redis cannot be reached at startup. It is separate
+	// from cache.model.fallback_to_local because a per-process response cache
+	// splits hits across replicas, a different trade-off from a local model
+	// list.
+	// Omitted (nil) means true; false fails startup instead.
+	FallbackToMemory *bool `yaml:"fallback_to_memory"`
+}
+
+// MemoryResponseConfig holds in-process exact response cache configuration.
+// Entries are private to one gateway process and lost on restart.
+type MemoryResponseConfig struct {
+	// TTL expires a cached response this many seconds after it was stored.
+	// 0 uses the default of one hour.
+	TTL int `yaml:"ttl"`
+	// MaxEntries bounds the cache; the least recently used response is
+	// evicted first. 0 uses the default of 1000.
+	MaxEntries int `yaml:"max_entries"`
 }
 
 // SemanticCacheConfig holds configuration for the semantic (vector-similarity) response cache.
@@ -135,9 +186,9 @@ type WeaviateConfig struct {
 }
 
 // ValidateCacheConfig validates the cache configuration in c.
-// For the model cache, exactly one backend (Local or Redis) must be configured;
-// having both or neither is an error. When Redis is selected, its URL must be
-// non-empty. Returns a descriptive error if any constraint is violated, or nil
+// For the model cache, exactly one backend (Local, Redis, Memory, or
+// Memcached) must be configured; having several or none is an error. When
+// Redis is selected, its URL must be non-empty; Memcached needs a server. Returns a descriptive error if any constraint is violated, or nil
 // if the configuration is valid.
 func ValidateCacheConfig(c *CacheConfig) error {
 	if c == nil {
@@ -146,17 +197,49 @@ func ValidateCacheConfig(c *CacheConfig) error {
 	m := &c.Model
 	hasLocal := m.Local != nil
 	hasRedis := m.Redis != nil
+	hasMemory := m.Memory != nil
+	hasMemcached := m.Memcached != nil
 
 	if hasLocal && hasRedis {
 		return fmt.Errorf("cache.model: cannot have both local and redis configured; choose one")
 	}
-	if !hasLocal && !hasRedis {
-		return fmt.Errorf("cache.model: must have either local or redis configured")
+	backends := 0
+	for _, has := range []bool{hasLocal, hasRedis, hasMemory, hasMemcached} {
+		if has {
+			backends++
+		}
+	}
+	if backends > 1 {
+		return fmt.Errorf("cache.model: configure only one of local, redis, memory, or memcached")
+	}
+	if backends == 0 {
+		return fmt.Errorf("cache.model: must have one of local, redis, memory, or memcached configured")
+	}
+	if hasMemcached && len(m.Memcached.Servers) == 0 {
+		return fmt.Errorf("cache.model.memcached: servers is required when using memcached")
+	}
+	if hasMemory && m.Memory.TTL < 0 {
+		return fmt.Errorf("cache.model.memory: ttl must be >= 0, got %d", m.Memory.TTL)
+	}
+	if hasLocal && m.Local.MaxAge < 0 {
+		return fmt.Errorf("cache.model.local: max_age must be >= 0, got %d", m.Local.MaxAge)
 	}
 	if hasRedis && m.Redis.URL == "" {
 		return fmt.Errorf("cache.model.redis: URL is required when using redis")
 	}
 
+	if simple := c.Response.Simple; simple != nil && SimpleCacheEnabled(simple) && simple.Memory != nil {
+		if simple.Redis != nil {
+			return fmt.Errorf("cache.response.simple: configure only one of redis or memory")
+		}
+		if simple.Memory.TTL < 0 {
+			return fmt.Errorf("cache.response.simple.memory: ttl must be >= 0, got %d", simple.Memory.TTL)
+		}
+		if simple.Memory.MaxEntries < 0 {
+			return fmt.Errorf("cache.response.simple.memory: max_entries must be >= 0, got %d", simple.Memory.MaxEntries)
+		}
+	}
+
 	sem := c.Response.Semantic
 	if sem != nil && SemanticCacheActive(sem) {
 		vsType := strings.TrimSpace(sem.VectorStore.Type)
@@ -221,6 +304,12 @@ func ValidateCacheConfig(c *CacheConfig) error {
 	return nil
 }
 
+// SimpleCacheFallbackToMemory reports whether an unreachable exact-cache Redis
+// should fall back to process memory. Omitted fallback_to_memory means true.
+func SimpleCacheFallbackToMemory(s *SimpleCacheConfig) bool {
+	return s == nil || s.FallbackToMemory == nil || *s.FallbackToMemory
+}
+
 // SimpleCacheEnabled reports whether the exact-match response cache layer is
 // allowed to run for a non-nil simple config. Omitted enabled means true.
 func SimpleCacheEnabled(s *SimpleCacheConfig) bool {
@@ -279,6 +368,15 @@ func applyResponseSimpleEnv(resp *ResponseCacheConfig) error {
 		b := parseBool(v)
 		simple.Enabled = &b
 	}
+	if v, ok := os.LookupEnv("RESPONSE_CACHE_FALLBACK_TO_MEMORY"); ok {
+		b := parseBool(v)
+		simple.FallbackToMemory = &b
+	}
+	// A memory-backed cache ignores the shared Redis env vars, which may be
+	// set for the model cache.
+	if simple.Memory != nil {
+		return nil
+	}
 	if u := os.Getenv("REDIS_URL"); u != "" {
 		if simple.Redis == nil {
 			simple.Redis = &RedisResponseConfig{}
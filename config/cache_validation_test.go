@@ -32,7 +32,7 @@ func TestValidateCacheConfig_NeitherLocalNorRedis(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error when neither local nor redis configured")
 	}
-	if err.Error() != "cache.model: must have either local or redis configured" {
+	if err.Error() != "cache.model: must have one of local, redis, memory, or memcached configured" {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
@@ -79,6 +79,54 @@ func TestValidateCacheConfig_RedisOnly(t *testing.T) {
 	}
 }
 
+func TestValidateCacheConfig_ResponseSimpleMemory(t *testing.T) {
+	model := ModelCacheConfig{Local: &LocalCacheConfig{CacheDir: ".cache"}}
+	tests := []struct {
+		name    string
+		simple  *SimpleCacheConfig
+		wantErr string
+	}{
+		{
+			name:   "memory only",
+			simple: &SimpleCacheConfig{Memory: &MemoryResponseConfig{TTL: 60, MaxEntries: 100}},
+		},
+		{
+			name: "memory and redis",
+			simple: &SimpleCacheConfig{
+				Memory: &MemoryResponseConfig{},
+				Redis:  &RedisResponseConfig{URL: "redis://localhost:6379"},
+			},
+			wantErr: "cache.response.simple: configure only one of redis or memory",
+		},
+		{
+			name:    "negative max_entries",
+			simple:  &SimpleCacheConfig{Memory: &MemoryResponseConfig{MaxEntries: -1}},
+			wantErr: "cache.response.simple.memory: max_entries must be >= 0, got -1",
+		},
+		{
+			name: "disabled block is not validated",
+			simple: &SimpleCacheConfig{
+				Enabled: new(false),
+				Memory:  &MemoryResponseConfig{TTL: -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCacheConfig(&CacheConfig{Model: model, Response: ResponseCacheConfig{Simple: tt.simple}})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateCacheConfig_SemanticDisabledIgnoresInvalidVectorStore(t *testing.T) {
 	cfg := &CacheConfig{
 		Model: ModelCacheConfig{
@@ -281,3 +329,72 @@ func TestValidateCacheConfig_SemanticNegativeTTL(t *testing.T) {
 		t.Fatalf("expected ttl in error: %v", err)
 	}
 }
+
+func TestValidateCacheConfig_MemoryAndMemcached(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   ModelCacheConfig
+		wantErr string
+	}{
+		{
+			name:  "memory only",
+			model: ModelCacheConfig{Memory: &MemoryCacheConfig{TTL: 300}},
+		},
+		{
+			name:    "memory with local",
+			model:   ModelCacheConfig{Memory: &MemoryCacheConfig{}, Local: &LocalCacheConfig{}},
+			wantErr: "cache.model: configure only one of local, redis, memory, or memcached",
+		},
+		{
+			name:    "memory with redis",
+			model:   ModelCacheConfig{Memory: &MemoryCacheConfig{}, Redis: &RedisModelConfig{URL: "redis://localhost:6379"}},
+			wantErr: "cache.model: configure only one of local, redis, memory, or memcached",
+		},
+		{
+			name:    "memory with memcached",
+			model:   ModelCacheConfig{Memory: &MemoryCacheConfig{}, Memcached: &MemcachedModelConfig{Servers: []string{"localhost:11211"}}},
+			wantErr: "cache.model: configure only one of local, redis, memory, or memcached",
+		},
+		{
+			name:  "memcached only",
+			model: ModelCacheConfig{Memcached: &MemcachedModelConfig{Servers: []string{"localhost:11211"}}},
+		},
+		{
+			name:    "memcached without servers",
+			model:   ModelCacheConfig{Memcached: &MemcachedModelConfig{}},
+			wantErr: "cache.model.memcached: servers is required when using memcached",
+		},
+		{
+			name:    "negative ttl",
+			model:   ModelCacheConfig{Memory: &MemoryCacheConfig{TTL: -1}},
+			wantErr: "cache.model.memory: ttl must be >= 0, got -1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCacheConfig(&CacheConfig{Model: tt.model})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCacheConfig_LocalMaxAge(t *testing.T) {
+	valid := &CacheConfig{Model: ModelCacheConfig{Local: &LocalCacheConfig{MaxAge: 86400}}}
+	if err := ValidateCacheConfig(valid); err != nil {
+		t.Fatalf("ValidateCacheConfig(max_age=86400) = %v, want nil", err)
+	}
+
+	negative := &CacheConfig{Model: ModelCacheConfig{Local: &LocalCacheConfig{MaxAge: -1}}}
+	err := ValidateCacheConfig(negative)
+	if err == nil || err.Error() != "cache.model.local: max_age must be >= 0, got -1" {
+		t.Fatalf("ValidateCacheConfig(max_age=-1) = %v, want max_age error", err)
+	}
+}
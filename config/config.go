@@ -37,10 +37,19 @@ type Config struct {
 	Resilience ResilienceConfig `yaml:"resilience"`
 	Tagging    TaggingConfig    `yaml:"tagging"`
 	MCP        MCPConfig        `yaml:"mcp"`
+	Sampling   SamplingConfig   `yaml:"sampling"`
+	Routing    RoutingConfig    `yaml:"routing"`
+
+	// OutputPacing paces streamed output to a tokens-per-second rate.
+	OutputPacing OutputPacingConfig `yaml:"output_pacing"`
 
 	// VirtualModels declares redirects, load balancers, and access policies as
 	// infrastructure-as-code. They override admin-store rows of the same source.
 	VirtualModels []VirtualModelConfig `yaml:"virtual_models"`
+
+	// Transformers rewrite chat and Responses requests before routing, in the
+	// order listed.
+	Transformers []TransformerConfig `yaml:"transformers"`
 }
 
 // LoadResult is returned by Load and bundles the application config with the raw
@@ -57,12 +66,16 @@ func buildDefaultConfig() *Config {
 		Server: ServerConfig{
 			Port:                    "8080",
 			BasePath:                "/",
-			UserPathHeader:          "X-GoModel-User-Path",
+			UserPathHeader:          userPathHeaderDefault,
 			SwaggerEnabled:          false,
 			PprofEnabled:            false,
 			EnablePassthroughRoutes: true,
 			AllowPassthroughV1Alias: true,
 			RealtimeEnabled:         true,
+			ResponsesAPIEnabled:     true,
+			HealthStatusCode:        200,
+			MaxToolsPerRequest:      512,
+			ModelNotFoundStatus:     400,
 			EnabledPassthroughProviders: []string{
 				"openai",
 				"anthropic",
@@ -77,11 +90,15 @@ func buildDefaultConfig() *Config {
 			EnabledByDefault:                true,
 			KeepOnlyAliasesAtModelsEndpoint: false,
 			ConfiguredProviderModelsMode:    ConfiguredProviderModelsModeFallback,
+			ListSortOrder:                   ModelListSortByID,
+			DuplicateModelPolicy:            DuplicateModelPolicyFirstWins,
+			RefreshConcurrency:              8,
 		},
 		Cache: CacheConfig{
 			Model: ModelCacheConfig{
 				RefreshInterval: 3600,
 				RecheckInterval: 60,
+				FallbackToLocal: true,
 				ModelList: ModelListConfig{
 					URL: "https://raw.githubusercontent.com/ENTERPILOT/ai-model-list/refs/heads/main/models.min.json",
 				},
@@ -139,6 +156,8 @@ func buildDefaultConfig() *Config {
 		Resilience: ResilienceConfig{
 			Retry:          DefaultRetryConfig(),
 			CircuitBreaker: DefaultCircuitBreakerConfig(),
+			Queue:          DefaultQueueConfig(),
+			TokenPacing:    DefaultTokenPacingConfig(),
 		},
 		Admin: AdminConfig{
 			EndpointsEnabled:         true,
@@ -152,6 +171,12 @@ func buildDefaultConfig() *Config {
 		MCP: MCPConfig{
 			Enabled: true,
 		},
+		Sampling: SamplingConfig{
+			MinTemperature: -1,
+			MaxTemperature: -1,
+			MinTopP:        -1,
+			MaxTopP:        -1,
+		},
 	}
 }
 
@@ -214,14 +239,19 @@ func Load() (*LoadResult, error) {
 	if err := validateRateLimitConfig(&cfg.RateLimits); err != nil {
 		return nil, err
 	}
+	// Values are canonicalized here and checked by Validate, so every
+	// problem is reported together. Invalid values are left as written.
 	cfg.Server.BasePath = NormalizeBasePath(cfg.Server.BasePath)
-	cfg.Server.UserPathHeader, err = NormalizeHeaderName(cfg.Server.UserPathHeader, "X-GoModel-User-Path")
-	if err != nil {
-		return nil, fmt.Errorf("invalid server.user_path_header: %w", err)
+	if header, err := NormalizeHeaderName(cfg.Server.UserPathHeader, userPathHeaderDefault); err == nil {
+		cfg.Server.UserPathHeader = header
 	}
 	cfg.Models.ConfiguredProviderModelsMode = ResolveConfiguredProviderModelsMode(cfg.Models.ConfiguredProviderModelsMode)
-	if !cfg.Models.ConfiguredProviderModelsMode.Valid() {
-		return nil, fmt.Errorf("models.configured_provider_models_mode must be one of: fallback, allowlist")
+	cfg.Models.ListSortOrder = ResolveModelListSortOrder(cfg.Models.ListSortOrder)
+	cfg.Models.DuplicateModelPolicy = ResolveDuplicateModelPolicy(cfg.Models.DuplicateModelPolicy)
+	// An unset strategy stays empty so validation can tell it from an
+	// explicit one.
+	if cfg.Routing.Strategy != "" {
+		cfg.Routing.Strategy = ResolveRoutingStrategy(cfg.Routing.Strategy)
 	}
 
 	if err := loadFailoverConfig(&cfg.Failover); err != nil {
@@ -229,17 +259,11 @@ func Load() (*LoadResult, error) {
 	}
 
 	// When no model cache backend was specified at all, default to local.
-	if cfg.Cache.Model.Local == nil && cfg.Cache.Model.Redis == nil {
+	if cfg.Cache.Model.Local == nil && cfg.Cache.Model.Redis == nil && cfg.Cache.Model.Memory == nil && cfg.Cache.Model.Memcached == nil {
 		cfg.Cache.Model.Local = &LocalCacheConfig{}
 	}
 
-	if cfg.Server.BodySizeLimit != "" {
-		if err := ValidateBodySizeLimit(cfg.Server.BodySizeLimit); err != nil {
-			return nil, fmt.Errorf("invalid BODY_SIZE_LIMIT: %w", err)
-		}
-	}
-
-	if err := ValidateCacheConfig(&cfg.Cache); err != nil {
+	if err := errors.Join(Validate(cfg), ValidateRoutingConfig(cfg.Routing, cfg.Models.DuplicateModelPolicy, rawProviders)); err != nil {
 		return nil, err
 	}
 
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -163,6 +165,60 @@ func TestExpandString(t *testing.T) {
 	}
 }
 
+func TestExpandString_FileSecrets(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "openai_key")
+	if err := os.WriteFile(secretPath, []byte("  sk-from-file\n"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	emptyPath := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyPath, []byte("\n"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	missingPath := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "present file is trimmed", input: "${file:" + secretPath + "}", expected: "sk-from-file"},
+		{name: "present file inside a string", input: "Bearer ${file:" + secretPath + "}", expected: "Bearer sk-from-file"},
+		{name: "missing file keeps placeholder", input: "${file:" + missingPath + "}", expected: "${file:" + missingPath + "}"},
+		{name: "missing file uses default", input: "${file:" + missingPath + ":-fallback}", expected: "fallback"},
+		{name: "present file wins over default", input: "${file:" + secretPath + ":-fallback}", expected: "sk-from-file"},
+		{name: "empty file uses default", input: "${file:" + emptyPath + ":-fallback}", expected: "fallback"},
+		{name: "empty file keeps placeholder", input: "${file:" + emptyPath + "}", expected: "${file:" + emptyPath + "}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandString(tt.input); got != tt.expected {
+				t.Errorf("expandString(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoad_ExpandsProviderSecretFromFile(t *testing.T) {
+	clearAllConfigEnvVars(t)
+	withTempDir(t, func(dir string) {
+		secretPath := filepath.Join(dir, "openai_key")
+		if err := os.WriteFile(secretPath, []byte("sk-mounted\n"), 0o600); err != nil {
+			t.Fatalf("write secret: %v", err)
+		}
+		yaml := "providers:\n  openai:\n    type: openai\n    api_key: ${file:" + secretPath + "}\n" +
+			"  anthropic:\n    type: anthropic\n    api_key: ${file:" + filepath.Join(dir, "missing") + "}\n"
+		if err := os.WriteFile("config.yaml", []byte(yaml), 0o600); err != nil {
+			t.Fatalf("write config.yaml: %v", err)
+		}
+
+		result, err := Load()
+		require.NoError(t, err)
+		require.Equal(t, "sk-mounted", result.RawProviders["openai"].APIKey)
+		require.Contains(t, result.RawProviders["anthropic"].APIKey, "${file:")
+	})
+}
+
 func TestNormalizeBasePath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -302,6 +358,47 @@ func TestApplyEnvOverrides(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "MAX_TOOLS_PER_REQUEST override",
+			envVars: map[string]string{"MAX_TOOLS_PER_REQUEST": "64"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.MaxToolsPerRequest != 64 {
+					t.Errorf("Server.MaxToolsPerRequest = %d, want 64", cfg.Server.MaxToolsPerRequest)
+				}
+			},
+		},
+		{
+			name:    "MODEL_NOT_FOUND_STATUS override",
+			envVars: map[string]string{"MODEL_NOT_FOUND_STATUS": "404"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.ModelNotFoundStatus != 404 {
+					t.Errorf("Server.ModelNotFoundStatus = %d, want 404", cfg.Server.ModelNotFoundStatus)
+				}
+			},
+		},
+		{
+			name:    "sampling policy overrides",
+			envVars: map[string]string{"SAMPLING_MAX_TEMPERATURE": "0.7", "SAMPLING_MAX_TOP_P": "0.9"},
+			check: func(t *testing.T, cfg *Config) {
+				want := SamplingConfig{MinTemperature: -1, MaxTemperature: 0.7, MinTopP: -1, MaxTopP: 0.9}
+				if cfg.Sampling != want {
+					t.Errorf("Sampling = %+v, want %+v", cfg.Sampling, want)
+				}
+				if !cfg.Sampling.Enabled() {
+					t.Error("Sampling.Enabled() should be true")
+				}
+			},
+		},
+		{
+			name:    "ALLOWED_UPSTREAM_HOSTS override",
+			envVars: map[string]string{"ALLOWED_UPSTREAM_HOSTS": "api.openai.com, *.internal.example"},
+			check: func(t *testing.T, cfg *Config) {
+				want := []string{"api.openai.com", "*.internal.example"}
+				if !reflect.DeepEqual(cfg.HTTP.AllowedUpstreamHosts, want) {
+					t.Errorf("HTTP.AllowedUpstreamHosts = %v, want %v", cfg.HTTP.AllowedUpstreamHosts, want)
+				}
+			},
+		},
 		{
 			name:    "CACHE_REFRESH_INTERVAL override",
 			envVars: map[string]string{"CACHE_REFRESH_INTERVAL": "1800"},
@@ -362,6 +459,24 @@ func TestApplyEnvOverrides(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "retry body error codes override",
+			envVars: map[string]string{"RETRY_BODY_ERROR_CODES": "overloaded_error, server_busy"},
+			check: func(t *testing.T, cfg *Config) {
+				if got, want := cfg.Resilience.Retry.BodyErrorCodes, []string{"overloaded_error", "server_busy"}; !reflect.DeepEqual(got, want) {
+					t.Errorf("BodyErrorCodes = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:    "retry max elapsed time override",
+			envVars: map[string]string{"RETRY_MAX_ELAPSED_TIME": "45s"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Resilience.Retry.MaxElapsedTime != 45*time.Second {
+					t.Errorf("MaxElapsedTime = %v, want 45s", cfg.Resilience.Retry.MaxElapsedTime)
+				}
+			},
+		},
 		{
 			name: "circuit breaker int overrides",
 			envVars: map[string]string{
@@ -386,6 +501,42 @@ func TestApplyEnvOverrides(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "responses API override",
+			envVars: map[string]string{"RESPONSES_API_ENABLED": "false"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.ResponsesAPIEnabled {
+					t.Error("ResponsesAPIEnabled = true, want false")
+				}
+			},
+		},
+		{
+			name:    "auth keys file override",
+			envVars: map[string]string{"AUTH_KEYS_FILE": "/etc/gomodel/keys.yaml"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.AuthKeysFile != "/etc/gomodel/keys.yaml" {
+					t.Errorf("AuthKeysFile = %q, want /etc/gomodel/keys.yaml", cfg.Server.AuthKeysFile)
+				}
+			},
+		},
+		{
+			name:    "lenient request shape override",
+			envVars: map[string]string{"LENIENT_REQUEST_SHAPE": "true"},
+			check: func(t *testing.T, cfg *Config) {
+				if !cfg.Server.LenientRequestShape {
+					t.Error("LenientRequestShape = false, want true")
+				}
+			},
+		},
+		{
+			name:    "output pacing rate override",
+			envVars: map[string]string{"OUTPUT_PACING_TOKENS_PER_SECOND": "12.5"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.OutputPacing.TokensPerSecond != 12.5 {
+					t.Errorf("OutputPacing.TokensPerSecond = %g, want 12.5", cfg.OutputPacing.TokensPerSecond)
+				}
+			},
+		},
 		{
 			name:    "circuit breaker timeout override",
 			envVars: map[string]string{"CIRCUIT_BREAKER_TIMEOUT": "10s"},
@@ -23,6 +23,7 @@ func clearProviderEnvVars(t *testing.T) {
 		"DEEPSEEK_API_KEY", "DEEPSEEK_BASE_URL", "DEEPSEEK_MODELS",
 		"XAI_API_KEY", "XAI_BASE_URL", "XAI_MODELS",
 		"GROQ_API_KEY", "GROQ_BASE_URL", "GROQ_MODELS",
+		"COHERE_API_KEY", "COHERE_BASE_URL", "COHERE_MODELS",
 		"OPENROUTER_API_KEY", "OPENROUTER_BASE_URL", "OPENROUTER_MODELS", "OPENROUTER_SITE_URL", "OPENROUTER_APP_NAME",
 		"KILO_API_KEY", "KILO_BASE_URL", "KILO_MODELS",
 		"ZAI_API_KEY", "ZAI_BASE_URL", "ZAI_MODELS",
@@ -40,11 +41,13 @@ func clearProviderEnvVars(t *testing.T) {
 func clearAllConfigEnvVars(t *testing.T) {
 	t.Helper()
 	for _, key := range []string{
-		"CONFIG_STRICT",
-		"PORT", "BASE_PATH", "GOMODEL_MASTER_KEY", "BODY_SIZE_LIMIT", "SWAGGER_ENABLED", "PPROF_ENABLED", "ENABLE_PASSTHROUGH_ROUTES", "ALLOW_PASSTHROUGH_V1_ALIAS", "USER_PATH_HEADER", "ENABLED_PASSTHROUGH_PROVIDERS",
-		"GOMODEL_CACHE_DIR", "CACHE_REFRESH_INTERVAL",
+		"CONFIG_STRICT", "CONFIG_WATCH_INTERVAL",
+		"PORT", "BASE_PATH", "GOMODEL_MASTER_KEY", "AUTH_KEYS_FILE", "BODY_SIZE_LIMIT", "SWAGGER_ENABLED", "PPROF_ENABLED", "ENABLE_PASSTHROUGH_ROUTES", "ALLOW_PASSTHROUGH_V1_ALIAS", "USER_PATH_HEADER", "ENABLED_PASSTHROUGH_PROVIDERS",
+		"HEALTH_STATUS_CODE", "HEALTH_BODY", "HEALTH_INCLUDE_BUILD_INFO", "MAX_TOOLS_PER_REQUEST", "MODEL_NOT_FOUND_STATUS", "RESPONSES_API_ENABLED", "LENIENT_REQUEST_SHAPE",
+		"GOMODEL_CACHE_DIR", "GOMODEL_CACHE_MAX_AGE", "CACHE_REFRESH_INTERVAL", "CACHE_FALLBACK_TO_LOCAL",
 		"REDIS_URL", "REDIS_KEY_MODELS", "REDIS_KEY_RESPONSES", "REDIS_TTL_MODELS", "REDIS_TTL_RESPONSES",
-		"RESPONSE_CACHE_SIMPLE_ENABLED",
+		"MEMCACHED_SERVERS", "MEMCACHED_KEY_MODELS", "MEMCACHED_TTL_MODELS",
+		"RESPONSE_CACHE_SIMPLE_ENABLED", "RESPONSE_CACHE_FALLBACK_TO_MEMORY",
 		"SEMANTIC_CACHE_ENABLED", "SEMANTIC_CACHE_THRESHOLD", "SEMANTIC_CACHE_TTL", "SEMANTIC_CACHE_MAX_CONV_MESSAGES",
 		"SEMANTIC_CACHE_EXCLUDE_SYSTEM_PROMPT", "SEMANTIC_CACHE_EMBEDDER_PROVIDER", "SEMANTIC_CACHE_EMBEDDER_MODEL",
 		"SEMANTIC_CACHE_VECTOR_STORE_TYPE",
@@ -62,13 +65,17 @@ func clearAllConfigEnvVars(t *testing.T) {
 		"USAGE_PRICING_RECALCULATION_ENABLED",
 		"USAGE_BUFFER_SIZE", "USAGE_FLUSH_INTERVAL", "USAGE_RETENTION_DAYS",
 		"BUDGETS_ENABLED",
-		"RATE_LIMITS_ENABLED",
+		"CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_METHODS", "CORS_ALLOWED_HEADERS", "CORS_ALLOW_CREDENTIALS",
+		"SERVER_READ_HEADER_TIMEOUT", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "SERVER_IDLE_TIMEOUT", "STREAM_KEEPALIVE_INTERVAL",
+		"RATE_LIMITS_ENABLED", "RATE_LIMIT_PER_KEY_RPM", "RATE_LIMIT_PER_KEY_BURST",
 		"DASHBOARD_LIVE_LOGS_ENABLED", "DASHBOARD_LIVE_LOGS_BUFFER_SIZE",
 		"DASHBOARD_LIVE_LOGS_REPLAY_LIMIT", "DASHBOARD_LIVE_LOGS_HEARTBEAT_SECONDS",
 		"GUARDRAILS_ENABLED", "ENABLE_GUARDRAILS_FOR_BATCH_PROCESSING",
 		"FAILOVER_MODE", "FAILOVER_MANUAL_RULES_PATH", "FAILOVER_ENABLED", "FAILOVER_RULES_JSON", "FAILOVER_DISABLED_MODELS", "FAILOVER_DISABLED_MODELS_JSON",
-		"MODELS_ENABLED_BY_DEFAULT", "KEEP_ONLY_ALIASES_AT_MODELS_ENDPOINT", "CONFIGURED_PROVIDER_MODELS_MODE",
-		"HTTP_TIMEOUT", "HTTP_RESPONSE_HEADER_TIMEOUT",
+		"MODELS_ENABLED_BY_DEFAULT", "KEEP_ONLY_ALIASES_AT_MODELS_ENDPOINT", "CONFIGURED_PROVIDER_MODELS_MODE", "MODELS_PREFERRED_PROVIDER", "MODELS_LIST_SORT_ORDER", "MODELS_DUPLICATE_MODEL_POLICY", "ROUTING_STRATEGY", "MODELS_STREAMING_FALLBACK", "MODELS_REFRESH_CONCURRENCY",
+		"HTTP_TIMEOUT", "HTTP_RESPONSE_HEADER_TIMEOUT", "HTTP_STREAM_IDLE_TIMEOUT", "HTTP_STREAM_MAX_EVENT_SIZE", "ALLOWED_UPSTREAM_HOSTS",
+		"SAMPLING_MIN_TEMPERATURE", "SAMPLING_MAX_TEMPERATURE", "SAMPLING_MIN_TOP_P", "SAMPLING_MAX_TOP_P",
+		"OUTPUT_PACING_TOKENS_PER_SECOND",
 		"WORKFLOW_REFRESH_INTERVAL",
 	} {
 		t.Setenv(key, "")
@@ -126,15 +133,36 @@ func TestBuildDefaultConfig(t *testing.T) {
 	if got, want := cfg.Server.EnabledPassthroughProviders, []string{"openai", "anthropic", "openrouter", "kilo", "zai", "vllm", "deepseek"}; !reflect.DeepEqual(got, want) {
 		t.Errorf("expected Server.EnabledPassthroughProviders=%v, got %v", want, got)
 	}
+	if cfg.Server.MaxToolsPerRequest != 512 {
+		t.Errorf("expected Server.MaxToolsPerRequest=512, got %d", cfg.Server.MaxToolsPerRequest)
+	}
+	if cfg.Server.ModelNotFoundStatus != 400 {
+		t.Errorf("expected Server.ModelNotFoundStatus=400, got %d", cfg.Server.ModelNotFoundStatus)
+	}
+	if !cfg.Server.ResponsesAPIEnabled {
+		t.Error("expected Server.ResponsesAPIEnabled=true")
+	}
 	if cfg.Models.ConfiguredProviderModelsMode != ConfiguredProviderModelsModeFallback {
 		t.Errorf("expected Models.ConfiguredProviderModelsMode=fallback, got %q", cfg.Models.ConfiguredProviderModelsMode)
 	}
+	if cfg.Models.DuplicateModelPolicy != DuplicateModelPolicyFirstWins {
+		t.Errorf("expected Models.DuplicateModelPolicy=first_wins, got %q", cfg.Models.DuplicateModelPolicy)
+	}
+	if cfg.Models.RefreshConcurrency != 8 {
+		t.Errorf("expected Models.RefreshConcurrency=8, got %d", cfg.Models.RefreshConcurrency)
+	}
+	if ResolveRoutingStrategy(cfg.Routing.Strategy) != RoutingStrategyRoundRobin {
+		t.Errorf("expected Routing.Strategy to resolve to round_robin, got %q", cfg.Routing.Strategy)
+	}
 	if cfg.Cache.Model.Local != nil {
 		t.Error("expected Cache.Model.Local to be nil in raw defaults")
 	}
 	if cfg.Cache.Model.RefreshInterval != 3600 {
 		t.Errorf("expected Cache.Model.RefreshInterval=3600, got %d", cfg.Cache.Model.RefreshInterval)
 	}
+	if !cfg.Cache.Model.FallbackToLocal {
+		t.Error("expected Cache.Model.FallbackToLocal=true")
+	}
 	if cfg.Storage.Type != "sqlite" {
 		t.Errorf("expected Storage.Type=sqlite, got %s", cfg.Storage.Type)
 	}
@@ -245,7 +273,7 @@ func TestBuildDefaultConfig(t *testing.T) {
 	}
 
 	expectedRetry := DefaultRetryConfig()
-	if cfg.Resilience.Retry != expectedRetry {
+	if !reflect.DeepEqual(cfg.Resilience.Retry, expectedRetry) {
 		t.Errorf("expected Resilience.Retry=%+v, got %+v", expectedRetry, cfg.Resilience.Retry)
 	}
 
@@ -722,6 +750,169 @@ failover:
 	})
 }
 
+func TestLoad_ServerAPIKeys(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+server:
+  api_keys:
+    - name: team-a
+      key: sk-team-a
+    - name: team-b
+      key: sk-team-b
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		want := []APIKeyConfig{{Name: "team-a", Key: "sk-team-a"}, {Name: "team-b", Key: "sk-team-b"}}
+		if !reflect.DeepEqual(result.Config.Server.APIKeys, want) {
+			t.Fatalf("Server.APIKeys = %+v, want %+v", result.Config.Server.APIKeys, want)
+		}
+	})
+}
+
+func TestValidateAPIKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []APIKeyConfig
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "valid", keys: []APIKeyConfig{{Name: "a", Key: "k1"}, {Name: "b", Key: "k2"}}},
+		{name: "missing name", keys: []APIKeyConfig{{Key: "k1"}}, wantErr: "server.api_keys[0]: name is required"},
+		{name: "missing key", keys: []APIKeyConfig{{Name: "a"}}, wantErr: "server.api_keys[0] (a): key is required"},
+		{name: "duplicate name", keys: []APIKeyConfig{{Name: "a", Key: "k1"}, {Name: "a", Key: "k2"}}, wantErr: `server.api_keys[1]: duplicate name "a"`},
+		{name: "duplicate key", keys: []APIKeyConfig{{Name: "a", Key: "k1"}, {Name: "b", Key: "k1"}}, wantErr: "server.api_keys[1] (b): key duplicates an earlier entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAPIKeys(tt.keys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateAPIKeys() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ValidateAPIKeys() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCORSConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     CORSConfig
+		wantErr bool
+	}{
+		{name: "disabled"},
+		{name: "explicit origins", cfg: CORSConfig{AllowedOrigins: []string{"https://app.example.com", "http://localhost:3000"}, AllowCredentials: true}},
+		{name: "wildcard", cfg: CORSConfig{AllowedOrigins: []string{"*"}}},
+		{name: "wildcard with credentials", cfg: CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, wantErr: true},
+		{name: "missing scheme", cfg: CORSConfig{AllowedOrigins: []string{"app.example.com"}}, wantErr: true},
+		{name: "trailing path", cfg: CORSConfig{AllowedOrigins: []string{"https://app.example.com/"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCORSConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCORSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_CORSFromEnv(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(string) {
+		t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example.com,https://b.example.com")
+		t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		cors := result.Config.Server.CORS
+		if !reflect.DeepEqual(cors.AllowedOrigins, []string{"https://a.example.com", "https://b.example.com"}) || !cors.AllowCredentials {
+			t.Fatalf("Server.CORS = %+v, want both origins with credentials", cors)
+		}
+	})
+}
+
+func TestLoad_ServerTimeouts(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+server:
+  timeouts:
+    read_header: 5s
+    idle: 2m
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+		t.Setenv("SERVER_WRITE_TIMEOUT", "45s")
+
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		want := ServerTimeoutsConfig{ReadHeader: 5 * time.Second, Write: 45 * time.Second, Idle: 2 * time.Minute}
+		if got := result.Config.Server.Timeouts; got != want {
+			t.Fatalf("Server.Timeouts = %+v, want %+v", got, want)
+		}
+	})
+}
+
+func TestLoad_NegativeServerTimeout(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(string) {
+		t.Setenv("SERVER_READ_TIMEOUT", "-1s")
+		_, err := Load()
+		if err == nil || !strings.Contains(err.Error(), "server.timeouts.read must be non-negative") {
+			t.Fatalf("Load() error = %v, want negative timeout validation error", err)
+		}
+	})
+}
+
+func TestLoad_ServerStreamKeepalive(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+server:
+  stream:
+    keepalive_interval: 15s
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		if got := result.Config.Server.Stream.KeepaliveInterval; got != 15*time.Second {
+			t.Fatalf("Server.Stream.KeepaliveInterval = %s, want 15s", got)
+		}
+
+		t.Setenv("STREAM_KEEPALIVE_INTERVAL", "-1s")
+		_, err = Load()
+		if err == nil || !strings.Contains(err.Error(), "server.stream.keepalive_interval must be non-negative") {
+			t.Fatalf("Load() error = %v, want negative keepalive validation error", err)
+		}
+	})
+}
+
 func TestLoad_InvalidConfiguredProviderModelsMode(t *testing.T) {
 	clearAllConfigEnvVars(t)
 
@@ -744,6 +935,336 @@ models:
 	})
 }
 
+func TestLoad_InvalidModelListSortOrder(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+models:
+  list_sort_order: newest
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("expected Load() to fail for invalid model list sort order")
+		}
+		if !strings.Contains(err.Error(), "models.list_sort_order must be one of") {
+			t.Fatalf("Load() error = %v, want model list sort order validation error", err)
+		}
+	})
+}
+
+func TestLoad_InvalidDuplicateModelPolicy(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+models:
+  duplicate_model_policy: last-wins
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("expected Load() to fail for invalid duplicate model policy")
+		}
+		if !strings.Contains(err.Error(), "models.duplicate_model_policy must be one of") {
+			t.Fatalf("Load() error = %v, want duplicate model policy validation error", err)
+		}
+	})
+}
+
+func TestLoad_InvalidRoutingStrategy(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+routing:
+  strategy: least_latency
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("expected Load() to fail for invalid routing strategy")
+		}
+		if !strings.Contains(err.Error(), "routing.strategy must be one of") {
+			t.Fatalf("Load() error = %v, want routing strategy validation error", err)
+		}
+	})
+}
+
+func TestLoad_RoutingSettingsRequireSharedModelPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr []string
+	}{
+		{
+			name: "strategy without all policy",
+			yaml: `
+routing:
+  strategy: first_wins
+`,
+			wantErr: []string{`routing.strategy only applies with models.duplicate_model_policy "all"`},
+		},
+		{
+			name: "weight without all policy",
+			yaml: `
+models:
+  duplicate_model_policy: first-wins
+providers:
+  openai:
+    type: openai
+    weight: 3
+`,
+			wantErr: []string{`providers.openai.weight only applies with models.duplicate_model_policy "all"`},
+		},
+		{
+			name: "weight with first_wins strategy",
+			yaml: `
+models:
+  duplicate_model_policy: all
+routing:
+  strategy: first-wins
+providers:
+  openai:
+    type: openai
+    weight: 3
+`,
+			wantErr: []string{`providers.openai.weight only applies with routing.strategy "round_robin"`},
+		},
+		{
+			name: "weighted round robin",
+			yaml: `
+models:
+  duplicate_model_policy: all
+routing:
+  strategy: round_robin
+providers:
+  openai:
+    type: openai
+    weight: 3
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearAllConfigEnvVars(t)
+			withTempDir(t, func(dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.yaml), 0644); err != nil {
+					t.Fatalf("Failed to write config.yaml: %v", err)
+				}
+				_, err := Load()
+				if len(tt.wantErr) == 0 {
+					if err != nil {
+						t.Fatalf("Load() error = %v", err)
+					}
+					return
+				}
+				if err == nil {
+					t.Fatal("expected Load() to fail")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Fatalf("Load() error = %v, want %q", err, want)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestLoad_NegativeModelRefreshConcurrency(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(_ string) {
+		t.Setenv("MODELS_REFRESH_CONCURRENCY", "-1")
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("expected Load() to fail for negative model refresh concurrency")
+		}
+		if !strings.Contains(err.Error(), "models.refresh_concurrency must be >= 0") {
+			t.Fatalf("Load() error = %v, want refresh concurrency validation error", err)
+		}
+	})
+}
+
+func TestLoad_OutputPacing(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+output_pacing:
+  tokens_per_second: 40
+  user_paths:
+    - path: "team/slow/"
+      tokens_per_second: 5
+    - path: /team/slow/unpaced
+      tokens_per_second: 0
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+		t.Setenv("OUTPUT_PACING_TOKENS_PER_SECOND", "25")
+
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		pacing := result.Config.OutputPacing
+		if !pacing.Enabled() {
+			t.Fatal("expected output pacing to be enabled")
+		}
+		for path, want := range map[string]float64{
+			"":                      25,
+			"/team":                 25,
+			"/team/slow":            5,
+			"/team/slow/user-1":     5,
+			"/team/slow/unpaced":    0,
+			"/team/slow/unpaced/me": 0,
+		} {
+			if got := pacing.TokensPerSecondFor(path); got != want {
+				t.Errorf("TokensPerSecondFor(%q) = %g, want %g", path, got, want)
+			}
+		}
+	})
+}
+
+func TestLoad_InvalidOutputPacing(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "negative rate",
+			yaml:    "output_pacing:\n  tokens_per_second: -1\n",
+			wantErr: "output_pacing.tokens_per_second must be 0 (disabled) or greater",
+		},
+		{
+			name:    "negative user path rate",
+			yaml:    "output_pacing:\n  user_paths:\n    - path: /team\n      tokens_per_second: -2\n",
+			wantErr: "output_pacing.user_paths[0].tokens_per_second",
+		},
+		{
+			name:    "duplicate user path",
+			yaml:    "output_pacing:\n  user_paths:\n    - path: /team\n      tokens_per_second: 2\n    - path: team/\n      tokens_per_second: 3\n",
+			wantErr: "duplicate path \"/team\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearAllConfigEnvVars(t)
+			withTempDir(t, func(dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.yaml), 0644); err != nil {
+					t.Fatalf("Failed to write config.yaml: %v", err)
+				}
+				_, err := Load()
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+			})
+		})
+	}
+}
+
+func TestLoad_ReportsInvalidSettingsTogether(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+server:
+  user_path_header: "bad header"
+models:
+  list_sort_order: size
+  refresh_concurrency: -1
+routing:
+  strategy: random
+output_pacing:
+  tokens_per_second: -1
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+		_, err := Load()
+		if err == nil {
+			t.Fatal("Load() error = nil, want joined validation errors")
+		}
+		for _, want := range []string{
+			"invalid server.user_path_header",
+			"models.list_sort_order must be one of",
+			"models.refresh_concurrency must be >= 0",
+			"routing.strategy must be one of",
+			"output_pacing.tokens_per_second must be 0 (disabled) or greater",
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, want)
+			}
+		}
+	})
+}
+
+func TestLoad_InvalidSamplingRange(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		yaml := `
+sampling:
+  min_temperature: 0.8
+  max_temperature: 0.7
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0644); err != nil {
+			t.Fatalf("Failed to write config.yaml: %v", err)
+		}
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("expected Load() to fail for an inverted sampling range")
+		}
+		if !strings.Contains(err.Error(), "sampling.min_temperature (0.8) must not exceed sampling.max_temperature (0.7)") {
+			t.Fatalf("Load() error = %v, want sampling range validation error", err)
+		}
+	})
+}
+
+func TestLoad_HealthStatusCode(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	t.Setenv("HEALTH_STATUS_CODE", "204")
+	result, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if result.Config.Server.HealthStatusCode != 204 {
+		t.Fatalf("HealthStatusCode = %d, want 204", result.Config.Server.HealthStatusCode)
+	}
+
+	t.Setenv("HEALTH_STATUS_CODE", "503")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "health_status_code must be a 2xx status") {
+		t.Fatalf("Load() error = %v, want health status code validation error", err)
+	}
+}
+
+func TestLoad_ModelNotFoundStatusValidation(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(_ string) {
+		t.Setenv("MODEL_NOT_FOUND_STATUS", "418")
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), "model_not_found_status must be 400 or 404") {
+			t.Fatalf("Load() error = %v, want model not found status validation error", err)
+		}
+	})
+}
+
 func TestLoad_EmptyFailoverOverrideModeIsAccepted(t *testing.T) {
 	clearAllConfigEnvVars(t)
 
@@ -1279,6 +1800,12 @@ func TestLoad_EnvOverridesDefaults(t *testing.T) {
 		t.Setenv("MODELS_ENABLED_BY_DEFAULT", "false")
 		t.Setenv("KEEP_ONLY_ALIASES_AT_MODELS_ENDPOINT", "true")
 		t.Setenv("CONFIGURED_PROVIDER_MODELS_MODE", "allowlist")
+		t.Setenv("MODELS_PREFERRED_PROVIDER", "openai-main")
+		t.Setenv("MODELS_LIST_SORT_ORDER", "Created")
+		t.Setenv("MODELS_DUPLICATE_MODEL_POLICY", "All")
+		t.Setenv("ROUTING_STRATEGY", "First-Wins")
+		t.Setenv("MODELS_STREAMING_FALLBACK", "true")
+		t.Setenv("MODELS_REFRESH_CONCURRENCY", "2")
 		t.Setenv("USAGE_PRICING_RECALCULATION_ENABLED", "false")
 		t.Setenv("STORAGE_TYPE", "postgresql")
 		t.Setenv("POSTGRES_URL", "postgres://localhost/test")
@@ -1302,6 +1829,24 @@ func TestLoad_EnvOverridesDefaults(t *testing.T) {
 		if cfg.Models.ConfiguredProviderModelsMode != ConfiguredProviderModelsModeAllowlist {
 			t.Errorf("expected configured provider models mode allowlist from env, got %q", cfg.Models.ConfiguredProviderModelsMode)
 		}
+		if cfg.Models.PreferredProvider != "openai-main" {
+			t.Errorf("expected preferred provider openai-main from env, got %q", cfg.Models.PreferredProvider)
+		}
+		if cfg.Models.ListSortOrder != ModelListSortByCreated {
+			t.Errorf("expected model list sort order created from env, got %q", cfg.Models.ListSortOrder)
+		}
+		if cfg.Models.DuplicateModelPolicy != DuplicateModelPolicyAll {
+			t.Errorf("expected duplicate model policy all from env, got %q", cfg.Models.DuplicateModelPolicy)
+		}
+		if cfg.Routing.Strategy != RoutingStrategyFirstWins {
+			t.Errorf("expected routing strategy first_wins from env, got %q", cfg.Routing.Strategy)
+		}
+		if !cfg.Models.StreamingFallback {
+			t.Error("expected streaming fallback enabled from env")
+		}
+		if cfg.Models.RefreshConcurrency != 2 {
+			t.Errorf("expected refresh concurrency 2 from env, got %d", cfg.Models.RefreshConcurrency)
+		}
 		if cfg.Usage.PricingRecalculationEnabled {
 			t.Error("expected usage pricing recalculation to be disabled from env")
 		}
@@ -1729,6 +2274,35 @@ func TestLoad_EnvOnlyRedisModelCache(t *testing.T) {
 	})
 }
 
+func TestLoad_EnvOnlyMemcachedModelCache(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(_ string) {
+		t.Setenv("MEMCACHED_SERVERS", "mc-1:11211, mc-2:11211")
+		t.Setenv("MEMCACHED_KEY_MODELS", "env:models")
+		t.Setenv("MEMCACHED_TTL_MODELS", "7200")
+
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		m := result.Config.Cache.Model
+
+		if m.Memcached == nil {
+			t.Fatal("expected Cache.Model.Memcached to be allocated from env vars")
+		}
+		if got := m.Memcached.Servers; len(got) != 2 || got[0] != "mc-1:11211" || got[1] != "mc-2:11211" {
+			t.Errorf("MEMCACHED_SERVERS = %v, want [mc-1:11211 mc-2:11211]", got)
+		}
+		if m.Memcached.Key != "env:models" || m.Memcached.TTL != 7200 {
+			t.Errorf("Memcached = %+v, want key env:models and ttl 7200", *m.Memcached)
+		}
+		if m.Local != nil {
+			t.Errorf("expected Cache.Model.Local to be nil when Memcached is configured via env, got %v", m.Local)
+		}
+	})
+}
+
 func TestLoad_EnvOnlyRedisResponseCache(t *testing.T) {
 	clearAllConfigEnvVars(t)
 
@@ -1745,6 +2319,7 @@ func TestLoad_EnvOnlyRedisResponseCache(t *testing.T) {
 		t.Setenv("REDIS_URL", "redis://env-host:6379")
 		t.Setenv("REDIS_KEY_RESPONSES", "env:responses")
 		t.Setenv("REDIS_TTL_RESPONSES", "1800")
+		t.Setenv("RESPONSE_CACHE_FALLBACK_TO_MEMORY", "false")
 
 		result, err := Load()
 		if err != nil {
@@ -1764,6 +2339,44 @@ func TestLoad_EnvOnlyRedisResponseCache(t *testing.T) {
 		if cfg.Cache.Response.Simple.Redis.TTL != 1800 {
 			t.Errorf("expected REDIS_TTL_RESPONSES=1800, got %d", cfg.Cache.Response.Simple.Redis.TTL)
 		}
+		if SimpleCacheFallbackToMemory(cfg.Cache.Response.Simple) {
+			t.Error("expected RESPONSE_CACHE_FALLBACK_TO_MEMORY=false to disable the in-memory fallback")
+		}
+	})
+}
+
+func TestLoad_ResponseSimpleMemoryIgnoresRedisEnv(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(dir string) {
+		cfgDir := filepath.Join(dir, "config")
+		if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		yamlContent := "cache:\n  response:\n    deterministic_only: true\n    simple:\n      memory:\n        ttl: 600\n        max_entries: 50\n"
+		if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte(yamlContent), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Setenv("REDIS_URL", "redis://env-host:6379")
+
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		simple := result.Config.Cache.Response.Simple
+		if simple == nil || simple.Memory == nil {
+			t.Fatal("expected Cache.Response.Simple.Memory from config.yaml")
+		}
+		if simple.Redis != nil {
+			t.Errorf("Redis = %+v, want nil for a memory-backed cache", simple.Redis)
+		}
+		if simple.Memory.TTL != 600 || simple.Memory.MaxEntries != 50 {
+			t.Errorf("Memory = %+v, want ttl 600 and max_entries 50", simple.Memory)
+		}
+		if !result.Config.Cache.Response.DeterministicOnly {
+			t.Error("Cache.Response.DeterministicOnly = false, want true")
+		}
 	})
 }
 
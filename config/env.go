@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"reflect"
 	"strconv"
@@ -140,7 +141,11 @@ func applyEnvOverridesValue(v reflect.Value) error {
 	return nil
 }
 
-// expandString expands environment variable references like ${VAR} or ${VAR:-default} in a string.
+// expandString expands environment variable references like ${VAR} or
+// ${VAR:-default} in a string. ${file:/path} expands to the trimmed contents of
+// a file, for Docker and Kubernetes secrets mounted as files; it accepts a
+// default the same way. An unset variable or unreadable file without a default
+// is left as the literal placeholder, so provider filtering drops the entry.
 func expandString(s string) string {
 	if s == "" {
 		return s
@@ -154,7 +159,12 @@ func expandString(s string) string {
 			defaultValue = after
 			hasDefault = true
 		}
-		value := os.Getenv(varname)
+		var value string
+		if path, ok := strings.CutPrefix(varname, "file:"); ok {
+			value = readSecretFile(path, hasDefault)
+		} else {
+			value = os.Getenv(varname)
+		}
 		if value == "" {
 			if hasDefault {
 				return defaultValue
@@ -165,6 +175,20 @@ func expandString(s string) string {
 	})
 }
 
+// readSecretFile returns the trimmed contents of a ${file:...} reference, or
+// "" when it cannot be read. The failure is logged unless a default covers
+// it; the contents never are.
+func readSecretFile(path string, hasDefault bool) string {
+	data, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		if !hasDefault {
+			slog.Warn("secret file could not be read; leaving placeholder unexpanded", "path", path, "error", err)
+		}
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 // parseBool returns true if s is "true" or "1" (case-insensitive).
 func parseBool(s string) bool {
 	return strings.EqualFold(s, "true") || s == "1"
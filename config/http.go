@@ -2,7 +2,8 @@ package config
 
 // HTTPConfig holds HTTP client configuration for upstream API requests. App
 // startup installs these values into internal/httpclient before providers are
-// constructed; the HTTP_TIMEOUT and HTTP_RESPONSE_HEADER_TIMEOUT env vars take
+// constructed; the HTTP_TIMEOUT, HTTP_RESPONSE_HEADER_TIMEOUT,
+// HTTP_STREAM_IDLE_TIMEOUT, and HTTP_STREAM_MAX_EVENT_SIZE env vars take
 // precedence over the YAML values.
 type HTTPConfig struct {
 	// Timeout is the overall HTTP request timeout in seconds (default: 600)
@@ -10,4 +11,20 @@ type HTTPConfig struct {
 
 	// ResponseHeaderTimeout is the time to wait for response headers in seconds (default: 600)
 	ResponseHeaderTimeout int `yaml:"response_header_timeout" env:"HTTP_RESPONSE_HEADER_TIMEOUT"`
+
+	// StreamIdleTimeout aborts a streaming response when the upstream sends no
+	// bytes for this many seconds (default: 0, disabled). It catches half-open
+	// connections well before Timeout ends the whole stream.
+	StreamIdleTimeout int `yaml:"stream_idle_timeout" env:"HTTP_STREAM_IDLE_TIMEOUT"`
+
+	// StreamMaxEventSize caps a single upstream SSE line that stream
+	// converters will buffer, using the BODY_SIZE_LIMIT format ("1M", "512K").
+	// A longer line ends the stream with an error. Empty means 1M.
+	StreamMaxEventSize string `yaml:"stream_max_event_size" env:"HTTP_STREAM_MAX_EVENT_SIZE"`
+
+	// AllowedUpstreamHosts restricts which hosts provider clients may contact.
+	// Entries are hostnames, "*.domain" wildcards, or "*" for any host. Empty
+	// allows the built-in provider hosts. Hosts of configured provider
+	// base_url values are always allowed.
+	AllowedUpstreamHosts []string `yaml:"allowed_upstream_hosts" env:"ALLOWED_UPSTREAM_HOSTS"`
 }
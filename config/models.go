@@ -1,6 +1,10 @@
 package config
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ModelsConfig holds global model access defaults.
 type ModelsConfig struct {
@@ -18,6 +22,96 @@ type ModelsConfig struct {
 	// provider *_MODELS env vars affect the provider model inventory.
 	// Supported values: "fallback", "allowlist". Default: "fallback".
 	ConfiguredProviderModelsMode ConfiguredProviderModelsMode `yaml:"configured_provider_models_mode" env:"CONFIGURED_PROVIDER_MODELS_MODE"`
+
+	// PreferredProvider names the provider instance whose model entries win
+	// when several providers expose the same bare model ID. Its model object
+	// (metadata) and routing take precedence over registration order.
+	// Default: "" (first registered provider wins).
+	PreferredProvider string `yaml:"preferred_provider" env:"MODELS_PREFERRED_PROVIDER"`
+
+	// DuplicateModelPolicy controls what happens when several providers expose
+	// the same bare model ID. Supported values: "first_wins" (registration
+	// order decides), "priority" (highest providers.<name>.priority wins),
+	// "all" (bare IDs rotate across every owner), "error" (refuse the model
+	// inventory, failing startup). Hyphenated spellings such as "first-wins"
+	// are accepted. Default: "first_wins".
+	DuplicateModelPolicy DuplicateModelPolicy `yaml:"duplicate_model_policy" env:"MODELS_DUPLICATE_MODEL_POLICY"`
+
+	// ListSortOrder controls the order of GET /v1/models entries.
+	// Supported values: "id" (alphabetical), "created" (newest first),
+	// "provider" (grouped by owner, then ID). Default: "id".
+	ListSortOrder ModelListSortOrder `yaml:"list_sort_order" env:"MODELS_LIST_SORT_ORDER"`
+
+	// StreamingFallback serves streaming chat requests for models whose
+	// metadata declares no streaming support with a non-streaming upstream
+	// call, returned to the client as a single-chunk SSE stream.
+	// Default: false (the request is forwarded to the provider as-is).
+	StreamingFallback bool `yaml:"streaming_fallback" env:"MODELS_STREAMING_FALLBACK"`
+
+	// RefreshConcurrency caps how many providers are asked for their model
+	// lists at once during startup and background refreshes, so deployments
+	// with many providers do not open dozens of upstream connections at the
+	// same moment. 0 removes the cap. Default: 8.
+	RefreshConcurrency int `yaml:"refresh_concurrency" env:"MODELS_REFRESH_CONCURRENCY"`
+}
+
+// ModelListSortOrder selects how GET /v1/models orders its entries.
+type ModelListSortOrder string
+
+const (
+	ModelListSortByID       ModelListSortOrder = "id"
+	ModelListSortByCreated  ModelListSortOrder = "created"
+	ModelListSortByProvider ModelListSortOrder = "provider"
+)
+
+// Valid reports whether order is one of the supported model list sort orders.
+func (o ModelListSortOrder) Valid() bool {
+	switch ResolveModelListSortOrder(o) {
+	case ModelListSortByID, ModelListSortByCreated, ModelListSortByProvider:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveModelListSortOrder canonicalizes order and applies the default.
+func ResolveModelListSortOrder(order ModelListSortOrder) ModelListSortOrder {
+	order = ModelListSortOrder(strings.ToLower(strings.TrimSpace(string(order))))
+	if order == "" {
+		return ModelListSortByID
+	}
+	return order
+}
+
+// DuplicateModelPolicy selects how bare model IDs shared by several providers
+// are resolved.
+type DuplicateModelPolicy string
+
+const (
+	DuplicateModelPolicyFirstWins DuplicateModelPolicy = "first_wins"
+	DuplicateModelPolicyPriority  DuplicateModelPolicy = "priority"
+	DuplicateModelPolicyAll       DuplicateModelPolicy = "all"
+	DuplicateModelPolicyError     DuplicateModelPolicy = "error"
+)
+
+// Valid reports whether policy is one of the supported duplicate model policies.
+func (p DuplicateModelPolicy) Valid() bool {
+	switch ResolveDuplicateModelPolicy(p) {
+	case DuplicateModelPolicyFirstWins, DuplicateModelPolicyPriority, DuplicateModelPolicyAll, DuplicateModelPolicyError:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveDuplicateModelPolicy canonicalizes policy and applies the default.
+func ResolveDuplicateModelPolicy(policy DuplicateModelPolicy) DuplicateModelPolicy {
+	normalized := strings.ToLower(strings.TrimSpace(string(policy)))
+	policy = DuplicateModelPolicy(strings.ReplaceAll(normalized, "-", "_"))
+	if policy == "" {
+		return DuplicateModelPolicyFirstWins
+	}
+	return policy
 }
 
 // ConfiguredProviderModelsMode controls how explicitly configured provider
@@ -52,3 +146,22 @@ func ResolveConfiguredProviderModelsMode(mode ConfiguredProviderModelsMode) Conf
 	}
 	return mode
 }
+
+// ValidateModelsConfig checks the models section's enumerated settings and
+// refresh concurrency, reporting every problem together.
+func ValidateModelsConfig(m ModelsConfig) error {
+	var errs []error
+	if !m.ConfiguredProviderModelsMode.Valid() {
+		errs = append(errs, fmt.Errorf("models.configured_provider_models_mode must be one of: fallback, allowlist"))
+	}
+	if !m.ListSortOrder.Valid() {
+		errs = append(errs, fmt.Errorf("models.list_sort_order must be one of: id, created, provider"))
+	}
+	if !m.DuplicateModelPolicy.Valid() {
+		errs = append(errs, fmt.Errorf("models.duplicate_model_policy must be one of: first_wins, priority, all, error"))
+	}
+	if m.RefreshConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("models.refresh_concurrency must be >= 0, got %d", m.RefreshConcurrency))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// OutputPacingConfig limits how fast streamed chat and Responses output is
+// released to clients, in estimated output tokens per second. Upstream chunks
+// are buffered and flushed at the configured rate. 0 (the default) disables
+// pacing.
+type OutputPacingConfig struct {
+	// TokensPerSecond is the rate applied to every streaming request without a
+	// more specific user path rule.
+	TokensPerSecond float64 `yaml:"tokens_per_second" env:"OUTPUT_PACING_TOKENS_PER_SECOND"`
+
+	// UserPaths overrides the rate for user paths and their descendants, so
+	// managed keys bound to those paths are paced individually. The deepest
+	// matching path wins; a rate of 0 turns pacing off for that subtree.
+	UserPaths []OutputPacingUserPathConfig `yaml:"user_paths"`
+}
+
+// OutputPacingUserPathConfig sets the output pacing rate for one user path.
+type OutputPacingUserPathConfig struct {
+	Path            string  `yaml:"path"`
+	TokensPerSecond float64 `yaml:"tokens_per_second"`
+}
+
+// Enabled reports whether any output pacing rate is configured.
+func (c OutputPacingConfig) Enabled() bool {
+	if c.TokensPerSecond > 0 {
+		return true
+	}
+	for _, entry := range c.UserPaths {
+		if entry.TokensPerSecond > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TokensPerSecondFor returns the pacing rate for a normalized user path,
+// falling back to the global rate when no user path rule matches.
+func (c OutputPacingConfig) TokensPerSecondFor(userPath string) float64 {
+	if len(c.UserPaths) > 0 && userPath != "" {
+		for _, ancestor := range core.UserPathAncestors(userPath) {
+			for _, entry := range c.UserPaths {
+				if entry.Path == ancestor {
+					return entry.TokensPerSecond
+				}
+			}
+		}
+	}
+	return c.TokensPerSecond
+}
+
+func validateOutputPacingConfig(cfg *OutputPacingConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.TokensPerSecond < 0 {
+		return fmt.Errorf("output_pacing.tokens_per_second must be 0 (disabled) or greater, got %g", cfg.TokensPerSecond)
+	}
+	seen := make(map[string]struct{}, len(cfg.UserPaths))
+	for i, entry := range cfg.UserPaths {
+		if strings.TrimSpace(entry.Path) == "" {
+			return fmt.Errorf("output_pacing.user_paths[%d].path is required", i)
+		}
+		normalizedPath, err := core.NormalizeUserPath(entry.Path)
+		if err != nil {
+			return fmt.Errorf("output_pacing.user_paths[%d].path is invalid: %w", i, err)
+		}
+		if _, dup := seen[normalizedPath]; dup {
+			return fmt.Errorf("output_pacing.user_paths[%d]: duplicate path %q", i, normalizedPath)
+		}
+		seen[normalizedPath] = struct{}{}
+		if entry.TokensPerSecond < 0 {
+			return fmt.Errorf("output_pacing.user_paths[%d].tokens_per_second must be 0 (disabled) or greater", i)
+		}
+		cfg.UserPaths[i].Path = normalizedPath
+	}
+	return nil
+}
@@ -23,4 +23,40 @@ type RawProviderConfig struct {
 	GCPScope                 string               `yaml:"gcp_scope"`
 	Models                   []RawProviderModel   `yaml:"models"`
 	Resilience               *RawResilienceConfig `yaml:"resilience"`
+	// DefaultParams are top-level request fields (for example temperature or
+	// top_p) merged into chat and Responses requests routed to this provider
+	// when the client omits them. Client-supplied values always win. The
+	// special system_prompt_prefix key is prepended to the system prompt.
+	DefaultParams map[string]any `yaml:"default_params"`
+	// Priority ranks this provider against others exposing the same bare
+	// model ID when models.duplicate_model_policy is "priority". Higher wins;
+	// ties keep registration order. Default: 0.
+	Priority int `yaml:"priority"`
+	// DefaultMaxTokens is the output-token limit sent when a client omits
+	// max_tokens, for providers whose API requires one (Anthropic). It is
+	// clamped to the model's max_output_tokens metadata, which is the
+	// fallback when this is 0.
+	DefaultMaxTokens int `yaml:"default_max_tokens"`
+	// Weight biases round_robin routing for bare model IDs this provider
+	// shares with others under models.duplicate_model_policy "all": a
+	// provider with weight 3 receives three requests for every one sent to a
+	// provider with weight 1, interleaved rather than in bursts. Setting it
+	// under any other policy, or with routing.strategy "first_wins", fails
+	// validation. Default: 1.
+	Weight int `yaml:"weight"`
+	// Proxy routes this provider's upstream requests through an http:// or
+	// https:// proxy, e.g. "http://proxy.internal:3128". Empty honours the
+	// HTTP_PROXY / HTTPS_PROXY / NO_PROXY environment variables.
+	Proxy string `yaml:"proxy"`
+	// SiteURL and AppName are sent as OpenRouter's HTTP-Referer and
+	// X-OpenRouter-Title attribution headers. Empty falls back to the
+	// OPENROUTER_SITE_URL / OPENROUTER_APP_NAME env vars, then GoModel's own.
+	// Ignored by other provider types.
+	SiteURL string `yaml:"site_url"`
+	AppName string `yaml:"app_name"`
+	// ModelPrefixes lists the model ID prefixes a Gemini provider exposes from
+	// its upstream model list, e.g. ["gemini-", "gemma-"]. Empty keeps the
+	// default of gemini-* and text-embedding-*. Ignored by other provider
+	// types.
+	ModelPrefixes []string `yaml:"model_prefixes"`
 }
@@ -28,6 +28,19 @@ type RateLimitsConfig struct {
 	// ("openai/gpt-4o") caps one provider's model; a bare id ("gpt-4o") caps
 	// the model across every provider.
 	Models []RateLimitModelConfig `yaml:"models"`
+
+	// PerKey throttles each caller at ingress with a token bucket, keyed by
+	// API key or by client IP when auth is disabled. Unlike the rules above
+	// it is in-memory only and applies to /v1 and passthrough routes.
+	PerKey RateLimitPerKeyConfig `yaml:"per_key"`
+}
+
+// RateLimitPerKeyConfig configures the per-caller token bucket.
+type RateLimitPerKeyConfig struct {
+	// RequestsPerMinute is the bucket refill rate. 0 (default) disables it.
+	RequestsPerMinute int `yaml:"requests_per_minute" env:"RATE_LIMIT_PER_KEY_RPM"`
+	// Burst is the bucket capacity. 0 defaults to RequestsPerMinute.
+	Burst int `yaml:"burst" env:"RATE_LIMIT_PER_KEY_BURST"`
 }
 
 // RateLimitUserPathConfig declares one or more rate limit rules for a user path.
@@ -228,6 +241,12 @@ func validateRateLimitConfig(cfg *RateLimitsConfig) error {
 	if !cfg.Enabled {
 		return nil
 	}
+	if cfg.PerKey.RequestsPerMinute < 0 {
+		return fmt.Errorf("rate_limits.per_key.requests_per_minute must be >= 0, got %d", cfg.PerKey.RequestsPerMinute)
+	}
+	if cfg.PerKey.Burst < 0 {
+		return fmt.Errorf("rate_limits.per_key.burst must be >= 0, got %d", cfg.PerKey.Burst)
+	}
 	seen := make(map[string]struct{})
 	for pathIdx, entry := range cfg.UserPaths {
 		if strings.TrimSpace(entry.Path) == "" {
@@ -407,6 +407,31 @@ func TestRateLimitsEnabledByDefaultAndTogglable(t *testing.T) {
 	})
 }
 
+func TestRateLimitsPerKeyFromEnv(t *testing.T) {
+	clearAllConfigEnvVars(t)
+
+	withTempDir(t, func(string) {
+		t.Setenv("RATE_LIMIT_PER_KEY_RPM", "120")
+		t.Setenv("RATE_LIMIT_PER_KEY_BURST", "20")
+		result, err := Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		perKey := result.Config.RateLimits.PerKey
+		if perKey.RequestsPerMinute != 120 || perKey.Burst != 20 {
+			t.Fatalf("PerKey = %+v, want 120 rpm with burst 20", perKey)
+		}
+	})
+
+	withTempDir(t, func(string) {
+		t.Setenv("RATE_LIMIT_PER_KEY_RPM", "-1")
+		_, err := Load()
+		if err == nil || !strings.Contains(err.Error(), "rate_limits.per_key.requests_per_minute must be >= 0") {
+			t.Fatalf("Load() error = %v, want per_key validation error", err)
+		}
+	})
+}
+
 func TestParseRateLimitEnvLimits_RejectsUnknownField(t *testing.T) {
 	_, err := parseRateLimitEnvLimits(`[{"period":"minute","max_requsts":100}]`, true)
 	if err == nil {
@@ -10,6 +10,16 @@ type RetryConfig struct {
 	MaxBackoff     time.Duration `yaml:"max_backoff"     env:"RETRY_MAX_BACKOFF"`
 	BackoffFactor  float64       `yaml:"backoff_factor"  env:"RETRY_BACKOFF_FACTOR"`
 	JitterFactor   float64       `yaml:"jitter_factor"   env:"RETRY_JITTER_FACTOR"`
+	// BodyErrorCodes lists upstream error.type / error.code values that are
+	// retried whatever the HTTP status, for providers that report transient
+	// failures such as "overloaded_error" in the body. Empty disables body
+	// inspection.
+	BodyErrorCodes []string `yaml:"body_error_codes" env:"RETRY_BODY_ERROR_CODES"`
+	// MaxElapsedTime caps the wall-clock time one logical request may spend
+	// across all attempts and backoffs. A retry whose backoff would end past
+	// the cap is not attempted and the last error is returned. Zero disables
+	// the cap.
+	MaxElapsedTime time.Duration `yaml:"max_elapsed_time" env:"RETRY_MAX_ELAPSED_TIME"`
 }
 
 // DefaultRetryConfig returns the default retry settings.
@@ -25,10 +35,17 @@ func DefaultRetryConfig() RetryConfig {
 
 // CircuitBreakerConfig holds resolved circuit breaker settings.
 // This is the canonical type shared between config and llmclient.
+//
+// SlowCallThreshold, when positive, counts successful responses slower than the
+// threshold as failures on a per-model breaker, so a model that keeps
+// answering too slowly for interactive use is cut off after FailureThreshold
+// consecutive slow calls while the provider's other models keep serving.
+// Zero disables latency tracking.
 type CircuitBreakerConfig struct {
-	FailureThreshold int           `yaml:"failure_threshold" env:"CIRCUIT_BREAKER_FAILURE_THRESHOLD"`
-	SuccessThreshold int           `yaml:"success_threshold" env:"CIRCUIT_BREAKER_SUCCESS_THRESHOLD"`
-	Timeout          time.Duration `yaml:"timeout"           env:"CIRCUIT_BREAKER_TIMEOUT"`
+	FailureThreshold  int           `yaml:"failure_threshold"   env:"CIRCUIT_BREAKER_FAILURE_THRESHOLD"`
+	SuccessThreshold  int           `yaml:"success_threshold"   env:"CIRCUIT_BREAKER_SUCCESS_THRESHOLD"`
+	Timeout           time.Duration `yaml:"timeout"             env:"CIRCUIT_BREAKER_TIMEOUT"`
+	SlowCallThreshold time.Duration `yaml:"slow_call_threshold" env:"CIRCUIT_BREAKER_SLOW_CALL_THRESHOLD"`
 }
 
 // DefaultCircuitBreakerConfig returns the default circuit breaker settings.
@@ -40,10 +57,51 @@ func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
 	}
 }
 
-// ResilienceConfig holds resolved resilience settings (retry and circuit breaker).
+// QueueConfig bounds concurrent upstream requests per provider. Requests over
+// MaxConcurrent wait in a FIFO queue of at most MaxQueue entries for at most
+// MaxWait; a full queue or an expired wait is rejected with a 429.
+type QueueConfig struct {
+	MaxConcurrent int           `yaml:"max_concurrent" env:"PROVIDER_MAX_CONCURRENT"`
+	MaxQueue      int           `yaml:"max_queue"      env:"PROVIDER_MAX_QUEUE"`
+	MaxWait       time.Duration `yaml:"max_wait"       env:"PROVIDER_MAX_QUEUE_WAIT"`
+}
+
+// DefaultQueueConfig returns the default request queue settings. MaxConcurrent
+// 0 leaves providers unbounded; the queue limits apply once it is set.
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		MaxConcurrent: 0,
+		MaxQueue:      100,
+		MaxWait:       30 * time.Second,
+	}
+}
+
+// TokenPacingConfig paces upstream requests per provider to stay under a
+// tokens-per-minute budget. Each request is charged its estimated prompt tokens
+// plus a running average of completion tokens, and waits until the budget has
+// refilled; a request that would wait longer than MaxWait is rejected with a
+// 429. TokensPerMinute 0 disables pacing.
+type TokenPacingConfig struct {
+	TokensPerMinute int           `yaml:"tokens_per_minute" env:"PROVIDER_TOKENS_PER_MINUTE"`
+	MaxWait         time.Duration `yaml:"max_wait"          env:"PROVIDER_TOKEN_PACING_MAX_WAIT"`
+}
+
+// DefaultTokenPacingConfig returns the default token pacing settings. Pacing is
+// off until TokensPerMinute is set.
+func DefaultTokenPacingConfig() TokenPacingConfig {
+	return TokenPacingConfig{
+		TokensPerMinute: 0,
+		MaxWait:         30 * time.Second,
+	}
+}
+
+// ResilienceConfig holds resolved resilience settings (retry, circuit breaker,
+// request queue, and token pacing).
 type ResilienceConfig struct {
 	Retry          RetryConfig          `yaml:"retry"`
 	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+	Queue          QueueConfig          `yaml:"queue"`
+	TokenPacing    TokenPacingConfig    `yaml:"token_pacing"`
 }
 
 // RawResilienceConfig holds optional per-provider resilience overrides from YAML.
@@ -51,14 +109,32 @@ type ResilienceConfig struct {
 type RawResilienceConfig struct {
 	Retry          *RawRetryConfig          `yaml:"retry"`
 	CircuitBreaker *RawCircuitBreakerConfig `yaml:"circuit_breaker"`
+	Queue          *RawQueueConfig          `yaml:"queue"`
+	TokenPacing    *RawTokenPacingConfig    `yaml:"token_pacing"`
+}
+
+// RawTokenPacingConfig holds optional per-provider token pacing overrides from
+// YAML. Nil fields inherit from the global TokenPacingConfig.
+type RawTokenPacingConfig struct {
+	TokensPerMinute *int           `yaml:"tokens_per_minute"`
+	MaxWait         *time.Duration `yaml:"max_wait"`
+}
+
+// RawQueueConfig holds optional per-provider request queue overrides from YAML.
+// Nil fields inherit from the global QueueConfig.
+type RawQueueConfig struct {
+	MaxConcurrent *int           `yaml:"max_concurrent"`
+	MaxQueue      *int           `yaml:"max_queue"`
+	MaxWait       *time.Duration `yaml:"max_wait"`
 }
 
 // RawCircuitBreakerConfig holds optional per-provider circuit breaker overrides from YAML.
 // Nil fields inherit from the global CircuitBreakerConfig.
 type RawCircuitBreakerConfig struct {
-	FailureThreshold *int           `yaml:"failure_threshold"`
-	SuccessThreshold *int           `yaml:"success_threshold"`
-	Timeout          *time.Duration `yaml:"timeout"`
+	FailureThreshold  *int           `yaml:"failure_threshold"`
+	SuccessThreshold  *int           `yaml:"success_threshold"`
+	Timeout           *time.Duration `yaml:"timeout"`
+	SlowCallThreshold *time.Duration `yaml:"slow_call_threshold"`
 }
 
 // RawRetryConfig holds optional per-provider retry overrides from YAML.
@@ -69,4 +145,6 @@ type RawRetryConfig struct {
 	MaxBackoff     *time.Duration `yaml:"max_backoff"`
 	BackoffFactor  *float64       `yaml:"backoff_factor"`
 	JitterFactor   *float64       `yaml:"jitter_factor"`
+	BodyErrorCodes []string       `yaml:"body_error_codes"`
+	MaxElapsedTime *time.Duration `yaml:"max_elapsed_time"`
 }
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RoutingConfig controls how the gateway picks a provider when several
+// providers serve the requested bare model ID (see
+// models.duplicate_model_policy "all").
+type RoutingConfig struct {
+	// Strategy selects among the providers serving a bare model ID.
+	// Supported values: "round_robin", "first_wins". Default: unset, which
+	// resolves to "round_robin". Setting it under any other duplicate model
+	// policy fails validation.
+	Strategy RoutingStrategy `yaml:"strategy" env:"ROUTING_STRATEGY"`
+}
+
+// RoutingStrategy names a provider selection strategy.
+type RoutingStrategy string
+
+const (
+	// RoutingStrategyRoundRobin rotates across the candidate providers.
+	RoutingStrategyRoundRobin RoutingStrategy = "round_robin"
+	// RoutingStrategyFirstWins always picks the first candidate in resolution
+	// order (preferred provider, then priority or registration order).
+	RoutingStrategyFirstWins RoutingStrategy = "first_wins"
+)
+
+// Valid reports whether strategy is one of the supported routing strategies.
+func (s RoutingStrategy) Valid() bool {
+	switch ResolveRoutingStrategy(s) {
+	case RoutingStrategyRoundRobin, RoutingStrategyFirstWins:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveRoutingStrategy canonicalizes strategy and applies the default.
+// Hyphenated spellings such as "round-robin" are accepted.
+func ResolveRoutingStrategy(strategy RoutingStrategy) RoutingStrategy {
+	normalized := strings.ToLower(strings.TrimSpace(string(strategy)))
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	if normalized == "" {
+		return RoutingStrategyRoundRobin
+	}
+	return RoutingStrategy(normalized)
+}
+
+// ValidateRoutingConfig rejects routing settings that would have no effect.
+// routing.strategy and providers.<name>.weight only choose among providers
+// sharing a bare model ID, which happens under models.duplicate_model_policy
+// "all", and weights only bias round_robin. It takes the raw provider map
+// because weights are not part of Config; an unset routing.strategy is
+// always accepted.
+func ValidateRoutingConfig(routing RoutingConfig, policy DuplicateModelPolicy, raw map[string]RawProviderConfig) error {
+	shared := ResolveDuplicateModelPolicy(policy) == DuplicateModelPolicyAll
+	var errs []error
+	if !shared && strings.TrimSpace(string(routing.Strategy)) != "" {
+		errs = append(errs, fmt.Errorf("routing.strategy only applies with models.duplicate_model_policy %q", DuplicateModelPolicyAll))
+	}
+	firstWins := ResolveRoutingStrategy(routing.Strategy) == RoutingStrategyFirstWins
+
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if raw[name].Weight == 0 {
+			continue
+		}
+		switch {
+		case !shared:
+			errs = append(errs, fmt.Errorf("providers.%s.weight only applies with models.duplicate_model_policy %q", name, DuplicateModelPolicyAll))
+		case firstWins:
+			errs = append(errs, fmt.Errorf("providers.%s.weight only applies with routing.strategy %q", name, RoutingStrategyRoundRobin))
+		}
+	}
+	return errors.Join(errs...)
+}
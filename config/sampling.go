@@ -0,0 +1,31 @@
+package config
+
+import "fmt"
+
+// SamplingConfig enforces an org-wide sampling policy on chat and Responses
+// requests for every provider. Client values outside a range are clamped to
+// the nearest bound. A negative bound (the default) leaves that side open;
+// setting a minimum equal to the maximum pins the parameter, which also fills
+// it in for requests that omit it.
+type SamplingConfig struct {
+	MinTemperature float64 `yaml:"min_temperature" env:"SAMPLING_MIN_TEMPERATURE"`
+	MaxTemperature float64 `yaml:"max_temperature" env:"SAMPLING_MAX_TEMPERATURE"`
+	MinTopP        float64 `yaml:"min_top_p"       env:"SAMPLING_MIN_TOP_P"`
+	MaxTopP        float64 `yaml:"max_top_p"       env:"SAMPLING_MAX_TOP_P"`
+}
+
+// Enabled reports whether any sampling bound is configured.
+func (c SamplingConfig) Enabled() bool {
+	return c.MinTemperature >= 0 || c.MaxTemperature >= 0 || c.MinTopP >= 0 || c.MaxTopP >= 0
+}
+
+// ValidateSamplingConfig rejects ranges whose minimum exceeds their maximum.
+func ValidateSamplingConfig(cfg SamplingConfig) error {
+	if cfg.MinTemperature >= 0 && cfg.MaxTemperature >= 0 && cfg.MinTemperature > cfg.MaxTemperature {
+		return fmt.Errorf("sampling.min_temperature (%g) must not exceed sampling.max_temperature (%g)", cfg.MinTemperature, cfg.MaxTemperature)
+	}
+	if cfg.MinTopP >= 0 && cfg.MaxTopP >= 0 && cfg.MinTopP > cfg.MaxTopP {
+		return fmt.Errorf("sampling.min_top_p (%g) must not exceed sampling.max_top_p (%g)", cfg.MinTopP, cfg.MaxTopP)
+	}
+	return nil
+}
@@ -3,10 +3,12 @@ package config
 import (
 	"fmt"
 	"net/textproto"
+	"net/url"
 	"path"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Body size limit constants
@@ -16,13 +18,21 @@ const (
 	MaxBodySizeLimit     int64 = 100 * 1024 * 1024 // 100MB
 )
 
+// userPathHeaderDefault is the header used when server.user_path_header is unset.
+const userPathHeaderDefault = "X-GoModel-User-Path"
+
 var bodySizeLimitRegex = regexp.MustCompile(`(?i)^(\d+)([KMG])?B?$`)
 
 // ServerConfig holds HTTP server configuration
 type ServerConfig struct {
-	Port           string `yaml:"port" env:"PORT"`
-	BasePath       string `yaml:"base_path" env:"BASE_PATH"`             // URL path prefix where the app is mounted (e.g., "/g")
-	MasterKey      string `yaml:"master_key" env:"GOMODEL_MASTER_KEY"`   // Optional: Master key for authentication
+	Port      string `yaml:"port" env:"PORT"`
+	BasePath  string `yaml:"base_path" env:"BASE_PATH"`           // URL path prefix where the app is mounted (e.g., "/g")
+	MasterKey string `yaml:"master_key" env:"GOMODEL_MASTER_KEY"` // Optional: Master key for authentication
+	// AuthKeysFile points at a YAML or JSON file of managed API keys merged
+	// with the persisted keys. The file is watched and reloaded on change, so
+	// keys can be added, bound to other user paths, or disabled without a
+	// restart. Default: "" (persisted keys only).
+	AuthKeysFile   string `yaml:"auth_keys_file" env:"AUTH_KEYS_FILE"`
 	BodySizeLimit  string `yaml:"body_size_limit" env:"BODY_SIZE_LIMIT"` // Max request body size (e.g., "10M", "1024K")
 	SwaggerEnabled bool   `yaml:"swagger_enabled" env:"SWAGGER_ENABLED"` // Whether to expose the Swagger UI at /swagger/index.html
 	PprofEnabled   bool   `yaml:"pprof_enabled" env:"PPROF_ENABLED"`     // Whether to expose debug profiling routes at /debug/pprof/*
@@ -43,6 +53,178 @@ type ServerConfig struct {
 	// at /v1/realtime and the /p/{provider}/v1/realtime passthrough upgrade.
 	// Default: true. Only providers implementing realtime accept sessions.
 	RealtimeEnabled bool `yaml:"realtime_enabled" env:"REALTIME_ENABLED"`
+	// ResponsesAPIEnabled exposes the OpenAI Responses API under /v1/responses,
+	// including streaming and the stored-response lifecycle routes. Disabled
+	// routes return 404. Default: true.
+	ResponsesAPIEnabled bool `yaml:"responses_api_enabled" env:"RESPONSES_API_ENABLED"`
+	// LenientRequestShape handles a Responses-shaped body ("input" without
+	// "messages") posted to /v1/chat/completions as a Responses request, and a
+	// chat-shaped body posted to /v1/responses as a chat completion. Enabling
+	// it buffers and scans every body posted to those endpoints before
+	// routing. Default: false (bodies are served only where they were posted).
+	LenientRequestShape bool `yaml:"lenient_request_shape" env:"LENIENT_REQUEST_SHAPE"`
+	// HealthStatusCode is the 2xx status GET /health returns; 204 sends no
+	// body. Default: 200.
+	HealthStatusCode int `yaml:"health_status_code" env:"HEALTH_STATUS_CODE"`
+	// HealthBody replaces the default {"status":"ok"} body of GET /health.
+	// Valid JSON is served as application/json, anything else as text/plain.
+	HealthBody string `yaml:"health_body" env:"HEALTH_BODY"`
+	// HealthIncludeBuildInfo adds version, commit, and uptime to the default
+	// GET /health body. Ignored when HealthBody is set. Default: false.
+	HealthIncludeBuildInfo bool `yaml:"health_include_build_info" env:"HEALTH_INCLUDE_BUILD_INFO"`
+	// MaxToolsPerRequest caps the tools array of chat, Responses, and Messages
+	// requests; larger requests are rejected before dispatch. 0 disables the
+	// cap. Default: 512.
+	MaxToolsPerRequest int `yaml:"max_tools_per_request" env:"MAX_TOOLS_PER_REQUEST"`
+	// ModelNotFoundStatus is the status returned for requests naming a model
+	// the gateway cannot route: 400 or 404. The body is an
+	// invalid_request_error with code "model_not_found" either way. Default: 400.
+	ModelNotFoundStatus int `yaml:"model_not_found_status" env:"MODEL_NOT_FOUND_STATUS"`
+	// APIKeys lists static bearer keys accepted alongside the master key,
+	// each with a name recorded as the request's auth key identity. Removing
+	// an entry revokes that key on the next restart. YAML only.
+	APIKeys []APIKeyConfig `yaml:"api_keys"`
+	// CORS enables cross-origin browser access to /v1 routes. Disabled (no
+	// CORS headers) unless AllowedOrigins is set.
+	CORS CORSConfig `yaml:"cors"`
+	// Timeouts bounds how long the HTTP server waits on slow clients, guarding
+	// against slow-loris connections.
+	Timeouts ServerTimeoutsConfig `yaml:"timeouts"`
+	// Stream tunes how streamed responses are written to clients.
+	Stream ServerStreamConfig `yaml:"stream"`
+}
+
+// ServerStreamConfig configures streamed (SSE) responses.
+type ServerStreamConfig struct {
+	// KeepaliveInterval writes an SSE comment line (": keepalive") when the
+	// upstream has sent nothing for this long, so load balancers and clients
+	// do not drop the connection during long thinking pauses. Go duration
+	// ("15s"); zero disables. Default: 0.
+	KeepaliveInterval time.Duration `yaml:"keepalive_interval" env:"STREAM_KEEPALIVE_INTERVAL"`
+}
+
+// ServerTimeoutsConfig configures the inbound http.Server timeouts. Values
+// are Go durations ("10s", "2m"); zero keeps the built-in default.
+type ServerTimeoutsConfig struct {
+	// ReadHeader limits reading request headers. Default: 10s.
+	ReadHeader time.Duration `yaml:"read_header" env:"SERVER_READ_HEADER_TIMEOUT"`
+	// Read limits reading the whole request, body included. Default: 30s.
+	Read time.Duration `yaml:"read" env:"SERVER_READ_TIMEOUT"`
+	// Write limits writing the response on ordinary routes. Model interaction
+	// routes (/v1 inference and /p/ passthrough) clear it per request so
+	// long-running and streaming responses are not cut off. Default: 30s.
+	Write time.Duration `yaml:"write" env:"SERVER_WRITE_TIMEOUT"`
+	// Idle limits how long a keep-alive connection waits for its next
+	// request. Default: 120s.
+	Idle time.Duration `yaml:"idle" env:"SERVER_IDLE_TIMEOUT"`
+}
+
+// ValidateServerTimeouts rejects negative server timeouts.
+func ValidateServerTimeouts(t ServerTimeoutsConfig) error {
+	for _, field := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"read_header", t.ReadHeader},
+		{"read", t.Read},
+		{"write", t.Write},
+		{"idle", t.Idle},
+	} {
+		if field.value < 0 {
+			return fmt.Errorf("server.timeouts.%s must be non-negative, got %s", field.name, field.value)
+		}
+	}
+	return nil
+}
+
+// ValidateServerStream rejects a negative keepalive interval.
+func ValidateServerStream(s ServerStreamConfig) error {
+	if s.KeepaliveInterval < 0 {
+		return fmt.Errorf("server.stream.keepalive_interval must be non-negative, got %s", s.KeepaliveInterval)
+	}
+	return nil
+}
+
+// CORSConfig configures CORS for /v1 routes.
+type CORSConfig struct {
+	// AllowedOrigins lists origins echoed in Access-Control-Allow-Origin,
+	// e.g. "https://app.example.com", or "*" for any origin.
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	// AllowedMethods answers preflights. Default: the route's methods.
+	AllowedMethods []string `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	// AllowedHeaders answers preflights. Default: the headers requested.
+	AllowedHeaders []string `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	// AllowCredentials sends Access-Control-Allow-Credentials: true. It
+	// cannot be combined with the "*" origin.
+	AllowCredentials bool `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+}
+
+// ValidateCORSConfig requires each allowed origin to be "*" or a
+// scheme://host[:port] origin, and rejects credentials with "*".
+func ValidateCORSConfig(c CORSConfig) error {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			if c.AllowCredentials {
+				return fmt.Errorf("server.cors: allow_credentials cannot be used with the \"*\" origin")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("server.cors.allowed_origins: %q must be \"*\" or scheme://host[:port]", origin)
+		}
+	}
+	return nil
+}
+
+// APIKeyConfig is one static API key from server.api_keys.
+type APIKeyConfig struct {
+	// Name identifies the key in audit logs and usage; it is never the secret.
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+// ValidateAPIKeys requires every static API key to have a name and a key,
+// with no name or key repeated.
+func ValidateAPIKeys(keys []APIKeyConfig) error {
+	names := make(map[string]struct{}, len(keys))
+	secrets := make(map[string]struct{}, len(keys))
+	for i, k := range keys {
+		name := strings.TrimSpace(k.Name)
+		if name == "" {
+			return fmt.Errorf("server.api_keys[%d]: name is required", i)
+		}
+		if strings.TrimSpace(k.Key) == "" {
+			return fmt.Errorf("server.api_keys[%d] (%s): key is required", i, name)
+		}
+		if _, dup := names[name]; dup {
+			return fmt.Errorf("server.api_keys[%d]: duplicate name %q", i, name)
+		}
+		if _, dup := secrets[k.Key]; dup {
+			return fmt.Errorf("server.api_keys[%d] (%s): key duplicates an earlier entry", i, name)
+		}
+		names[name] = struct{}{}
+		secrets[k.Key] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateModelNotFoundStatus accepts only 400 and 404, the two statuses
+// OpenAI-compatible SDKs expect for unknown models.
+func ValidateModelNotFoundStatus(code int) error {
+	if code != 400 && code != 404 {
+		return fmt.Errorf("server.model_not_found_status must be 400 or 404, got %d", code)
+	}
+	return nil
+}
+
+// ValidateHealthStatusCode rejects health-check status codes outside 2xx; a
+// non-success liveness response would take every instance out of rotation.
+func ValidateHealthStatusCode(code int) error {
+	if code < 200 || code > 299 {
+		return fmt.Errorf("server.health_status_code must be a 2xx status, got %d", code)
+	}
+	return nil
 }
 
 var headerNameRegex = regexp.MustCompile(`^[!#$%&'*+\-.^_` + "`" + `|~0-9A-Za-z]+$`)
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Built-in request transformer types.
+const (
+	TransformerTypeSystemPrompt = "system_prompt"
+	TransformerTypeStripFields  = "strip_fields"
+)
+
+// protectedTransformerFields are request fields a strip_fields transformer may
+// not remove because routing or the request shape depends on them.
+var protectedTransformerFields = map[string]struct{}{
+	"model":    {},
+	"provider": {},
+	"messages": {},
+	"input":    {},
+	"stream":   {},
+}
+
+// TransformerConfig declares one built-in transformer applied to chat and
+// Responses requests. Transformers run in the order listed, before guardrails
+// and provider routing.
+type TransformerConfig struct {
+	// Type selects the transformer: "system_prompt" or "strip_fields".
+	Type string `yaml:"type"`
+
+	// Content is the text a system_prompt transformer prepends to the system
+	// prompt, combined as a provider's system_prompt_prefix is.
+	Content string `yaml:"content,omitempty"`
+
+	// Fields lists the top-level request fields a strip_fields transformer
+	// removes before the request is forwarded.
+	Fields []string `yaml:"fields,omitempty"`
+}
+
+// ValidateTransformers rejects unknown transformer types and entries missing
+// the settings their type requires.
+func ValidateTransformers(transformers []TransformerConfig) error {
+	for i, t := range transformers {
+		switch strings.TrimSpace(t.Type) {
+		case TransformerTypeSystemPrompt:
+			if strings.TrimSpace(t.Content) == "" {
+				return fmt.Errorf("transformers[%d]: system_prompt requires content", i)
+			}
+		case TransformerTypeStripFields:
+			if len(t.Fields) == 0 {
+				return fmt.Errorf("transformers[%d]: strip_fields requires fields", i)
+			}
+			for _, field := range t.Fields {
+				field = strings.TrimSpace(field)
+				if field == "" {
+					return fmt.Errorf("transformers[%d]: strip_fields entries must not be empty", i)
+				}
+				if _, ok := protectedTransformerFields[field]; ok {
+					return fmt.Errorf("transformers[%d]: strip_fields cannot remove %q", i, field)
+				}
+			}
+		default:
+			return fmt.Errorf("transformers[%d]: type must be one of: system_prompt, strip_fields", i)
+		}
+	}
+	return nil
+}
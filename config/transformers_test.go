@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTransformers(t *testing.T) {
+	tests := []struct {
+		name         string
+		transformers []TransformerConfig
+		wantErr      string
+	}{
+		{name: "none"},
+		{
+			name: "valid chain",
+			transformers: []TransformerConfig{
+				{Type: "strip_fields", Fields: []string{"user", "metadata"}},
+				{Type: "system_prompt", Content: "Be brief."},
+			},
+		},
+		{name: "unknown type", transformers: []TransformerConfig{{Type: "rename"}}, wantErr: "type must be one of"},
+		{name: "empty system prompt", transformers: []TransformerConfig{{Type: "system_prompt", Content: " "}}, wantErr: "requires content"},
+		{name: "no fields", transformers: []TransformerConfig{{Type: "strip_fields"}}, wantErr: "requires fields"},
+		{name: "protected field", transformers: []TransformerConfig{{Type: "strip_fields", Fields: []string{"model"}}}, wantErr: `cannot remove "model"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransformers(tt.transformers)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateTransformers() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateTransformers() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validate checks a fully resolved Config and returns every problem found,
+// joined into one error, so an operator can fix a broken config in a single
+// pass instead of one restart per mistake. Load calls it as its last step.
+func Validate(cfg *Config) error {
+	if cfg == nil {
+		return fmt.Errorf("config is required")
+	}
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	check(ValidateServerPort(cfg.Server.Port))
+	check(ValidateMetricsEndpoint(cfg.Metrics))
+	if cfg.Server.BodySizeLimit != "" {
+		if err := ValidateBodySizeLimit(cfg.Server.BodySizeLimit); err != nil {
+			check(fmt.Errorf("invalid BODY_SIZE_LIMIT: %w", err))
+		}
+	}
+	if cfg.HTTP.StreamMaxEventSize != "" {
+		if err := ValidateBodySizeLimit(cfg.HTTP.StreamMaxEventSize); err != nil {
+			check(fmt.Errorf("invalid HTTP_STREAM_MAX_EVENT_SIZE: %w", err))
+		}
+	}
+	check(ValidateHealthStatusCode(cfg.Server.HealthStatusCode))
+	check(ValidateModelNotFoundStatus(cfg.Server.ModelNotFoundStatus))
+	if _, err := NormalizeHeaderName(cfg.Server.UserPathHeader, userPathHeaderDefault); err != nil {
+		check(fmt.Errorf("invalid server.user_path_header: %w", err))
+	}
+	check(ValidateAPIKeys(cfg.Server.APIKeys))
+	check(ValidateCORSConfig(cfg.Server.CORS))
+	check(ValidateServerTimeouts(cfg.Server.Timeouts))
+	check(ValidateServerStream(cfg.Server.Stream))
+	check(ValidateCacheConfig(&cfg.Cache))
+	check(ValidateSamplingConfig(cfg.Sampling))
+	check(ValidateTransformers(cfg.Transformers))
+	check(ValidateModelsConfig(cfg.Models))
+	if !cfg.Routing.Strategy.Valid() {
+		check(fmt.Errorf("routing.strategy must be one of: round_robin, first_wins"))
+	}
+	check(validateOutputPacingConfig(&cfg.OutputPacing))
+	return errors.Join(errs...)
+}
+
+// ValidateServerPort requires server.port to be a TCP port number.
+func ValidateServerPort(port string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(port))
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("server.port must be a number between 1 and 65535, got %q", port)
+	}
+	return nil
+}
+
+// ValidateMetricsEndpoint rejects an enabled metrics endpoint that would
+// shadow the API routes: the endpoint skips authentication, so mounting it
+// under /v1 or /p would expose it on an authenticated prefix.
+func ValidateMetricsEndpoint(m MetricsConfig) error {
+	if !m.Enabled || strings.TrimSpace(m.Endpoint) == "" {
+		return nil
+	}
+	cleaned := path.Clean(m.Endpoint)
+	for _, prefix := range []string{"/v1", "/p"} {
+		if cleaned == prefix || strings.HasPrefix(cleaned, prefix+"/") {
+			return fmt.Errorf("metrics.endpoint %q conflicts with the %s API routes; choose a path such as /metrics", m.Endpoint, prefix)
+		}
+	}
+	return nil
+}
+
+// ValidateProviderTypes checks that every provider declared in config.yaml
+// names one of the registered provider types. It lives apart from Validate
+// because the registered types are only known to the provider factory.
+func ValidateProviderTypes(raw map[string]RawProviderConfig, registered []string) error {
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	known := slices.Sorted(slices.Values(registered))
+
+	var errs []error
+	for _, name := range names {
+		providerType := strings.TrimSpace(raw[name].Type)
+		switch {
+		case providerType == "":
+			errs = append(errs, fmt.Errorf("providers.%s: type is required", name))
+		case !slices.Contains(known, providerType):
+			errs = append(errs, fmt.Errorf("providers.%s: unknown type %q; registered types: %s",
+				name, providerType, strings.Join(known, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr []string
+	}{
+		{
+			name:   "defaults are valid",
+			mutate: func(*Config) {},
+		},
+		{
+			name:    "non-numeric port",
+			mutate:  func(cfg *Config) { cfg.Server.Port = "http" },
+			wantErr: []string{`server.port must be a number between 1 and 65535, got "http"`},
+		},
+		{
+			name:    "port out of range",
+			mutate:  func(cfg *Config) { cfg.Server.Port = "70000" },
+			wantErr: []string{"server.port must be a number between 1 and 65535"},
+		},
+		{
+			name: "metrics endpoint under /v1",
+			mutate: func(cfg *Config) {
+				cfg.Metrics.Enabled = true
+				cfg.Metrics.Endpoint = "/v1/metrics"
+			},
+			wantErr: []string{`metrics.endpoint "/v1/metrics" conflicts with the /v1 API routes`},
+		},
+		{
+			name: "metrics endpoint escaping to /p",
+			mutate: func(cfg *Config) {
+				cfg.Metrics.Enabled = true
+				cfg.Metrics.Endpoint = "/metrics/../p/x"
+			},
+			wantErr: []string{"conflicts with the /p API routes"},
+		},
+		{
+			name: "disabled metrics endpoint is not checked",
+			mutate: func(cfg *Config) {
+				cfg.Metrics.Endpoint = "/v1/metrics"
+			},
+		},
+		{
+			name:    "invalid stream max event size",
+			mutate:  func(cfg *Config) { cfg.HTTP.StreamMaxEventSize = "lots" },
+			wantErr: []string{"invalid HTTP_STREAM_MAX_EVENT_SIZE"},
+		},
+		{
+			name: "no model cache backend",
+			mutate: func(cfg *Config) {
+				cfg.Cache.Model.Local = nil
+			},
+			wantErr: []string{"cache.model: must have one of local, redis, memory, or memcached configured"},
+		},
+		{
+			name: "all problems are reported together",
+			mutate: func(cfg *Config) {
+				cfg.Server.Port = "abc"
+				cfg.Metrics.Enabled = true
+				cfg.Metrics.Endpoint = "/v1"
+				cfg.Cache.Model.Local = nil
+			},
+			wantErr: []string{"server.port", "metrics.endpoint", "cache.model"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := buildDefaultConfig()
+			cfg.Cache.Model.Local = &LocalCacheConfig{}
+			tt.mutate(cfg)
+
+			err := Validate(cfg)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateProviderTypes(t *testing.T) {
+	registered := []string{"openai", "anthropic"}
+
+	if err := ValidateProviderTypes(map[string]RawProviderConfig{
+		"primary": {Type: "openai"},
+		"claude":  {Type: " anthropic "},
+	}, registered); err != nil {
+		t.Fatalf("ValidateProviderTypes() error = %v, want nil", err)
+	}
+
+	err := ValidateProviderTypes(map[string]RawProviderConfig{
+		"primary": {Type: "openai"},
+		"typo":    {Type: "opnai"},
+		"missing": {},
+	}, registered)
+	if err == nil {
+		t.Fatal("ValidateProviderTypes() error = nil, want unknown and missing types reported")
+	}
+	for _, want := range []string{
+		"providers.missing: type is required",
+		`providers.typo: unknown type "opnai"; registered types: anthropic, openai`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "primary") {
+		t.Errorf("error = %q, want valid providers left out", err)
+	}
+}
+
+func TestLoad_ReportsAllValidationErrors(t *testing.T) {
+	clearAllConfigEnvVars(t)
+	withTempDir(t, func(dir string) {
+		yaml := `
+server:
+  port: "eighty"
+metrics:
+  enabled: true
+  endpoint: /v1/metrics
+`
+		if err := os.WriteFile("config.yaml", []byte(yaml), 0o600); err != nil {
+			t.Fatalf("write config.yaml: %v", err)
+		}
+
+		_, err := Load()
+		if err == nil {
+			t.Fatal("Load() error = nil, want validation errors")
+		}
+		for _, want := range []string{"server.port", "metrics.endpoint"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Load() error = %q, want it to contain %q", err, want)
+			}
+		}
+	})
+}
@@ -16,7 +16,8 @@ type VirtualModelConfig struct {
 	Source string `yaml:"source" json:"source"`
 
 	// Strategy selects load balancing across multiple targets: "round_robin"
-	// (default) or "cost". Ignored for single-target aliases and access policies.
+	// (default), "cost", or "race". Ignored for single-target aliases and access
+	// policies.
 	Strategy string `yaml:"strategy,omitempty" json:"strategy,omitempty"`
 
 	// Target is shorthand for a single-target alias, e.g. "openai/gpt-4o". Use
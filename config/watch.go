@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const envConfigWatchInterval = "CONFIG_WATCH_INTERVAL"
+
+// ResolveWatchInterval reads CONFIG_WATCH_INTERVAL, the number of seconds
+// between config file checks. Unset or 0 disables watching.
+//
+// Like CONFIG_STRICT it is read directly from the environment: it controls
+// whether configuration is re-read at all, so it cannot live in Config.
+func ResolveWatchInterval() (time.Duration, error) {
+	raw := strings.TrimSpace(os.Getenv(envConfigWatchInterval))
+	if raw == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid %s: %q is not a non-negative number of seconds", envConfigWatchInterval, raw)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Interval is how often the watched files are checked. Required.
+	Interval time.Duration
+	// ExtraPaths are watched alongside the config.yaml search paths, e.g.
+	// ".env". A change to any of them triggers a reload.
+	ExtraPaths []string
+	// BeforeLoad, when set, runs after a change is detected and before Load,
+	// so the caller can refresh the process environment from ExtraPaths.
+	BeforeLoad func()
+}
+
+// Watch polls the config file search paths and opts.ExtraPaths in the
+// background, and re-runs Load whenever their content changes. onChange
+// receives each successfully loaded result; a load error is logged and the
+// previous configuration stays in effect until the files change again.
+//
+// The current file contents are captured before Watch returns, so only later
+// edits trigger a reload. Polling stops when ctx is done or the returned stop
+// function is called; stop waits for an in-progress reload to finish.
+//
+// Polling is used instead of filesystem notifications because editors and
+// Kubernetes ConfigMap mounts replace files through renames and symlink
+// swaps, which notification watches tend to lose.
+func Watch(ctx context.Context, opts WatchOptions, onChange func(*LoadResult)) (stop func()) {
+	if opts.Interval <= 0 || onChange == nil {
+		return func() {}
+	}
+	paths := append(append([]string(nil), configFilePaths...), opts.ExtraPaths...)
+	last := snapshotWatchedFiles(paths)
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current := snapshotWatchedFiles(paths)
+			if current == last {
+				continue
+			}
+			last = current
+
+			if opts.BeforeLoad != nil {
+				opts.BeforeLoad()
+			}
+			result, err := Load()
+			if err != nil {
+				slog.Error("config reload failed; keeping current configuration", "error", err)
+				continue
+			}
+			onChange(result)
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// snapshotWatchedFiles hashes the contents of paths in order. A missing file
+// contributes a distinct marker so creating or deleting one counts as a change;
+// an unreadable file is treated as unchanged content and reported by Load.
+func snapshotWatchedFiles(paths []string) [sha256.Size]byte {
+	h := sha256.New()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			fmt.Fprintf(h, "%s\x00%d\x00", path, len(data))
+			h.Write(data)
+		case errors.Is(err, fs.ErrNotExist):
+			fmt.Fprintf(h, "%s\x00missing\x00", path)
+		default:
+			fmt.Fprintf(h, "%s\x00unreadable\x00", path)
+		}
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResolveWatchInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "unset disables", value: "", want: 0},
+		{name: "zero disables", value: "0", want: 0},
+		{name: "seconds", value: "5", want: 5 * time.Second},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "not a number", value: "5s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_WATCH_INTERVAL", tt.value)
+			got, err := ResolveWatchInterval()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveWatchInterval() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveWatchInterval() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveWatchInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatch_ReloadsProvidersOnConfigChange(t *testing.T) {
+	clearAllConfigEnvVars(t)
+	withTempDir(t, func(dir string) {
+		writeWatchConfig(t, `
+providers:
+  openai:
+    type: openai
+    api_key: sk-one
+`)
+
+		results := startWatch(t, WatchOptions{Interval: 10 * time.Millisecond})
+
+		writeWatchConfig(t, `
+providers:
+  openai:
+    type: openai
+    api_key: sk-one
+  anthropic:
+    type: anthropic
+    api_key: sk-two
+`)
+
+		result := waitForReload(t, results)
+		if len(result.RawProviders) != 2 {
+			t.Fatalf("RawProviders = %v, want openai and anthropic", result.RawProviders)
+		}
+		if got := result.RawProviders["anthropic"].APIKey; got != "sk-two" {
+			t.Errorf("anthropic api_key = %q, want sk-two", got)
+		}
+	})
+}
+
+func TestWatch_KeepsCurrentConfigOnLoadError(t *testing.T) {
+	clearAllConfigEnvVars(t)
+	withTempDir(t, func(dir string) {
+		writeWatchConfig(t, `
+providers:
+  openai:
+    type: openai
+    api_key: sk-one
+`)
+
+		results := startWatch(t, WatchOptions{Interval: 10 * time.Millisecond})
+
+		writeWatchConfig(t, "providers: [not, a, map\n")
+		select {
+		case result := <-results:
+			t.Fatalf("onChange called with %v for an invalid config", result.RawProviders)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		writeWatchConfig(t, `
+providers:
+  groq:
+    type: groq
+    api_key: sk-three
+`)
+		result := waitForReload(t, results)
+		if _, ok := result.RawProviders["groq"]; !ok || len(result.RawProviders) != 1 {
+			t.Fatalf("RawProviders = %v, want only groq", result.RawProviders)
+		}
+	})
+}
+
+func TestWatch_ExtraPathRunsBeforeLoad(t *testing.T) {
+	clearAllConfigEnvVars(t)
+	withTempDir(t, func(dir string) {
+		if err := os.WriteFile(".env", []byte("GROQ_API_KEY=\n"), 0o600); err != nil {
+			t.Fatalf("write .env: %v", err)
+		}
+
+		results := startWatch(t, WatchOptions{
+			Interval:   10 * time.Millisecond,
+			ExtraPaths: []string{".env"},
+			BeforeLoad: func() { t.Setenv("BASE_PATH", "/reloaded") },
+		})
+
+		if err := os.WriteFile(".env", []byte("GROQ_API_KEY=sk-env\n"), 0o600); err != nil {
+			t.Fatalf("write .env: %v", err)
+		}
+
+		result := waitForReload(t, results)
+		if got := result.Config.Server.BasePath; got != "/reloaded" {
+			t.Errorf("BasePath = %q, want /reloaded (BeforeLoad must run before Load)", got)
+		}
+	})
+}
+
+func writeWatchConfig(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile("config.yaml", []byte(content), 0o600); err != nil {
+		t.Fatalf("write config.yaml: %v", err)
+	}
+}
+
+func startWatch(t *testing.T, opts WatchOptions) <-chan *LoadResult {
+	t.Helper()
+	results := make(chan *LoadResult, 4)
+	stop := Watch(context.Background(), opts, func(result *LoadResult) { results <- result })
+	t.Cleanup(stop)
+	return results
+}
+
+func waitForReload(t *testing.T, results <-chan *LoadResult) *LoadResult {
+	t.Helper()
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(2 * time.Second):
+		t.Fatal("onChange was not called after the config file changed")
+		return nil
+	}
+}
@@ -403,8 +403,10 @@
                 const errors = Number(requestHealth.errors || 0);
                 const minutes = Math.round(Number(requestHealth.window_seconds || 0) / 60);
                 const windowText = minutes > 0 ? 'last ' + minutes + ' min' : 'recent';
+                const p95 = Number(requestHealth.latency_p95_ms || 0);
                 return String(requests) + ' request' + (requests === 1 ? '' : 's') +
                     ' · ' + String(errors) + ' error' + (errors === 1 ? '' : 's') +
+                    (p95 > 0 ? ' · p95 ' + String(p95) + ' ms' : '') +
                     ' (' + windowText + ')';
             },
 
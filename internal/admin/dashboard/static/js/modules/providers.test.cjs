@@ -299,6 +299,15 @@ test('request health helpers summarize windowed traffic and breaker state', () =
     assert.equal(module.providerHealthModelTitle(provider.request_health.models[1]), '');
 });
 
+test('recent traffic summary includes p95 latency when reported', () => {
+    const module = createProvidersModule();
+    const provider = {
+        request_health: { requests: 3, errors: 0, window_seconds: 600, latency_p95_ms: 840 }
+    };
+
+    assert.equal(module.providerRecentTrafficSummary(provider), '3 requests · 0 errors · p95 840 ms (last 10 min)');
+});
+
 test('request health helpers tolerate providers without request health', () => {
     const module = createProvidersModule();
     const provider = { name: 'openai' };
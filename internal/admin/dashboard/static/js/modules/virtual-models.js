@@ -243,11 +243,16 @@
 
             // vmFormShowWeights reports whether per-target weight inputs are
             // meaningful. Only round-robin honors weight; cost balancing always
-            // routes to the cheapest target, so it hides weights to avoid implying
-            // they have an effect.
+            // routes to the cheapest target and race sends to every target, so
+            // both hide weights to avoid implying they have an effect.
             vmFormShowWeights() {
                 return this.vmFormShowStrategy()
-                    && String(this.vmForm.strategy || '').toLowerCase() !== 'cost';
+                    && this.strategyUsesWeights(this.vmForm.strategy);
+            },
+
+            strategyUsesWeights(strategy) {
+                const normalized = String(strategy || '').toLowerCase();
+                return normalized !== 'cost' && normalized !== 'race';
             },
 
             // targetEntry builds a {model, weight?} API target, attaching weight
@@ -290,6 +295,8 @@
                 switch (String(strategy || '').toLowerCase()) {
                     case 'cost':
                         return 'lowest cost';
+                    case 'race':
+                        return 'race';
                     case 'round_robin':
                     case '':
                         return 'round robin';
@@ -1088,9 +1095,10 @@
                 const lbTargets = Array.isArray(alias.targets) ? alias.targets : [];
                 if (lbTargets.length > 1) {
                     payload.strategy = alias.strategy || 'round_robin';
-                    // Weight only biases round-robin, so cost balancers persist
-                    // weight-less targets — same contract as the editor save path.
-                    payload.targets = payload.strategy === 'cost'
+                    // Weight only biases round-robin, so cost and race balancers
+                    // persist weight-less targets — same contract as the editor
+                    // save path.
+                    payload.targets = !this.strategyUsesWeights(payload.strategy)
                         ? lbTargets.map((target) => ({ model: this.qualifyTarget(target) }))
                         : lbTargets.map((target) =>
                             this.targetEntry(this.qualifyTarget(target), target.weight));
@@ -1485,10 +1493,11 @@
                     targets.push(...extraTargets);
                     if (targets.length > 1) {
                         // Multiple targets load balance; carry the chosen strategy.
-                        // Weight only biases round-robin, so cost balancers drop it
-                        // rather than persist a value that has no effect.
+                        // Weight only biases round-robin, so cost and race
+                        // balancers drop it rather than persist a value that has
+                        // no effect.
                         const strategy = this.vmForm.strategy || 'round_robin';
-                        payload.targets = strategy === 'cost'
+                        payload.targets = !this.strategyUsesWeights(strategy)
                             ? targets.map((target) => ({ model: target.model }))
                             : targets;
                         payload.strategy = strategy;
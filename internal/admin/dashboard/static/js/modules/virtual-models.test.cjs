@@ -1823,6 +1823,11 @@ test('vmFormShowWeights hides weight inputs unless round-robin balances 2+ targe
     module.vmForm.strategy = 'cost';
     assert.equal(module.vmFormShowStrategy(), true);
     assert.equal(module.vmFormShowWeights(), false);
+
+    // Race sends to every target, so weights are hidden as well.
+    module.vmForm.strategy = 'race';
+    assert.equal(module.vmFormShowStrategy(), true);
+    assert.equal(module.vmFormShowWeights(), false);
 });
 
 test('vmFormShowStrategy appears as soon as a second target row exists, even if blank', () => {
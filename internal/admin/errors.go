@@ -37,6 +37,14 @@ func featureUnavailableError(message string) error {
 		WithCode("feature_unavailable")
 }
 
+// refreshInProgressError reports a model refresh refused because another one
+// is running. It is a gateway state, not an upstream failure, so it carries
+// its own code rather than a provider_error.
+func refreshInProgressError(err error) error {
+	return core.NewInvalidRequestErrorWithStatus(http.StatusServiceUnavailable, "a model refresh is already in progress; retry shortly", err).
+		WithCode("refresh_in_progress")
+}
+
 func validationWriter(isValidation func(error) bool) func(error) error {
 	return func(err error) error {
 		if err == nil {
@@ -53,6 +53,7 @@ type Handler struct {
 	runtimeRefresher    RuntimeRefresher
 	configuredProviders []providers.SanitizedProviderConfig
 	requestHealth       RequestHealthSource
+	chatExecutor        ChatCompletionExecutor
 
 	mutationMu sync.Mutex
 	pricingMu  sync.Mutex
@@ -121,6 +122,20 @@ type providerStatusResponse struct {
 	Providers []providerStatusItemResponse  `json:"providers"`
 }
 
+// providerListItemResponse is one row of GET /admin/providers. CircuitState is
+// empty for providers without a circuit breaker.
+type providerListItemResponse struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	ModelCount   int    `json:"model_count"`
+	CircuitState string `json:"circuit_state,omitempty"`
+}
+
+type providerCircuitResetResponse struct {
+	Provider     string `json:"provider"`
+	CircuitState string `json:"circuit_state"`
+}
+
 type auditLogEntryResponse struct {
 	auditlog.LogEntry
 	Usage *usage.RequestUsageSummary `json:"usage,omitempty"`
@@ -272,6 +287,12 @@ type RequestHealthSource interface {
 	Snapshot() map[string]health.ProviderHealth
 }
 
+// circuitStateRecorder is implemented by request health sources that accept
+// circuit state updates outside of request traffic, such as operator resets.
+type circuitStateRecorder interface {
+	RecordCircuitState(providerName, state string)
+}
+
 // WithRequestHealth folds recent request outcomes (per-model errors and the
 // live circuit-breaker state) into the provider status endpoint.
 func WithRequestHealth(source RequestHealthSource) Option {
@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+const (
+	// compareMaxModels caps how many models one comparison may fan out to.
+	compareMaxModels = 10
+	// compareMaxConcurrency bounds the upstream calls in flight per comparison.
+	compareMaxConcurrency = 4
+)
+
+// ChatCompletionExecutor runs a non-streaming chat completion. The app wires
+// the provider router, wrapped with virtual-model redirects when enabled.
+type ChatCompletionExecutor interface {
+	ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error)
+}
+
+// WithChatExecutor enables the model comparison endpoint.
+func WithChatExecutor(executor ChatCompletionExecutor) Option {
+	return func(h *Handler) {
+		h.chatExecutor = executor
+	}
+}
+
+type compareModelsRequest struct {
+	Prompt      string   `json:"prompt"`
+	System      string   `json:"system,omitempty"`
+	Models      []string `json:"models"`
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+type compareModelResult struct {
+	Model     string      `json:"model"`
+	Provider  string      `json:"provider,omitempty"`
+	Content   string      `json:"content,omitempty"`
+	LatencyMS int64       `json:"latency_ms"`
+	Usage     *core.Usage `json:"usage,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type compareModelsResponse struct {
+	Results []compareModelResult `json:"results"`
+}
+
+// CompareModels handles POST /admin/compare.
+//
+// @Summary      Run one prompt against several models side by side
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      compareModelsRequest  true  "Prompt and models to compare"
+// @Success      200  {object}  compareModelsResponse
+// @Failure      400  {object}  core.GatewayError
+// @Failure      401  {object}  core.GatewayError
+// @Failure      403  {object}  core.GatewayError
+// @Failure      503  {object}  core.GatewayError
+// @Router       /admin/compare [post]
+func (h *Handler) CompareModels(c *echo.Context) error {
+	if h.chatExecutor == nil {
+		return handleError(c, featureUnavailableError("model comparison is unavailable"))
+	}
+	// Comparisons spend provider credit on arbitrary models, so managed API
+	// keys are refused even when they can reach the admin API.
+	if core.GetAuthKeyID(c.Request().Context()) != "" {
+		return handleError(c, core.NewInvalidRequestErrorWithStatus(http.StatusForbidden, "model comparison requires the master key", nil).
+			WithCode("forbidden"))
+	}
+
+	var req compareModelsRequest
+	if err := c.Bind(&req); err != nil {
+		return handleError(c, core.NewInvalidRequestError("invalid request body: "+err.Error(), err))
+	}
+	models, err := normalizeCompareRequest(&req)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	results := make([]compareModelResult, len(models))
+	sem := make(chan struct{}, compareMaxConcurrency)
+	var wg sync.WaitGroup
+	for i, model := range models {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = h.compareOne(c.Request().Context(), &req, model)
+		}()
+	}
+	wg.Wait()
+
+	return c.JSON(http.StatusOK, compareModelsResponse{Results: results})
+}
+
+func normalizeCompareRequest(req *compareModelsRequest) ([]string, error) {
+	if strings.TrimSpace(req.Prompt) == "" {
+		return nil, core.NewInvalidRequestError("prompt is required", nil)
+	}
+	models := make([]string, 0, len(req.Models))
+	seen := make(map[string]struct{}, len(req.Models))
+	for _, model := range req.Models {
+		model = strings.TrimSpace(model)
+		if model == "" {
+			continue
+		}
+		if _, ok := seen[model]; ok {
+			continue
+		}
+		seen[model] = struct{}{}
+		models = append(models, model)
+	}
+	if len(models) == 0 {
+		return nil, core.NewInvalidRequestError("models must list at least one model", nil)
+	}
+	if len(models) > compareMaxModels {
+		return nil, core.NewInvalidRequestError("models must not list more than 10 models", nil)
+	}
+	return models, nil
+}
+
+func (h *Handler) compareOne(ctx context.Context, req *compareModelsRequest, model string) compareModelResult {
+	messages := make([]core.Message, 0, 2)
+	if system := strings.TrimSpace(req.System); system != "" {
+		messages = append(messages, core.Message{Role: "system", Content: system})
+	}
+	messages = append(messages, core.Message{Role: "user", Content: req.Prompt})
+
+	result := compareModelResult{Model: model}
+	start := time.Now()
+	resp, err := h.chatExecutor.ChatCompletion(ctx, &core.ChatRequest{
+		Model:       model,
+		Messages:    messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+	})
+	result.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = compareErrorMessage(err)
+		return result
+	}
+	if resp == nil {
+		result.Error = "empty response"
+		return result
+	}
+	result.Provider = resp.Provider
+	usage := resp.Usage
+	result.Usage = &usage
+	if len(resp.Choices) > 0 {
+		result.Content = core.ExtractTextContent(resp.Choices[0].Message.Content)
+	}
+	return result
+}
+
+func compareErrorMessage(err error) string {
+	var gatewayErr *core.GatewayError
+	if errors.As(err, &gatewayErr) {
+		return gatewayErr.Message
+	}
+	return err.Error()
+}
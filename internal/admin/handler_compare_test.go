@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/concurrencytest"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+type compareTestExecutor struct {
+	calls concurrencytest.Gauge
+}
+
+func (e *compareTestExecutor) ChatCompletion(_ context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	defer e.calls.Enter()()
+	time.Sleep(10 * time.Millisecond)
+
+	if req.Model == "broken" {
+		return nil, core.NewModelNotFoundError(req.Model)
+	}
+	prompt := core.ExtractTextContent(req.Messages[len(req.Messages)-1].Content)
+	return &core.ChatResponse{
+		Model:    req.Model,
+		Provider: "mock",
+		Choices: []core.Choice{{
+			Message: core.ResponseMessage{Role: "assistant", Content: req.Model + " says: " + prompt},
+		}},
+		Usage: core.Usage{PromptTokens: 3, CompletionTokens: len(req.Model), TotalTokens: 3 + len(req.Model)},
+	}, nil
+}
+
+func serveCompare(t *testing.T, ctx context.Context, h *Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	h.RegisterRoutes(e.Group("/admin"))
+	req := httptest.NewRequest(http.MethodPost, "/admin/compare", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompareModels_ReturnsEachModelsCompletionInOrder(t *testing.T) {
+	executor := &compareTestExecutor{}
+	h := NewHandler(nil, nil, WithChatExecutor(executor))
+
+	rec := serveCompare(t, context.Background(), h, `{"prompt":"hi","models":["alpha","broken","gamma-long"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp compareModelsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(resp.Results))
+	}
+	alpha, broken, gamma := resp.Results[0], resp.Results[1], resp.Results[2]
+	if alpha.Model != "alpha" || alpha.Content != "alpha says: hi" || alpha.Provider != "mock" {
+		t.Fatalf("alpha result = %+v", alpha)
+	}
+	if alpha.Usage == nil || alpha.Usage.CompletionTokens != 5 {
+		t.Fatalf("alpha usage = %+v, want completion_tokens=5", alpha.Usage)
+	}
+	if gamma.Content != "gamma-long says: hi" || gamma.Usage == nil || gamma.Usage.CompletionTokens != 10 {
+		t.Fatalf("gamma result = %+v", gamma)
+	}
+	if broken.Error == "" || broken.Content != "" || broken.Usage != nil {
+		t.Fatalf("broken result = %+v, want only an error", broken)
+	}
+	if alpha.LatencyMS <= 0 {
+		t.Fatalf("alpha latency_ms = %d, want > 0", alpha.LatencyMS)
+	}
+}
+
+func TestCompareModels_BoundsConcurrency(t *testing.T) {
+	executor := &compareTestExecutor{}
+	h := NewHandler(nil, nil, WithChatExecutor(executor))
+
+	rec := serveCompare(t, context.Background(), h, `{"prompt":"hi","models":["m1","m2","m3","m4","m5","m6","m7","m8"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+	if peak := executor.calls.Peak(); peak > compareMaxConcurrency || peak < 2 {
+		t.Fatalf("peak concurrent calls = %d, want parallel and at most %d", peak, compareMaxConcurrency)
+	}
+}
+
+func TestCompareModels_RejectsInvalidRequests(t *testing.T) {
+	h := NewHandler(nil, nil, WithChatExecutor(&compareTestExecutor{}))
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing prompt", body: `{"models":["alpha"]}`},
+		{name: "no models", body: `{"prompt":"hi","models":[" "]}`},
+		{name: "too many models", body: `{"prompt":"hi","models":["1","2","3","4","5","6","7","8","9","10","11"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := serveCompare(t, context.Background(), h, tt.body); rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400 body=%s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompareModels_RequiresMasterKeyAndExecutor(t *testing.T) {
+	h := NewHandler(nil, nil, WithChatExecutor(&compareTestExecutor{}))
+	ctx := core.WithAuthKeyID(context.Background(), "key-1")
+	if rec := serveCompare(t, ctx, h, `{"prompt":"hi","models":["alpha"]}`); rec.Code != http.StatusForbidden {
+		t.Fatalf("managed key status = %d, want 403 body=%s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveCompare(t, context.Background(), NewHandler(nil, nil), `{"prompt":"hi","models":["alpha"]}`); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("no executor status = %d, want 503 body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -1,9 +1,13 @@
 package admin
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
 	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v5"
 
@@ -26,13 +30,17 @@ type modelInventoryResponse struct {
 }
 
 // ListModels handles GET /admin/models
-// Supports optional ?category= query param for filtering by model category.
+// Supports optional ?category= query param for filtering by model category and
+// ?provider= for filtering by provider instance name or type. Each entry
+// reports where it came from (live, cache, or configured) and whether its
+// provider owns the bare model ID after dedup.
 //
 // @Summary      List all registered models with provider info and access state
 // @Tags         admin
 // @Produce      json
 // @Security     BearerAuth
 // @Param        category    query     string  false  "Filter by model category"
+// @Param        provider    query     string  false  "Filter by provider name or type"
 // @Success      200  {array}  modelInventoryResponse
 // @Failure      400  {object}  core.GatewayError
 // @Failure      401  {object}  core.GatewayError
@@ -56,12 +64,13 @@ func (h *Handler) ListModels(c *echo.Context) error {
 		models = h.registry.ListModelsWithProvider()
 	}
 
-	if models == nil {
-		models = []providers.ModelWithProvider{}
-	}
+	providerFilter := strings.TrimSpace(c.QueryParam("provider"))
 	access := h.modelAccessResolver()
 	response := make([]modelInventoryResponse, 0, len(models))
 	for _, model := range models {
+		if providerFilter != "" && model.ProviderName != providerFilter && model.ProviderType != providerFilter {
+			continue
+		}
 		selector := core.ModelSelector{
 			Provider: strings.TrimSpace(model.ProviderName),
 			Model:    strings.TrimSpace(model.Model.ID),
@@ -141,3 +150,96 @@ func (h *Handler) ListCategories(c *echo.Context) error {
 func (h *Handler) DashboardConfig(c *echo.Context) error {
 	return c.JSON(http.StatusOK, cloneDashboardRuntimeConfig(h.runtimeConfig))
 }
+
+type modelRefreshProviderResponse struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	ModelCount int    `json:"model_count"`
+	Error      string `json:"error,omitempty"`
+}
+
+type modelRefreshResponse struct {
+	Status        string                         `json:"status"`
+	ModelCount    int                            `json:"model_count"`
+	ProviderCount int                            `json:"provider_count"`
+	DurationMS    int64                          `json:"duration_ms"`
+	Error         string                         `json:"error,omitempty"`
+	Providers     []modelRefreshProviderResponse `json:"providers"`
+}
+
+// RefreshModels handles POST /admin/models/refresh
+//
+// It re-fetches every provider's model list now instead of waiting for the
+// background refresh interval. Unlike POST /admin/runtime/refresh it touches
+// only the model registry and does not queue: when another refresh is already
+// running it returns 503 right away.
+//
+// @Summary      Refresh provider model inventories now
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  modelRefreshResponse
+// @Failure      401  {object}  core.GatewayError
+// @Failure      403  {object}  core.GatewayError
+// @Failure      503  {object}  core.GatewayError
+// @Router       /admin/models/refresh [post]
+func (h *Handler) RefreshModels(c *echo.Context) error {
+	if h.registry == nil {
+		return handleError(c, featureUnavailableError("model registry is unavailable"))
+	}
+	ctx := c.Request().Context()
+	// A refresh calls every provider, so static and managed API keys that can
+	// reach the admin API are refused. Without a master key configured there
+	// is no key to check and the admin API is open, as for its other routes.
+	if !core.IsMasterKeyAuth(ctx) && core.GetAuthKeyID(ctx) != "" {
+		return handleError(c, core.NewInvalidRequestErrorWithStatus(http.StatusForbidden, "refreshing models requires the master key", nil).
+			WithCode("forbidden"))
+	}
+
+	startedAt := time.Now()
+	err := h.registry.TryRefresh(ctx)
+	if errors.Is(err, providers.ErrRefreshInProgress) {
+		return handleError(c, refreshInProgressError(err))
+	}
+
+	resp := modelRefreshResponse{
+		Status:        RuntimeRefreshStatusOK,
+		ModelCount:    h.registry.ModelCount(),
+		ProviderCount: h.registry.ProviderCount(),
+		DurationMS:    time.Since(startedAt).Milliseconds(),
+		Providers:     []modelRefreshProviderResponse{},
+	}
+	failed := 0
+	for _, snapshot := range h.registry.ProviderRuntimeSnapshots() {
+		item := modelRefreshProviderResponse{
+			Name:       snapshot.Name,
+			Type:       snapshot.Type,
+			Status:     RuntimeRefreshStatusOK,
+			ModelCount: snapshot.DiscoveredModelCount,
+			Error:      snapshot.LastModelFetchError,
+		}
+		if item.Error != "" {
+			item.Status = RuntimeRefreshStatusFailed
+			failed++
+		}
+		resp.Providers = append(resp.Providers, item)
+	}
+	sort.Slice(resp.Providers, func(i, j int) bool { return resp.Providers[i].Name < resp.Providers[j].Name })
+
+	switch {
+	case err != nil && resp.ModelCount == 0:
+		resp.Status = RuntimeRefreshStatusFailed
+		resp.Error = err.Error()
+	case err != nil:
+		resp.Status = RuntimeRefreshStatusPartial
+		resp.Error = err.Error()
+	case failed > 0:
+		resp.Status = RuntimeRefreshStatusPartial
+	default:
+		if saveErr := h.registry.SaveToCache(ctx); saveErr != nil {
+			slog.Warn("failed to save models to cache after admin refresh", "error", saveErr)
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -3,9 +3,14 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/labstack/echo/v5"
+
 	"github.com/enterpilot/gomodel/internal/core"
 	"github.com/enterpilot/gomodel/internal/providers"
 	"github.com/enterpilot/gomodel/internal/virtualmodels"
@@ -180,3 +185,188 @@ func TestListModels_AppliesGlobalOverrideToConcreteModels(t *testing.T) {
 		t.Fatalf("row.Access.Override = %#v, want nil for global override", row.Access.Override)
 	}
 }
+
+func TestListModels_FiltersByProviderAndReportsSource(t *testing.T) {
+	registry := providers.NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(&handlerMockProvider{
+		models: &core.ModelsResponse{Object: "list", Data: []core.Model{{ID: "gpt-4o", Object: "model"}}},
+	}, "openai", "openai")
+	registry.RegisterProviderWithNameAndType(&handlerMockProvider{
+		models: &core.ModelsResponse{Object: "list", Data: []core.Model{{ID: "gpt-4o", Object: "model"}}},
+	}, "backup", "openai")
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	h := NewHandler(nil, registry)
+	c, rec := newHandlerContext("/admin/models?provider=backup")
+	if err := h.ListModels(c); err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+
+	var body []modelInventoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(body) != 1 {
+		t.Fatalf("len(body) = %d, want 1", len(body))
+	}
+	if body[0].Selector != "backup/gpt-4o" {
+		t.Fatalf("selector = %q, want backup/gpt-4o", body[0].Selector)
+	}
+	if body[0].Source != providers.ModelSourceLive {
+		t.Fatalf("source = %q, want live", body[0].Source)
+	}
+	if body[0].UnqualifiedOwner {
+		t.Fatal("backup must not own the bare model ID registered first by openai")
+	}
+}
+
+// blockingModelsProvider holds ListModels until release is closed so a test
+// can keep a registry refresh in flight.
+type blockingModelsProvider struct {
+	handlerMockProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingModelsProvider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
+	select {
+	case p.started <- struct{}{}:
+	default:
+	}
+	<-p.release
+	return p.handlerMockProvider.ListModels(ctx)
+}
+
+// newMasterKeyRefreshContext builds a POST /admin/models/refresh context
+// authenticated with the master key.
+func newMasterKeyRefreshContext() (*echo.Context, *httptest.ResponseRecorder) {
+	c, rec := newHandlerContext("/admin/models/refresh")
+	c.SetRequest(c.Request().WithContext(core.WithMasterKeyAuth(c.Request().Context())))
+	c.Request().Method = http.MethodPost
+	return c, rec
+}
+
+func TestRefreshModels_ReturnsCountsAndProviderStatus(t *testing.T) {
+	registry := providers.NewModelRegistry()
+	openai := &handlerMockProvider{models: &core.ModelsResponse{Object: "list"}}
+	registry.RegisterProviderWithNameAndType(openai, "openai", "openai")
+	registry.RegisterProviderWithNameAndType(&handlerMockProvider{err: errors.New("upstream down")}, "groq", "groq")
+
+	// A model enabled upstream after startup shows up on the forced refresh.
+	openai.models = &core.ModelsResponse{Object: "list", Data: []core.Model{
+		{ID: "gpt-4o", Object: "model"},
+		{ID: "gpt-4.1", Object: "model"},
+	}}
+
+	h := NewHandler(nil, registry)
+	c, rec := newMasterKeyRefreshContext()
+	if err := h.RefreshModels(c); err != nil {
+		t.Fatalf("RefreshModels() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+
+	var body modelRefreshResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Status != RuntimeRefreshStatusPartial {
+		t.Errorf("status = %q, want partial", body.Status)
+	}
+	if body.ModelCount != 2 || body.ProviderCount != 2 {
+		t.Errorf("counts = %d models/%d providers, want 2/2", body.ModelCount, body.ProviderCount)
+	}
+	if len(body.Providers) != 2 {
+		t.Fatalf("providers = %+v, want groq and openai", body.Providers)
+	}
+	groq, openaiItem := body.Providers[0], body.Providers[1]
+	if groq.Name != "groq" || groq.Status != RuntimeRefreshStatusFailed || !strings.Contains(groq.Error, "upstream down") {
+		t.Errorf("groq = %+v, want failed with upstream error", groq)
+	}
+	if openaiItem.Name != "openai" || openaiItem.Status != RuntimeRefreshStatusOK || openaiItem.ModelCount != 2 {
+		t.Errorf("openai = %+v, want ok with 2 models", openaiItem)
+	}
+}
+
+func TestRefreshModels_RejectsConcurrentRefresh(t *testing.T) {
+	registry := providers.NewModelRegistry()
+	blocking := &blockingModelsProvider{
+		handlerMockProvider: handlerMockProvider{models: &core.ModelsResponse{Object: "list"}},
+		started:             make(chan struct{}, 1),
+		release:             make(chan struct{}),
+	}
+	registry.RegisterProviderWithNameAndType(blocking, "openai", "openai")
+
+	done := make(chan error, 1)
+	go func() { done <- registry.Refresh(context.Background()) }()
+	<-blocking.started
+
+	h := NewHandler(nil, registry)
+	c, rec := newMasterKeyRefreshContext()
+	if err := h.RefreshModels(c); err != nil {
+		t.Fatalf("RefreshModels() error = %v", err)
+	}
+	close(blocking.release)
+	<-done
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503 body=%s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "refresh_in_progress") {
+		t.Errorf("body = %s, want refresh_in_progress code", rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), string(core.ErrorTypeProvider)) {
+		t.Errorf("body = %s, want the busy state kept apart from provider errors", rec.Body.String())
+	}
+}
+
+func TestRefreshModels_RequiresMasterKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantStatus int
+		wantModels int
+	}{
+		{name: "auth disabled", ctx: context.Background(), wantStatus: http.StatusOK, wantModels: 1},
+		{name: "master key", ctx: core.WithMasterKeyAuth(context.Background()), wantStatus: http.StatusOK, wantModels: 1},
+		{name: "api key", ctx: core.WithAuthKeyID(context.Background(), "team-a"), wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := providers.NewModelRegistry()
+			registry.RegisterProviderWithNameAndType(&handlerMockProvider{models: &core.ModelsResponse{
+				Object: "list",
+				Data:   []core.Model{{ID: "gpt-4o", Object: "model"}},
+			}}, "openai", "openai")
+
+			h := NewHandler(nil, registry)
+			c, rec := newHandlerContext("/admin/models/refresh")
+			c.SetRequest(c.Request().WithContext(tt.ctx))
+			c.Request().Method = http.MethodPost
+			if err := h.RefreshModels(c); err != nil {
+				t.Fatalf("RefreshModels() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d body=%s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := registry.ModelCount(); got != tt.wantModels {
+				t.Errorf("ModelCount() = %d, want %d", got, tt.wantModels)
+			}
+		})
+	}
+}
+
+func TestRefreshModels_UnavailableWithoutRegistry(t *testing.T) {
+	h := NewHandler(nil, nil)
+	c, rec := newHandlerContext("/admin/models/refresh")
+	c.Request().Method = http.MethodPost
+	if err := h.RefreshModels(c); err != nil {
+		t.Fatalf("RefreshModels() error = %v", err)
+	}
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rec.Code)
+	}
+}
@@ -13,10 +13,84 @@ import (
 	"github.com/enterpilot/gomodel/internal/providers/health"
 )
 
+// ListProviders handles GET /admin/providers.
+//
+// @Summary      List registered providers with their live circuit state
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   providerListItemResponse
+// @Failure      401  {object}  core.GatewayError
+// @Failure      503  {object}  core.GatewayError
+// @Router       /admin/providers [get]
+func (h *Handler) ListProviders(c *echo.Context) error {
+	if h.registry == nil {
+		return handleError(c, featureUnavailableError("provider registry is unavailable"))
+	}
+	snapshots := h.registry.ProviderRuntimeSnapshots()
+	items := make([]providerListItemResponse, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		items = append(items, providerListItemResponse{
+			Name:         snapshot.Name,
+			Type:         snapshot.Type,
+			ModelCount:   snapshot.DiscoveredModelCount,
+			CircuitState: snapshot.CircuitState,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return c.JSON(http.StatusOK, items)
+}
+
 func (h *Handler) ProviderStatus(c *echo.Context) error {
 	return c.JSON(http.StatusOK, h.buildProviderStatusResponse())
 }
 
+// ResetProviderCircuit handles POST /admin/providers/:name/reset-circuit.
+//
+// @Summary      Force-close a provider's circuit breaker
+// @Description  Closes the named provider's circuit breaker immediately, instead of waiting for the open timeout and half-open probes. Use it when the provider is known to have recovered. Requires the master key.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name  path  string  true  "Configured provider name"
+// @Success      200   {object}  providerCircuitResetResponse
+// @Failure      400   {object}  core.GatewayError
+// @Failure      401   {object}  core.GatewayError
+// @Failure      403   {object}  core.GatewayError
+// @Failure      404   {object}  core.GatewayError
+// @Failure      503   {object}  core.GatewayError
+// @Router       /admin/providers/{name}/reset-circuit [post]
+func (h *Handler) ResetProviderCircuit(c *echo.Context) error {
+	if h.registry == nil {
+		return handleError(c, featureUnavailableError("provider registry is unavailable"))
+	}
+	if core.GetAuthKeyID(c.Request().Context()) != "" {
+		return handleError(c, core.NewInvalidRequestErrorWithStatus(http.StatusForbidden, "resetting a circuit breaker requires the master key", nil).
+			WithCode("forbidden"))
+	}
+
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return handleError(c, core.NewInvalidRequestError("name is required", nil))
+	}
+	provider := h.registry.ProviderByName(name)
+	if provider == nil {
+		return handleError(c, core.NewNotFoundError("provider not found: "+name))
+	}
+	resetter, ok := provider.(core.CircuitResetter)
+	if !ok {
+		return handleError(c, core.NewInvalidRequestError("provider "+name+" has no circuit breaker", nil))
+	}
+	state := resetter.ResetCircuit()
+	if state == "" {
+		return handleError(c, core.NewInvalidRequestError("provider "+name+" has no circuit breaker enabled", nil))
+	}
+	if recorder, ok := h.requestHealth.(circuitStateRecorder); ok {
+		recorder.RecordCircuitState(name, state)
+	}
+	return c.JSON(http.StatusOK, providerCircuitResetResponse{Provider: name, CircuitState: state})
+}
+
 // RefreshRuntime handles POST /admin/runtime/refresh
 func (h *Handler) RefreshRuntime(c *echo.Context) error {
 	if h.runtimeRefresher == nil {
@@ -0,0 +1,169 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/providers"
+	"github.com/enterpilot/gomodel/internal/providers/health"
+	"github.com/enterpilot/gomodel/internal/providers/openai"
+)
+
+func serveResetCircuit(t *testing.T, ctx context.Context, h *Handler, name string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	h.RegisterRoutes(e.Group("/admin"))
+	req := httptest.NewRequest(http.MethodPost, "/admin/providers/"+name+"/reset-circuit", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResetProviderCircuit_ClosesOpenCircuitAndTrafficFlows(t *testing.T) {
+	var healthy atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"message":"upstream down"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+	}))
+	defer upstream.Close()
+
+	tracker := health.NewTracker()
+	provider := openai.New(providers.ProviderConfig{Type: "openai", APIKey: "sk-test", BaseURL: upstream.URL}, providers.ProviderOptions{
+		Hooks: tracker.Hooks(),
+		Resilience: config.ResilienceConfig{
+			CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 2, SuccessThreshold: 2, Timeout: time.Hour},
+		},
+	})
+	registry := providers.NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(provider, "openai", "openai")
+	h := NewHandler(nil, registry, WithRequestHealth(tracker))
+
+	chat := func() error {
+		_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+			Model:    "gpt-4o",
+			Messages: []core.Message{{Role: "user", Content: "hi"}},
+		})
+		return err
+	}
+	for range 2 {
+		_ = chat()
+	}
+	if got := tracker.Snapshot()["openai"].CircuitState; got != "open" {
+		t.Fatalf("circuit state before reset = %q, want open", got)
+	}
+
+	healthy.Store(true)
+	rec := serveResetCircuit(t, context.Background(), h, "openai")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+	var resp providerCircuitResetResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Provider != "openai" || resp.CircuitState != "closed" {
+		t.Fatalf("response = %+v, want openai closed", resp)
+	}
+	if got := tracker.Snapshot()["openai"].CircuitState; got != "closed" {
+		t.Fatalf("tracked circuit state after reset = %q, want closed", got)
+	}
+
+	if err := chat(); err != nil {
+		t.Fatalf("chat after reset: %v", err)
+	}
+}
+
+func TestResetProviderCircuit_Errors(t *testing.T) {
+	registry := providers.NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(&handlerMockProvider{}, "no_breaker", "mock")
+	h := NewHandler(nil, registry)
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		provider   string
+		wantStatus int
+	}{
+		{name: "managed key", ctx: core.WithAuthKeyID(context.Background(), "key-1"), provider: "no_breaker", wantStatus: http.StatusForbidden},
+		{name: "unknown provider", ctx: context.Background(), provider: "missing", wantStatus: http.StatusNotFound},
+		{name: "provider without circuit breaker", ctx: context.Background(), provider: "no_breaker", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := serveResetCircuit(t, tt.ctx, h, tt.provider); rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d body=%s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+
+	if rec := serveResetCircuit(t, context.Background(), NewHandler(nil, nil), "openai"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("no registry status = %d, want 503 body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestListProviders_ReportsLiveCircuitState(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"message":"upstream down"}}`))
+	}))
+	defer upstream.Close()
+
+	provider := openai.New(providers.ProviderConfig{Type: "openai", APIKey: "sk-test", BaseURL: upstream.URL}, providers.ProviderOptions{
+		Resilience: config.ResilienceConfig{
+			CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Hour},
+		},
+	})
+	registry := providers.NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(provider, "primary", "openai")
+	registry.RegisterProviderWithNameAndType(&handlerMockProvider{}, "local", "mock")
+	h := NewHandler(nil, registry)
+
+	_, _ = provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []core.Message{{Role: "user", Content: "hi"}},
+	})
+
+	e := echo.New()
+	h.RegisterRoutes(e.Group("/admin"))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/providers", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 body=%s", rec.Code, rec.Body.String())
+	}
+	var items []providerListItemResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := []providerListItemResponse{
+		{Name: "local", Type: "mock"},
+		{Name: "primary", Type: "openai", CircuitState: "open"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("items = %+v, want %+v", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("items[%d] = %+v, want %+v", i, items[i], want[i])
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	NewHandler(nil, nil).RegisterRoutes(e.Group("/unwired"))
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unwired/providers", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("no registry status = %d, want 503", rec.Code)
+	}
+}
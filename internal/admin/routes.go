@@ -34,7 +34,9 @@ func (h *Handler) RegisterRoutes(g RouteRegistrar) {
 	g.GET("/audit/detail", h.AuditLogDetail)
 	g.GET("/audit/conversation", h.AuditConversation)
 
+	g.GET("/providers", h.ListProviders)
 	g.GET("/providers/status", h.ProviderStatus)
+	g.POST("/providers/:name/reset-circuit", h.ResetProviderCircuit)
 	g.POST("/runtime/refresh", h.RefreshRuntime)
 
 	g.GET("/budgets", h.ListBudgets)
@@ -55,7 +57,9 @@ func (h *Handler) RegisterRoutes(g RouteRegistrar) {
 	g.PUT("/tagging/settings", h.UpdateTaggingSettings)
 
 	g.GET("/models", h.ListModels)
+	g.POST("/models/refresh", h.RefreshModels)
 	g.GET("/models/categories", h.ListCategories)
+	g.POST("/compare", h.CompareModels)
 
 	g.GET("/virtual-models", h.ListVirtualModels)
 	g.PUT("/virtual-models", h.UpsertVirtualModel)
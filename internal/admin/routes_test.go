@@ -49,7 +49,9 @@ func TestRegisterRoutes_RegistersExpectedPaths(t *testing.T) {
 		"GET /admin/audit/detail",
 		"GET /admin/audit/conversation",
 
+		"GET /admin/providers",
 		"GET /admin/providers/status",
+		"POST /admin/providers/:name/reset-circuit",
 		"POST /admin/runtime/refresh",
 
 		"GET /admin/budgets",
@@ -70,7 +72,9 @@ func TestRegisterRoutes_RegistersExpectedPaths(t *testing.T) {
 		"PUT /admin/tagging/settings",
 
 		"GET /admin/models",
+		"POST /admin/models/refresh",
 		"GET /admin/models/categories",
+		"POST /admin/compare",
 
 		"GET /admin/virtual-models",
 		"PUT /admin/virtual-models",
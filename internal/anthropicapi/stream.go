@@ -110,7 +110,7 @@ func (sc *streamConverter) Read(p []byte) (int, error) {
 	}
 
 	for {
-		line, err := sc.reader.ReadBytes('\n')
+		line, err := streaming.ReadLine(sc.reader)
 		if len(bytes.TrimSpace(line)) > 0 {
 			if done := sc.consumeLine(line); done {
 				sc.finalize()
@@ -124,6 +124,8 @@ func (sc *streamConverter) Read(p []byte) (int, error) {
 				}
 				return 0, io.EOF
 			}
+			// Skip the closing events: the stream ended on an error, not a stop.
+			sc.finalized = true
 			return 0, err
 		}
 		if sc.buffer.Len() > 0 {
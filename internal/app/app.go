@@ -31,6 +31,7 @@ import (
 	"github.com/enterpilot/gomodel/internal/httpclient"
 	"github.com/enterpilot/gomodel/internal/live"
 	"github.com/enterpilot/gomodel/internal/mcpgateway"
+	"github.com/enterpilot/gomodel/internal/observability"
 	"github.com/enterpilot/gomodel/internal/pricingoverrides"
 	"github.com/enterpilot/gomodel/internal/providers"
 	"github.com/enterpilot/gomodel/internal/providers/health"
@@ -39,7 +40,9 @@ import (
 	"github.com/enterpilot/gomodel/internal/responsestore"
 	"github.com/enterpilot/gomodel/internal/server"
 	"github.com/enterpilot/gomodel/internal/storage"
+	"github.com/enterpilot/gomodel/internal/streaming"
 	"github.com/enterpilot/gomodel/internal/tagging"
+	"github.com/enterpilot/gomodel/internal/transform"
 	"github.com/enterpilot/gomodel/internal/usage"
 	"github.com/enterpilot/gomodel/internal/virtualmodels"
 	"github.com/enterpilot/gomodel/internal/workflows"
@@ -124,6 +127,11 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 	// Install config-file HTTP timeouts before any provider constructs a
 	// transport; env vars still take precedence inside httpclient.
 	httpclient.SetConfiguredTimeouts(appCfg.HTTP.Timeout, appCfg.HTTP.ResponseHeaderTimeout)
+	httpclient.SetConfiguredStreamIdleTimeout(appCfg.HTTP.StreamIdleTimeout)
+	// Load has already validated the size string.
+	maxEventBytes, _ := config.ParseBodySizeLimitBytes(appCfg.HTTP.StreamMaxEventSize)
+	streaming.SetMaxEventBytes(maxEventBytes)
+	core.SetModelNotFoundStatus(appCfg.Server.ModelNotFoundStatus)
 	if appCfg.Budgets.Enabled && !appCfg.Usage.Enabled {
 		appCfg.Budgets.Enabled = false
 		slog.Warn("budget management disabled because usage tracking is disabled",
@@ -405,17 +413,18 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 	}
 
 	refreshInterval := workflowRefreshInterval(appCfg)
-	var guardrailExecutor guardrails.ChatCompletionExecutor = app.providers.Router
+	// chatExecutor backs LLM-based guardrails and the admin model comparison.
+	var chatExecutor guardrails.ChatCompletionExecutor = app.providers.Router
 	if vm != nil {
-		guardrailExecutor = virtualmodels.NewChatExecutor(app.providers.Router, vm)
+		chatExecutor = virtualmodels.NewChatExecutor(app.providers.Router, vm)
 	}
 
 	// Initialize reusable guardrail definitions using shared storage when already available.
 	var guardrailResult *guardrails.Result
 	if sharedStorage != nil {
-		guardrailResult, err = guardrails.NewWithSharedStorage(ctx, sharedStorage, refreshInterval, guardrailExecutor)
+		guardrailResult, err = guardrails.NewWithSharedStorage(ctx, sharedStorage, refreshInterval, chatExecutor)
 	} else {
-		guardrailResult, err = guardrails.New(ctx, appCfg, refreshInterval, guardrailExecutor)
+		guardrailResult, err = guardrails.New(ctx, appCfg, refreshInterval, chatExecutor)
 	}
 	if err != nil {
 		return fail("failed to initialize guardrails", err)
@@ -462,7 +471,7 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 
 	var authKeyResult *authkeys.Result
 	if sharedStorage != nil {
-		authKeyResult, err = authkeys.NewWithSharedStorage(ctx, sharedStorage)
+		authKeyResult, err = authkeys.NewWithSharedStorage(ctx, appCfg, sharedStorage)
 	} else {
 		authKeyResult, err = authkeys.New(ctx, appCfg)
 	}
@@ -471,6 +480,23 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 	}
 	app.authKeys = authKeyResult
 	closers = append(closers, app.authKeys.Close)
+	// Rate limits and budgets declared in the auth key file are enforced by
+	// the same services as configured ones and follow every key file reload.
+	var warnRateLimitsOff, warnBudgetsOff sync.Once
+	authKeyResult.Service.SetLimitsListener(func(rules []ratelimit.Rule, budgets []budget.Budget) {
+		if len(rules) > 0 && rateLimitResult.Service == nil {
+			warnRateLimitsOff.Do(func() {
+				slog.Warn("auth key file declares rate limits but rate limits are disabled; they will not be enforced")
+			})
+		}
+		if len(budgets) > 0 && budgetResult.Service == nil {
+			warnBudgetsOff.Do(func() {
+				slog.Warn("auth key file declares budgets but budgets are disabled; they will not be enforced")
+			})
+		}
+		rateLimitResult.Service.SetKeyRules(rules)
+		budgetResult.Service.SetKeyBudgets(budgets)
+	})
 
 	// Log configuration status after auth has been initialized so the startup
 	// message reflects both bootstrap and managed auth modes.
@@ -489,6 +515,20 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 			)
 		}
 	}
+	// Configured transformers run before the guardrails patcher so guardrails
+	// see the request as it will be routed.
+	if len(appCfg.Transformers) > 0 {
+		configured, err := transform.FromConfig(appCfg.Transformers)
+		if err != nil {
+			return fail("failed to initialize transformers", err)
+		}
+		chain := transform.NewChain().AddRequest(configured...)
+		if translatedRequestPatcher != nil {
+			chain.AddRequest(transform.FromPatcher(translatedRequestPatcher))
+		}
+		translatedRequestPatcher = chain
+		slog.Info("request transformers enabled", "count", len(configured))
+	}
 	if vm != nil {
 		// One combined preparer rewrites redirect sources and validates access,
 		// replacing the previous two-preparer pipeline.
@@ -513,6 +553,11 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 	if rateLimitResult.Service != nil {
 		serverUsageLogger = ratelimit.NewUsageTap(serverUsageLogger, rateLimitResult.Service)
 	}
+	// Token and cost counters are fed the same way, so they need usage
+	// tracking enabled to see any entries.
+	if appCfg.Metrics.Enabled {
+		serverUsageLogger = observability.NewUsageMetricsTap(serverUsageLogger)
+	}
 
 	// Initialize the MCP gateway (aggregated upstream MCP servers behind /mcp).
 	var mcpResult *mcpgateway.Result
@@ -557,6 +602,7 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 	serverCfg := &server.Config{
 		BasePath:                        appCfg.Server.BasePath,
 		MasterKey:                       appCfg.Server.MasterKey,
+		APIKeys:                         staticAPIKeys(appCfg.Server.APIKeys),
 		Authenticator:                   authKeyResult.Service,
 		MetricsEnabled:                  appCfg.Metrics.Enabled,
 		MetricsEndpoint:                 appCfg.Metrics.Endpoint,
@@ -574,6 +620,13 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 		BatchRequestPreparer:            batchRequestPreparer,
 		ExposedModelLister:              vm,
 		KeepOnlyAliasesAtModelsEndpoint: appCfg.Models.KeepOnlyAliasesAtModelsEndpoint,
+		ModelListSortOrder:              appCfg.Models.ListSortOrder,
+		HealthStatusCode:                appCfg.Server.HealthStatusCode,
+		HealthBody:                      appCfg.Server.HealthBody,
+		HealthIncludeBuildInfo:          appCfg.Server.HealthIncludeBuildInfo,
+		MaxToolsPerRequest:              appCfg.Server.MaxToolsPerRequest,
+		StreamKeepaliveInterval:         appCfg.Server.Stream.KeepaliveInterval,
+		ProviderTypes:                   cfg.Factory.RegisteredTypes(),
 		PassthroughSemanticEnrichers:    cfg.Factory.PassthroughSemanticEnrichers(),
 		BatchStore:                      batchResult.Store,
 		FileStore:                       fileStoreResult.Store,
@@ -583,11 +636,15 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 		DisablePassthroughRoutes:        !appCfg.Server.EnablePassthroughRoutes,
 		EnabledPassthroughProviders:     appCfg.Server.EnabledPassthroughProviders,
 		RealtimeEnabled:                 appCfg.Server.RealtimeEnabled,
+		DisableResponsesRoutes:          !appCfg.Server.ResponsesAPIEnabled,
+		LenientRequestShape:             appCfg.Server.LenientRequestShape,
 		AllowPassthroughV1Alias:         &allowPassthroughV1Alias,
 		UserPathHeader:                  appCfg.Server.UserPathHeader,
 		SwaggerEnabled:                  swaggerEnabled,
 		Tagging:                         taggingResult.Service,
 		MCPEnabled:                      appCfg.MCP.Enabled,
+		SamplingPolicyEnabled:           appCfg.Sampling.Enabled(),
+		OutputPacing:                    appCfg.OutputPacing,
 	}
 	if mcpResult != nil {
 		serverCfg.MCPGateway = mcpResult.Service
@@ -598,6 +655,19 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 	if rateLimitResult.Service != nil {
 		serverCfg.RateLimiter = rateLimitResult.Service
 	}
+	serverCfg.CORS = server.CORSConfig{
+		AllowedOrigins:   appCfg.Server.CORS.AllowedOrigins,
+		AllowedMethods:   appCfg.Server.CORS.AllowedMethods,
+		AllowedHeaders:   appCfg.Server.CORS.AllowedHeaders,
+		AllowCredentials: appCfg.Server.CORS.AllowCredentials,
+	}
+	serverCfg.Timeouts = appCfg.Server.Timeouts
+	if appCfg.RateLimits.Enabled {
+		serverCfg.KeyRateLimit = server.KeyRateLimitConfig{
+			RequestsPerMinute: appCfg.RateLimits.PerKey.RequestsPerMinute,
+			Burst:             appCfg.RateLimits.PerKey.Burst,
+		}
+	}
 	if usageReader != nil {
 		serverCfg.UsageSummarizer = usageReader
 	}
@@ -636,6 +706,7 @@ func New(ctx context.Context, cfg Config) (*App, error) {
 			rateLimitResult.Service,
 			taggingResult.Service,
 			mcpResult,
+			chatExecutor,
 			app,
 			dashboardRuntimeConfig(appCfg, usageEnabledForDashboard),
 			app.live,
@@ -1021,17 +1092,34 @@ func (a *App) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// staticAPIKeys converts server.api_keys into the server's static key list.
+func staticAPIKeys(keys []config.APIKeyConfig) []server.StaticAPIKey {
+	if len(keys) == 0 {
+		return nil
+	}
+	out := make([]server.StaticAPIKey, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, server.StaticAPIKey{Name: strings.TrimSpace(k.Name), Key: k.Key})
+	}
+	return out
+}
+
 // logStartupInfo logs the application configuration on startup.
 func (a *App) logStartupInfo() {
 	cfg := a.config
 
 	// Security warnings
 	managedKeysConfigured := a.authKeys != nil && a.authKeys.Service != nil && a.authKeys.Service.Enabled()
+	if n := len(cfg.Server.APIKeys); n > 0 {
+		slog.Info("static API keys enabled", "api_key_count", n)
+	}
 	switch {
 	case cfg.Server.MasterKey != "" && managedKeysConfigured:
 		slog.Info("authentication enabled", "mode", "master_key+managed_keys", "managed_key_total", a.authKeys.Service.Total(), "managed_key_active", a.authKeys.Service.ActiveCount())
 	case managedKeysConfigured:
 		slog.Info("authentication enabled", "mode", "managed_keys", "managed_key_total", a.authKeys.Service.Total(), "managed_key_active", a.authKeys.Service.ActiveCount())
+	case cfg.Server.MasterKey == "" && len(cfg.Server.APIKeys) > 0:
+		slog.Info("authentication enabled", "mode", "api_keys")
 	case cfg.Server.MasterKey == "":
 		slog.Warn("SECURITY WARNING: GOMODEL_MASTER_KEY not set - server running in UNSAFE MODE",
 			"security_risk", "unauthenticated access allowed",
@@ -1097,6 +1185,7 @@ func initAdmin(
 	rateLimitService *ratelimit.Service,
 	taggingService *tagging.Service,
 	mcpResult *mcpgateway.Result,
+	chatExecutor admin.ChatCompletionExecutor,
 	runtimeRefresher admin.RuntimeRefresher,
 	runtimeConfig admin.DashboardConfigResponse,
 	liveBroker *live.Broker,
@@ -1153,6 +1242,7 @@ func initAdmin(
 		admin.WithRateLimits(rateLimitService),
 		admin.WithTagging(taggingService),
 		mcpOption,
+		admin.WithChatExecutor(chatExecutor),
 		admin.WithRuntimeRefresher(runtimeRefresher),
 		admin.WithDashboardRuntimeConfig(runtimeConfig),
 		admin.WithLiveBroker(liveBroker),
@@ -1341,7 +1431,7 @@ func simpleResponseCacheConfigured(cfg *config.Config) bool {
 
 func simpleResponseCacheConfiguredFromResponse(cfg config.ResponseCacheConfig) bool {
 	return cfg.Simple != nil && config.SimpleCacheEnabled(cfg.Simple) &&
-		cfg.Simple.Redis != nil && strings.TrimSpace(cfg.Simple.Redis.URL) != ""
+		(cfg.Simple.Memory != nil || cfg.Simple.Redis != nil && strings.TrimSpace(cfg.Simple.Redis.URL) != "")
 }
 
 func semanticResponseCacheConfigured(cfg *config.Config) bool {
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/enterpilot/gomodel/config"
+)
+
+// ReloadProviders applies the providers section of a reloaded configuration
+// to the running gateway. The provider set is swapped in the registry without
+// interrupting in-flight requests, then the model inventory is refreshed so
+// added and updated providers serve their models.
+//
+// Settings outside the providers section are not applied; they still take
+// effect only on restart. Runs are serialized with admin runtime refreshes.
+func (a *App) ReloadProviders(ctx context.Context, result *config.LoadResult) error {
+	if a == nil || a.providers == nil {
+		return fmt.Errorf("providers are not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	release, err := a.acquireRuntimeRefresh(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	diff, err := a.providers.Reload(ctx, result)
+	if err != nil {
+		return fmt.Errorf("reload providers: %w", err)
+	}
+	if !diff.Changed() {
+		slog.Info("config reloaded; provider set unchanged")
+		return nil
+	}
+	slog.Info("config reloaded; provider set updated",
+		"added", diff.Added,
+		"removed", diff.Removed,
+		"updated", diff.Updated,
+		"providers", a.providers.Registry.ProviderCount())
+
+	registry := a.providers.Registry
+	if err := registry.Refresh(ctx); err != nil {
+		slog.Warn("model refresh after provider reload failed; the background refresh will retry", "error", err)
+		return nil
+	}
+	if err := registry.SaveToCache(ctx); err != nil {
+		slog.Warn("failed to save models to cache", "error", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,6 +22,7 @@ type Result struct {
 	Storage storage.Storage
 
 	stopRefresh func()
+	stopWatch   func()
 	closeOnce   sync.Once
 	closeErr    error
 }
@@ -31,6 +33,10 @@ func (r *Result) Close() error {
 		return nil
 	}
 	r.closeOnce.Do(func() {
+		if r.stopWatch != nil {
+			r.stopWatch()
+			r.stopWatch = nil
+		}
 		if r.stopRefresh != nil {
 			r.stopRefresh()
 			r.stopRefresh = nil
@@ -63,7 +69,7 @@ func New(ctx context.Context, cfg *config.Config) (*Result, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage: %w", err)
 	}
-	result, err := newResult(ctx, storeConn)
+	result, err := newResult(ctx, storeConn, cfg.Server.AuthKeysFile)
 	if err != nil {
 		_ = storeConn.Close()
 		return nil, err
@@ -73,14 +79,17 @@ func New(ctx context.Context, cfg *config.Config) (*Result, error) {
 }
 
 // NewWithSharedStorage creates an auth key subsystem using an existing storage connection.
-func NewWithSharedStorage(ctx context.Context, shared storage.Storage) (*Result, error) {
+func NewWithSharedStorage(ctx context.Context, cfg *config.Config, shared storage.Storage) (*Result, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
 	if shared == nil {
 		return nil, fmt.Errorf("shared storage is required")
 	}
-	return newResult(ctx, shared)
+	return newResult(ctx, shared, cfg.Server.AuthKeysFile)
 }
 
-func newResult(ctx context.Context, storeConn storage.Storage) (*Result, error) {
+func newResult(ctx context.Context, storeConn storage.Storage, keysFile string) (*Result, error) {
 	store, err := createStore(ctx, storeConn)
 	if err != nil {
 		return nil, err
@@ -89,6 +98,9 @@ func newResult(ctx context.Context, storeConn storage.Storage) (*Result, error)
 	if err != nil {
 		return nil, err
 	}
+	if strings.TrimSpace(keysFile) != "" {
+		service.SetKeyStore(NewFileKeyStore(keysFile))
+	}
 	if err := service.Refresh(ctx); err != nil {
 		return nil, err
 	}
@@ -97,6 +109,7 @@ func newResult(ctx context.Context, storeConn storage.Storage) (*Result, error)
 		Service:     service,
 		Store:       store,
 		stopRefresh: service.StartBackgroundRefresh(defaultRefreshInterval),
+		stopWatch:   service.StartKeyStoreWatch(defaultKeyFileWatchInterval),
 	}, nil
 }
 
@@ -0,0 +1,331 @@
+package authkeys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/budget"
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/ratelimit"
+)
+
+// fileKeyIDPrefix namespaces file-declared key IDs so they never collide with
+// persisted UUIDs.
+const fileKeyIDPrefix = "file:"
+
+// defaultKeyFileWatchInterval is how often a watched key file is checked for
+// changes.
+const defaultKeyFileWatchInterval = 5 * time.Second
+
+// KeyStore supplies managed auth keys from an external, read-only source.
+// Its keys are merged into the service snapshot on every refresh alongside
+// the persisted keys, so they can be added or changed without a restart.
+type KeyStore interface {
+	List(ctx context.Context) ([]AuthKey, error)
+}
+
+// changeReporter is implemented by key stores that can cheaply tell whether
+// their source changed since the last List.
+type changeReporter interface {
+	Changed() (bool, error)
+}
+
+// fileKeys is the on-disk layout of a key file. JSON files parse as YAML.
+type fileKeys struct {
+	Keys []fileKey `yaml:"keys"`
+}
+
+type fileKey struct {
+	ID          string     `yaml:"id"`
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Value       string     `yaml:"value"`
+	SecretHash  string     `yaml:"secret_hash"`
+	UserPath    string     `yaml:"user_path"`
+	Labels      []string   `yaml:"labels"`
+	Enabled     *bool      `yaml:"enabled"`
+	ExpiresAt   *time.Time `yaml:"expires_at"`
+
+	// AllowedModels lists the models the key may use. Rate limits and
+	// budgets take the same shape as their static configuration and apply
+	// to the key's user path, which they therefore require.
+	AllowedModels []string                     `yaml:"allowed_models"`
+	RateLimits    []config.RateLimitRuleConfig `yaml:"rate_limits"`
+	Budgets       []config.BudgetLimitConfig   `yaml:"budgets"`
+}
+
+// fileState identifies one version of the key file on disk.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFileState(path string) (fileState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// FileKeyStore reads managed auth keys from a YAML or JSON file. Each entry
+// carries either the plaintext token ("value") or the hex SHA-256 of its
+// secret part ("secret_hash"), and may restrict the key with allowed_models,
+// rate_limits, and budgets.
+type FileKeyStore struct {
+	path string
+
+	mu sync.Mutex
+	// loaded is the file version the last successful List parsed.
+	loaded fileState
+	// pending is a newer version Changed has seen once; it is only reported
+	// after it stays the same for another check, so an editor still writing
+	// the file is not read half-way.
+	pending fileState
+}
+
+// NewFileKeyStore creates a key store backed by the file at path.
+func NewFileKeyStore(path string) *FileKeyStore {
+	return &FileKeyStore{path: strings.TrimSpace(path)}
+}
+
+// List parses the key file. A file that changes while it is being read, or
+// fails to parse, is returned as an error without being marked loaded, so
+// the watcher keeps the previous keys and tries again on its next check.
+func (s *FileKeyStore) List(_ context.Context) ([]AuthKey, error) {
+	before, err := statFileState(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("stat auth keys file: %w", err)
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("read auth keys file: %w", err)
+	}
+	after, err := statFileState(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("stat auth keys file: %w", err)
+	}
+	if !after.modTime.Equal(before.modTime) || after.size != before.size || int64(len(data)) != after.size {
+		return nil, fmt.Errorf("auth keys file %s changed while it was read", s.path)
+	}
+
+	keys, err := parseFileKeys(data, before.modTime.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("parse auth keys file %s: %w", s.path, err)
+	}
+	s.mu.Lock()
+	s.loaded = before
+	s.pending = fileState{}
+	s.mu.Unlock()
+	return keys, nil
+}
+
+// Changed reports whether the file differs from the version the last
+// successful List loaded and has stayed unchanged since the previous check.
+func (s *FileKeyStore) Changed() (bool, error) {
+	current, err := statFileState(s.path)
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current.modTime.Equal(s.loaded.modTime) && current.size == s.loaded.size {
+		s.pending = fileState{}
+		return false, nil
+	}
+	if !current.modTime.Equal(s.pending.modTime) || current.size != s.pending.size {
+		s.pending = current
+		return false, nil
+	}
+	return true, nil
+}
+
+func parseFileKeys(data []byte, loadedAt time.Time) ([]AuthKey, error) {
+	var parsed fileKeys
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	keys := make([]AuthKey, 0, len(parsed.Keys))
+	seenIDs := make(map[string]struct{}, len(parsed.Keys))
+	// limitOwners names the key that declared each user path and period, so
+	// two keys sharing a user path cannot stack limits on one counter.
+	limitOwners := make(map[string]string)
+	for i, entry := range parsed.Keys {
+		name := strings.TrimSpace(entry.Name)
+		if name == "" {
+			return nil, fmt.Errorf("keys[%d]: name is required", i)
+		}
+		id := strings.TrimSpace(entry.ID)
+		if id == "" {
+			id = name
+		}
+		id = fileKeyIDPrefix + id
+		if _, dup := seenIDs[id]; dup {
+			return nil, fmt.Errorf("keys[%d]: duplicate id %q", i, id)
+		}
+		seenIDs[id] = struct{}{}
+
+		secretHash, redacted, err := fileKeySecret(entry)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d] %q: %w", i, name, err)
+		}
+		userPath, err := core.NormalizeUserPath(entry.UserPath)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d] %q: invalid user_path: %w", i, name, err)
+		}
+		rateLimits, budgets, err := fileKeyLimits(entry, userPath)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d] %q: %w", i, name, err)
+		}
+		for _, rule := range rateLimits {
+			if err := claimLimit(limitOwners, "rate_limits", rule.Subject, rule.PeriodSeconds, name); err != nil {
+				return nil, fmt.Errorf("keys[%d] %q: %w", i, name, err)
+			}
+		}
+		for _, keyBudget := range budgets {
+			if err := claimLimit(limitOwners, "budgets", keyBudget.UserPath, keyBudget.PeriodSeconds, name); err != nil {
+				return nil, fmt.Errorf("keys[%d] %q: %w", i, name, err)
+			}
+		}
+		enabled := entry.Enabled == nil || *entry.Enabled
+		var expiresAt *time.Time
+		if entry.ExpiresAt != nil {
+			utc := entry.ExpiresAt.UTC()
+			expiresAt = &utc
+		}
+
+		keys = append(keys, AuthKey{
+			ID:            id,
+			Name:          name,
+			Description:   strings.TrimSpace(entry.Description),
+			UserPath:      userPath,
+			Labels:        core.MergeLabels(entry.Labels),
+			RedactedValue: redacted,
+			SecretHash:    secretHash,
+			Enabled:       enabled,
+			ExpiresAt:     expiresAt,
+			ReadOnly:      true,
+			AllowedModels: fileKeyAllowedModels(entry.AllowedModels),
+			RateLimits:    rateLimits,
+			Budgets:       budgets,
+			CreatedAt:     loadedAt,
+			UpdatedAt:     loadedAt,
+		})
+	}
+	return keys, nil
+}
+
+func fileKeyAllowedModels(models []string) []string {
+	var allowed []string
+	for _, model := range models {
+		model = strings.TrimSpace(model)
+		if model != "" && !slices.Contains(allowed, model) {
+			allowed = append(allowed, model)
+		}
+	}
+	return allowed
+}
+
+// fileKeyLimits resolves a key's rate limits and budgets into the rules and
+// budgets the rate limit and budget services enforce on its user path.
+func fileKeyLimits(entry fileKey, userPath string) ([]ratelimit.Rule, []budget.Budget, error) {
+	if len(entry.RateLimits) == 0 && len(entry.Budgets) == 0 {
+		return nil, nil, nil
+	}
+	if userPath == "" {
+		return nil, nil, fmt.Errorf("rate_limits and budgets require a user_path")
+	}
+
+	rules := make([]ratelimit.Rule, 0, len(entry.RateLimits))
+	for j, limit := range entry.RateLimits {
+		var seconds int64
+		if limit.PeriodSeconds != nil {
+			seconds = *limit.PeriodSeconds
+		} else {
+			parsed, ok := ratelimit.PeriodSecondsFromName(limit.Period)
+			if !ok {
+				return nil, nil, fmt.Errorf("rate_limits[%d]: invalid period %q", j, limit.Period)
+			}
+			seconds = parsed
+		}
+		rule, err := ratelimit.NormalizeRule(ratelimit.Rule{
+			Scope:         ratelimit.ScopeUserPath,
+			Subject:       userPath,
+			PeriodSeconds: seconds,
+			MaxRequests:   limit.MaxRequests,
+			MaxTokens:     limit.MaxTokens,
+			Source:        ratelimit.SourceKeyFile,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("rate_limits[%d]: %w", j, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	budgets := make([]budget.Budget, 0, len(entry.Budgets))
+	for j, limit := range entry.Budgets {
+		seconds := limit.PeriodSeconds
+		if seconds <= 0 {
+			parsed, ok := budget.PeriodSeconds(limit.Period)
+			if !ok {
+				return nil, nil, fmt.Errorf("budgets[%d]: invalid period %q", j, limit.Period)
+			}
+			seconds = parsed
+		}
+		keyBudget, err := budget.NormalizeBudget(budget.Budget{
+			UserPath:      userPath,
+			PeriodSeconds: seconds,
+			Amount:        limit.Amount,
+			Source:        budget.SourceKeyFile,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("budgets[%d]: %w", j, err)
+		}
+		budgets = append(budgets, keyBudget)
+	}
+	return rules, budgets, nil
+}
+
+func claimLimit(owners map[string]string, field, userPath string, periodSeconds int64, name string) error {
+	key := field + ":" + userPath + ":" + strconv.FormatInt(periodSeconds, 10)
+	if owner, taken := owners[key]; taken {
+		if owner == name {
+			return fmt.Errorf("%s: duplicate period %ds", field, periodSeconds)
+		}
+		return fmt.Errorf("%s: user_path %s already has a %ds limit from key %q", field, userPath, periodSeconds, owner)
+	}
+	owners[key] = name
+	return nil
+}
+
+func fileKeySecret(entry fileKey) (secretHash string, redacted string, err error) {
+	value := strings.TrimSpace(entry.Value)
+	hash := strings.ToLower(strings.TrimSpace(entry.SecretHash))
+	switch {
+	case value != "" && hash != "":
+		return "", "", fmt.Errorf("set either value or secret_hash, not both")
+	case value != "":
+		secret, err := parseTokenSecret(value)
+		if err != nil {
+			return "", "", fmt.Errorf("value must start with %s", TokenPrefix)
+		}
+		return hashSecret(secret), redactTokenValue(value), nil
+	case hash != "":
+		if len(hash) != 64 || strings.Trim(hash, "0123456789abcdef") != "" {
+			return "", "", fmt.Errorf("secret_hash must be a hex SHA-256 digest")
+		}
+		return hash, TokenPrefix + "...", nil
+	default:
+		return "", "", fmt.Errorf("value or secret_hash is required")
+	}
+}
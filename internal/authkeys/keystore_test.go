@@ -0,0 +1,329 @@
+package authkeys
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/budget"
+	"github.com/enterpilot/gomodel/internal/ratelimit"
+)
+
+const (
+	fileKeyAlpha = TokenPrefix + "alpha-secret"
+	fileKeyBeta  = TokenPrefix + "beta-secret"
+)
+
+func writeKeyFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write key file: %v", err)
+	}
+}
+
+// touchKeyFile moves the file's modification time to at, so a rewrite is seen
+// as a change even on filesystems with coarse timestamps.
+func touchKeyFile(t *testing.T, path string, at time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, at, at); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+}
+
+func newFileBackedService(t *testing.T, path string) *Service {
+	t.Helper()
+	service, err := NewService(newTestStore())
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	service.SetKeyStore(NewFileKeyStore(path))
+	if err := service.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	return service
+}
+
+func TestFileKeyStoreLoadsKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, `
+keys:
+  - name: ci-bot
+    value: `+fileKeyAlpha+`
+    user_path: /team/ci
+    labels: [ci]
+  - id: legacy
+    name: legacy-client
+    secret_hash: `+hashSecret("beta-secret")+`
+    enabled: false
+`)
+	service := newFileBackedService(t, path)
+
+	if !service.Enabled() || service.Total() != 2 || service.ActiveCount() != 1 {
+		t.Fatalf("enabled=%v total=%d active=%d, want true/2/1", service.Enabled(), service.Total(), service.ActiveCount())
+	}
+	result, err := service.Authenticate(context.Background(), fileKeyAlpha)
+	if err != nil {
+		t.Fatalf("Authenticate(alpha) error = %v", err)
+	}
+	if result.ID != "file:ci-bot" || result.UserPath != "/team/ci" || len(result.Labels) != 1 || result.Labels[0] != "ci" {
+		t.Fatalf("Authenticate(alpha) = %+v", result)
+	}
+	if _, err := service.Authenticate(context.Background(), fileKeyBeta); !errors.Is(err, ErrInactive) {
+		t.Fatalf("Authenticate(beta) error = %v, want ErrInactive", err)
+	}
+	for _, view := range service.ListViews() {
+		if !view.ReadOnly || strings.Contains(view.RedactedValue, "alpha-secret") {
+			t.Fatalf("view = %+v, want read-only with a redacted value", view)
+		}
+	}
+}
+
+func TestFileKeyStoreParsesKeyRestrictions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, `
+keys:
+  - name: ci-bot
+    value: `+fileKeyAlpha+`
+    user_path: /team/ci
+    allowed_models: [openai/gpt-4o, " gpt-4o-mini "]
+    rate_limits:
+      - period: minute
+        max_requests: 10
+      - period_seconds: 0
+        max_requests: 2
+    budgets:
+      - period: daily
+        amount: 5
+`)
+	service := newFileBackedService(t, path)
+
+	result, err := service.Authenticate(context.Background(), fileKeyAlpha)
+	if err != nil {
+		t.Fatalf("Authenticate(alpha) error = %v", err)
+	}
+	if want := []string{"openai/gpt-4o", "gpt-4o-mini"}; !slices.Equal(result.AllowedModels, want) {
+		t.Fatalf("AllowedModels = %v, want %v", result.AllowedModels, want)
+	}
+
+	var gotRules []ratelimit.Rule
+	var gotBudgets []budget.Budget
+	service.SetLimitsListener(func(rules []ratelimit.Rule, budgets []budget.Budget) {
+		gotRules, gotBudgets = rules, budgets
+	})
+	if len(gotRules) != 2 || len(gotBudgets) != 1 {
+		t.Fatalf("listener got %d rules and %d budgets, want 2 and 1", len(gotRules), len(gotBudgets))
+	}
+	minute := gotRules[0]
+	if minute.Scope != ratelimit.ScopeUserPath || minute.Subject != "/team/ci" || minute.PeriodSeconds != ratelimit.PeriodMinuteSeconds ||
+		*minute.MaxRequests != 10 || minute.Source != ratelimit.SourceKeyFile {
+		t.Fatalf("minute rule = %+v", minute)
+	}
+	if gotRules[1].PeriodSeconds != ratelimit.PeriodConcurrent {
+		t.Fatalf("second rule period = %d, want concurrent", gotRules[1].PeriodSeconds)
+	}
+	daily := gotBudgets[0]
+	if daily.UserPath != "/team/ci" || daily.PeriodSeconds != budget.PeriodDailySeconds || daily.Amount != 5 || daily.Source != budget.SourceKeyFile {
+		t.Fatalf("budget = %+v", daily)
+	}
+}
+
+func TestFileKeyStoreKeysAreReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, "keys:\n  - name: ci-bot\n    value: "+fileKeyAlpha+"\n")
+	service := newFileBackedService(t, path)
+
+	if _, err := service.UpdateLabels(context.Background(), "file:ci-bot", []string{"x"}); !IsValidationError(err) {
+		t.Fatalf("UpdateLabels() error = %v, want validation error", err)
+	}
+	if err := service.Deactivate(context.Background(), "file:ci-bot"); !IsValidationError(err) {
+		t.Fatalf("Deactivate() error = %v, want validation error", err)
+	}
+}
+
+func TestFileKeyStoreRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "missing name", content: "keys:\n  - value: " + fileKeyAlpha + "\n", wantErr: "name is required"},
+		{name: "missing secret", content: "keys:\n  - name: a\n", wantErr: "value or secret_hash is required"},
+		{name: "both secrets", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n    secret_hash: " + hashSecret("x") + "\n", wantErr: "not both"},
+		{name: "foreign token", content: "keys:\n  - name: a\n    value: sk-other\n", wantErr: "value must start with"},
+		{name: "bad hash", content: "keys:\n  - name: a\n    secret_hash: nothex\n", wantErr: "hex SHA-256"},
+		{name: "limits without user path", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n    budgets: [{period: daily, amount: 5}]\n", wantErr: "require a user_path"},
+		{name: "bad rate limit period", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n    user_path: /a\n    rate_limits: [{period: fortnight, max_requests: 1}]\n", wantErr: "rate_limits[0]: invalid period"},
+		{name: "empty rate limit", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n    user_path: /a\n    rate_limits: [{period: minute}]\n", wantErr: "at least one of max_requests or max_tokens"},
+		{name: "bad budget amount", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n    user_path: /a\n    budgets: [{period: daily, amount: 0}]\n", wantErr: "amount must be"},
+		{name: "shared user path limit", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n    user_path: /a\n    rate_limits: [{period: minute, max_requests: 1}]\n  - name: b\n    value: " + fileKeyBeta + "\n    user_path: /a\n    rate_limits: [{period: minute, max_requests: 2}]\n", wantErr: `already has a 60s limit from key "a"`},
+		{name: "duplicate id", content: "keys:\n  - name: a\n    value: " + fileKeyAlpha + "\n  - name: a\n    value: " + fileKeyBeta + "\n", wantErr: "duplicate id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keys.yaml")
+			writeKeyFile(t, path, tt.content)
+			_, err := NewFileKeyStore(path).List(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("List() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceReloadsKeyFileOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyFile(t, path, `{"keys":[{"name":"alpha","value":"`+fileKeyAlpha+`"}]}`)
+	service := newFileBackedService(t, path)
+	stop := service.StartKeyStoreWatch(10 * time.Millisecond)
+	defer stop()
+
+	if _, err := service.Authenticate(context.Background(), fileKeyBeta); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Authenticate(beta) before reload error = %v, want ErrInvalidToken", err)
+	}
+
+	writeKeyFile(t, path, `{"keys":[{"name":"beta","value":"`+fileKeyBeta+`","user_path":"/team/new"}]}`)
+	future := time.Now().Add(time.Minute)
+	touchKeyFile(t, path, future)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		result, err := service.Authenticate(context.Background(), fileKeyBeta)
+		if err == nil {
+			if result.UserPath != "/team/new" {
+				t.Fatalf("reloaded key user path = %q, want /team/new", result.UserPath)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("key file change not picked up: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := service.Authenticate(context.Background(), fileKeyAlpha); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Authenticate(alpha) after reload error = %v, want ErrInvalidToken", err)
+	}
+
+	// A broken edit keeps the last good keys.
+	writeKeyFile(t, path, `{"keys":[{"value":"`+fileKeyAlpha+`"}]}`)
+	touchKeyFile(t, path, future.Add(time.Minute))
+	time.Sleep(100 * time.Millisecond)
+	if _, err := service.Authenticate(context.Background(), fileKeyBeta); err != nil {
+		t.Fatalf("Authenticate(beta) after broken edit error = %v, want previous keys kept", err)
+	}
+}
+
+func TestServiceRefreshKeepsFileKeysWhenKeyFileFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, "keys:\n  - name: alpha\n    value: "+fileKeyAlpha+"\n")
+	store := newTestStore()
+	service, err := NewService(store)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	service.SetKeyStore(NewFileKeyStore(path))
+	if err := service.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	issued, err := service.Create(context.Background(), CreateInput{Name: "persisted"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	writeKeyFile(t, path, "keys: [")
+	if err := service.Refresh(context.Background()); err == nil {
+		t.Fatalf("Refresh() with a broken key file error = nil, want the parse error")
+	}
+
+	if _, err := service.Authenticate(context.Background(), fileKeyAlpha); err != nil {
+		t.Fatalf("Authenticate(file key) error = %v, want the last good file keys kept", err)
+	}
+	if _, err := service.Authenticate(context.Background(), issued.Value); err != nil {
+		t.Fatalf("Authenticate(persisted key) error = %v", err)
+	}
+
+	// A key added to storage behind the service's back still swaps in while
+	// the file stays broken.
+	store.keys["direct"] = AuthKey{ID: "direct", Name: "direct", SecretHash: hashSecret("direct-secret"), Enabled: true}
+	if err := service.Refresh(context.Background()); err == nil {
+		t.Fatalf("Refresh() error = nil, want the parse error")
+	}
+	if _, err := service.Authenticate(context.Background(), TokenPrefix+"direct-secret"); err != nil {
+		t.Fatalf("Authenticate(direct) error = %v, want persisted keys refreshed", err)
+	}
+}
+
+func TestFileKeyStoreChangedWaitsForFileToSettle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, "keys:\n  - name: a\n    value: "+fileKeyAlpha+"\n")
+	store := NewFileKeyStore(path)
+	if _, err := store.List(context.Background()); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if changed, err := store.Changed(); err != nil || changed {
+		t.Fatalf("Changed() after List = %v, %v; want false", changed, err)
+	}
+
+	// First sighting of a new version is held back in case the write is
+	// still in progress.
+	writeKeyFile(t, path, "keys:\n")
+	touchKeyFile(t, path, time.Now().Add(time.Minute))
+	if changed, _ := store.Changed(); changed {
+		t.Fatal("Changed() reported a version seen only once")
+	}
+	// Still being written: the version moved again, so keep waiting.
+	writeKeyFile(t, path, "keys:\n  - name: b\n")
+	touchKeyFile(t, path, time.Now().Add(2*time.Minute))
+	if changed, _ := store.Changed(); changed {
+		t.Fatal("Changed() reported a version that was still moving")
+	}
+	if changed, _ := store.Changed(); !changed {
+		t.Fatal("Changed() = false for a settled new version")
+	}
+
+	// A version that fails to parse stays pending, so it is retried rather
+	// than skipped until the next edit.
+	if _, err := store.List(context.Background()); err == nil {
+		t.Fatal("List() error = nil for a key without a secret")
+	}
+	if changed, _ := store.Changed(); !changed {
+		t.Fatal("Changed() = false after a failed List, want a retry")
+	}
+}
+
+func TestServiceKeepsKeysThroughPartialWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyFile(t, path, `{"keys":[{"name":"alpha","value":"`+fileKeyAlpha+`"}]}`)
+	service := newFileBackedService(t, path)
+	stop := service.StartKeyStoreWatch(10 * time.Millisecond)
+	defer stop()
+
+	// An editor that truncates and rewrites in place leaves a half-written
+	// file for a moment; the watcher must keep the loaded keys meanwhile.
+	full := `{"keys":[{"name":"alpha","value":"` + fileKeyAlpha + `"},{"name":"beta","value":"` + fileKeyBeta + `"}]}`
+	writeKeyFile(t, path, full[:len(full)/2])
+	touchKeyFile(t, path, time.Now().Add(time.Minute))
+	time.Sleep(60 * time.Millisecond)
+	if _, err := service.Authenticate(context.Background(), fileKeyAlpha); err != nil {
+		t.Fatalf("Authenticate(alpha) during partial write error = %v, want previous keys kept", err)
+	}
+
+	writeKeyFile(t, path, full)
+	touchKeyFile(t, path, time.Now().Add(2*time.Minute))
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := service.Authenticate(context.Background(), fileKeyBeta); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("completed key file not picked up: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := service.Authenticate(context.Background(), fileKeyAlpha); err != nil {
+		t.Fatalf("Authenticate(alpha) after reload error = %v", err)
+	}
+}
@@ -17,7 +17,9 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/enterpilot/gomodel/internal/budget"
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/ratelimit"
 )
 
 const defaultRefreshInterval = time.Minute
@@ -31,17 +33,27 @@ type snapshot struct {
 
 // AuthenticationResult describes one successful managed auth key lookup.
 type AuthenticationResult struct {
-	ID       string
-	UserPath string
-	Labels   []string
+	ID            string
+	UserPath      string
+	Labels        []string
+	AllowedModels []string
 }
 
+// LimitsListener receives the rate limits and budgets declared on key store
+// keys whenever the snapshot is rebuilt.
+type LimitsListener func(rateLimits []ratelimit.Rule, budgets []budget.Budget)
+
 // Service keeps managed auth keys cached in memory for request authentication.
 type Service struct {
-	store Store
+	store    Store
+	keyStore KeyStore
 
 	mu       sync.RWMutex
 	snapshot snapshot
+	// external is the last key set the key store listed successfully; it
+	// stands in when a later List fails.
+	external       []AuthKey
+	limitsListener LimitsListener
 }
 
 // NewService creates a managed auth key service backed by storage.
@@ -60,12 +72,48 @@ func NewService(store Store) (*Service, error) {
 	}, nil
 }
 
+// SetKeyStore adds an external key source whose keys are merged with the
+// persisted keys on every Refresh. Call before the first Refresh.
+func (s *Service) SetKeyStore(keyStore KeyStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyStore = keyStore
+}
+
+// SetLimitsListener registers fn to receive the key store's rate limits and
+// budgets. It is called at once with the current snapshot, then after every
+// Refresh.
+func (s *Service) SetLimitsListener(fn LimitsListener) {
+	s.mu.Lock()
+	s.limitsListener = fn
+	external := s.external
+	s.mu.Unlock()
+	notifyLimits(fn, external)
+}
+
 // Refresh reloads keys from storage and atomically swaps the in-memory snapshot.
+// When storage fails the previous snapshot stays in place. When only the key
+// store fails, the persisted keys are still swapped in alongside the last
+// key store keys that loaded, and the key store error is returned.
 func (s *Service) Refresh(ctx context.Context) error {
 	keys, err := s.store.List(ctx)
 	if err != nil {
 		return fmt.Errorf("list auth keys: %w", err)
 	}
+	s.mu.RLock()
+	keyStore := s.keyStore
+	external := s.external
+	s.mu.RUnlock()
+	var keyStoreErr error
+	if keyStore != nil {
+		listed, err := keyStore.List(ctx)
+		if err != nil {
+			keyStoreErr = fmt.Errorf("list external auth keys: %w", err)
+		} else {
+			external = listed
+		}
+		keys = append(keys, external...)
+	}
 
 	now := time.Now().UTC()
 	next := snapshot{
@@ -102,8 +150,24 @@ func (s *Service) Refresh(ctx context.Context) error {
 
 	s.mu.Lock()
 	s.snapshot = next
+	s.external = external
+	listener := s.limitsListener
 	s.mu.Unlock()
-	return nil
+	notifyLimits(listener, external)
+	return keyStoreErr
+}
+
+func notifyLimits(fn LimitsListener, keys []AuthKey) {
+	if fn == nil {
+		return
+	}
+	var rateLimits []ratelimit.Rule
+	var budgets []budget.Budget
+	for _, key := range keys {
+		rateLimits = append(rateLimits, key.RateLimits...)
+		budgets = append(budgets, key.Budgets...)
+	}
+	fn(rateLimits, budgets)
 }
 
 // Enabled reports whether managed auth keys should be enforced.
@@ -215,6 +279,9 @@ func (s *Service) UpdateLabels(ctx context.Context, id string, labels []string)
 		return nil, newValidationError("auth key id is required", nil)
 	}
 	labels = core.MergeLabels(labels)
+	if err := s.checkWritable(id); err != nil {
+		return nil, err
+	}
 
 	now := time.Now().UTC()
 	if err := s.store.UpdateLabels(ctx, id, labels, now); err != nil {
@@ -248,6 +315,9 @@ func (s *Service) Deactivate(ctx context.Context, id string) error {
 	if id == "" {
 		return newValidationError("auth key id is required", nil)
 	}
+	if err := s.checkWritable(id); err != nil {
+		return err
+	}
 
 	now := time.Now().UTC()
 	if err := s.store.Deactivate(ctx, id, now); err != nil {
@@ -306,7 +376,9 @@ func (s *Service) StartBackgroundRefresh(interval time.Duration) func() {
 				return
 			case <-ticker.C:
 				refreshCtx, refreshCancel := context.WithTimeout(ctx, 30*time.Second)
-				_ = s.Refresh(refreshCtx)
+				if err := s.Refresh(refreshCtx); err != nil {
+					slog.Warn("auth key refresh failed", "error", err)
+				}
 				refreshCancel()
 			}
 		}
@@ -320,6 +392,71 @@ func (s *Service) StartBackgroundRefresh(interval time.Duration) func() {
 	}
 }
 
+// StartKeyStoreWatch polls the external key store for changes and refreshes
+// the snapshot as soon as one is seen, so edits to a key file take effect
+// within one interval instead of waiting for the periodic refresh. It is a
+// no-op for key stores that cannot report changes.
+func (s *Service) StartKeyStoreWatch(interval time.Duration) func() {
+	s.mu.RLock()
+	reporter, ok := s.keyStore.(changeReporter)
+	s.mu.RUnlock()
+	if !ok {
+		return func() {}
+	}
+	if interval <= 0 {
+		interval = defaultKeyFileWatchInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				changed, err := reporter.Changed()
+				if err != nil {
+					slog.Warn("auth key store change check failed", "error", err)
+					continue
+				}
+				if !changed {
+					continue
+				}
+				refreshCtx, refreshCancel := context.WithTimeout(ctx, 30*time.Second)
+				if err := s.Refresh(refreshCtx); err != nil {
+					slog.Warn("auth key store reload failed; keeping previous keys", "error", err)
+				} else {
+					slog.Info("auth key store reloaded", "total", s.Total())
+				}
+				refreshCancel()
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+}
+
+func (s *Service) checkWritable(id string) error {
+	s.mu.RLock()
+	key, exists := s.snapshot.byID[id]
+	s.mu.RUnlock()
+	if exists && key.ReadOnly {
+		return newValidationError("auth key "+id+" is managed by its key file and cannot be changed here", nil)
+	}
+	return nil
+}
+
 func authenticateKey(key AuthKey, now time.Time) (AuthenticationResult, error) {
 	if !key.Enabled || key.DeactivatedAt != nil {
 		return AuthenticationResult{}, ErrInactive
@@ -331,9 +468,10 @@ func authenticateKey(key AuthKey, now time.Time) (AuthenticationResult, error) {
 		return AuthenticationResult{}, ErrInvalidToken
 	}
 	return AuthenticationResult{
-		ID:       key.ID,
-		UserPath: strings.TrimSpace(key.UserPath),
-		Labels:   key.Labels,
+		ID:            key.ID,
+		UserPath:      strings.TrimSpace(key.UserPath),
+		Labels:        key.Labels,
+		AllowedModels: key.AllowedModels,
 	}, nil
 }
 
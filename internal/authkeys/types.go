@@ -1,6 +1,11 @@
 package authkeys
 
-import "time"
+import (
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/budget"
+	"github.com/enterpilot/gomodel/internal/ratelimit"
+)
 
 const (
 	// TokenPrefix is the managed API key prefix returned to clients.
@@ -22,6 +27,15 @@ type AuthKey struct {
 	DeactivatedAt *time.Time `json:"deactivated_at,omitempty" bson:"deactivated_at,omitempty"`
 	CreatedAt     time.Time  `json:"created_at" bson:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" bson:"updated_at"`
+	// ReadOnly marks keys supplied by a KeyStore; they are managed in their
+	// source and cannot be changed through the admin API.
+	ReadOnly bool `json:"read_only,omitempty" bson:"-"`
+	// AllowedModels, RateLimits, and Budgets restrict a key supplied by a
+	// KeyStore. An empty AllowedModels allows every model. Rate limits and
+	// budgets are scoped to the key's user path, like configured ones.
+	AllowedModels []string         `json:"allowed_models,omitempty" bson:"-"`
+	RateLimits    []ratelimit.Rule `json:"rate_limits,omitempty" bson:"-"`
+	Budgets       []budget.Budget  `json:"budgets,omitempty" bson:"-"`
 }
 
 // View is the admin-facing representation of a managed auth key.
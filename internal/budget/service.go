@@ -19,6 +19,9 @@ type Service struct {
 
 	budgets  []Budget
 	settings Settings
+	// keyBudgets are the in-memory budgets declared on auth key file keys. A
+	// persisted budget for the same user path and period takes precedence.
+	keyBudgets []Budget
 }
 
 func NewService(ctx context.Context, store Store) (*Service, error) {
@@ -97,6 +100,18 @@ func (s *Service) ReplaceConfigBudgets(ctx context.Context, budgets []Budget) er
 	return s.Refresh(ctx)
 }
 
+// SetKeyBudgets replaces the budgets declared on auth key file keys. They
+// are not persisted and do not appear in Budgets or Statuses; only the
+// per-path checks see them.
+func (s *Service) SetKeyBudgets(budgets []Budget) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyBudgets = append([]Budget(nil), budgets...)
+}
+
 func (s *Service) Budgets() []Budget {
 	if s == nil {
 		return nil
@@ -208,10 +223,7 @@ func (s *Service) CheckWithResults(ctx context.Context, userPath string, now tim
 	}
 	now = now.UTC()
 
-	s.mu.RLock()
-	budgets := append([]Budget(nil), s.budgets...)
-	settings := s.settings
-	s.mu.RUnlock()
+	budgets, settings := s.enforcedBudgets()
 	if len(budgets) == 0 {
 		return nil, nil
 	}
@@ -249,10 +261,7 @@ func (s *Service) StatusesForPath(ctx context.Context, userPath string, now time
 	}
 	now = now.UTC()
 
-	s.mu.RLock()
-	budgets := append([]Budget(nil), s.budgets...)
-	settings := s.settings
-	s.mu.RUnlock()
+	budgets, settings := s.enforcedBudgets()
 
 	results := make([]CheckResult, 0, len(budgets))
 	for _, budget := range budgets {
@@ -287,6 +296,27 @@ func (s *Service) evaluateBudget(ctx context.Context, budget Budget, now time.Ti
 	}, nil
 }
 
+// enforcedBudgets returns the persisted budgets followed by the key file
+// budgets they do not shadow, with the current settings.
+func (s *Service) enforcedBudgets() ([]Budget, Settings) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	budgets := append([]Budget(nil), s.budgets...)
+	for _, keyBudget := range s.keyBudgets {
+		shadowed := false
+		for _, budget := range s.budgets {
+			if budget.UserPath == keyBudget.UserPath && budget.PeriodSeconds == keyBudget.PeriodSeconds {
+				shadowed = true
+				break
+			}
+		}
+		if !shadowed {
+			budgets = append(budgets, keyBudget)
+		}
+	}
+	return budgets, s.settings
+}
+
 func budgetAppliesToPath(budgetPath, requestPath string) bool {
 	budgetPath = strings.TrimSpace(budgetPath)
 	requestPath = strings.TrimSpace(requestPath)
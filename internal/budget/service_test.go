@@ -261,6 +261,39 @@ func TestServiceCheckRejectsExceededBudgetForMatchingUserPath(t *testing.T) {
 	}
 }
 
+func TestServiceCheckEnforcesKeyBudgetsUnlessShadowed(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeStore{
+		budgets: []Budget{
+			{UserPath: "/team", PeriodSeconds: PeriodDailySeconds, Amount: 100},
+		},
+		sum: func(string, time.Time, time.Time) (float64, bool, error) {
+			return 10, true, nil
+		},
+	}
+	service, err := NewService(ctx, store)
+	if err != nil {
+		t.Fatalf("NewService() failed: %v", err)
+	}
+	service.SetKeyBudgets([]Budget{
+		{UserPath: "/keys/ci", PeriodSeconds: PeriodDailySeconds, Amount: 5, Source: SourceKeyFile},
+		// Shadowed by the persisted /team budget for the same period.
+		{UserPath: "/team", PeriodSeconds: PeriodDailySeconds, Amount: 5, Source: SourceKeyFile},
+	})
+	now := time.Date(2026, time.April, 25, 12, 0, 0, 0, time.UTC)
+
+	var exceeded *ExceededError
+	if err := service.Check(ctx, "/keys/ci", now); !errors.As(err, &exceeded) {
+		t.Fatalf("Check(/keys/ci) error = %v, want ExceededError", err)
+	}
+	if err := service.Check(ctx, "/team", now); err != nil {
+		t.Fatalf("Check(/team) error = %v, want nil; the persisted budget must win", err)
+	}
+	if got := len(service.Budgets()); got != 1 {
+		t.Fatalf("Budgets() = %d, want only the persisted budget", got)
+	}
+}
+
 func TestServiceStatusesForPathReportsAllMatchingBudgetsWithoutEnforcing(t *testing.T) {
 	ctx := context.Background()
 	store := &fakeStore{
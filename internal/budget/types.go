@@ -22,6 +22,9 @@ const (
 	SourceConfig = "config"
 	// SourceManual marks budgets created or changed through admin APIs.
 	SourceManual = "manual"
+	// SourceKeyFile marks budgets declared on keys in an auth key file. They
+	// are held in memory only and replaced on every key file reload.
+	SourceKeyFile = "key_file"
 )
 
 // Budget stores one spend limit for one user path and reset period.
@@ -0,0 +1,27 @@
+// Package cachetest provides helpers for tests that exercise cache backends
+// which cannot be reached.
+package cachetest
+
+import (
+	"net"
+	"testing"
+)
+
+// UnreachableAddr returns a loopback host:port that nothing listens on. The
+// port is reserved and released, so dialing it is refused promptly.
+func UnreachableAddr(t testing.TB) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return addr
+}
+
+// UnreachableRedisURL returns a redis:// URL for UnreachableAddr.
+func UnreachableRedisURL(t testing.TB) string {
+	t.Helper()
+	return "redis://" + UnreachableAddr(t)
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRUStore is a bounded in-memory Store. Entries expire after the TTL passed
+// to Set, and once MaxEntries is reached the least recently used entry is
+// evicted to make room. It never leaves the process, so it suits single
+// instance deployments that do not run Redis.
+type LRUStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUStore creates an in-memory store holding at most maxEntries values.
+// maxEntries below 1 is treated as 1.
+func NewLRUStore(maxEntries int) *LRUStore {
+	return &LRUStore{
+		maxEntries: max(maxEntries, 1),
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Get retrieves value by key and marks it most recently used. Expired or
+// missing keys return nil.
+func (s *LRUStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !s.now().Before(entry.expiresAt) {
+		s.remove(elem)
+		return nil, nil
+	}
+	s.order.MoveToFront(elem)
+	cp := make([]byte, len(entry.value))
+	copy(cp, entry.value)
+	return cp, nil
+}
+
+// Set stores value for ttl, evicting the least recently used entry when the
+// store is full. A ttl of zero or less keeps the value until it is evicted.
+func (s *LRUStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	cp := make([]byte, len(value))
+	copy(cp, value)
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = s.now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = cp
+		entry.expiresAt = expiresAt
+		s.order.MoveToFront(elem)
+		return nil
+	}
+	s.items[key] = s.order.PushFront(&lruEntry{key: key, value: cp, expiresAt: expiresAt})
+	for s.order.Len() > s.maxEntries {
+		s.remove(s.order.Back())
+	}
+	return nil
+}
+
+// Close drops every entry.
+func (s *LRUStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.order.Init()
+	clear(s.items)
+	return nil
+}
+
+func (s *LRUStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.items, elem.Value.(*lruEntry).key)
+}
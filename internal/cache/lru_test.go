@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUStore_ExpiresAfterTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	store := NewLRUStore(10)
+	store.now = func() time.Time { return now }
+
+	if err := store.Set(ctx, "k", []byte("v"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, _ := store.Get(ctx, "k"); string(got) != "v" {
+		t.Fatalf("Get before expiry = %q, want v", got)
+	}
+
+	now = now.Add(time.Minute)
+	if got, _ := store.Get(ctx, "k"); got != nil {
+		t.Fatalf("Get after expiry = %q, want nil", got)
+	}
+	if store.order.Len() != 0 {
+		t.Fatalf("expired entry kept, len = %d", store.order.Len())
+	}
+}
+
+func TestLRUStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRUStore(2)
+
+	_ = store.Set(ctx, "a", []byte("1"), 0)
+	_ = store.Set(ctx, "b", []byte("2"), 0)
+	// Touch a so b becomes the eviction candidate.
+	if got, _ := store.Get(ctx, "a"); string(got) != "1" {
+		t.Fatalf("Get(a) = %q, want 1", got)
+	}
+	_ = store.Set(ctx, "c", []byte("3"), 0)
+
+	if got, _ := store.Get(ctx, "b"); got != nil {
+		t.Fatalf("Get(b) = %q, want evicted", got)
+	}
+	for key, want := range map[string]string{"a": "1", "c": "3"} {
+		if got, _ := store.Get(ctx, key); string(got) != want {
+			t.Fatalf("Get(%s) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLRUStore_OverwriteRefreshesValueAndTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC)
+	store := NewLRUStore(2)
+	store.now = func() time.Time { return now }
+
+	_ = store.Set(ctx, "k", []byte("old"), time.Minute)
+	now = now.Add(50 * time.Second)
+	_ = store.Set(ctx, "k", []byte("new"), time.Minute)
+	now = now.Add(50 * time.Second)
+
+	got, _ := store.Get(ctx, "k")
+	if string(got) != "new" {
+		t.Fatalf("Get = %q, want new", got)
+	}
+	got[0] = 'X'
+	if again, _ := store.Get(ctx, "k"); string(again) != "new" {
+		t.Fatalf("stored value mutated through returned slice: %q", again)
+	}
+	if store.order.Len() != 1 {
+		t.Fatalf("len = %d, want 1", store.order.Len())
+	}
+}
@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMemcachedTTL = 24 * time.Hour
+
+	// memcachedDialTimeout bounds connecting to a server when the caller's
+	// context has no earlier deadline.
+	memcachedDialTimeout = 5 * time.Second
+
+	// memcachedMaxRelativeTTL is the longest expiration memcached accepts as
+	// a relative number of seconds; longer values are read as Unix times.
+	memcachedMaxRelativeTTL = 30 * 24 * time.Hour
+)
+
+// MemcachedStoreConfig holds configuration for a Memcached key-value store.
+type MemcachedStoreConfig struct {
+	// Servers lists host:port addresses. Keys are spread across them by hash.
+	Servers []string
+	Prefix  string
+	TTL     time.Duration
+}
+
+// MemcachedStore implements Store over the memcached text protocol. Each
+// operation uses its own short-lived connection, which suits the model
+// cache's infrequent reads and writes without a connection pool.
+type MemcachedStore struct {
+	servers []string
+	prefix  string
+	ttl     time.Duration
+	dial    func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// NewMemcachedStore creates a Memcached-based key-value store and verifies
+// that every server answers.
+func NewMemcachedStore(cfg MemcachedStoreConfig) (*MemcachedStore, error) {
+	servers := make([]string, 0, len(cfg.Servers))
+	for _, server := range cfg.Servers {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("memcached: at least one server is required")
+	}
+	ttl := cfg.TTL
+	if ttl == 0 {
+		ttl = DefaultMemcachedTTL
+	}
+	store := &MemcachedStore{
+		servers: servers,
+		prefix:  cfg.Prefix,
+		ttl:     ttl,
+		dial:    (&net.Dialer{Timeout: memcachedDialTimeout}).DialContext,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to memcached: %w", err)
+	}
+	slog.Info("memcached store connected", "servers", servers, "prefix", cfg.Prefix, "ttl", ttl)
+	return store, nil
+}
+
+// Get retrieves value by key.
+func (s *MemcachedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	fullKey, err := s.fullKey(key)
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	err = s.roundTrip(ctx, s.serverFor(fullKey), func(rw *bufio.ReadWriter) error {
+		if _, err := fmt.Fprintf(rw, "get %s\r\n", fullKey); err != nil {
+			return err
+		}
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+		value, err = readMemcachedValue(rw.Reader, fullKey)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("memcached get: %w", err)
+	}
+	return value, nil
+}
+
+// Set stores value with TTL.
+func (s *MemcachedStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = s.ttl
+	}
+	fullKey, err := s.fullKey(key)
+	if err != nil {
+		return err
+	}
+	err = s.roundTrip(ctx, s.serverFor(fullKey), func(rw *bufio.ReadWriter) error {
+		if _, err := fmt.Fprintf(rw, "set %s 0 %d %d\r\n", fullKey, memcachedExpiration(ttl, time.Now()), len(value)); err != nil {
+			return err
+		}
+		if _, err := rw.Write(value); err != nil {
+			return err
+		}
+		if _, err := rw.WriteString("\r\n"); err != nil {
+			return err
+		}
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+		line, err := readMemcachedLine(rw.Reader)
+		if err != nil {
+			return err
+		}
+		if line != "STORED" {
+			return memcachedReplyError(line)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("memcached set: %w", err)
+	}
+	return nil
+}
+
+// Ping verifies that every configured server answers a version request.
+func (s *MemcachedStore) Ping(ctx context.Context) error {
+	for _, server := range s.servers {
+		err := s.roundTrip(ctx, server, func(rw *bufio.ReadWriter) error {
+			if _, err := rw.WriteString("version\r\n"); err != nil {
+				return err
+			}
+			if err := rw.Flush(); err != nil {
+				return err
+			}
+			line, err := readMemcachedLine(rw.Reader)
+			if err != nil {
+				return err
+			}
+			if !strings.HasPrefix(line, "VERSION ") {
+				return memcachedReplyError(line)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", server, err)
+		}
+	}
+	return nil
+}
+
+// Close is a no-op: MemcachedStore holds no open connections between calls.
+func (s *MemcachedStore) Close() error {
+	return nil
+}
+
+func (s *MemcachedStore) fullKey(key string) (string, error) {
+	fullKey := s.prefix + key
+	if fullKey == "" || len(fullKey) > 250 || strings.ContainsFunc(fullKey, func(r rune) bool { return r <= ' ' || r == 0x7f }) {
+		return "", fmt.Errorf("memcached: invalid key %q", fullKey)
+	}
+	return fullKey, nil
+}
+
+func (s *MemcachedStore) serverFor(key string) string {
+	if len(s.servers) == 1 {
+		return s.servers[0]
+	}
+	return s.servers[crc32.ChecksumIEEE([]byte(key))%uint32(len(s.servers))]
+}
+
+func (s *MemcachedStore) roundTrip(ctx context.Context, server string, fn func(*bufio.ReadWriter) error) error {
+	conn, err := s.dial(ctx, "tcp", server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	return fn(bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn)))
+}
+
+// memcachedExpiration converts ttl to the protocol's exptime field, which is
+// relative seconds up to 30 days and an absolute Unix time beyond that.
+func memcachedExpiration(ttl time.Duration, now time.Time) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	seconds := int64((ttl + time.Second - 1) / time.Second)
+	if ttl > memcachedMaxRelativeTTL {
+		return now.Unix() + seconds
+	}
+	return seconds
+}
+
+// readMemcachedValue parses a get reply for key: either "END" (a miss) or a
+// single VALUE block followed by "END".
+func readMemcachedValue(r *bufio.Reader, key string) ([]byte, error) {
+	line, err := readMemcachedLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if line == "END" {
+		return nil, nil
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "VALUE" || fields[1] != key {
+		return nil, memcachedReplyError(line)
+	}
+	size, err := strconv.Atoi(fields[3])
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+	value := make([]byte, size+2)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(value, []byte("\r\n")) {
+		return nil, errors.New("malformed value block")
+	}
+	if line, err = readMemcachedLine(r); err != nil {
+		return nil, err
+	}
+	if line != "END" {
+		return nil, memcachedReplyError(line)
+	}
+	return value[:size], nil
+}
+
+func readMemcachedLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func memcachedReplyError(line string) error {
+	return fmt.Errorf("unexpected reply %q", line)
+}
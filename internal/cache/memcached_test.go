@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/cache/memcachedtest"
+)
+
+func TestMemcachedStore_GetSet(t *testing.T) {
+	server := memcachedtest.NewServer(t)
+	store, err := NewMemcachedStore(MemcachedStoreConfig{Servers: []string{server.Addr()}, Prefix: "test:"})
+	if err != nil {
+		t.Fatalf("NewMemcachedStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	got, err := store.Get(ctx, "models")
+	if err != nil || got != nil {
+		t.Fatalf("Get(missing) = %q, %v; want nil, nil", got, err)
+	}
+
+	value := []byte("{\"a\":1}\r\nwith a CRLF inside")
+	if err := store.Set(ctx, "models", value, time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err = store.Get(ctx, "models")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(got) != string(value) {
+		t.Fatalf("Get() = %q, want %q", got, value)
+	}
+	if exptime := server.Exptime("test:models"); exptime != "3600" {
+		t.Fatalf("exptime = %q, want 3600", exptime)
+	}
+}
+
+func TestMemcachedStore_DefaultTTL(t *testing.T) {
+	server := memcachedtest.NewServer(t)
+	store, err := NewMemcachedStore(MemcachedStoreConfig{Servers: []string{server.Addr()}})
+	if err != nil {
+		t.Fatalf("NewMemcachedStore() error = %v", err)
+	}
+	if err := store.Set(context.Background(), "k", []byte("v"), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if exptime := server.Exptime("k"); exptime != "86400" {
+		t.Fatalf("exptime = %q, want the 24h default", exptime)
+	}
+}
+
+func TestMemcachedStore_SpreadsKeysAcrossServers(t *testing.T) {
+	first, second := memcachedtest.NewServer(t), memcachedtest.NewServer(t)
+	store, err := NewMemcachedStore(MemcachedStoreConfig{Servers: []string{first.Addr(), second.Addr()}})
+	if err != nil {
+		t.Fatalf("NewMemcachedStore() error = %v", err)
+	}
+	ctx := context.Background()
+	for i := range 20 {
+		key := fmt.Sprintf("key-%d", i)
+		if err := store.Set(ctx, key, []byte(key), 0); err != nil {
+			t.Fatalf("Set(%s) error = %v", key, err)
+		}
+		if got, err := store.Get(ctx, key); err != nil || string(got) != key {
+			t.Fatalf("Get(%s) = %q, %v", key, got, err)
+		}
+	}
+	if len(first.Keys()) == 0 || len(second.Keys()) == 0 {
+		t.Fatalf("keys per server = %d/%d, want both servers used", len(first.Keys()), len(second.Keys()))
+	}
+}
+
+func TestNewMemcachedStore_Errors(t *testing.T) {
+	if _, err := NewMemcachedStore(MemcachedStoreConfig{Servers: []string{" "}}); err == nil {
+		t.Fatal("NewMemcachedStore(no servers) error = nil, want error")
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	if _, err := NewMemcachedStore(MemcachedStoreConfig{Servers: []string{addr}}); err == nil {
+		t.Fatal("NewMemcachedStore(unreachable) error = nil, want connection error")
+	}
+}
+
+func TestMemcachedStore_RejectsInvalidKey(t *testing.T) {
+	server := memcachedtest.NewServer(t)
+	store, err := NewMemcachedStore(MemcachedStoreConfig{Servers: []string{server.Addr()}})
+	if err != nil {
+		t.Fatalf("NewMemcachedStore() error = %v", err)
+	}
+	if err := store.Set(context.Background(), "has space", []byte("v"), 0); err == nil {
+		t.Fatal("Set(key with space) error = nil, want invalid key error")
+	}
+}
+
+func TestMemcachedExpiration(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		ttl  time.Duration
+		want int64
+	}{
+		{ttl: 0, want: 0},
+		{ttl: 1500 * time.Millisecond, want: 2},
+		{ttl: 24 * time.Hour, want: 86400},
+		{ttl: 31 * 24 * time.Hour, want: now.Unix() + 31*86400},
+	}
+	for _, tt := range tests {
+		if got := memcachedExpiration(tt.ttl, now); got != tt.want {
+			t.Errorf("memcachedExpiration(%v) = %d, want %d", tt.ttl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package memcachedtest provides an in-process memcached server for tests.
+// It speaks the subset of the text protocol cache.MemcachedStore uses
+// (version, get, set), so tests need no live memcached.
+package memcachedtest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server is a fake memcached server backed by a map. It stops when the test
+// that started it finishes.
+type Server struct {
+	mu       sync.Mutex
+	data     map[string][]byte
+	exptimes map[string]string
+	listener net.Listener
+}
+
+// NewServer starts a Server on a loopback port.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &Server{data: map[string][]byte{}, exptimes: map[string]string{}, listener: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+// Addr returns the host:port the server listens on.
+func (s *Server) Addr() string { return s.listener.Addr().String() }
+
+// Keys returns the stored keys in sorted order.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Exptime returns the expiration field the last set of key carried, as sent.
+func (s *Server) Exptime(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exptimes[key]
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) == 1 && fields[0] == "version":
+			_, _ = io.WriteString(conn, "VERSION 1.6.0\r\n")
+		case len(fields) == 2 && fields[0] == "get":
+			s.mu.Lock()
+			value, ok := s.data[fields[1]]
+			s.mu.Unlock()
+			if ok {
+				_, _ = fmt.Fprintf(conn, "VALUE %s 0 %d\r\n%s\r\n", fields[1], len(value), value)
+			}
+			_, _ = io.WriteString(conn, "END\r\n")
+		case len(fields) == 5 && fields[0] == "set":
+			size, _ := strconv.Atoi(fields[4])
+			value := make([]byte, size+2)
+			if _, err := io.ReadFull(r, value); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data[fields[1]] = value[:size]
+			s.exptimes[fields[1]] = fields[3]
+			s.mu.Unlock()
+			_, _ = io.WriteString(conn, "STORED\r\n")
+		default:
+			_, _ = io.WriteString(conn, "ERROR\r\n")
+		}
+	}
+}
@@ -3,9 +3,11 @@ package modelcache
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -15,13 +17,25 @@ import (
 type LocalCache struct {
 	mu       sync.RWMutex
 	filePath string
+	maxAge   time.Duration
+	now      func() time.Time
 }
 
 // NewLocalCache creates a new local file-based cache.
 // The filePath specifies where the cache file will be stored.
 func NewLocalCache(filePath string) *LocalCache {
+	return NewLocalCacheWithMaxAge(filePath, 0)
+}
+
+// NewLocalCacheWithMaxAge creates a local file-based cache that ignores
+// files whose UpdatedAt is older than maxAge, so a server restarted after a
+// long stop fetches fresh models instead of serving a stale list. Zero
+// disables the check.
+func NewLocalCacheWithMaxAge(filePath string, maxAge time.Duration) *LocalCache {
 	return &LocalCache{
 		filePath: filePath,
+		maxAge:   max(maxAge, 0),
+		now:      time.Now,
 	}
 }
 
@@ -47,6 +61,18 @@ func (c *LocalCache) Get(ctx context.Context) (*ModelCache, error) {
 		return nil, fmt.Errorf("failed to parse cache file: %w", err)
 	}
 
+	if c.maxAge > 0 {
+		// A file without updated_at has unknown age and is treated as stale.
+		if age := c.now().Sub(cache.UpdatedAt); cache.UpdatedAt.IsZero() || age > c.maxAge {
+			slog.Warn("ignoring stale model cache file; waiting for a fresh fetch",
+				"path", c.filePath,
+				"updated_at", cache.UpdatedAt,
+				"max_age", c.maxAge,
+			)
+			return nil, nil
+		}
+	}
+
 	return &cache, nil
 }
 
@@ -108,6 +108,43 @@ func TestLocalCache(t *testing.T) {
 		}
 	})
 
+	t.Run("MaxAge", func(t *testing.T) {
+		now := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		tests := []struct {
+			name      string
+			updatedAt time.Time
+			maxAge    time.Duration
+			wantNil   bool
+		}{
+			{name: "fresh file loads", updatedAt: now.Add(-time.Hour), maxAge: 24 * time.Hour},
+			{name: "expired file is skipped", updatedAt: now.Add(-25 * time.Hour), maxAge: 24 * time.Hour, wantNil: true},
+			{name: "missing updated_at is treated as stale", maxAge: 24 * time.Hour, wantNil: true},
+			{name: "zero max age loads any age", updatedAt: now.Add(-365 * 24 * time.Hour)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cacheFile := filepath.Join(t.TempDir(), "models.json")
+				data, err := json.Marshal(&ModelCache{UpdatedAt: tt.updatedAt, Providers: map[string]CachedProvider{}})
+				if err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+				if err := os.WriteFile(cacheFile, data, 0o644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+
+				cache := NewLocalCacheWithMaxAge(cacheFile, tt.maxAge)
+				cache.now = func() time.Time { return now }
+				result, err := cache.Get(context.Background())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if (result == nil) != tt.wantNil {
+					t.Fatalf("Get() = %v, want nil: %v", result, tt.wantNil)
+				}
+			})
+		}
+	})
+
 	t.Run("InvalidJSON", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		cacheFile := filepath.Join(tmpDir, "models.json")
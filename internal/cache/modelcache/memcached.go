@@ -0,0 +1,51 @@
+package modelcache
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/cache"
+)
+
+const (
+	// DefaultMemcachedKey is the Memcached key used to store the model registry cache.
+	DefaultMemcachedKey = "gomodel:models"
+)
+
+// MemcachedModelCacheConfig is the configuration passed to
+// NewMemcachedModelCache. It mirrors RedisModelCacheConfig.
+type MemcachedModelCacheConfig struct {
+	// Servers lists the Memcached host:port addresses. Required.
+	Servers []string
+
+	// Key is the Memcached key under which the serialised ModelCache is stored.
+	// Defaults to DefaultMemcachedKey ("gomodel:models") when empty.
+	Key string
+
+	// TTL is how long a cached entry lives in Memcached before expiring.
+	// Defaults to cache.DefaultMemcachedTTL (24 h) when zero.
+	TTL time.Duration
+}
+
+// NewMemcachedModelCache creates a Cache backed by Memcached. The model list
+// is stored as one JSON value, so it must fit Memcached's item size limit
+// (1 MB unless the server raises it with -I).
+func NewMemcachedModelCache(cfg MemcachedModelCacheConfig) (Cache, error) {
+	key := cfg.Key
+	if key == "" {
+		key = DefaultMemcachedKey
+	}
+	ttl := cfg.TTL
+	if ttl == 0 {
+		ttl = cache.DefaultMemcachedTTL
+	}
+	store, err := cache.NewMemcachedStore(cache.MemcachedStoreConfig{
+		Servers: cfg.Servers,
+		TTL:     ttl,
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("memcached model cache connected", "key", key, "ttl", ttl)
+	return &storeModelCache{store: store, key: key, ttl: ttl, owned: true}, nil
+}
@@ -0,0 +1,50 @@
+package modelcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/cache/memcachedtest"
+)
+
+func TestMemcachedModelCache_GetSet(t *testing.T) {
+	server := memcachedtest.NewServer(t)
+	c, err := NewMemcachedModelCache(MemcachedModelCacheConfig{Servers: []string{server.Addr()}})
+	if err != nil {
+		t.Fatalf("NewMemcachedModelCache: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	got, err := c.Get(ctx)
+	if err != nil || got != nil {
+		t.Fatalf("Get empty = %v, %v; want nil, nil", got, err)
+	}
+
+	mc := &ModelCache{
+		UpdatedAt: time.Now(),
+		Providers: map[string]CachedProvider{
+			"openai": {ProviderType: "openai", OwnedBy: "openai", Models: []CachedModel{{ID: "gpt-4", Created: 123}}},
+		},
+	}
+	if err := c.Set(ctx, mc); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, err = c.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get after Set: %v", err)
+	}
+	if got == nil || len(got.Providers["openai"].Models) != 1 || got.Providers["openai"].Models[0].ID != "gpt-4" {
+		t.Fatalf("Get after Set = %+v, want the stored openai model", got)
+	}
+	if stored := server.Keys(); len(stored) != 1 || stored[0] != DefaultMemcachedKey {
+		t.Fatalf("stored keys = %v, want [%s]", stored, DefaultMemcachedKey)
+	}
+}
+
+func TestNewMemcachedModelCache_RequiresServers(t *testing.T) {
+	if _, err := NewMemcachedModelCache(MemcachedModelCacheConfig{}); err == nil {
+		t.Fatal("NewMemcachedModelCache(no servers) error = nil, want error")
+	}
+}
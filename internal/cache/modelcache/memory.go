@@ -0,0 +1,73 @@
+package modelcache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// MemoryCache implements Cache in process memory and never touches disk.
+// It suits read-only containers, serverless deployments, and tests. Nothing
+// survives a restart, so each process starts from an empty cache and serves
+// models as the background fetch discovers them.
+type MemoryCache struct {
+	mu       sync.RWMutex
+	data     []byte
+	storedAt time.Time
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewMemoryCache creates an in-memory cache. A positive ttl expires the
+// stored entry that long after the last Set; zero keeps it until the
+// process exits.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl: max(ttl, 0),
+		now: time.Now,
+	}
+}
+
+// Get returns a copy of the stored model cache, or nil when nothing is
+// stored or the entry has expired.
+func (c *MemoryCache) Get(ctx context.Context) (*ModelCache, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.data == nil {
+		return nil, nil
+	}
+	if c.ttl > 0 && c.now().Sub(c.storedAt) >= c.ttl {
+		return nil, nil
+	}
+
+	// Entries are stored encoded so callers never share maps or slices with
+	// the cache, matching what the file and Redis backends hand back.
+	var cache ModelCache
+	if err := json.Unmarshal(c.data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to decode cached models: %w", err)
+	}
+	return &cache, nil
+}
+
+// Set stores the model cache, replacing any previous entry.
+func (c *MemoryCache) Set(ctx context.Context, cache *ModelCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data = data
+	c.storedAt = c.now()
+	return nil
+}
+
+// Close is a no-op for the memory cache.
+func (c *MemoryCache) Close() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+package modelcache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	data := &ModelCache{
+		UpdatedAt: time.Now().UTC(),
+		Providers: map[string]CachedProvider{
+			"openai": {
+				ProviderType: "openai",
+				OwnedBy:      "openai",
+				Models:       []CachedModel{{ID: "test-model", Created: 1234567890}},
+			},
+		},
+	}
+
+	t.Run("GetSetRoundTrip", func(t *testing.T) {
+		cache := NewMemoryCache(0)
+		if result, err := cache.Get(ctx); err != nil || result != nil {
+			t.Fatalf("Get() on empty cache = %v, %v; want nil, nil", result, err)
+		}
+		if err := cache.Set(ctx, data); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		result, err := cache.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if result == nil || len(result.Providers["openai"].Models) != 1 || result.Providers["openai"].Models[0].ID != "test-model" {
+			t.Fatalf("Get() = %+v, want the stored openai model", result)
+		}
+	})
+
+	t.Run("ReturnsIndependentCopies", func(t *testing.T) {
+		cache := NewMemoryCache(0)
+		if err := cache.Set(ctx, data); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		first, _ := cache.Get(ctx)
+		delete(first.Providers, "openai")
+
+		second, _ := cache.Get(ctx)
+		if _, ok := second.Providers["openai"]; !ok {
+			t.Fatal("mutating a Get() result changed the cached entry")
+		}
+	})
+
+	t.Run("ExpiresAfterTTL", func(t *testing.T) {
+		now := time.Unix(1_700_000_000, 0)
+		cache := NewMemoryCache(time.Minute)
+		cache.now = func() time.Time { return now }
+		if err := cache.Set(ctx, data); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+
+		now = now.Add(59 * time.Second)
+		if result, _ := cache.Get(ctx); result == nil {
+			t.Fatal("Get() before TTL = nil, want cached entry")
+		}
+		now = now.Add(time.Second)
+		if result, _ := cache.Get(ctx); result != nil {
+			t.Fatalf("Get() after TTL = %+v, want nil", result)
+		}
+	})
+}
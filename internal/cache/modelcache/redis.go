@@ -1,13 +1,9 @@
 package modelcache
 
 import (
-	"context"
-	"fmt"
 	"log/slog"
 	"time"
 
-	"github.com/goccy/go-json"
-
 	"github.com/enterpilot/gomodel/internal/cache"
 )
 
@@ -53,42 +49,5 @@ func NewRedisModelCache(cfg RedisModelCacheConfig) (Cache, error) {
 		return nil, err
 	}
 	slog.Info("redis model cache connected", "key", key, "ttl", ttl)
-	return &redisModelCache{store: store, key: key, ttl: ttl, owned: true}, nil
-}
-
-type redisModelCache struct {
-	store cache.Store
-	key   string
-	ttl   time.Duration
-	owned bool
-}
-
-func (c *redisModelCache) Get(ctx context.Context) (*ModelCache, error) {
-	data, err := c.store.Get(ctx, c.key)
-	if err != nil || data == nil {
-		return nil, err
-	}
-	var mc ModelCache
-	if err := json.Unmarshal(data, &mc); err != nil {
-		return nil, fmt.Errorf("model cache parse: %w", err)
-	}
-	return &mc, nil
-}
-
-func (c *redisModelCache) Set(ctx context.Context, mc *ModelCache) error {
-	if mc == nil {
-		return fmt.Errorf("model cache set: nil ModelCache")
-	}
-	data, err := json.Marshal(mc)
-	if err != nil {
-		return fmt.Errorf("model cache marshal: %w", err)
-	}
-	return c.store.Set(ctx, c.key, data, c.ttl)
-}
-
-func (c *redisModelCache) Close() error {
-	if c.owned {
-		return c.store.Close()
-	}
-	return nil
+	return &storeModelCache{store: store, key: key, ttl: ttl, owned: true}, nil
 }
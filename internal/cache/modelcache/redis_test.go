@@ -9,7 +9,7 @@ import (
 )
 
 // NewRedisModelCacheWithStore creates a Cache from an existing Store, letting
-// tests exercise redisModelCache without a real Redis connection.
+// tests exercise storeModelCache without a real Redis connection.
 func NewRedisModelCacheWithStore(store cache.Store, key string, ttl time.Duration) Cache {
 	if key == "" {
 		key = DefaultRedisKey
@@ -17,7 +17,7 @@ func NewRedisModelCacheWithStore(store cache.Store, key string, ttl time.Duratio
 	if ttl == 0 {
 		ttl = cache.DefaultRedisTTL
 	}
-	return &redisModelCache{store: store, key: key, ttl: ttl, owned: false}
+	return &storeModelCache{store: store, key: key, ttl: ttl, owned: false}
 }
 
 func TestRedisModelCache_GetSet(t *testing.T) {
@@ -83,9 +83,9 @@ func TestRedisModelCache_DefaultKeyAndTTL(t *testing.T) {
 	c := NewRedisModelCacheWithStore(store, "", 0)
 	defer c.Close()
 
-	rc, ok := c.(*redisModelCache)
+	rc, ok := c.(*storeModelCache)
 	if !ok {
-		t.Fatal("expected *redisModelCache from NewRedisModelCacheWithStore")
+		t.Fatal("expected *storeModelCache from NewRedisModelCacheWithStore")
 	}
 	if rc.key != DefaultRedisKey {
 		t.Errorf("key = %q, want %q", rc.key, DefaultRedisKey)
@@ -125,7 +125,7 @@ func TestRedisModelCacheWithStore_CloseDoesNotCloseSharedStore(t *testing.T) {
 
 func TestRedisModelCache_CloseClosesOwnedStore(t *testing.T) {
 	spy := &spyStore{}
-	c := &redisModelCache{store: spy, key: DefaultRedisKey, ttl: cache.DefaultRedisTTL, owned: true}
+	c := &storeModelCache{store: spy, key: DefaultRedisKey, ttl: cache.DefaultRedisTTL, owned: true}
 
 	if err := c.Close(); err != nil {
 		t.Fatalf("first Close: %v", err)
@@ -158,7 +158,7 @@ func (s *spyStore) Close() error { s.closeCalls++; return nil }
 
 func TestRedisModelCache_SetNilReturnsError(t *testing.T) {
 	spy := &spyStore{}
-	c := &redisModelCache{store: spy, key: DefaultRedisKey, ttl: cache.DefaultRedisTTL, owned: false}
+	c := &storeModelCache{store: spy, key: DefaultRedisKey, ttl: cache.DefaultRedisTTL, owned: false}
 
 	err := c.Set(context.Background(), nil)
 	if err == nil {
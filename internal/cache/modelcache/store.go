@@ -0,0 +1,51 @@
+package modelcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/cache"
+)
+
+// storeModelCache adapts a networked cache.Store (Redis, Memcached) to Cache,
+// storing the ModelCache as JSON under a single key. owned reports whether
+// Close should also close the store.
+type storeModelCache struct {
+	store cache.Store
+	key   string
+	ttl   time.Duration
+	owned bool
+}
+
+func (c *storeModelCache) Get(ctx context.Context) (*ModelCache, error) {
+	data, err := c.store.Get(ctx, c.key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var mc ModelCache
+	if err := json.Unmarshal(data, &mc); err != nil {
+		return nil, fmt.Errorf("model cache parse: %w", err)
+	}
+	return &mc, nil
+}
+
+func (c *storeModelCache) Set(ctx context.Context, mc *ModelCache) error {
+	if mc == nil {
+		return fmt.Errorf("model cache set: nil ModelCache")
+	}
+	data, err := json.Marshal(mc)
+	if err != nil {
+		return fmt.Errorf("model cache marshal: %w", err)
+	}
+	return c.store.Set(ctx, c.key, data, c.ttl)
+}
+
+func (c *storeModelCache) Close() error {
+	if c.owned {
+		return c.store.Close()
+	}
+	return nil
+}
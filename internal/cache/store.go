@@ -1,5 +1,5 @@
 // Package cache provides a generic key-value store abstraction.
-// Concrete backends (Redis, in-memory) implement the Store interface.
+// Concrete backends (Redis, Memcached, in-memory) implement the Store interface.
 // Domain-specific caches (model cache, response cache) build on top of Store.
 package cache
 
@@ -9,7 +9,8 @@ import (
 	"time"
 )
 
-// Store is a generic key-value store. RedisStore and MapStore implement it.
+// Store is a generic key-value store. RedisStore, LRUStore, and MapStore
+// implement it.
 type Store interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
@@ -0,0 +1,31 @@
+// Package concurrencytest provides helpers for tests that assert how many
+// calls a component runs at once.
+package concurrencytest
+
+import "sync/atomic"
+
+// Gauge counts calls in flight and records the highest count seen. The zero
+// value is ready to use and safe for concurrent use.
+type Gauge struct {
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+// Enter marks a call as started and returns the func that marks it finished:
+//
+//	defer gauge.Enter()()
+func (g *Gauge) Enter() func() {
+	current := g.inFlight.Add(1)
+	for {
+		peak := g.peak.Load()
+		if current <= peak || g.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	return func() { g.inFlight.Add(-1) }
+}
+
+// Peak returns the highest number of calls that were in flight at once.
+func (g *Gauge) Peak() int32 {
+	return g.peak.Load()
+}
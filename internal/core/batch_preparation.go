@@ -274,7 +274,12 @@ func wrapBatchInputFileLineError(lineNo int, err error) error {
 	}
 	if gatewayErr, ok := errors.AsType[*GatewayError](err); ok {
 		if gatewayErr.Type == ErrorTypeInvalidRequest {
-			return NewInvalidRequestError(fmt.Sprintf("batch input file line %d: %s", lineNo, gatewayErr.Message), err)
+			// Keep the status, param, and code (e.g. model_not_found) so the
+			// line prefix only adds context.
+			wrapped := *gatewayErr
+			wrapped.Message = fmt.Sprintf("batch input file line %d: %s", lineNo, gatewayErr.Message)
+			wrapped.Err = err
+			return &wrapped
 		}
 		return err
 	}
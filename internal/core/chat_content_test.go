@@ -165,6 +165,70 @@ func TestResponseMessageUnmarshalJSON_PreservesNullContentForToolCalls(t *testin
 	}
 }
 
+func TestResponseMessageJSON_PreservesAudioWithNullContent(t *testing.T) {
+	input := `{"role":"assistant","content":null,"audio":{"id":"audio_1","data":"UklGRg==","expires_at":1729234747,"transcript":"Hello","format":"wav"}}`
+	var msg ResponseMessage
+	if err := json.Unmarshal([]byte(input), &msg); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if msg.Audio == nil || msg.Audio.ID != "audio_1" || msg.Audio.Data != "UklGRg==" || msg.Audio.ExpiresAt != 1729234747 || msg.Audio.Transcript != "Hello" {
+		t.Fatalf("Audio = %#v, want decoded audio output", msg.Audio)
+	}
+	if got := string(msg.Audio.ExtraFields.Lookup("format")); got != `"wav"` {
+		t.Fatalf("Audio.ExtraFields[format] = %s, want \"wav\"", got)
+	}
+	if msg.ExtraFields.Lookup("audio") != nil {
+		t.Fatalf("audio leaked into ExtraFields: %s", msg.ExtraFields.Lookup("audio"))
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(body) != input {
+		t.Fatalf("round trip = %s, want %s", body, input)
+	}
+}
+
+func TestMessageJSON_PreservesAssistantAudioReference(t *testing.T) {
+	input := `{"role":"assistant","content":null,"audio":{"id":"audio_1"}}`
+	var msg Message
+	if err := json.Unmarshal([]byte(input), &msg); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if msg.Audio == nil || msg.Audio.ID != "audio_1" {
+		t.Fatalf("Audio = %#v, want id audio_1", msg.Audio)
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(body) != input {
+		t.Fatalf("round trip = %s, want %s", body, input)
+	}
+}
+
+func TestChatRequestRequestsAudioOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *ChatRequest
+		want bool
+	}{
+		{name: "nil", req: nil, want: false},
+		{name: "text only", req: &ChatRequest{Modalities: []string{"text"}}, want: false},
+		{name: "audio modality", req: &ChatRequest{Modalities: []string{"text", "audio"}}, want: true},
+		{name: "audio config", req: &ChatRequest{Audio: &ChatAudioConfig{Voice: "alloy"}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.RequestsAudioOutput(); got != tt.want {
+				t.Fatalf("RequestsAudioOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNormalizeMessageContent_RejectsEmptyTypedTextPart(t *testing.T) {
 	_, err := NormalizeMessageContent([]ContentPart{{Type: "text", Text: ""}})
 	if err == nil {
@@ -6,6 +6,8 @@ import "github.com/goccy/go-json"
 // the known-field list cannot drift from the type definition.
 var chatRequestFields = jsonFieldNames(ChatRequest{})
 
+var chatAudioConfigFields = jsonFieldNames(ChatAudioConfig{})
+
 // UnmarshalJSON decodes the typed fields via an alias (so new fields are
 // picked up automatically) and captures every other member in ExtraFields.
 func (r *ChatRequest) UnmarshalJSON(data []byte) error {
@@ -34,3 +36,28 @@ func (r ChatRequest) MarshalJSON() ([]byte, error) {
 	type alias ChatRequest
 	return marshalWithUnknownJSONFields(alias(r), r.ExtraFields)
 }
+
+// ChatAudioConfig.UnmarshalJSON unmarshals a ChatAudioConfig from JSON,
+// preserving provider-specific members in ExtraFields.
+func (a *ChatAudioConfig) UnmarshalJSON(data []byte) error {
+	type alias ChatAudioConfig
+	var raw alias
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	extraFields, err := extractUnknownJSONFields(data, chatAudioConfigFields...)
+	if err != nil {
+		return err
+	}
+
+	*a = ChatAudioConfig(raw)
+	a.ExtraFields = extraFields
+	return nil
+}
+
+// ChatAudioConfig.MarshalJSON marshals a ChatAudioConfig to JSON, including
+// unknown JSON members from ExtraFields.
+func (a ChatAudioConfig) MarshalJSON() ([]byte, error) {
+	type alias ChatAudioConfig
+	return marshalWithUnknownJSONFields(alias(a), a.ExtraFields)
+}
@@ -159,3 +159,38 @@ func TestChatRequestJSON_RoundTripPreservesUnknownFields(t *testing.T) {
 		t.Fatalf("x_tool_meta = %#v, want keep-me", tool["x_tool_meta"])
 	}
 }
+
+func TestChatAudioConfigJSON_RoundTripPreservesUnknownFields(t *testing.T) {
+	body := []byte(`{"model":"gpt-4o-audio-preview","messages":[{"role":"user","content":"hi"}],"audio":{"voice":"alloy","format":"wav","x_speed":1.25}}`)
+
+	var req ChatRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if req.Audio == nil {
+		t.Fatal("Audio = nil, want config")
+	}
+	if req.Audio.Voice != "alloy" || req.Audio.Format != "wav" {
+		t.Fatalf("Audio = %+v, want voice alloy and format wav", req.Audio)
+	}
+	if got := lookupUnknownField(t, req.Audio.ExtraFields, "x_speed"); string(got) != "1.25" {
+		t.Fatalf("x_speed = %s, want 1.25", got)
+	}
+	if req.Audio.ExtraFields.Lookup("voice") != nil {
+		t.Fatal("ExtraFields captured known field voice")
+	}
+
+	roundTrip, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded struct {
+		Audio map[string]any `json:"audio"`
+	}
+	if err := json.Unmarshal(roundTrip, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(roundTrip) error = %v", err)
+	}
+	if decoded.Audio["voice"] != "alloy" || decoded.Audio["format"] != "wav" || decoded.Audio["x_speed"] != 1.25 {
+		t.Fatalf("audio = %#v, want voice, format and x_speed", decoded.Audio)
+	}
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// CompletionRequest is the legacy OpenAI text completions request
+// (POST /v1/completions). The gateway serves it by translating it to a chat
+// request with a single user message, so it routes to any chat provider.
+// Sampling fields without a typed counterpart (stop, n, seed, penalties,
+// logit_bias) are kept in ExtraFields and forwarded unchanged, since chat
+// accepts them in the same shape.
+type CompletionRequest struct {
+	Temperature   *float64          `json:"temperature,omitempty"`
+	TopP          *float64          `json:"top_p,omitempty"`
+	MaxTokens     *int              `json:"max_tokens,omitempty"`
+	Model         string            `json:"model"`
+	Provider      string            `json:"provider,omitempty"` // Gateway routing hint; stripped before upstream execution.
+	Prompt        string            `json:"prompt"`
+	Suffix        string            `json:"suffix,omitempty"`
+	Echo          bool              `json:"echo,omitempty"`
+	Stream        bool              `json:"stream,omitempty"`
+	StreamOptions *StreamOptions    `json:"stream_options,omitempty"`
+	User          string            `json:"user,omitempty"`
+	ExtraFields   UnknownJSONFields `json:"-" swaggerignore:"true"`
+}
+
+// completionRequestFields is derived from the struct's json tags at package
+// init so the known-field list cannot drift from the type definition.
+var completionRequestFields = jsonFieldNames(CompletionRequest{})
+
+// UnmarshalJSON decodes the typed fields and captures every other member in
+// ExtraFields. prompt may be a string or an array holding one string; batched
+// and token-array prompts are rejected.
+func (r *CompletionRequest) UnmarshalJSON(data []byte) error {
+	type alias CompletionRequest
+	var raw struct {
+		alias
+		Prompt json.RawMessage `json:"prompt"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	extraFields, err := extractUnknownJSONFields(data, completionRequestFields...)
+	if err != nil {
+		return err
+	}
+	prompt, err := decodeCompletionPrompt(raw.Prompt)
+	if err != nil {
+		return err
+	}
+
+	*r = CompletionRequest(raw.alias)
+	r.Prompt = prompt
+	r.ExtraFields = extraFields
+	return nil
+}
+
+func decodeCompletionPrompt(raw json.RawMessage) (string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if IsJSONNull(trimmed) {
+		return "", nil
+	}
+	if trimmed[0] == '"' {
+		var prompt string
+		if err := json.Unmarshal(trimmed, &prompt); err != nil {
+			return "", err
+		}
+		return prompt, nil
+	}
+	var prompts []string
+	if err := json.Unmarshal(trimmed, &prompts); err != nil || len(prompts) != 1 {
+		return "", NewInvalidRequestError("prompt must be a string or an array with one string", nil).WithParam("prompt")
+	}
+	return prompts[0], nil
+}
+
+// ToChatRequest translates the completion request to the canonical chat
+// request. Legacy-only features without a chat equivalent are rejected rather
+// than silently dropped.
+func (r *CompletionRequest) ToChatRequest() (*ChatRequest, error) {
+	if r == nil {
+		return nil, NewInvalidRequestError("request is required", nil)
+	}
+	if strings.TrimSpace(r.Model) == "" {
+		return nil, NewInvalidRequestError("model is required", nil).WithParam("model")
+	}
+	if r.Prompt == "" {
+		return nil, NewInvalidRequestError("prompt is required", nil).WithParam("prompt")
+	}
+	if r.Suffix != "" {
+		return nil, NewInvalidRequestError("suffix is not supported on /v1/completions", nil).WithParam("suffix")
+	}
+	if raw := bytes.TrimSpace(r.ExtraFields.Lookup("logprobs")); raw != nil && !IsJSONNull(raw) {
+		return nil, NewInvalidRequestError("logprobs is not supported on /v1/completions", nil).WithParam("logprobs")
+	}
+	if raw := bytes.TrimSpace(r.ExtraFields.Lookup("best_of")); raw != nil && !IsJSONNull(raw) && string(raw) != "1" {
+		return nil, NewInvalidRequestError("best_of is not supported on /v1/completions", nil).WithParam("best_of")
+	}
+
+	return &ChatRequest{
+		Temperature:   r.Temperature,
+		TopP:          r.TopP,
+		MaxTokens:     r.MaxTokens,
+		Model:         r.Model,
+		Provider:      r.Provider,
+		Messages:      []Message{{Role: "user", Content: r.Prompt}},
+		Stream:        r.Stream,
+		StreamOptions: r.StreamOptions,
+		User:          r.User,
+		ExtraFields:   r.ExtraFields.Without("logprobs", "best_of"),
+	}, nil
+}
+
+// CompletionResponse is the legacy OpenAI text completions response.
+type CompletionResponse struct {
+	ID                string             `json:"id"`
+	Object            string             `json:"object"`
+	Created           int64              `json:"created"`
+	Model             string             `json:"model"`
+	Provider          string             `json:"provider,omitempty"`
+	SystemFingerprint string             `json:"system_fingerprint,omitempty"`
+	Choices           []CompletionChoice `json:"choices"`
+	Usage             Usage              `json:"usage"`
+}
+
+// CompletionChoice is one generated text in a CompletionResponse. Logprobs is
+// always null: the gateway does not translate chat logprobs to the legacy
+// token-offset format.
+type CompletionChoice struct {
+	Text         string          `json:"text"`
+	Index        int             `json:"index"`
+	Logprobs     json.RawMessage `json:"logprobs" swaggertype:"object"`
+	FinishReason string          `json:"finish_reason"`
+}
+
+// CompletionResponseFromChat converts a chat completion into the legacy
+// text completion shape. When echoPrompt is non-empty it is prepended to
+// every choice's text, as the legacy echo option does.
+func CompletionResponseFromChat(resp *ChatResponse, echoPrompt string) *CompletionResponse {
+	if resp == nil {
+		return nil
+	}
+	out := &CompletionResponse{
+		ID:                CompletionIDFromChat(resp.ID),
+		Object:            "text_completion",
+		Created:           resp.Created,
+		Model:             resp.Model,
+		Provider:          resp.Provider,
+		SystemFingerprint: resp.SystemFingerprint,
+		Choices:           make([]CompletionChoice, 0, len(resp.Choices)),
+		Usage:             resp.Usage,
+	}
+	for _, choice := range resp.Choices {
+		out.Choices = append(out.Choices, CompletionChoice{
+			Text:         echoPrompt + ExtractTextContent(choice.Message.Content),
+			Index:        choice.Index,
+			Logprobs:     json.RawMessage("null"),
+			FinishReason: choice.FinishReason,
+		})
+	}
+	return out
+}
+
+// CompletionIDFromChat swaps the chat completion id prefix for the legacy
+// "cmpl-" prefix clients of /v1/completions expect.
+func CompletionIDFromChat(id string) string {
+	if rest, ok := strings.CutPrefix(id, "chatcmpl-"); ok {
+		return "cmpl-" + rest
+	}
+	return id
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCompletionRequest_ToChatRequest(t *testing.T) {
+	body := []byte(`{
+		"model":"gpt-3.5-turbo-instruct",
+		"prompt":["Say hi"],
+		"max_tokens":16,
+		"temperature":0.2,
+		"stop":["\n"],
+		"seed":7,
+		"best_of":1,
+		"user":"u-1"
+	}`)
+
+	var req CompletionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if req.Prompt != "Say hi" {
+		t.Fatalf("Prompt = %q, want %q", req.Prompt, "Say hi")
+	}
+
+	chatReq, err := req.ToChatRequest()
+	if err != nil {
+		t.Fatalf("ToChatRequest: %v", err)
+	}
+	if len(chatReq.Messages) != 1 || chatReq.Messages[0].Role != "user" || chatReq.Messages[0].Content != "Say hi" {
+		t.Fatalf("Messages = %+v", chatReq.Messages)
+	}
+	if chatReq.MaxTokens == nil || *chatReq.MaxTokens != 16 {
+		t.Fatalf("MaxTokens = %v, want 16", chatReq.MaxTokens)
+	}
+	if chatReq.Temperature == nil || *chatReq.Temperature != 0.2 {
+		t.Fatalf("Temperature = %v, want 0.2", chatReq.Temperature)
+	}
+	if chatReq.User != "u-1" {
+		t.Fatalf("User = %q, want u-1", chatReq.User)
+	}
+	if got := string(lookupUnknownField(t, chatReq.ExtraFields, "stop")); got != `["\n"]` {
+		t.Fatalf("stop = %s", got)
+	}
+	if got := string(lookupUnknownField(t, chatReq.ExtraFields, "seed")); got != "7" {
+		t.Fatalf("seed = %s", got)
+	}
+	if chatReq.ExtraFields.Lookup("best_of") != nil {
+		t.Fatal("best_of should not be forwarded to chat")
+	}
+}
+
+func TestCompletionRequest_RejectsUnsupportedFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		param string
+	}{
+		{name: "missing prompt", body: `{"model":"m"}`, param: "prompt"},
+		{name: "missing model", body: `{"prompt":"hi"}`, param: "model"},
+		{name: "batched prompt", body: `{"model":"m","prompt":["a","b"]}`, param: "prompt"},
+		{name: "token prompt", body: `{"model":"m","prompt":[1,2,3]}`, param: "prompt"},
+		{name: "suffix", body: `{"model":"m","prompt":"hi","suffix":"end"}`, param: "suffix"},
+		{name: "logprobs", body: `{"model":"m","prompt":"hi","logprobs":5}`, param: "logprobs"},
+		{name: "best_of", body: `{"model":"m","prompt":"hi","best_of":3}`, param: "best_of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req CompletionRequest
+			err := json.Unmarshal([]byte(tt.body), &req)
+			if err == nil {
+				_, err = req.ToChatRequest()
+			}
+			var gatewayErr *GatewayError
+			if !errors.As(err, &gatewayErr) {
+				t.Fatalf("err = %v, want *GatewayError", err)
+			}
+			if gatewayErr.Type != ErrorTypeInvalidRequest {
+				t.Fatalf("Type = %q, want %q", gatewayErr.Type, ErrorTypeInvalidRequest)
+			}
+			if gatewayErr.Param == nil || *gatewayErr.Param != tt.param {
+				t.Fatalf("Param = %v, want %q", gatewayErr.Param, tt.param)
+			}
+		})
+	}
+}
+
+func TestCompletionResponseFromChat(t *testing.T) {
+	resp := CompletionResponseFromChat(&ChatResponse{
+		ID:      "chatcmpl-abc",
+		Created: 1700000000,
+		Model:   "gpt-4o-mini",
+		Choices: []Choice{{
+			Index:        0,
+			Message:      ResponseMessage{Role: "assistant", Content: " there"},
+			FinishReason: "length",
+		}},
+		Usage: Usage{PromptTokens: 2, CompletionTokens: 1, TotalTokens: 3},
+	}, "Hello")
+
+	if resp.ID != "cmpl-abc" || resp.Object != "text_completion" {
+		t.Fatalf("envelope = %+v", resp)
+	}
+	if len(resp.Choices) != 1 {
+		t.Fatalf("Choices = %+v", resp.Choices)
+	}
+	if resp.Choices[0].Text != "Hello there" || resp.Choices[0].FinishReason != "length" {
+		t.Fatalf("choice = %+v", resp.Choices[0])
+	}
+	if resp.Usage.TotalTokens != 3 {
+		t.Fatalf("Usage = %+v", resp.Usage)
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	choice := decoded["choices"].([]any)[0].(map[string]any)
+	if v, ok := choice["logprobs"]; !ok || v != nil {
+		t.Fatalf("logprobs = %v, want explicit null", v)
+	}
+}
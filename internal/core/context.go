@@ -1,6 +1,10 @@
 package core
 
-import "context"
+import (
+	"context"
+	"strings"
+	"sync"
+)
 
 // contextKey is a custom type for context keys to avoid collisions.
 type contextKey string
@@ -16,6 +20,11 @@ const (
 	workflowKey contextKey = "workflow"
 	// authKeyIDKey stores the internal managed auth key id for the request.
 	authKeyIDKey contextKey = "auth-key-id"
+	// authKeyAllowedModelsKey stores the models the authenticated auth key
+	// is restricted to.
+	authKeyAllowedModelsKey contextKey = "auth-key-allowed-models"
+	// masterKeyAuthKey marks a request authenticated with the master key.
+	masterKeyAuthKey contextKey = "master-key-auth"
 	// effectiveUserPathKey stores a request-scoped user path override applied
 	// after ingress capture, for example from a managed auth key.
 	effectiveUserPathKey contextKey = "effective-user-path"
@@ -35,6 +44,10 @@ const (
 	// to include usage when the provider supports it.
 	enforceReturningUsageDataKey contextKey = "enforce-returning-usage-data"
 
+	// partialOnCancelKey stores whether a non-streaming chat request keeps
+	// its partial output when cancelled mid-flight.
+	partialOnCancelKey contextKey = "partial-on-cancel"
+
 	// primaryRouteSaturatedKey stores the rate-limit rejection for the
 	// resolved primary route when failover targets exist: dispatch skips the
 	// primary provider and sweeps failover instead of returning 429 outright.
@@ -59,6 +72,22 @@ const (
 	// request rewriters estimate they removed from the request body. Usage
 	// recording folds it into the request's usage entry as rewrite savings.
 	rewriteTokensSavedKey contextKey = "rewrite-tokens-saved"
+
+	// providerOverrideKey stores a trusted caller's forced provider for the
+	// request. Model resolution uses it in place of any provider hint.
+	providerOverrideKey contextKey = "provider-override"
+
+	// upstreamRequestIDKey stores the recorder that captures the request ID a
+	// provider assigned to the upstream call serving the request.
+	upstreamRequestIDKey contextKey = "upstream-request-id"
+
+	// rawResponseKey stores the recorder that captures the provider-native
+	// response body for callers that asked for it unmodified.
+	rawResponseKey contextKey = "raw-response"
+
+	// samplingAdjustmentsKey stores the recorder that captures sampling
+	// parameters clamped by the configured sampling policy.
+	samplingAdjustmentsKey contextKey = "sampling-adjustments"
 )
 
 // RequestOrigin identifies whether a request came from an external caller or an
@@ -146,6 +175,42 @@ func GetAuthKeyID(ctx context.Context) string {
 	return ""
 }
 
+// WithAuthKeyAllowedModels returns a new context restricting the request to
+// models, as declared on the authenticated auth key. An empty list allows
+// every model.
+func WithAuthKeyAllowedModels(ctx context.Context, models []string) context.Context {
+	return context.WithValue(ctx, authKeyAllowedModelsKey, models)
+}
+
+// AuthKeyAllowsModel reports whether the authenticated auth key may use
+// selector. Entries match the bare model id or the provider-qualified one.
+func AuthKeyAllowsModel(ctx context.Context, selector ModelSelector) bool {
+	models, _ := ctx.Value(authKeyAllowedModelsKey).([]string)
+	if len(models) == 0 {
+		return true
+	}
+	qualified := selector.QualifiedModel()
+	for _, model := range models {
+		if strings.EqualFold(model, selector.Model) || strings.EqualFold(model, qualified) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithMasterKeyAuth returns a new context marking the request as
+// authenticated with the master key.
+func WithMasterKeyAuth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, masterKeyAuthKey, true)
+}
+
+// IsMasterKeyAuth reports whether the request was authenticated with the
+// master key. It is false when authentication is disabled.
+func IsMasterKeyAuth(ctx context.Context) bool {
+	authenticated, _ := ctx.Value(masterKeyAuthKey).(bool)
+	return authenticated
+}
+
 // WithEffectiveUserPath returns a new context with an effective user path override attached.
 func WithEffectiveUserPath(ctx context.Context, userPath string) context.Context {
 	return context.WithValue(ctx, effectiveUserPathKey, userPath)
@@ -203,6 +268,23 @@ func GetEnforceReturningUsageData(ctx context.Context) bool {
 	return false
 }
 
+// WithPartialOnCancel returns a new context that asks non-streaming chat
+// execution to stream from the provider and keep partial output on cancellation.
+func WithPartialOnCancel(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, partialOnCancelKey, enabled)
+}
+
+// GetPartialOnCancel reports whether a cancelled non-streaming chat request
+// should return the content generated so far instead of an error.
+func GetPartialOnCancel(ctx context.Context) bool {
+	if v := ctx.Value(partialOnCancelKey); v != nil {
+		if enabled, ok := v.(bool); ok {
+			return enabled
+		}
+	}
+	return false
+}
+
 // WithPrimaryRouteSaturated marks the resolved primary route as rate-saturated.
 // The stored error is the 429 the client would have received; dispatch uses it
 // as the synthetic primary failure that triggers the failover sweep, and it
@@ -294,3 +376,108 @@ func GetRequestOrigin(ctx context.Context) RequestOrigin {
 	}
 	return RequestOriginExternal
 }
+
+// WithProviderOverride returns a new context that forces model resolution to
+// the named provider. An empty provider leaves the context unchanged.
+func WithProviderOverride(ctx context.Context, provider string) context.Context {
+	if provider == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, providerOverrideKey, provider)
+}
+
+// GetProviderOverride retrieves the forced provider from context, or "" when
+// routing is not overridden.
+func GetProviderOverride(ctx context.Context) string {
+	if v := ctx.Value(providerOverrideKey); v != nil {
+		if provider, ok := v.(string); ok {
+			return provider
+		}
+	}
+	return ""
+}
+
+// UpstreamRequestIDRecorder captures the request ID a provider assigned to the
+// latest upstream call made on behalf of a gateway request. The gateway
+// installs one per request; the provider HTTP client fills it in. It is safe
+// for concurrent use.
+type UpstreamRequestIDRecorder struct {
+	mu sync.Mutex
+	id string
+}
+
+// ID returns the recorded upstream request ID, or "" when none was seen.
+func (r *UpstreamRequestIDRecorder) ID() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.id
+}
+
+// WithUpstreamRequestIDRecorder returns a context carrying a fresh recorder.
+func WithUpstreamRequestIDRecorder(ctx context.Context) (context.Context, *UpstreamRequestIDRecorder) {
+	recorder := &UpstreamRequestIDRecorder{}
+	return context.WithValue(ctx, upstreamRequestIDKey, recorder), recorder
+}
+
+// RecordUpstreamRequestID stores id on the context's recorder. Later calls
+// overwrite earlier ones so retries and failover report the attempt that
+// produced the response. It is a no-op without a recorder or for empty IDs.
+func RecordUpstreamRequestID(ctx context.Context, id string) {
+	if id == "" {
+		return
+	}
+	if recorder, ok := ctx.Value(upstreamRequestIDKey).(*UpstreamRequestIDRecorder); ok && recorder != nil {
+		recorder.mu.Lock()
+		recorder.id = id
+		recorder.mu.Unlock()
+	}
+}
+
+// GetUpstreamRequestID returns the upstream request ID recorded on ctx, or ""
+// when none was captured.
+func GetUpstreamRequestID(ctx context.Context) string {
+	recorder, _ := ctx.Value(upstreamRequestIDKey).(*UpstreamRequestIDRecorder)
+	return recorder.ID()
+}
+
+// RawResponseRecorder captures the provider-native body of the latest
+// successful upstream call made on behalf of a gateway request. The gateway
+// installs one only when the caller asked for the raw response; the provider
+// HTTP client fills it in. It is safe for concurrent use.
+type RawResponseRecorder struct {
+	mu          sync.Mutex
+	body        []byte
+	contentType string
+}
+
+// Response returns the recorded body and content type, or a nil body when no
+// successful upstream response was seen.
+func (r *RawResponseRecorder) Response() ([]byte, string) {
+	if r == nil {
+		return nil, ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body, r.contentType
+}
+
+// WithRawResponseRecorder returns a context carrying a fresh recorder.
+func WithRawResponseRecorder(ctx context.Context) (context.Context, *RawResponseRecorder) {
+	recorder := &RawResponseRecorder{}
+	return context.WithValue(ctx, rawResponseKey, recorder), recorder
+}
+
+// RecordRawResponse stores body on the context's recorder. Later calls
+// overwrite earlier ones so failover reports the attempt that produced the
+// response. It is a no-op without a recorder.
+func RecordRawResponse(ctx context.Context, contentType string, body []byte) {
+	if recorder, ok := ctx.Value(rawResponseKey).(*RawResponseRecorder); ok && recorder != nil {
+		recorder.mu.Lock()
+		recorder.body = body
+		recorder.contentType = contentType
+		recorder.mu.Unlock()
+	}
+}
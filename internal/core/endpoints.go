@@ -111,6 +111,15 @@ func describeEndpointPath(path string) EndpointDescriptor {
 			Dialect:          "anthropic",
 			Operation:        OperationChatCompletions,
 		}
+	case path == "/v1/completions":
+		// Legacy OpenAI text completions. The prompt is translated to a chat
+		// request at ingress and runs through the chat-completions pipeline.
+		return EndpointDescriptor{
+			ModelInteraction: true,
+			IngressManaged:   true,
+			Dialect:          "openai_compat",
+			Operation:        OperationChatCompletions,
+		}
 	case path == "/v1/batches" || strings.HasPrefix(path, "/v1/batches/"):
 		return EndpointDescriptor{
 			ModelInteraction: true,
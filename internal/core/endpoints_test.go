@@ -16,6 +16,7 @@ func TestDescribeEndpointPath(t *testing.T) {
 	}{
 		{path: "/v1/chat/completions", managed: true, dialect: "openai_compat", operation: OperationChatCompletions, bodyMode: BodyModeJSON, interaction: true},
 		{path: "/v1/chat/completions/", managed: true, dialect: "openai_compat", operation: OperationChatCompletions, bodyMode: BodyModeJSON, interaction: true},
+		{path: "/v1/completions", managed: true, dialect: "openai_compat", operation: OperationChatCompletions, bodyMode: BodyModeJSON, interaction: true},
 		{path: "/v1/responses/resp_1", managed: true, dialect: "openai_compat", operation: OperationResponses, bodyMode: BodyModeNone, interaction: true},
 		{path: "/v1/responses/resp_1/input_items", managed: true, dialect: "openai_compat", operation: OperationResponses, bodyMode: BodyModeNone, interaction: true},
 		{path: "/v1/conversations", managed: true, dialect: "openai_compat", operation: OperationConversations, bodyMode: BodyModeNone, interaction: true},
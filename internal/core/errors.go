@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -40,6 +42,9 @@ type GatewayError struct {
 	// failed provider attempts can be audited. Never serialized to API clients.
 	ResponseBody    []byte      `json:"-"`
 	ResponseHeaders http.Header `json:"-"`
+	// RetryAfter is the upstream's back-off hint on a rate-limited request,
+	// relayed to clients as a Retry-After header. Zero means no hint.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // maxGatewayErrorBodyBytes caps the raw upstream error body retained for audit.
@@ -201,11 +206,28 @@ func NewNotFoundError(message string) *GatewayError {
 	}
 }
 
-// NewModelNotFoundError reports a model the gateway cannot route. It mirrors
-// OpenAI's contract for unknown models — HTTP 404 with code "model_not_found" —
-// so clients that key on the status or code behave the same as against OpenAI.
+var modelNotFoundStatus atomic.Int64
+
+// SetModelNotFoundStatus installs the process-wide HTTP status for unknown
+// models, http.StatusBadRequest or http.StatusNotFound. App startup calls it
+// once; any other value restores the 400 default.
+func SetModelNotFoundStatus(status int) {
+	if status != http.StatusNotFound {
+		status = http.StatusBadRequest
+	}
+	modelNotFoundStatus.Store(int64(status))
+}
+
+// NewModelNotFoundError reports a model the gateway cannot route as an
+// invalid_request_error with code "model_not_found" in the OpenAI error
+// envelope. The status is 400 by default; deployments whose SDKs key on 404
+// switch it with SetModelNotFoundStatus.
 func NewModelNotFoundError(model string) *GatewayError {
-	return NewNotFoundError("unsupported model: " + model).WithCode("model_not_found")
+	status := http.StatusBadRequest
+	if modelNotFoundStatus.Load() == http.StatusNotFound {
+		status = http.StatusNotFound
+	}
+	return NewInvalidRequestErrorWithStatus(status, "unsupported model: "+model, nil).WithCode("model_not_found")
 }
 
 // ParseProviderError parses an error response from a provider and returns an appropriate GatewayError
@@ -215,6 +237,10 @@ func ParseProviderError(provider string, statusCode int, body []byte, originalEr
 	if errorResponse.Message != "" {
 		message = errorResponse.Message
 	}
+	if errorResponse.Status != "" {
+		statusCode = googleRPCStatusHTTPCode(statusCode, errorResponse)
+		errorResponse.Code = errorResponse.Status
+	}
 
 	// Determine error type based on status code
 	var gatewayErr *GatewayError
@@ -275,6 +301,11 @@ type providerErrorDetails struct {
 	Message string
 	Param   string
 	Code    string
+	// Status and Reason carry Google's canonical RPC status (e.g.
+	// "RESOURCE_EXHAUSTED") and the first details[].reason, as returned by
+	// Gemini and Vertex AI in {"error":{"code","message","status"}} bodies.
+	Status string
+	Reason string
 }
 
 func parseProviderErrorBody(body []byte) providerErrorDetails {
@@ -299,6 +330,10 @@ func parseProviderErrorBody(body []byte) providerErrorDetails {
 		Param:   jsonString(errorFields["param"]),
 		Code:    jsonScalarString(errorFields["code"]),
 	}
+	if status := jsonString(errorFields["status"]); googleRPCStatuses[status] {
+		details.Status = status
+		details.Reason = googleErrorReason(errorFields["details"])
+	}
 
 	if raw := providerErrorMetadataRaw(errorFields["metadata"]); shouldPreferProviderRaw(details.Message, raw) {
 		details.Message = raw
@@ -307,6 +342,64 @@ func parseProviderErrorBody(body []byte) providerErrorDetails {
 	return details
 }
 
+// googleRPCStatuses lists the canonical google.rpc.Code names. Only these are
+// trusted as a status hint so unrelated "status" fields are ignored.
+var googleRPCStatuses = map[string]bool{
+	"CANCELLED":           true,
+	"UNKNOWN":             true,
+	"INVALID_ARGUMENT":    true,
+	"DEADLINE_EXCEEDED":   true,
+	"NOT_FOUND":           true,
+	"ALREADY_EXISTS":      true,
+	"PERMISSION_DENIED":   true,
+	"RESOURCE_EXHAUSTED":  true,
+	"FAILED_PRECONDITION": true,
+	"ABORTED":             true,
+	"OUT_OF_RANGE":        true,
+	"UNIMPLEMENTED":       true,
+	"INTERNAL":            true,
+	"UNAVAILABLE":         true,
+	"DATA_LOSS":           true,
+	"UNAUTHENTICATED":     true,
+}
+
+// googleRPCStatusHTTPCode returns the HTTP status that best classifies a
+// Google API error. Google sometimes reports quota and credential failures
+// under a generic 400, so the RPC status and reason take precedence for those;
+// everything else keeps the upstream status code.
+func googleRPCStatusHTTPCode(statusCode int, details providerErrorDetails) int {
+	switch details.Status {
+	case "RESOURCE_EXHAUSTED":
+		return http.StatusTooManyRequests
+	case "UNAUTHENTICATED":
+		return http.StatusUnauthorized
+	case "PERMISSION_DENIED":
+		return http.StatusForbidden
+	case "NOT_FOUND":
+		return http.StatusNotFound
+	case "INVALID_ARGUMENT":
+		if details.Reason == "API_KEY_INVALID" {
+			return http.StatusUnauthorized
+		}
+	}
+	return statusCode
+}
+
+func googleErrorReason(raw json.RawMessage) string {
+	var details []struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(raw, &details); err != nil {
+		return ""
+	}
+	for _, detail := range details {
+		if detail.Reason != "" {
+			return detail.Reason
+		}
+	}
+	return ""
+}
+
 func providerErrorMetadataRaw(raw json.RawMessage) string {
 	var metadata map[string]json.RawMessage
 	if err := json.Unmarshal(raw, &metadata); err != nil {
@@ -120,6 +120,43 @@ func TestGatewayError_HTTPStatusCode(t *testing.T) {
 	}
 }
 
+func TestNewModelNotFoundError_ConfiguredStatus(t *testing.T) {
+	t.Cleanup(func() { SetModelNotFoundStatus(http.StatusBadRequest) })
+
+	tests := []struct {
+		name       string
+		configured int
+		wantStatus int
+		wantType   ErrorType
+	}{
+		{name: "default", configured: 0, wantStatus: http.StatusBadRequest, wantType: ErrorTypeInvalidRequest},
+		{name: "400", configured: http.StatusBadRequest, wantStatus: http.StatusBadRequest, wantType: ErrorTypeInvalidRequest},
+		{name: "404", configured: http.StatusNotFound, wantStatus: http.StatusNotFound, wantType: ErrorTypeInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetModelNotFoundStatus(tt.configured)
+			err := NewModelNotFoundError("gpt-missing")
+			if got := err.HTTPStatusCode(); got != tt.wantStatus {
+				t.Fatalf("HTTPStatusCode() = %d, want %d", got, tt.wantStatus)
+			}
+			body, ok := err.ToJSON()["error"].(map[string]any)
+			if !ok {
+				t.Fatalf("ToJSON() = %#v, want error envelope", err.ToJSON())
+			}
+			if body["type"] != tt.wantType {
+				t.Fatalf("type = %v, want %s", body["type"], tt.wantType)
+			}
+			if body["code"] != "model_not_found" {
+				t.Fatalf("code = %v, want model_not_found", body["code"])
+			}
+			if body["message"] != "unsupported model: gpt-missing" {
+				t.Fatalf("message = %v, want unsupported model: gpt-missing", body["message"])
+			}
+		})
+	}
+}
+
 func TestGatewayError_ToJSON(t *testing.T) {
 	param := "model"
 	code := "model_not_found"
@@ -453,6 +490,113 @@ func TestParseProviderError_OpenRouter_TableDriven(t *testing.T) {
 	}
 }
 
+func TestParseProviderError_Gemini_TableDriven(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        []byte
+		wantType    ErrorType
+		wantStatus  int
+		wantMessage string
+		wantCode    string
+	}{
+		{
+			name:        "invalid argument",
+			statusCode:  http.StatusBadRequest,
+			body:        []byte(`{"error":{"code":400,"message":"* GenerateContentRequest.contents: contents is not specified","status":"INVALID_ARGUMENT"}}`),
+			wantType:    ErrorTypeInvalidRequest,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "* GenerateContentRequest.contents: contents is not specified",
+			wantCode:    "INVALID_ARGUMENT",
+		},
+		{
+			name:        "invalid api key reported as 400",
+			statusCode:  http.StatusBadRequest,
+			body:        []byte(`{"error":{"code":400,"message":"API key not valid. Please pass a valid API key.","status":"INVALID_ARGUMENT","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"API_KEY_INVALID","domain":"googleapis.com"}]}}`),
+			wantType:    ErrorTypeAuthentication,
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "API key not valid. Please pass a valid API key.",
+			wantCode:    "INVALID_ARGUMENT",
+		},
+		{
+			name:        "resource exhausted",
+			statusCode:  http.StatusTooManyRequests,
+			body:        []byte(`{"error":{"code":429,"message":"You exceeded your current quota, please check your plan and billing details.","status":"RESOURCE_EXHAUSTED"}}`),
+			wantType:    ErrorTypeRateLimit,
+			wantStatus:  http.StatusTooManyRequests,
+			wantMessage: "You exceeded your current quota, please check your plan and billing details.",
+			wantCode:    "RESOURCE_EXHAUSTED",
+		},
+		{
+			name:        "resource exhausted under generic 400",
+			statusCode:  http.StatusBadRequest,
+			body:        []byte(`{"error":{"code":400,"message":"Quota exceeded for quota metric 'Generate Content API requests per minute'","status":"RESOURCE_EXHAUSTED"}}`),
+			wantType:    ErrorTypeRateLimit,
+			wantStatus:  http.StatusTooManyRequests,
+			wantMessage: "Quota exceeded for quota metric 'Generate Content API requests per minute'",
+			wantCode:    "RESOURCE_EXHAUSTED",
+		},
+		{
+			name:        "permission denied",
+			statusCode:  http.StatusForbidden,
+			body:        []byte(`{"error":{"code":403,"message":"Method doesn't allow unregistered callers.","status":"PERMISSION_DENIED"}}`),
+			wantType:    ErrorTypeAuthentication,
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "Method doesn't allow unregistered callers.",
+			wantCode:    "PERMISSION_DENIED",
+		},
+		{
+			name:        "failed precondition keeps status",
+			statusCode:  http.StatusBadRequest,
+			body:        []byte(`{"error":{"code":400,"message":"User location is not supported for the API use.","status":"FAILED_PRECONDITION"}}`),
+			wantType:    ErrorTypeInvalidRequest,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "User location is not supported for the API use.",
+			wantCode:    "FAILED_PRECONDITION",
+		},
+		{
+			name:        "unavailable",
+			statusCode:  http.StatusServiceUnavailable,
+			body:        []byte(`{"error":{"code":503,"message":"The model is overloaded. Please try again later.","status":"UNAVAILABLE"}}`),
+			wantType:    ErrorTypeProvider,
+			wantStatus:  http.StatusServiceUnavailable,
+			wantMessage: "The model is overloaded. Please try again later.",
+			wantCode:    "UNAVAILABLE",
+		},
+		{
+			name:        "non-canonical status is ignored",
+			statusCode:  http.StatusBadRequest,
+			body:        []byte(`{"error":{"code":400,"message":"bad","status":"error"}}`),
+			wantType:    ErrorTypeInvalidRequest,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "bad",
+			wantCode:    "400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseProviderError("gemini", tt.statusCode, tt.body, nil)
+
+			if err.Type != tt.wantType {
+				t.Fatalf("Type = %v, want %v", err.Type, tt.wantType)
+			}
+			if err.HTTPStatusCode() != tt.wantStatus {
+				t.Fatalf("HTTPStatusCode() = %d, want %d", err.HTTPStatusCode(), tt.wantStatus)
+			}
+			if err.Message != tt.wantMessage {
+				t.Fatalf("Message = %q, want %q", err.Message, tt.wantMessage)
+			}
+			if err.Code == nil || *err.Code != tt.wantCode {
+				t.Fatalf("Code = %v, want %q", err.Code, tt.wantCode)
+			}
+			if err.Provider != "gemini" {
+				t.Fatalf("Provider = %q, want gemini", err.Provider)
+			}
+		})
+	}
+}
+
 func equalStringPointers(a, b *string) bool {
 	switch {
 	case a == nil && b == nil:
@@ -192,6 +192,24 @@ type ProviderNameTypeResolver interface {
 	GetProviderTypeForName(providerName string) string
 }
 
+// CircuitResetter is an optional interface for providers whose upstream
+// clients use a circuit breaker. Operators call it to force traffic back to a
+// provider they know has recovered.
+type CircuitResetter interface {
+	// ResetCircuit force-closes the provider's circuit breakers and returns the
+	// resulting state, or "" when the provider has no circuit breaker enabled.
+	ResetCircuit() string
+}
+
+// CircuitStateReporter is an optional interface for providers whose upstream
+// clients use a circuit breaker, so health reporting can show tripped
+// providers without waiting for request traffic.
+type CircuitStateReporter interface {
+	// CircuitState returns "closed", "open" or "half-open", or "" when the
+	// provider has no circuit breaker enabled.
+	CircuitState() string
+}
+
 // AvailabilityChecker is an optional interface for providers that can report
 // backend reachability during startup diagnostics.
 type AvailabilityChecker interface {
@@ -246,6 +246,32 @@ func (fields UnknownJSONFields) Lookup(key string) json.RawMessage {
 	return nil
 }
 
+// Without returns a copy of fields with the named members removed. Provider
+// adapters use it when they move a passthrough field into a different shape.
+func (fields UnknownJSONFields) Without(keys ...string) UnknownJSONFields {
+	if len(fields.raw) == 0 || len(keys) == 0 {
+		return fields
+	}
+	parsed := gjson.ParseBytes(fields.raw)
+	if !parsed.IsObject() {
+		return fields
+	}
+	kept := make(map[string]json.RawMessage)
+	removed := false
+	parsed.ForEach(func(key, value gjson.Result) bool {
+		if slices.Contains(keys, key.String()) {
+			removed = true
+			return true
+		}
+		kept[key.String()] = json.RawMessage(value.Raw)
+		return true
+	})
+	if !removed {
+		return fields
+	}
+	return UnknownJSONFieldsFromMap(kept)
+}
+
 // IsEmpty reports whether the container has no stored fields.
 func (fields UnknownJSONFields) IsEmpty() bool {
 	trimmed := bytes.TrimSpace(fields.raw)
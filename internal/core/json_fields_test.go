@@ -192,6 +192,24 @@ func TestMergeUnknownJSONFields_NoAdditionsReturnsBase(t *testing.T) {
 	}
 }
 
+func TestUnknownJSONFieldsWithout_RemovesNamedMembers(t *testing.T) {
+	base := UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+		"a": json.RawMessage(`1`),
+		"b": json.RawMessage(`{"nested":true}`),
+	})
+
+	trimmed := base.Without("a", "missing")
+	if trimmed.Lookup("a") != nil {
+		t.Fatalf("a = %q, want removed", trimmed.Lookup("a"))
+	}
+	if !bytes.Equal(trimmed.Lookup("b"), []byte(`{"nested":true}`)) {
+		t.Fatalf("b = %q, want preserved", trimmed.Lookup("b"))
+	}
+	if base.Lookup("a") == nil {
+		t.Fatal("Without must not modify the receiver")
+	}
+}
+
 // extractUnknownJSONFields assumes its input is already valid JSON: every
 // production caller is an UnmarshalJSON method that runs json.Unmarshal on the
 // same bytes first. This test pins the meaningful guarantee at that boundary —
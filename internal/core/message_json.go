@@ -14,6 +14,7 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 		Content    json.RawMessage `json:"content"`
 		ToolCalls  []ToolCall      `json:"tool_calls,omitempty"`
 		ToolCallID string          `json:"tool_call_id,omitempty"`
+		Audio      *MessageAudio   `json:"audio,omitempty"`
 	}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
@@ -23,6 +24,7 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 		"content",
 		"tool_calls",
 		"tool_call_id",
+		"audio",
 	)
 	if err != nil {
 		return err
@@ -37,6 +39,7 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 	m.Content = content
 	m.ToolCalls = raw.ToolCalls
 	m.ToolCallID = raw.ToolCallID
+	m.Audio = raw.Audio
 	m.ContentNull = content == nil
 	m.ExtraFields = extraFields
 	return nil
@@ -51,33 +54,36 @@ func (m Message) MarshalJSON() ([]byte, error) {
 	case m.ContentNull && isNullEquivalentContent(m.Content):
 		content = nil
 	default:
-		content, err = marshalMessageContent(m.Content, m.ToolCalls)
+		content, err = marshalMessageContent(m.Content, len(m.ToolCalls) > 0 || m.Audio != nil)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	return marshalWithUnknownJSONFields(struct {
-		Role       string     `json:"role"`
-		Content    any        `json:"content"`
-		ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
-		ToolCallID string     `json:"tool_call_id,omitempty"`
+		Role       string        `json:"role"`
+		Content    any           `json:"content"`
+		ToolCalls  []ToolCall    `json:"tool_calls,omitempty"`
+		ToolCallID string        `json:"tool_call_id,omitempty"`
+		Audio      *MessageAudio `json:"audio,omitempty"`
 	}{
 		Role:       m.Role,
 		Content:    content,
 		ToolCalls:  m.ToolCalls,
 		ToolCallID: m.ToolCallID,
+		Audio:      m.Audio,
 	}, m.ExtraFields)
 }
 
 // ResponseMessage.UnmarshalJSON validates chat response message content,
-// preserves unknown JSON members in ExtraFields, and keeps tool-call null
-// content handling intact.
+// preserves unknown JSON members in ExtraFields, and keeps tool-call and audio
+// null content handling intact.
 func (m *ResponseMessage) UnmarshalJSON(data []byte) error {
 	var raw struct {
 		Role      string          `json:"role"`
 		Content   json.RawMessage `json:"content"`
 		ToolCalls []ToolCall      `json:"tool_calls,omitempty"`
+		Audio     *ResponseAudio  `json:"audio,omitempty"`
 	}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
@@ -86,6 +92,7 @@ func (m *ResponseMessage) UnmarshalJSON(data []byte) error {
 		"role",
 		"content",
 		"tool_calls",
+		"audio",
 	)
 	if err != nil {
 		return err
@@ -99,27 +106,30 @@ func (m *ResponseMessage) UnmarshalJSON(data []byte) error {
 	m.Role = raw.Role
 	m.Content = content
 	m.ToolCalls = raw.ToolCalls
+	m.Audio = raw.Audio
 	m.ExtraFields = extraFields
 	return nil
 }
 
 // ResponseMessage.MarshalJSON preserves OpenAI-compatible null content for
-// tool-call response messages and includes unknown JSON members from
+// tool-call and audio response messages and includes unknown JSON members from
 // ExtraFields.
 func (m ResponseMessage) MarshalJSON() ([]byte, error) {
-	content, err := marshalMessageContent(m.Content, m.ToolCalls)
+	content, err := marshalMessageContent(m.Content, len(m.ToolCalls) > 0 || m.Audio != nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return marshalWithUnknownJSONFields(struct {
-		Role      string     `json:"role"`
-		Content   any        `json:"content"`
-		ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+		Role      string         `json:"role"`
+		Content   any            `json:"content"`
+		ToolCalls []ToolCall     `json:"tool_calls,omitempty"`
+		Audio     *ResponseAudio `json:"audio,omitempty"`
 	}{
 		Role:      m.Role,
 		Content:   content,
 		ToolCalls: m.ToolCalls,
+		Audio:     m.Audio,
 	}, m.ExtraFields)
 }
 
@@ -192,14 +202,45 @@ func (f FunctionCall) MarshalJSON() ([]byte, error) {
 	return marshalWithUnknownJSONFields(alias(f), f.ExtraFields)
 }
 
-func marshalMessageContent(raw MessageContent, toolCalls []ToolCall) (any, error) {
+// ResponseAudio.UnmarshalJSON unmarshals ResponseAudio from JSON, preserving
+// provider-specific members (such as format) in ExtraFields.
+func (a *ResponseAudio) UnmarshalJSON(data []byte) error {
+	type alias ResponseAudio
+	var raw alias
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	extraFields, err := extractUnknownJSONFields(data,
+		"id",
+		"data",
+		"expires_at",
+		"transcript",
+	)
+	if err != nil {
+		return err
+	}
+
+	*a = ResponseAudio(raw)
+	a.ExtraFields = extraFields
+	return nil
+}
+
+// ResponseAudio.MarshalJSON marshals ResponseAudio to JSON, including unknown
+// JSON members from ExtraFields.
+func (a ResponseAudio) MarshalJSON() ([]byte, error) {
+	type alias ResponseAudio
+	return marshalWithUnknownJSONFields(alias(a), a.ExtraFields)
+}
+
+func marshalMessageContent(raw MessageContent, nullable bool) (any, error) {
 	var (
 		content any
 		err     error
 	)
 
-	// OpenAI-compatible tool-call assistant messages use `content: null`.
-	if len(toolCalls) > 0 && isNullEquivalentContent(raw) {
+	// OpenAI-compatible tool-call and audio assistant messages use
+	// `content: null`.
+	if nullable && isNullEquivalentContent(raw) {
 		content = nil
 	} else {
 		content, err = NormalizeMessageContent(raw)
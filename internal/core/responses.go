@@ -151,6 +151,22 @@ func (r *ResponsesRequest) WithStreaming() *ResponsesRequest {
 	return &cp
 }
 
+// WithInstructionsPrefix returns a shallow copy of the request whose
+// instructions start with prefix on its own line, the Responses counterpart
+// of ChatRequest.WithSystemPromptPrefix.
+func (r *ResponsesRequest) WithInstructionsPrefix(prefix string) *ResponsesRequest {
+	if prefix == "" {
+		return r
+	}
+	cp := *r
+	if r.Instructions == "" {
+		cp.Instructions = prefix
+	} else {
+		cp.Instructions = prefix + "\n" + r.Instructions
+	}
+	return &cp
+}
+
 // ResponsesInputElement represents a single item in the Responses API input array.
 // It is a discriminated union keyed on Type:
 //   - "" or "message": a chat-style message with Role and Content
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// SamplingRange bounds one sampling parameter. A nil bound leaves that side
+// open; equal bounds pin the parameter to a single value.
+type SamplingRange struct {
+	Min *float64
+	Max *float64
+}
+
+// SamplingPolicy is the org-wide temperature and top_p policy applied to chat
+// and Responses requests before they are converted for a provider.
+type SamplingPolicy struct {
+	Temperature SamplingRange
+	TopP        SamplingRange
+}
+
+// Enabled reports whether the policy bounds any parameter.
+func (p SamplingPolicy) Enabled() bool {
+	return p.Temperature.bounded() || p.TopP.bounded()
+}
+
+// Apply clamps *temperature and *topP into the policy's ranges. Adjusted
+// values are stored in fresh pointers, so values shared with the caller's
+// request are never mutated. It returns one "name=value" entry per adjusted
+// parameter.
+func (p SamplingPolicy) Apply(temperature, topP **float64) []string {
+	var adjusted []string
+	if value, changed := p.Temperature.clamp(*temperature); changed {
+		*temperature = value
+		adjusted = append(adjusted, "temperature="+strconv.FormatFloat(*value, 'f', -1, 64))
+	}
+	if value, changed := p.TopP.clamp(*topP); changed {
+		*topP = value
+		adjusted = append(adjusted, "top_p="+strconv.FormatFloat(*value, 'f', -1, 64))
+	}
+	return adjusted
+}
+
+func (r SamplingRange) bounded() bool {
+	return r.Min != nil || r.Max != nil
+}
+
+func (r SamplingRange) clamp(value *float64) (*float64, bool) {
+	if value == nil {
+		// A pinned parameter is forced even when the client left it unset.
+		if r.Min != nil && r.Max != nil && *r.Min == *r.Max {
+			pinned := *r.Min
+			return &pinned, true
+		}
+		return nil, false
+	}
+	clamped := *value
+	if r.Min != nil && clamped < *r.Min {
+		clamped = *r.Min
+	}
+	if r.Max != nil && clamped > *r.Max {
+		clamped = *r.Max
+	}
+	if clamped == *value {
+		return value, false
+	}
+	return &clamped, true
+}
+
+// SamplingAdjustmentRecorder collects the sampling adjustments made while
+// serving a gateway request so the HTTP layer can report them in a response
+// header. It is safe for concurrent use.
+type SamplingAdjustmentRecorder struct {
+	mu       sync.Mutex
+	adjusted []string
+}
+
+// Adjustments returns the adjustments recorded by the latest provider attempt.
+func (r *SamplingAdjustmentRecorder) Adjustments() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.adjusted
+}
+
+// WithSamplingAdjustmentRecorder returns a context carrying a fresh recorder.
+func WithSamplingAdjustmentRecorder(ctx context.Context) (context.Context, *SamplingAdjustmentRecorder) {
+	recorder := &SamplingAdjustmentRecorder{}
+	return context.WithValue(ctx, samplingAdjustmentsKey, recorder), recorder
+}
+
+// RecordSamplingAdjustments stores adjusted on the context's recorder. Later
+// calls overwrite earlier ones so failover reports the attempt that served the
+// request. It is a no-op without a recorder or for empty adjustments.
+func RecordSamplingAdjustments(ctx context.Context, adjusted []string) {
+	if len(adjusted) == 0 || ctx == nil {
+		return
+	}
+	if recorder, ok := ctx.Value(samplingAdjustmentsKey).(*SamplingAdjustmentRecorder); ok && recorder != nil {
+		recorder.mu.Lock()
+		recorder.adjusted = adjusted
+		recorder.mu.Unlock()
+	}
+}
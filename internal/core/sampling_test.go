@@ -0,0 +1,89 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSamplingPolicyApply(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+	policy := SamplingPolicy{
+		Temperature: SamplingRange{Max: float(0.7)},
+		TopP:        SamplingRange{Min: float(0.5), Max: float(0.5)},
+	}
+
+	tests := []struct {
+		name            string
+		temperature     *float64
+		topP            *float64
+		wantTemperature *float64
+		wantTopP        *float64
+		wantAdjusted    []string
+	}{
+		{
+			name:            "above max is clamped",
+			temperature:     float(1.2),
+			topP:            float(0.5),
+			wantTemperature: float(0.7),
+			wantTopP:        float(0.5),
+			wantAdjusted:    []string{"temperature=0.7"},
+		},
+		{
+			name:            "in range is untouched",
+			temperature:     float(0.2),
+			topP:            float(0.5),
+			wantTemperature: float(0.2),
+			wantTopP:        float(0.5),
+		},
+		{
+			name:         "pinned parameter is forced when omitted",
+			wantTopP:     float(0.5),
+			wantAdjusted: []string{"top_p=0.5"},
+		},
+		{
+			name:            "below pinned value is raised",
+			temperature:     float(0),
+			topP:            float(0.1),
+			wantTemperature: float(0),
+			wantTopP:        float(0.5),
+			wantAdjusted:    []string{"top_p=0.5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			temperature, topP := tt.temperature, tt.topP
+			adjusted := policy.Apply(&temperature, &topP)
+			if !reflect.DeepEqual(temperature, tt.wantTemperature) {
+				t.Errorf("temperature = %v, want %v", samplingValue(temperature), samplingValue(tt.wantTemperature))
+			}
+			if !reflect.DeepEqual(topP, tt.wantTopP) {
+				t.Errorf("top_p = %v, want %v", samplingValue(topP), samplingValue(tt.wantTopP))
+			}
+			if !reflect.DeepEqual(adjusted, tt.wantAdjusted) {
+				t.Errorf("adjusted = %v, want %v", adjusted, tt.wantAdjusted)
+			}
+		})
+	}
+}
+
+func TestSamplingPolicyApply_KeepsCallerPointerWhenInRange(t *testing.T) {
+	max := 0.7
+	policy := SamplingPolicy{Temperature: SamplingRange{Max: &max}}
+	value := 0.4
+	temperature := &value
+	var topP *float64
+	policy.Apply(&temperature, &topP)
+	if temperature != &value {
+		t.Fatal("in-range temperature should keep the caller's pointer")
+	}
+	if (SamplingPolicy{}).Enabled() || !policy.Enabled() {
+		t.Fatal("Enabled() should report whether any bound is set")
+	}
+}
+
+func samplingValue(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"bytes"
 	"maps"
+	"slices"
 
 	"github.com/goccy/go-json"
 )
@@ -40,9 +42,65 @@ type ChatRequest struct {
 	Reasoning         *Reasoning        `json:"reasoning,omitempty"`
 	User              string            `json:"user,omitempty"`
 	ServiceTier       string            `json:"service_tier,omitempty"`
+	Modalities        []string          `json:"modalities,omitempty"` // Output types, e.g. ["text","audio"]
+	Audio             *ChatAudioConfig  `json:"audio,omitempty"`      // Audio output settings; requires "audio" in Modalities
 	ExtraFields       UnknownJSONFields `json:"-" swaggerignore:"true"`
 }
 
+// ChatAudioConfig configures spoken output for audio-capable chat models such
+// as OpenAI's gpt-4o-audio family.
+type ChatAudioConfig struct {
+	Voice       string            `json:"voice,omitempty"`
+	Format      string            `json:"format,omitempty"`
+	ExtraFields UnknownJSONFields `json:"-" swaggerignore:"true"`
+}
+
+// RequestsAudioOutput reports whether the request asks the model to return
+// audio, either through "audio" in Modalities or an audio config.
+func (r *ChatRequest) RequestsAudioOutput() bool {
+	return r != nil && (r.Audio != nil || slices.Contains(r.Modalities, "audio"))
+}
+
+// StopSequences returns the OpenAI-compatible stop field, which clients send
+// as a string or an array of strings and which travels in ExtraFields, as a
+// list for providers with a native stop_sequences parameter. Empty entries
+// are dropped; missing or malformed values yield nil.
+func (r *ChatRequest) StopSequences() []string {
+	if r == nil {
+		return nil
+	}
+	raw := bytes.TrimSpace(r.ExtraFields.Lookup("stop"))
+	if IsJSONNull(raw) {
+		return nil
+	}
+
+	var list []string
+	switch raw[0] {
+	case '"':
+		var single string
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil
+		}
+		list = []string{single}
+	case '[':
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	sequences := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != "" {
+			sequences = append(sequences, item)
+		}
+	}
+	if len(sequences) == 0 {
+		return nil
+	}
+	return sequences
+}
+
 func (r *ChatRequest) semanticSelector() (string, string) {
 	if r == nil {
 		return "", ""
@@ -66,6 +124,26 @@ func (r *ChatRequest) WithStreaming() *ChatRequest {
 	return &cp
 }
 
+// WithSystemPromptPrefix returns a shallow copy of the request whose system
+// prompt starts with prefix on its own line. A leading plain-text system
+// message is extended; otherwise prefix becomes a new leading system message.
+// The caller's messages slice is not modified.
+func (r *ChatRequest) WithSystemPromptPrefix(prefix string) *ChatRequest {
+	if prefix == "" {
+		return r
+	}
+	cp := *r
+	if len(r.Messages) > 0 && r.Messages[0].Role == "system" {
+		if text, ok := r.Messages[0].Content.(string); ok {
+			cp.Messages = slices.Clone(r.Messages)
+			cp.Messages[0].Content = prefix + "\n" + text
+			return &cp
+		}
+	}
+	cp.Messages = append([]Message{{Role: "system", Content: prefix}}, r.Messages...)
+	return &cp
+}
+
 // MessageContent stores message content as either text or structured parts.
 type MessageContent any
 
@@ -82,9 +160,15 @@ type Message struct {
 	// or an array of ContentPart values.
 	ContentSchema []ContentPart     `json:"content,omitempty" extensions:"x-oneOf=[{\"type\":\"null\"},{\"type\":\"string\"},{\"type\":\"array\",\"items\":{\"$ref\":\"#/definitions/core.ContentPart\"}}]"`
 	ToolCalls     []ToolCall        `json:"tool_calls,omitempty"`
+	Audio         *MessageAudio     `json:"audio,omitempty"` // Prior assistant audio, referenced by ID in multi-turn audio chats
 	ExtraFields   UnknownJSONFields `json:"-" swaggerignore:"true"`
 }
 
+// MessageAudio references the audio of an earlier assistant response.
+type MessageAudio struct {
+	ID string `json:"id"`
+}
+
 // ToolCall represents a single tool invocation emitted by a model.
 type ToolCall struct {
 	ID          string            `json:"id"`
@@ -133,9 +217,22 @@ type ResponseMessage struct {
 	//nolint:govet // Intentional duplicate json tag for Swagger docs: content is null OR string OR []ContentPart.
 	ContentSchema []ContentPart     `json:"content,omitempty" extensions:"x-oneOf=[{\"type\":\"null\"},{\"type\":\"string\"},{\"type\":\"array\",\"items\":{\"$ref\":\"#/definitions/core.ContentPart\"}}]"`
 	ToolCalls     []ToolCall        `json:"tool_calls,omitempty"`
+	Audio         *ResponseAudio    `json:"audio,omitempty"`
 	ExtraFields   UnknownJSONFields `json:"-" swaggerignore:"true"`
 }
 
+// ResponseAudio is the spoken output of a chat completion choice. Data holds
+// the base64-encoded audio in the requested format; ID can be sent back as
+// MessageAudio to continue the conversation.
+type ResponseAudio struct {
+	ID         string `json:"id,omitempty"`
+	Data       string `json:"data,omitempty"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
+
+	ExtraFields UnknownJSONFields `json:"-" swaggerignore:"true"`
+}
+
 // PromptTokensDetails holds extended input token breakdown (OpenAI/xAI).
 type PromptTokensDetails struct {
 	CachedTokens int `json:"cached_tokens"`
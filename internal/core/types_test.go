@@ -2,6 +2,7 @@ package core
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -637,3 +638,32 @@ func TestModelMetadataClone_DeepClonesRankingPointers(t *testing.T) {
 		t.Errorf("original Rank mutated: %v", *m.Rankings["bench"].Rank)
 	}
 }
+
+func TestChatRequestStopSequences(t *testing.T) {
+	tests := []struct {
+		name string
+		stop string
+		want []string
+	}{
+		{name: "array", stop: `["FOO","BAR"]`, want: []string{"FOO", "BAR"}},
+		{name: "single string", stop: `"END"`, want: []string{"END"}},
+		{name: "empty entries dropped", stop: `["","END"]`, want: []string{"END"}},
+		{name: "only empty entries", stop: `[""]`, want: nil},
+		{name: "null", stop: `null`, want: nil},
+		{name: "malformed", stop: `42`, want: nil},
+		{name: "absent", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &ChatRequest{Model: "m"}
+			if tt.stop != "" {
+				req.ExtraFields = UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+					"stop": json.RawMessage(tt.stop),
+				})
+			}
+			if got := req.StopSequences(); !slices.Equal(got, tt.want) {
+				t.Fatalf("StopSequences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ const (
 	AttemptKindPrimary  = "primary"
 	AttemptKindFailover = "failover"
 	AttemptKindRetry    = "retry"
+	AttemptKindRace     = "race"
 )
 
 type attemptRecorderKey struct{}
@@ -149,6 +150,8 @@ func normalizeProviderAttemptKind(kind string) string {
 		return AttemptKindFailover
 	case AttemptKindRetry:
 		return AttemptKindRetry
+	case AttemptKindRace:
+		return AttemptKindRace
 	default:
 		return ""
 	}
@@ -133,6 +133,23 @@ func executeTranslatedWithFailover[Req any, Resp any](
 	cloneForSelector func(Req, core.ModelSelector) Req,
 	call func(context.Context, Req) (Resp, string, error),
 ) (Resp, string, string, string, bool, error) {
+	failoverFn := func(selector core.ModelSelector, providerType, providerName string) (Resp, string, error) {
+		resp, responseProvider, err := call(ctx, cloneForSelector(req, selector))
+		if err != nil {
+			var zero Resp
+			return zero, "", err
+		}
+		return resp, ResponseProviderType(providerType, responseProvider), nil
+	}
+	if selectors := o.raceSelectors(ctx, workflow); len(selectors) > 1 {
+		// The winning target is reported in the failover-model slot so usage is
+		// priced against the model that actually answered.
+		resp, providerType, providerName, winner, err := raceTranslatedRequest(ctx, o, workflow, req, selectors, cloneForSelector, call)
+		if err == nil {
+			return resp, providerType, providerName, winner, false, nil
+		}
+		return tryFailoverResponse(ctx, o, workflow, model, provider, err, failoverFn)
+	}
 	return executeWithFailoverResponse(ctx, o, workflow, model, provider,
 		func() (Resp, string, string, error) {
 			started := time.Now()
@@ -152,14 +169,7 @@ func executeTranslatedWithFailover[Req any, Resp any](
 			}
 			return resp, ResponseProviderType(ProviderTypeFromWorkflow(workflow), responseProvider), ProviderNameFromWorkflow(workflow), nil
 		},
-		func(selector core.ModelSelector, providerType, providerName string) (Resp, string, error) {
-			resp, responseProvider, err := call(ctx, cloneForSelector(req, selector))
-			if err != nil {
-				var zero Resp
-				return zero, "", err
-			}
-			return resp, ResponseProviderType(providerType, responseProvider), nil
-		},
+		failoverFn,
 	)
 }
 
@@ -181,6 +181,7 @@ func TestShouldAttemptFailover(t *testing.T) {
 		// Server-side and rate-limit failures always fall back.
 		{"500 server error", http.StatusInternalServerError, "internal error", true},
 		{"429 rate limited", http.StatusTooManyRequests, "slow down", true},
+		{"circuit open", http.StatusServiceUnavailable, "circuit breaker is open - provider temporarily unavailable", true},
 
 		// Model-availability phrasing falls back regardless of status code.
 		{"model not found message", http.StatusBadRequest, "model gpt-9 does not exist", true},
@@ -198,6 +199,11 @@ func TestShouldAttemptFailover(t *testing.T) {
 
 		// A plain client error without availability phrasing is not retried.
 		{"plain 400", http.StatusBadRequest, "invalid request", false},
+
+		// Credential failures are the caller's or operator's to fix; another
+		// provider would not make the request valid.
+		{"401 unauthorized", http.StatusUnauthorized, "invalid api key", false},
+		{"403 forbidden", http.StatusForbidden, "permission denied", false},
 	}
 
 	for _, tt := range tests {
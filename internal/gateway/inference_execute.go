@@ -180,6 +180,15 @@ func (o *InferenceOrchestrator) CanFastPathStreamingChatPassthrough(workflow *co
 	if translatedStreamingSelectorRewriteRequired(workflow.Resolution) {
 		return false
 	}
+	if defaults, ok := o.provider.(defaultParamsProvider); ok && defaults.HasDefaultParams(workflow.Resolution.ResolvedSelector.Provider) {
+		return false
+	}
+	if sampling, ok := o.provider.(samplingPolicyProvider); ok && sampling.HasSamplingPolicy() {
+		return false
+	}
+	if fallback, ok := o.provider.(streamingFallbackProvider); ok && fallback.NeedsStreamingFallback(workflow.Resolution.ResolvedSelector.QualifiedModel()) {
+		return false
+	}
 	if translatedStreamingChatBodyRewriteRequired(req) {
 		return false
 	}
@@ -441,7 +450,11 @@ func (o *InferenceOrchestrator) validateProviderAndRequest(requestPresent bool,
 }
 
 func (o *InferenceOrchestrator) chatCompletionProviderCall(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
-	resp, err := o.provider.ChatCompletion(ctx, req)
+	call := o.provider.ChatCompletion
+	if core.GetPartialOnCancel(ctx) {
+		call = o.partialChatCompletion
+	}
+	resp, err := call(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +27,9 @@ type InferenceConfig struct {
 	PricingResolver          usage.PricingResolver
 	RouteGate                RouteGate
 	GuardrailsHash           string
+	// MaxToolsPerRequest rejects translated requests declaring more tools;
+	// 0 disables the check.
+	MaxToolsPerRequest int
 }
 
 // InferenceOrchestrator owns translated inference workflow resolution, request
@@ -42,6 +45,7 @@ type InferenceOrchestrator struct {
 	pricingResolver          usage.PricingResolver
 	routeGate                RouteGate
 	guardrailsHash           string
+	maxToolsPerRequest       int
 }
 
 // NewInferenceOrchestrator creates a translated inference orchestrator.
@@ -57,6 +61,7 @@ func NewInferenceOrchestrator(cfg InferenceConfig) *InferenceOrchestrator {
 		pricingResolver:          cfg.PricingResolver,
 		routeGate:                cfg.RouteGate,
 		guardrailsHash:           cfg.GuardrailsHash,
+		maxToolsPerRequest:       cfg.MaxToolsPerRequest,
 	}
 }
 
@@ -2,8 +2,10 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"io"
 	"math"
+	"net/http"
 	"testing"
 
 	"github.com/enterpilot/gomodel/internal/core"
@@ -237,3 +239,50 @@ func (p *providerTypeResolverStub) Supports(string) bool { return true }
 func (p *providerTypeResolverStub) GetProviderType(model string) string {
 	return p.providerTypes[model]
 }
+
+func TestPrepareTranslatedRequestsEnforceMaxToolsPerRequest(t *testing.T) {
+	tools := func(n int) []map[string]any {
+		out := make([]map[string]any, n)
+		for i := range out {
+			out[i] = map[string]any{"type": "function", "function": map[string]any{"name": "tool"}}
+		}
+		return out
+	}
+	orchestrator := NewInferenceOrchestrator(InferenceConfig{
+		Provider:           &providerTypeResolverStub{},
+		MaxToolsPerRequest: 2,
+	})
+
+	if _, err := orchestrator.PrepareChatRequest(context.Background(), &core.ChatRequest{
+		Model: "openai/gpt-4o-mini",
+		Tools: tools(2),
+	}, RequestMeta{}); err != nil {
+		t.Fatalf("PrepareChatRequest() at limit error = %v", err)
+	}
+
+	_, err := orchestrator.PrepareChatRequest(context.Background(), &core.ChatRequest{
+		Model: "openai/gpt-4o-mini",
+		Tools: tools(3),
+	}, RequestMeta{})
+	assertTooManyToolsError(t, err)
+
+	_, err = orchestrator.PrepareResponsesRequest(context.Background(), &core.ResponsesRequest{
+		Model: "openai/gpt-4o-mini",
+		Tools: tools(3),
+	}, RequestMeta{})
+	assertTooManyToolsError(t, err)
+}
+
+func assertTooManyToolsError(t *testing.T, err error) {
+	t.Helper()
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		t.Fatalf("error = %v, want gateway error", err)
+	}
+	if gatewayErr.Type != core.ErrorTypeInvalidRequest || gatewayErr.HTTPStatusCode() != http.StatusBadRequest {
+		t.Fatalf("error = %+v, want 400 invalid_request_error", gatewayErr)
+	}
+	if gatewayErr.Code == nil || *gatewayErr.Code != "too_many_tools" || gatewayErr.Message != "tools must not contain more than 2 entries, got 3" {
+		t.Fatalf("error = %+v, want too_many_tools with count", gatewayErr)
+	}
+}
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/enterpilot/gomodel/internal/core"
@@ -35,6 +36,7 @@ type translatedPrepareSpec[Req any, Prepared any] struct {
 	requiredMessage string
 	patchNilMessage string
 	selector        func(Req) (*string, *string)
+	toolCount       func(Req) int
 	patch           func(*InferenceOrchestrator) func(context.Context, Req) (Req, error)
 	valid           func(Req) bool
 	build           func(context.Context, Req, *core.Workflow) Prepared
@@ -44,6 +46,7 @@ var chatPrepareSpec = translatedPrepareSpec[*core.ChatRequest, *PreparedChatRequ
 	requiredMessage: "chat request is required",
 	patchNilMessage: "patched chat request is required",
 	selector:        chatRequestSelector,
+	toolCount:       func(req *core.ChatRequest) int { return len(req.Tools) },
 	patch:           chatRequestPatch,
 	valid:           validChatRequest,
 	build: func(ctx context.Context, req *core.ChatRequest, workflow *core.Workflow) *PreparedChatRequest {
@@ -55,6 +58,7 @@ var responsesPrepareSpec = translatedPrepareSpec[*core.ResponsesRequest, *Prepar
 	requiredMessage: "responses request is required",
 	patchNilMessage: "patched responses request is required",
 	selector:        responsesRequestSelector,
+	toolCount:       func(req *core.ResponsesRequest) int { return len(req.Tools) },
 	patch:           responsesRequestPatch,
 	valid:           validResponsesRequest,
 	build: func(ctx context.Context, req *core.ResponsesRequest, workflow *core.Workflow) *PreparedResponsesRequest {
@@ -73,6 +77,9 @@ func prepareTranslated[Req any, Prepared any](
 	if !spec.valid(req) {
 		return zero, core.NewInvalidRequestError(spec.requiredMessage, nil)
 	}
+	if err := o.checkToolCount(spec.toolCount(req)); err != nil {
+		return zero, err
+	}
 	ctx = WithAttemptRecorder(ctx)
 	model, provider := spec.selector(req)
 	ctx, req, workflow, err := prepareTranslatedRequest(o, ctx, req, meta, model, provider, spec.patch(o), spec.valid, spec.patchNilMessage)
@@ -115,6 +122,18 @@ func prepareTranslatedRequest[Req any](
 	return ctx, req, workflow, nil
 }
 
+// checkToolCount rejects requests declaring more tools than the configured
+// maximum before any model resolution or provider work happens.
+func (o *InferenceOrchestrator) checkToolCount(count int) error {
+	if o.maxToolsPerRequest <= 0 || count <= o.maxToolsPerRequest {
+		return nil
+	}
+	return core.NewInvalidRequestError(
+		fmt.Sprintf("tools must not contain more than %d entries, got %d", o.maxToolsPerRequest, count), nil).
+		WithParam("tools").
+		WithCode("too_many_tools")
+}
+
 func validChatRequest(req *core.ChatRequest) bool {
 	return req != nil
 }
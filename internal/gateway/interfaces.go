@@ -22,6 +22,12 @@ type UserPathModelResolver interface {
 	ResolveModelForUserPath(ctx context.Context, requested core.RequestedModelSelector) (core.ModelSelector, bool, error)
 }
 
+// RaceTargetResolver is an optional ModelResolver that expands a race redirect
+// into the concrete targets a translated request is sent to concurrently.
+type RaceTargetResolver interface {
+	RaceTargets(ctx context.Context, requested core.RequestedModelSelector) []core.ModelSelector
+}
+
 // FailoverResolver resolves alternate concrete model selectors for a translated
 // request after the primary selector has already been resolved.
 type FailoverResolver interface {
@@ -52,3 +58,24 @@ type TranslatedRequestPatcher interface {
 type BatchRequestPreparer interface {
 	PrepareBatchRequest(ctx context.Context, providerType string, req *core.BatchRequest) (*core.BatchRewriteResult, error)
 }
+
+// defaultParamsProvider is implemented by providers that merge per-provider
+// default request params at dispatch. Requests to such providers cannot take
+// verbatim body passthrough fast paths.
+type defaultParamsProvider interface {
+	HasDefaultParams(providerName string) bool
+}
+
+// samplingPolicyProvider is implemented by providers that clamp sampling
+// parameters to a configured policy at dispatch, which likewise rules out
+// verbatim body passthrough.
+type samplingPolicyProvider interface {
+	HasSamplingPolicy() bool
+}
+
+// streamingFallbackProvider is implemented by providers that answer streaming
+// requests for non-streaming models with a buffered completion, so those
+// requests cannot be passed through to the upstream stream verbatim.
+type streamingFallbackProvider interface {
+	NeedsStreamingFallback(model string) bool
+}
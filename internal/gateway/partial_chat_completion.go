@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/streaming"
+)
+
+// FinishReasonCancelled marks a choice cut short by request cancellation.
+const FinishReasonCancelled = "cancelled"
+
+// partialChatCompletion serves a non-streaming chat request from the provider
+// stream so the content generated so far survives cancellation. A completed
+// stream yields the same response a non-streaming call would.
+func (o *InferenceOrchestrator) partialChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	streamReq := req.WithStreaming()
+	streamOptions := core.StreamOptions{IncludeUsage: true}
+	if req.StreamOptions != nil {
+		streamOptions = *req.StreamOptions
+		streamOptions.IncludeUsage = true
+	}
+	streamReq.StreamOptions = &streamOptions
+
+	stream, err := o.provider.StreamChatCompletion(ctx, streamReq)
+	if err != nil {
+		return nil, err
+	}
+	if stream == nil {
+		return nil, emptyProviderStreamError("")
+	}
+	collector := newChatChunkCollector()
+	observed := streaming.NewObservedSSEStream(stream, collector)
+	_, readErr := io.Copy(io.Discard, observed)
+	_ = observed.Close() //nolint:errcheck
+
+	cancelled := readErr != nil && ctx.Err() != nil
+	if readErr != nil && (!cancelled || !collector.hasOutput()) {
+		return nil, core.NewProviderError("", http.StatusBadGateway, "upstream stream failed: "+readErr.Error(), readErr)
+	}
+	return collector.response(req.Model, cancelled), nil
+}
+
+// chatChunkCollector rebuilds a chat completion from streamed chunks. Tool-call
+// deltas are not reassembled; partial tool arguments are rarely usable.
+type chatChunkCollector struct {
+	id          string
+	model       string
+	provider    string
+	fingerprint string
+	created     int64
+	usage       core.Usage
+	choices     map[int]*collectedChoice
+}
+
+type collectedChoice struct {
+	role         string
+	content      strings.Builder
+	finishReason string
+}
+
+func newChatChunkCollector() *chatChunkCollector {
+	return &chatChunkCollector{choices: make(map[int]*collectedChoice)}
+}
+
+func (o *chatChunkCollector) OnJSONEvent(event map[string]any) {
+	if id, ok := event["id"].(string); ok && o.id == "" {
+		o.id = id
+	}
+	if model, ok := event["model"].(string); ok && o.model == "" {
+		o.model = model
+	}
+	if provider, ok := event["provider"].(string); ok && o.provider == "" {
+		o.provider = provider
+	}
+	if fingerprint, ok := event["system_fingerprint"].(string); ok && o.fingerprint == "" {
+		o.fingerprint = fingerprint
+	}
+	if created, ok := event["created"].(float64); ok && o.created == 0 {
+		o.created = int64(created)
+	}
+	if raw, ok := event["usage"].(map[string]any); ok {
+		o.usage.PromptTokens = intField(raw, "prompt_tokens")
+		o.usage.CompletionTokens = intField(raw, "completion_tokens")
+		o.usage.TotalTokens = intField(raw, "total_tokens")
+	}
+
+	choices, _ := event["choices"].([]any)
+	for _, rawChoice := range choices {
+		choice, ok := rawChoice.(map[string]any)
+		if !ok {
+			continue
+		}
+		index := intField(choice, "index")
+		state := o.choices[index]
+		if state == nil {
+			state = &collectedChoice{role: "assistant"}
+			o.choices[index] = state
+		}
+		if delta, ok := choice["delta"].(map[string]any); ok {
+			if role, ok := delta["role"].(string); ok && role != "" {
+				state.role = role
+			}
+			if content, ok := delta["content"].(string); ok {
+				state.content.WriteString(content)
+			}
+		}
+		if reason, ok := choice["finish_reason"].(string); ok && reason != "" {
+			state.finishReason = reason
+		}
+	}
+}
+
+func (o *chatChunkCollector) OnStreamClose() {}
+
+func (o *chatChunkCollector) hasOutput() bool {
+	for _, state := range o.choices {
+		if state.content.Len() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// response returns the collected completion. When cancelled, choices that had
+// not finished report FinishReasonCancelled.
+func (o *chatChunkCollector) response(requestedModel string, cancelled bool) *core.ChatResponse {
+	resp := &core.ChatResponse{
+		ID:                o.id,
+		Object:            "chat.completion",
+		Model:             o.model,
+		Provider:          o.provider,
+		SystemFingerprint: o.fingerprint,
+		Created:           o.created,
+		Usage:             o.usage,
+		Choices:           make([]core.Choice, 0, len(o.choices)),
+	}
+	core.EnsureModel(&resp.Model, requestedModel)
+
+	indexes := make([]int, 0, len(o.choices))
+	for index := range o.choices {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		state := o.choices[index]
+		finishReason := state.finishReason
+		if finishReason == "" && cancelled {
+			finishReason = FinishReasonCancelled
+		}
+		resp.Choices = append(resp.Choices, core.Choice{
+			Index:        index,
+			FinishReason: finishReason,
+			Message: core.ResponseMessage{
+				Role:    state.role,
+				Content: state.content.String(),
+			},
+		})
+	}
+	return resp
+}
+
+func intField(m map[string]any, key string) int {
+	if v, ok := m[key].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+var _ streaming.Observer = (*chatChunkCollector)(nil)
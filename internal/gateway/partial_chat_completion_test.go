@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/usage"
+)
+
+type chatStreamStub struct {
+	providerTypeResolverStub
+	streamData string
+	streamReq  *core.ChatRequest
+}
+
+func (p *chatStreamStub) StreamChatCompletion(_ context.Context, req *core.ChatRequest) (io.ReadCloser, error) {
+	p.streamReq = req
+	return io.NopCloser(strings.NewReader(p.streamData)), nil
+}
+
+func TestExecuteChatCompletionPartialOnCancelRunsExecutePipeline(t *testing.T) {
+	provider := &chatStreamStub{streamData: "data: {\"id\":\"chatcmpl-1\",\"model\":\"gpt-4o-mini\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hi\"}}]}\n\n" +
+		"data: {\"id\":\"chatcmpl-1\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":1,\"total_tokens\":4}}\n\n" +
+		"data: [DONE]\n\n"}
+	logger := &usageCaptureLogger{config: usage.Config{Enabled: true}}
+	orchestrator := NewInferenceOrchestrator(InferenceConfig{
+		Provider:    provider,
+		UsageLogger: logger,
+	})
+	workflow := &core.Workflow{
+		ProviderType: "openai",
+		Resolution: &core.RequestModelResolution{
+			Requested:        core.NewRequestedModelSelector("gpt-4o-mini", ""),
+			ResolvedSelector: core.ModelSelector{Provider: "openai", Model: "gpt-4o-mini"},
+			ProviderType:     "openai",
+		},
+	}
+
+	ctx := core.WithPartialOnCancel(context.Background(), true)
+	result, err := orchestrator.ExecuteChatCompletion(ctx, workflow, &core.ChatRequest{Model: "gpt-4o-mini"}, "req-1", "/v1/chat/completions")
+	if err != nil {
+		t.Fatalf("ExecuteChatCompletion() error = %v", err)
+	}
+	if provider.streamReq == nil || !provider.streamReq.Stream || provider.streamReq.StreamOptions == nil || !provider.streamReq.StreamOptions.IncludeUsage {
+		t.Fatalf("stream request = %+v, want streaming with usage", provider.streamReq)
+	}
+	if result.Response.ID != "chatcmpl-1" {
+		t.Fatalf("response ID = %q, want chatcmpl-1", result.Response.ID)
+	}
+	if len(result.Response.Choices) != 1 || result.Response.Choices[0].Message.Content != "Hi" || result.Response.Choices[0].FinishReason != "stop" {
+		t.Fatalf("choices = %+v, want collected completion", result.Response.Choices)
+	}
+	if len(logger.entries) != 1 || logger.entries[0].TotalTokens != 4 {
+		t.Fatalf("usage entries = %+v, want one entry with 4 tokens", logger.entries)
+	}
+}
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// raceSelectors returns the targets a translated request is raced across, or
+// nil when the requested model is not a race redirect with at least two
+// targets the caller may use.
+func (o *InferenceOrchestrator) raceSelectors(ctx context.Context, workflow *core.Workflow) []core.ModelSelector {
+	if workflow == nil || workflow.Resolution == nil || !workflow.Resolution.AliasApplied {
+		return nil
+	}
+	racer, ok := o.modelResolver.(RaceTargetResolver)
+	if !ok {
+		return nil
+	}
+	selectors := racer.RaceTargets(ctx, workflow.Resolution.Requested)
+	if o.modelAuthorizer != nil {
+		allowed := make([]core.ModelSelector, 0, len(selectors))
+		for _, selector := range selectors {
+			if o.modelAuthorizer.AllowsModel(ctx, selector) {
+				allowed = append(allowed, selector)
+			}
+		}
+		selectors = allowed
+	}
+	if len(selectors) < 2 {
+		return nil
+	}
+	return selectors
+}
+
+type raceResult[Resp any] struct {
+	index            int
+	resp             Resp
+	responseProvider string
+	started          time.Time
+	err              error
+}
+
+// raceTranslatedRequest sends req to every selector concurrently and returns
+// the first successful response together with the winning target's provider
+// type, provider name, and qualified model. Every call that finishes before
+// the winner, failed ones included, is recorded as a race attempt alongside
+// the winner; calls still in flight when the winner arrives are cancelled and
+// not recorded. When every target fails, the error of the first declared
+// target is returned.
+func raceTranslatedRequest[Req any, Resp any](
+	ctx context.Context,
+	o *InferenceOrchestrator,
+	workflow *core.Workflow,
+	req Req,
+	selectors []core.ModelSelector,
+	cloneForSelector func(Req, core.ModelSelector) Req,
+	call func(context.Context, Req) (Resp, string, error),
+) (Resp, string, string, string, error) {
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so cancelled losers never block after the winner returns.
+	results := make(chan raceResult[Resp], len(selectors))
+	for i, selector := range selectors {
+		targetReq := cloneForSelector(req, selector)
+		go func() {
+			started := time.Now()
+			resp, responseProvider, err := call(raceCtx, targetReq)
+			results <- raceResult[Resp]{index: i, resp: resp, responseProvider: responseProvider, started: started, err: err}
+		}()
+	}
+
+	errs := make([]error, len(selectors))
+	for range selectors {
+		result := <-results
+		selector := selectors[result.index]
+		qualified := selector.QualifiedModel()
+		providerType := o.ProviderTypeForSelector(selector, ProviderTypeFromWorkflow(workflow))
+		providerName := ResolvedProviderName(o.provider, selector, ProviderNameFromWorkflow(workflow))
+		if result.err == nil {
+			providerType = ResponseProviderType(providerType, result.responseProvider)
+		}
+		recordProviderAttempt(ctx, providerAttemptFromResult(AttemptKindRace, providerType, providerName, qualified, result.started, result.err))
+		if result.err == nil {
+			cancel()
+			return result.resp, providerType, providerName, qualified, nil
+		}
+		errs[result.index] = result.err
+	}
+
+	var zero Resp
+	return zero, "", "", "", errs[0]
+}
@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// stubRaceResolver resolves every request to the first race target and
+// expands it to the full target list.
+type stubRaceResolver struct {
+	targets []core.ModelSelector
+}
+
+func (s stubRaceResolver) ResolveModel(core.RequestedModelSelector) (core.ModelSelector, bool, error) {
+	return s.targets[0], true, nil
+}
+
+func (s stubRaceResolver) RaceTargets(context.Context, core.RequestedModelSelector) []core.ModelSelector {
+	return s.targets
+}
+
+func raceTestFixture(targets ...core.ModelSelector) (*InferenceOrchestrator, *core.Workflow) {
+	o := &InferenceOrchestrator{modelResolver: stubRaceResolver{targets: targets}}
+	workflow := &core.Workflow{
+		Endpoint: core.EndpointDescriptor{Operation: core.OperationChatCompletions},
+		Resolution: &core.RequestModelResolution{
+			Requested:        core.NewRequestedModelSelector("council", ""),
+			ResolvedSelector: targets[0],
+			AliasApplied:     true,
+		},
+	}
+	return o, workflow
+}
+
+func TestExecuteTranslatedRaceReturnsFastestTarget(t *testing.T) {
+	o, workflow := raceTestFixture(
+		core.ModelSelector{Provider: "openai", Model: "gpt-4o"},
+		core.ModelSelector{Provider: "groq", Model: "llama"},
+	)
+	ctx := WithAttemptRecorder(context.Background())
+
+	slowCancelled := make(chan struct{})
+	resp, providerType, _, winner, didFailover, err := executeTranslatedWithFailover(
+		ctx, o, workflow, "req", "council", "",
+		func(_ string, selector core.ModelSelector) string { return selector.QualifiedModel() },
+		func(ctx context.Context, req string) (string, string, error) {
+			if req == "openai/gpt-4o" {
+				select {
+				case <-ctx.Done():
+					close(slowCancelled)
+					return "", "", ctx.Err()
+				case <-time.After(5 * time.Second):
+					return "slow", "openai", nil
+				}
+			}
+			return "fast", "groq", nil
+		},
+	)
+
+	if err != nil || resp != "fast" || winner != "groq/llama" || providerType != "groq" || didFailover {
+		t.Fatalf("result = (resp:%q winner:%q type:%q failover:%v err:%v), want the groq response", resp, winner, providerType, didFailover, err)
+	}
+	select {
+	case <-slowCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("losing target was not cancelled after the winner returned")
+	}
+	attempts := AttemptsFromContext(ctx)
+	if len(attempts) != 1 || attempts[0].Kind != AttemptKindRace || attempts[0].Model != "groq/llama" || !attempts[0].Success {
+		t.Fatalf("attempts = %+v, want only the winning race attempt", attempts)
+	}
+}
+
+func TestExecuteTranslatedRaceSkipsFailedTargets(t *testing.T) {
+	o, workflow := raceTestFixture(
+		core.ModelSelector{Provider: "openai", Model: "gpt-4o"},
+		core.ModelSelector{Provider: "groq", Model: "llama"},
+	)
+
+	resp, _, _, winner, _, err := executeTranslatedWithFailover(
+		context.Background(), o, workflow, "req", "council", "",
+		func(_ string, selector core.ModelSelector) string { return selector.QualifiedModel() },
+		func(_ context.Context, req string) (string, string, error) {
+			if req == "groq/llama" {
+				return "", "", core.NewProviderError("groq", 503, "unavailable", nil)
+			}
+			time.Sleep(20 * time.Millisecond)
+			return "slow", "openai", nil
+		},
+	)
+
+	if err != nil || resp != "slow" || winner != "openai/gpt-4o" {
+		t.Fatalf("result = (resp:%q winner:%q err:%v), want the surviving openai response", resp, winner, err)
+	}
+}
+
+func TestExecuteTranslatedRaceReturnsFirstTargetErrorWhenAllFail(t *testing.T) {
+	o, workflow := raceTestFixture(
+		core.ModelSelector{Provider: "openai", Model: "gpt-4o"},
+		core.ModelSelector{Provider: "groq", Model: "llama"},
+	)
+	openaiErr := core.NewProviderError("openai", 500, "openai down", nil)
+
+	_, _, _, _, _, err := executeTranslatedWithFailover(
+		context.Background(), o, workflow, "req", "council", "",
+		func(_ string, selector core.ModelSelector) string { return selector.QualifiedModel() },
+		func(_ context.Context, req string) (string, string, error) {
+			if req == "openai/gpt-4o" {
+				time.Sleep(10 * time.Millisecond)
+				return "", "", openaiErr
+			}
+			return "", "", core.NewProviderError("groq", 500, "groq down", nil)
+		},
+	)
+
+	if !errors.Is(err, openaiErr) {
+		t.Fatalf("err = %v, want the first declared target's error", err)
+	}
+}
+
+func TestExecuteTranslatedWithoutAliasDoesNotRace(t *testing.T) {
+	o, workflow := raceTestFixture(
+		core.ModelSelector{Provider: "openai", Model: "gpt-4o"},
+		core.ModelSelector{Provider: "groq", Model: "llama"},
+	)
+	workflow.Resolution.AliasApplied = false
+
+	var calls []string
+	_, _, _, _, _, err := executeTranslatedWithFailover(
+		context.Background(), o, workflow, "req", "openai/gpt-4o", "",
+		func(_ string, selector core.ModelSelector) string { return selector.QualifiedModel() },
+		func(_ context.Context, req string) (string, string, error) {
+			calls = append(calls, req)
+			return "ok", "openai", nil
+		},
+	)
+
+	if err != nil || len(calls) != 1 || calls[0] != "req" {
+		t.Fatalf("calls = %v err = %v, want a single primary call", calls, err)
+	}
+}
@@ -2,6 +2,8 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"strings"
 
 	"github.com/enterpilot/gomodel/internal/core"
@@ -75,11 +77,11 @@ func ResolveRequestModelWithAuthorizer(
 			return nil, refreshErr
 		}
 		if !refreshed {
-			return nil, core.NewInvalidRequestError(err.Error(), err)
+			return nil, selectorResolutionError(err)
 		}
 		resolvedSelector, aliasApplied, err = ResolveExecutionSelector(ctx, provider, resolver, requested)
 		if err != nil {
-			return nil, core.NewInvalidRequestError(err.Error(), err)
+			return nil, selectorResolutionError(err)
 		}
 	}
 	if resolvedSelector == (core.ModelSelector{}) {
@@ -100,14 +102,14 @@ func ResolveRequestModelWithAuthorizer(
 			if refreshed {
 				resolvedSelector, aliasApplied, err = ResolveExecutionSelector(ctx, provider, resolver, requested)
 				if err != nil {
-					return nil, core.NewInvalidRequestError(err.Error(), err)
+					return nil, selectorResolutionError(err)
 				}
 				resolvedModel = resolvedSelector.QualifiedModel()
 			}
 		}
 	}
 	if counted, ok := provider.(modelCountProvider); ok && counted.ModelCount() == 0 {
-		return nil, core.NewProviderError("", 0, "model registry not initialized", nil)
+		return nil, core.NewProviderError("", http.StatusServiceUnavailable, "model registry not initialized", nil)
 	}
 	if !provider.Supports(resolvedModel) {
 		if !refreshed {
@@ -119,7 +121,7 @@ func ResolveRequestModelWithAuthorizer(
 			if refreshed {
 				resolvedSelector, aliasApplied, err = ResolveExecutionSelector(ctx, provider, resolver, requested)
 				if err != nil {
-					return nil, core.NewInvalidRequestError(err.Error(), err)
+					return nil, selectorResolutionError(err)
 				}
 				resolvedModel = resolvedSelector.QualifiedModel()
 			}
@@ -143,6 +145,17 @@ func ResolveRequestModelWithAuthorizer(
 	}, nil
 }
 
+// selectorResolutionError keeps a gateway error from selector resolution, such
+// as the router's 503 for a model registry that is not ready, and reports
+// anything else as an invalid request.
+func selectorResolutionError(err error) error {
+	var gatewayErr *core.GatewayError
+	if errors.As(err, &gatewayErr) {
+		return gatewayErr
+	}
+	return core.NewInvalidRequestError(err.Error(), err)
+}
+
 func refreshProviderModelsForResolution(
 	ctx context.Context,
 	provider core.RoutableProvider,
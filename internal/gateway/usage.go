@@ -36,6 +36,7 @@ func (o *InferenceOrchestrator) logUsage(
 		entry.UserPath = core.UserPathFromContext(ctx)
 		entry.Labels = core.RequestLabelsFromContext(ctx)
 		usage.ApplyRewriteSavings(entry, core.RewriteTokensSavedFromContext(ctx), pricing)
+		usage.ApplyUpstreamRequestID(entry, core.GetUpstreamRequestID(ctx))
 		o.usageLogger.Write(entry)
 	}
 }
@@ -31,10 +31,19 @@ func cloneChatMessageEnvelope(message core.Message) core.Message {
 		ContentNull: message.ContentNull,
 		Content:     cloneMessageContent(message.Content),
 		ToolCalls:   cloneToolCalls(message.ToolCalls),
+		Audio:       cloneMessageAudio(message.Audio),
 		ExtraFields: core.CloneUnknownJSONFields(message.ExtraFields),
 	}
 }
 
+func cloneMessageAudio(audio *core.MessageAudio) *core.MessageAudio {
+	if audio == nil {
+		return nil
+	}
+	cloned := *audio
+	return &cloned
+}
+
 func cloneMessageContent(content any) any {
 	switch value := content.(type) {
 	case nil:
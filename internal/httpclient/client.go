@@ -2,8 +2,10 @@
 package httpclient
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"sync/atomic"
@@ -35,6 +37,10 @@ type ClientConfig struct {
 
 	// ResponseHeaderTimeout specifies the amount of time to wait for a server's response headers
 	ResponseHeaderTimeout time.Duration
+
+	// Proxy routes every request through the given proxy URL. When nil, the
+	// HTTP_PROXY / HTTPS_PROXY / NO_PROXY environment variables apply.
+	Proxy *url.URL
 }
 
 // getEnvDuration reads a duration from an environment variable, returning the default if not set or invalid.
@@ -61,6 +67,7 @@ func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
 var (
 	configuredTimeoutSeconds               atomic.Int64
 	configuredResponseHeaderTimeoutSeconds atomic.Int64
+	configuredStreamIdleTimeoutSeconds     atomic.Int64
 )
 
 // SetConfiguredTimeouts installs the config-file (`http:` block) timeout
@@ -73,6 +80,21 @@ func SetConfiguredTimeouts(timeoutSeconds, responseHeaderTimeoutSeconds int) {
 	configuredResponseHeaderTimeoutSeconds.Store(int64(max(responseHeaderTimeoutSeconds, 0)))
 }
 
+// SetConfiguredStreamIdleTimeout installs the config-file stream idle timeout,
+// in seconds. Like SetConfiguredTimeouts it is called once at startup, and the
+// HTTP_STREAM_IDLE_TIMEOUT env var still takes precedence. Non-positive values
+// disable the timeout.
+func SetConfiguredStreamIdleTimeout(seconds int) {
+	configuredStreamIdleTimeoutSeconds.Store(int64(max(seconds, 0)))
+}
+
+// StreamIdleTimeout returns how long a streaming response may go without
+// receiving upstream bytes before it is aborted. Zero disables the check,
+// which is the default: the overall request timeout still bounds the stream.
+func StreamIdleTimeout() time.Duration {
+	return getEnvDuration("HTTP_STREAM_IDLE_TIMEOUT", configuredOrDefault(&configuredStreamIdleTimeoutSeconds, 0))
+}
+
 func configuredOrDefault(configured *atomic.Int64, fallback time.Duration) time.Duration {
 	if secs := configured.Load(); secs > 0 {
 		return time.Duration(secs) * time.Second
@@ -109,8 +131,13 @@ func NewHTTPClient(config *ClientConfig) *http.Client {
 		config = &cfg
 	}
 
+	proxy := http.ProxyFromEnvironment
+	if config.Proxy != nil {
+		proxy = http.ProxyURL(config.Proxy)
+	}
+
 	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: proxy,
 		DialContext: (&net.Dialer{
 			Timeout:   config.DialTimeout,
 			KeepAlive: config.KeepAlive,
@@ -130,6 +157,22 @@ func NewHTTPClient(config *ClientConfig) *http.Client {
 	}
 }
 
+// ParseProxyURL validates a proxy URL from configuration. Only http:// and
+// https:// proxies are accepted, and the URL must name a host.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http or https", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", u.Redacted())
+	}
+	return u, nil
+}
+
 // NewDefaultHTTPClient creates a new HTTP client with default configuration.
 // This is a convenience function equivalent to NewHTTPClient(nil).
 func NewDefaultHTTPClient() *http.Client {
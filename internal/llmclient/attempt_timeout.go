@@ -0,0 +1,83 @@
+package llmclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// ErrAttemptTimeout is wrapped by the error an upstream attempt returns when
+// it runs longer than Request.Timeout.
+var ErrAttemptTimeout = errors.New("upstream attempt timeout")
+
+// attemptTimer bounds a single upstream attempt by Request.Timeout. Unlike a
+// deadline on the caller's context, it only cancels the current attempt, so
+// retryable requests move on to the next attempt instead of failing outright.
+type attemptTimer struct {
+	timer  *time.Timer
+	cancel context.CancelFunc
+	fired  atomic.Bool
+}
+
+// startAttempt derives the context for one attempt. A zero timeout returns
+// ctx unchanged and a nil timer; all attemptTimer methods accept nil. The
+// parent context's own deadline still applies, so the shorter of the two ends
+// the attempt.
+func startAttempt(ctx context.Context, timeout time.Duration) (context.Context, *attemptTimer) {
+	if timeout <= 0 {
+		return ctx, nil
+	}
+	attemptCtx, cancel := context.WithCancel(ctx)
+	t := &attemptTimer{cancel: cancel}
+	t.timer = time.AfterFunc(timeout, func() {
+		t.fired.Store(true)
+		cancel()
+	})
+	return attemptCtx, t
+}
+
+// disarm stops the timer and reports whether it had already cut the attempt
+// off. Callers disarm once they hold what they need from the attempt: the
+// full body for buffered requests, or response headers for streams, whose
+// body may legitimately outlive the timeout.
+func (t *attemptTimer) disarm() bool {
+	if t == nil {
+		return false
+	}
+	t.timer.Stop()
+	return t.fired.Load()
+}
+
+// done releases the attempt context.
+func (t *attemptTimer) done() {
+	if t == nil {
+		return
+	}
+	t.timer.Stop()
+	t.cancel()
+}
+
+// chainRelease defers done until release runs, for attempts whose live body
+// is handed back to the caller.
+func (t *attemptTimer) chainRelease(release func()) func() {
+	if t == nil {
+		return release
+	}
+	return func() {
+		t.done()
+		if release != nil {
+			release()
+		}
+	}
+}
+
+// attemptTimeoutError builds the retryable error for an attempt that exceeded
+// Request.Timeout.
+func (c *Client) attemptTimeoutError(timeout time.Duration) error {
+	return core.NewProviderError(c.config.ProviderName, http.StatusGatewayTimeout,
+		"upstream attempt exceeded "+timeout.String(), ErrAttemptTimeout)
+}
@@ -120,6 +120,18 @@ func (cb *circuitBreaker) RecordFailure() {
 	}
 }
 
+// reset force-closes the circuit and clears its counters, as if the provider
+// had never failed.
+func (cb *circuitBreaker) reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = circuitClosed
+	cb.failures = 0
+	cb.successes = 0
+	cb.halfOpenAllowed = true
+}
+
 // State returns the current circuit state (for testing/monitoring)
 func (cb *circuitBreaker) State() string {
 	cb.mu.Lock()
@@ -136,6 +148,31 @@ func (cb *circuitBreaker) State() string {
 	return "unknown"
 }
 
+// WorstCircuitState returns the most severe of states ("open" over
+// "half-open" over "closed"), ignoring "" entries from clients without a
+// breaker. Providers that fan out over several clients use it to report one
+// state.
+func WorstCircuitState(states ...string) string {
+	worst := ""
+	rank := func(state string) int {
+		switch state {
+		case "open":
+			return 3
+		case "half-open":
+			return 2
+		case "closed":
+			return 1
+		}
+		return 0
+	}
+	for _, state := range states {
+		if rank(state) > rank(worst) {
+			worst = state
+		}
+	}
+	return worst
+}
+
 func (cb *circuitBreaker) IsHalfOpen() bool {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
@@ -3,10 +3,12 @@
 // - Retries with exponential backoff and jitter
 // - Standardized error parsing (429, 502, 503, 504)
 // - Circuit breaking with half-open state protection
+// - Request queueing and tokens-per-minute pacing per provider
 package llmclient
 
 import (
 	"bytes"
+	"container/list"
 	"context"
 	"errors"
 	"fmt"
@@ -15,11 +17,14 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
+	"github.com/tidwall/gjson"
 
 	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/core"
@@ -33,6 +38,9 @@ type RequestInfo struct {
 	Endpoint string // API endpoint (e.g., "/chat/completions", "/models")
 	Method   string // HTTP method (e.g., "POST", "GET")
 	Stream   bool   // Whether this is a streaming request
+	// RequestID is the gateway request ID carried by the context (empty when
+	// none), for correlating upstream calls with client requests.
+	RequestID string
 }
 
 // ResponseInfo contains metadata about a response for observability hooks
@@ -49,6 +57,9 @@ type ResponseInfo struct {
 	// disabled. It reflects the moment of completion, so metrics built from it
 	// update as traffic flows.
 	CircuitState string
+	// RequestID is the gateway request ID carried by the context (empty when
+	// none), for correlating upstream errors with client requests.
+	RequestID string
 }
 
 // Hooks defines observability callbacks for request lifecycle events.
@@ -71,11 +82,29 @@ type Config struct {
 	BaseURL string
 	// Retry specifies retry behaviour for failed requests, including backoff and jitter settings.
 	Retry config.RetryConfig
+	// RetryableStatusCodes overrides the HTTP statuses that are retried. Nil
+	// or empty keeps the default set: 429, 502, 503 and 504. Statuses in the
+	// set also count as circuit breaker failures, like every 5xx does.
+	RetryableStatusCodes []int
 	// CircuitBreaker configures the circuit breaker that prevents cascading failures by
 	// stopping requests to an unhealthy provider until it recovers.
 	CircuitBreaker config.CircuitBreakerConfig
 	// Hooks provides optional observability callbacks invoked on request start and end.
 	Hooks Hooks
+	// Queue bounds concurrent requests and queues the excess in FIFO order.
+	// Share one queue across every client of a provider; nil is unbounded.
+	Queue *RequestQueue
+	// TokenPacer paces requests to a tokens-per-minute budget. Share one pacer
+	// across every client of a provider; nil disables pacing.
+	TokenPacer *TokenPacer
+	// StreamIdleTimeout aborts a DoStream body that receives no upstream bytes
+	// for this long. Zero inherits httpclient.StreamIdleTimeout(); a negative
+	// value disables the check for this client.
+	StreamIdleTimeout time.Duration
+	// HTTPClient sends the requests. Nil uses httpclient.NewDefaultHTTPClient;
+	// providers set it to apply per-provider transport settings such as a
+	// proxy.
+	HTTPClient *http.Client
 }
 
 // DefaultConfig returns default client configuration
@@ -88,6 +117,16 @@ func DefaultConfig(providerName, baseURL string) Config {
 	}
 }
 
+// maxSlowBreakers bounds the per-model slow-call breakers one client keeps.
+// When a new model would exceed it, the least recently used breaker is
+// dropped; that model starts with a fresh breaker if it is seen again.
+const maxSlowBreakers = 256
+
+type slowBreakerEntry struct {
+	model   string
+	breaker *circuitBreaker
+}
+
 // HeaderSetter is a function that sets headers on an HTTP request
 type HeaderSetter func(req *http.Request)
 
@@ -98,12 +137,29 @@ type Client struct {
 	config         Config
 	headerSetter   HeaderSetter
 	circuitBreaker *circuitBreaker
+	// slowBreakers holds one breaker per model for slow-call detection, so a
+	// model that answers too slowly is cut off without taking its provider's
+	// other models down with it. Model names come from requests, so at most
+	// maxSlowBreakers are kept; slowOrder lists them most recently used first.
+	slowMu       sync.Mutex
+	slowBreakers map[string]*list.Element // of *slowBreakerEntry
+	slowOrder    *list.List
+	// wait sleeps between retry attempts; nil means waitForRetry. Tests
+	// replace it to observe backoff delays without sleeping.
+	wait func(ctx context.Context, delay time.Duration) error
 }
 
 // New creates a new LLM client with the given configuration
 func New(cfg Config, headerSetter HeaderSetter) *Client {
+	if cfg.StreamIdleTimeout == 0 {
+		cfg.StreamIdleTimeout = httpclient.StreamIdleTimeout()
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = httpclient.NewDefaultHTTPClient()
+	}
 	c := &Client{
-		httpClient:   httpclient.NewDefaultHTTPClient(),
+		httpClient:   httpClient,
 		config:       cfg,
 		headerSetter: headerSetter,
 	}
@@ -126,6 +182,63 @@ func NewWithHTTPClient(httpClient *http.Client, cfg Config, headerSetter HeaderS
 	return c
 }
 
+// ResetCircuit force-closes the client's circuit breaker so traffic flows
+// immediately instead of waiting for the open timeout and half-open probes.
+// It returns the resulting state ("closed"), or "" when the breaker is
+// disabled.
+func (c *Client) ResetCircuit() string {
+	if c.circuitBreaker == nil {
+		return ""
+	}
+	c.circuitBreaker.reset()
+	c.slowMu.Lock()
+	clear(c.slowBreakers)
+	if c.slowOrder != nil {
+		c.slowOrder.Init()
+	}
+	c.slowMu.Unlock()
+	return c.circuitBreaker.State()
+}
+
+// slowBreaker returns the slow-call breaker for model, or nil when slow-call
+// detection is off. It shares the provider breaker's thresholds and timeout.
+func (c *Client) slowBreaker(model string) *circuitBreaker {
+	if c.circuitBreaker == nil || c.config.CircuitBreaker.SlowCallThreshold <= 0 {
+		return nil
+	}
+	c.slowMu.Lock()
+	defer c.slowMu.Unlock()
+	if elem, ok := c.slowBreakers[model]; ok {
+		c.slowOrder.MoveToFront(elem)
+		return elem.Value.(*slowBreakerEntry).breaker
+	}
+	if c.slowBreakers == nil {
+		c.slowBreakers = make(map[string]*list.Element)
+		c.slowOrder = list.New()
+	}
+	if c.slowOrder.Len() >= maxSlowBreakers {
+		oldest := c.slowOrder.Back()
+		c.slowOrder.Remove(oldest)
+		delete(c.slowBreakers, oldest.Value.(*slowBreakerEntry).model)
+	}
+	cb := newCircuitBreaker(
+		c.config.CircuitBreaker.FailureThreshold,
+		c.config.CircuitBreaker.SuccessThreshold,
+		c.config.CircuitBreaker.Timeout,
+	)
+	c.slowBreakers[model] = c.slowOrder.PushFront(&slowBreakerEntry{model: model, breaker: cb})
+	return cb
+}
+
+// CircuitState reports the circuit breaker state ("closed", "open" or
+// "half-open"), or "" when the client has no circuit breaker enabled.
+func (c *Client) CircuitState() string {
+	if c.circuitBreaker == nil {
+		return ""
+	}
+	return c.circuitBreaker.State()
+}
+
 // SetBaseURL updates the base URL (thread-safe)
 func (c *Client) SetBaseURL(url string) {
 	c.mu.Lock()
@@ -150,6 +263,12 @@ type Request struct {
 	// It is intended for one-shot passthrough requests and is not replayable for retries.
 	RawBodyReader io.Reader
 	Headers       http.Header
+	// Timeout bounds each upstream attempt rather than the whole logical
+	// request: an attempt that exceeds it is abandoned and, when retryable,
+	// retried. The caller's context still bounds the request as a whole, so
+	// the shorter of the two wins. For DoStream and DoPassthrough it covers
+	// the wait for response headers only. Zero means no per-attempt limit.
+	Timeout time.Duration
 }
 
 // Response represents an HTTP response
@@ -170,8 +289,9 @@ type Response struct {
 const maxErrorBodyBytes = 64 * 1024
 
 // attachResponseHeaders records the upstream response headers on a provider
-// GatewayError so failed attempts can be audited. It is a no-op for other
-// error types or a nil header set.
+// GatewayError so failed attempts can be audited, and keeps the upstream
+// Retry-After hint on rate-limit errors so it can be relayed to the client.
+// It is a no-op for other error types or a nil header set.
 func attachResponseHeaders(err error, header http.Header) error {
 	if header == nil {
 		return err
@@ -179,15 +299,35 @@ func attachResponseHeaders(err error, header http.Header) error {
 	var gatewayErr *core.GatewayError
 	if errors.As(err, &gatewayErr) && gatewayErr != nil {
 		gatewayErr.ResponseHeaders = header.Clone()
+		if gatewayErr.StatusCode == http.StatusTooManyRequests {
+			if retryAfter := parseRetryAfter(header, time.Now()); retryAfter > 0 {
+				gatewayErr.RetryAfter = retryAfter
+			}
+		}
 	}
 	return err
 }
 
 type requestScope struct {
-	ctx           context.Context
-	startedAt     time.Time
-	requestInfo   RequestInfo
-	halfOpenProbe bool
+	ctx       context.Context
+	startedAt time.Time
+	// attemptStartedAt marks when the latest upstream attempt began, so
+	// slow-call detection ignores queue waits and earlier retries.
+	attemptStartedAt time.Time
+	requestInfo      RequestInfo
+	halfOpenProbe    bool
+	// slowBreaker is the model's slow-call breaker, nil when slow-call
+	// detection is off; slowProbe reports whether this request holds its
+	// half-open probe.
+	slowBreaker *circuitBreaker
+	slowProbe   bool
+	// tokens is the TokenPacer charge, settled against reported usage when
+	// the response is available and refunded otherwise; nil once ownership
+	// has moved to a returned stream body.
+	tokens *tokenReservation
+	// release frees the request queue slot; nil once ownership has moved to
+	// a returned stream body.
+	release func()
 }
 
 func (c *Client) beginRequest(ctx context.Context, req Request, stream bool) (requestScope, error) {
@@ -195,11 +335,12 @@ func (c *Client) beginRequest(ctx context.Context, req Request, stream bool) (re
 		ctx:       ctx,
 		startedAt: time.Now(),
 		requestInfo: RequestInfo{
-			Provider: c.config.ProviderName,
-			Model:    extractModel(req.Body),
-			Endpoint: req.Endpoint,
-			Method:   req.Method,
-			Stream:   stream,
+			Provider:  c.config.ProviderName,
+			Model:     extractModel(req.Body),
+			Endpoint:  req.Endpoint,
+			Method:    req.Method,
+			Stream:    stream,
+			RequestID: core.GetRequestID(ctx),
 		},
 	}
 
@@ -207,6 +348,14 @@ func (c *Client) beginRequest(ctx context.Context, req Request, stream bool) (re
 		scope.ctx = c.config.Hooks.OnRequestStart(scope.ctx, scope.requestInfo)
 	}
 
+	release, err := c.config.Queue.Acquire(scope.ctx)
+	if err != nil {
+		err = c.queueError(err)
+		c.finishRequest(scope, extractStatusCode(err), err)
+		return requestScope{}, err
+	}
+	scope.release = release
+
 	if c.circuitBreaker != nil {
 		allowed, probe := c.circuitBreaker.acquire()
 		if !allowed {
@@ -217,11 +366,39 @@ func (c *Client) beginRequest(ctx context.Context, req Request, stream bool) (re
 		}
 		scope.halfOpenProbe = probe
 	}
+	if slow := c.slowBreaker(scope.requestInfo.Model); slow != nil {
+		allowed, probe := slow.acquire()
+		if !allowed {
+			c.releaseHalfOpenProbe(scope)
+			err := core.NewProviderError(c.config.ProviderName, http.StatusServiceUnavailable,
+				"circuit breaker is open - model "+scope.requestInfo.Model+" is responding too slowly", nil)
+			c.finishRequest(scope, http.StatusServiceUnavailable, err)
+			return requestScope{}, err
+		}
+		scope.slowBreaker = slow
+		scope.slowProbe = probe
+	}
+
+	// Pace only admitted requests, so a request the queue or a breaker turns
+	// away never holds token budget.
+	reserved, err := c.config.TokenPacer.Reserve(scope.ctx, c.pacedRequestTokens(req))
+	if err != nil {
+		err = c.queueError(err)
+		c.releaseHalfOpenProbe(scope)
+		c.finishRequest(scope, extractStatusCode(err), err)
+		return requestScope{}, err
+	}
+	scope.tokens = c.config.TokenPacer.reservation(reserved)
 
 	return scope, nil
 }
 
 func (c *Client) finishRequest(scope requestScope, statusCode int, err error) {
+	if scope.release != nil {
+		scope.release()
+	}
+	// A settled reservation ignores the refund.
+	scope.tokens.refund()
 	if c.config.Hooks.OnRequestEnd == nil {
 		return
 	}
@@ -238,6 +415,7 @@ func (c *Client) finishRequest(scope requestScope, statusCode int, err error) {
 		Stream:       scope.requestInfo.Stream,
 		Error:        err,
 		CircuitState: circuitState,
+		RequestID:    scope.requestInfo.RequestID,
 	})
 }
 
@@ -265,6 +443,9 @@ func (c *Client) releaseHalfOpenProbe(scope requestScope) {
 	if c.circuitBreaker != nil && scope.halfOpenProbe {
 		c.circuitBreaker.releaseProbe()
 	}
+	if scope.slowBreaker != nil && scope.slowProbe {
+		scope.slowBreaker.releaseProbe()
+	}
 }
 
 // failAfterRetries handles the "exhausted retries with no captured error"
@@ -276,11 +457,15 @@ func (c *Client) failAfterRetries(scope requestScope) error {
 	return err
 }
 
-// waitForRetryAttempt sleeps for the per-attempt backoff (a no-op for
-// attempt 0) and finalises the scope if the context cancels mid-wait. The
-// caller should return early when this returns a non-nil error.
-func (c *Client) waitForRetryAttempt(ctx context.Context, scope requestScope, attempt int) error {
-	if err := c.waitForRetry(ctx, attempt); err != nil {
+// waitForRetryAttempt sleeps for the delay chosen by nextRetry (a no-op for
+// the first attempt) and finalises the scope if the context cancels mid-wait.
+// The caller should return early when this returns a non-nil error.
+func (c *Client) waitForRetryAttempt(ctx context.Context, scope requestScope, delay time.Duration) error {
+	wait := c.wait
+	if wait == nil {
+		wait = waitForRetry
+	}
+	if err := wait(ctx, delay); err != nil {
 		c.finishRequest(scope, 0, err)
 		return err
 	}
@@ -288,6 +473,7 @@ func (c *Client) waitForRetryAttempt(ctx context.Context, scope requestScope, at
 }
 
 func (c *Client) recordCircuitBreakerCompletion(scope requestScope, statusCode int, err error) {
+	c.recordSlowCallCompletion(scope, statusCode, err)
 	if c.circuitBreaker == nil {
 		return
 	}
@@ -297,7 +483,9 @@ func (c *Client) recordCircuitBreakerCompletion(scope requestScope, statusCode i
 		// Client deadlines (context.DeadlineExceeded) still count: the
 		// provider failed to answer within the latency budget.
 		if errors.Is(err, context.Canceled) {
-			c.releaseHalfOpenProbe(scope)
+			if scope.halfOpenProbe {
+				c.circuitBreaker.releaseProbe()
+			}
 			return
 		}
 		c.circuitBreaker.RecordFailure()
@@ -316,6 +504,43 @@ func (c *Client) recordCircuitBreakerCompletion(scope requestScope, statusCode i
 	c.circuitBreaker.RecordSuccess()
 }
 
+// recordSlowCallCompletion charges the model's slow-call breaker with a
+// successful response's latency: a slow one counts as a failure, a fast one
+// resets the count. Errors are the provider breaker's concern, so they only
+// return a held probe slot.
+func (c *Client) recordSlowCallCompletion(scope requestScope, statusCode int, err error) {
+	slow := scope.slowBreaker
+	if slow == nil {
+		return
+	}
+	switch {
+	case err != nil || statusCode >= http.StatusBadRequest:
+		if scope.slowProbe {
+			slow.releaseProbe()
+		}
+	case c.isSlowCall(scope):
+		slow.RecordFailure()
+	default:
+		slow.RecordSuccess()
+	}
+}
+
+// isSlowCall reports whether the latest upstream attempt took longer than the
+// configured slow-call threshold. A model that answers, but too slowly for
+// interactive use, is charged a failure on its slow-call breaker. For
+// streams the measured latency is time to response headers.
+func (c *Client) isSlowCall(scope requestScope) bool {
+	threshold := c.config.CircuitBreaker.SlowCallThreshold
+	if threshold <= 0 {
+		return false
+	}
+	startedAt := scope.attemptStartedAt
+	if startedAt.IsZero() {
+		startedAt = scope.startedAt
+	}
+	return time.Since(startedAt) > threshold
+}
+
 func (c *Client) shouldTripCircuitBreaker(statusCode int) bool {
 	if statusCode == http.StatusTooManyRequests {
 		return false
@@ -331,15 +556,33 @@ func (c *Client) maxAttempts() int {
 	return maxAttempts
 }
 
-func (c *Client) waitForRetry(ctx context.Context, attempt int) error {
-	if attempt <= 0 {
+// nextRetry decides whether the attempt that just failed may be retried and
+// returns the backoff to wait first. retryAfter is the failed response's
+// Retry-After hint; negative means none. A retry is refused once attempts
+// run out or, with Retry.MaxElapsedTime set, when its backoff would end past
+// the request's time budget.
+func (c *Client) nextRetry(scope requestScope, attempt, maxAttempts int, retryAfter time.Duration) (time.Duration, bool) {
+	if attempt+1 >= maxAttempts {
+		return 0, false
+	}
+	delay := c.calculateBackoff(attempt + 1)
+	if retryAfter >= 0 {
+		delay = min(retryAfter, c.config.Retry.MaxBackoff)
+	}
+	if budget := c.config.Retry.MaxElapsedTime; budget > 0 && time.Since(scope.startedAt)+delay >= budget {
+		return 0, false
+	}
+	return delay, true
+}
+
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
 		return nil
 	}
-	backoff := c.calculateBackoff(attempt)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.After(backoff):
+	case <-time.After(delay):
 		return nil
 	}
 }
@@ -393,18 +636,25 @@ func (c *Client) DoRaw(ctx context.Context, req Request) (*Response, error) {
 	var lastErr error
 	var lastStatusCode int
 	lastErrFromTransport := false
+	var delay time.Duration
 	maxAttempts := c.maxAttempts()
 	if req.RawBodyReader != nil {
 		maxAttempts = 1
 	}
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
-		if err := c.waitForRetryAttempt(ctx, scope, attempt); err != nil {
+		if err := c.waitForRetryAttempt(ctx, scope, delay); err != nil {
 			closeRawBodyReader(req)
 			return nil, err
 		}
 
-		resp, err := c.doRequest(ctx, req)
+		scope.attemptStartedAt = time.Now()
+		attemptCtx, timer := startAttempt(ctx, req.Timeout)
+		resp, err := c.doRequest(attemptCtx, req)
+		if timer.disarm() && err != nil {
+			err = c.attemptTimeoutError(req.Timeout)
+		}
+		timer.done()
 		if err != nil {
 			lastErr = err
 			lastStatusCode = extractStatusCode(err)
@@ -424,18 +674,30 @@ func (c *Client) DoRaw(ctx context.Context, req Request) (*Response, error) {
 				c.completeScope(scope, lastStatusCode, lastErr, lastErr)
 				return nil, lastErr
 			}
+			var retry bool
+			if delay, retry = c.nextRetry(scope, attempt, maxAttempts, -1); !retry {
+				break
+			}
 			continue
 		}
 
-		// Check for retryable status codes
-		if c.isRetryable(resp.StatusCode) {
+		// Check for retryable status codes and configured body error codes
+		if c.isRetryable(resp.StatusCode) || c.isRetryableBodyError(resp.Body) {
 			lastErr = attachResponseHeaders(core.ParseProviderError(c.config.ProviderName, resp.StatusCode, resp.Body, nil), resp.Header)
 			lastStatusCode = resp.StatusCode
+			if resp.StatusCode < http.StatusBadRequest {
+				// A body error on a 2xx is reported as the 502 it parses to.
+				lastStatusCode = extractStatusCode(lastErr)
+			}
 			lastErrFromTransport = false
 			if scope.halfOpenProbe {
 				c.completeScope(scope, lastStatusCode, lastErr, nil)
 				return nil, lastErr
 			}
+			var retry bool
+			if delay, retry = c.nextRetry(scope, attempt, maxAttempts, parseRetryAfter(resp.Header, time.Now())); !retry {
+				break
+			}
 			continue
 		}
 
@@ -447,11 +709,12 @@ func (c *Client) DoRaw(ctx context.Context, req Request) (*Response, error) {
 		}
 
 		// Success
+		c.settleTokens(scope, resp.Body)
 		c.completeScope(scope, resp.StatusCode, nil, nil)
 		return resp, nil
 	}
 
-	// All retries exhausted
+	// All retries exhausted, or the retry time budget ran out
 	if lastErr != nil {
 		var circuitErr error
 		if lastErrFromTransport {
@@ -466,6 +729,10 @@ func (c *Client) DoRaw(ctx context.Context, req Request) (*Response, error) {
 // DoStream executes a streaming request, returning a ReadCloser
 // Note: Streaming requests do NOT retry (as partial data may have been sent)
 // Metrics note: Duration is measured from start to stream establishment, not stream close
+//
+// When StreamIdleTimeout is positive, a read that waits longer than that for
+// upstream bytes fails with an error wrapping ErrStreamIdleTimeout and charges
+// a failure to the circuit breaker.
 func (c *Client) DoStream(ctx context.Context, req Request) (io.ReadCloser, error) {
 	scope, err := c.beginRequest(ctx, req, true)
 	if err != nil {
@@ -473,8 +740,14 @@ func (c *Client) DoStream(ctx context.Context, req Request) (io.ReadCloser, erro
 		return nil, err
 	}
 
-	resp, err := c.doHTTPRequest(scope.ctx, req)
+	scope.attemptStartedAt = time.Now()
+	attemptCtx, timer := startAttempt(scope.ctx, req.Timeout)
+	resp, err := c.doHTTPRequest(attemptCtx, req)
+	if timer.disarm() && err != nil {
+		err = c.attemptTimeoutError(req.Timeout)
+	}
 	if err != nil {
+		timer.done()
 		statusCode := extractStatusCode(err)
 		// Caller-side build errors never reached the upstream — skip the
 		// breaker entirely so neither RecordFailure nor RecordSuccess fires.
@@ -485,6 +758,7 @@ func (c *Client) DoStream(ctx context.Context, req Request) (io.ReadCloser, erro
 		c.completeScope(scope, statusCode, err, err)
 		return nil, err
 	}
+	decodeContentEncoding(resp)
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, readErr := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
@@ -492,6 +766,7 @@ func (c *Client) DoStream(ctx context.Context, req Request) (io.ReadCloser, erro
 			respBody = []byte("failed to read error response")
 		}
 		_ = resp.Body.Close()
+		timer.done()
 
 		providerErr := attachResponseHeaders(core.ParseProviderError(c.config.ProviderName, resp.StatusCode, respBody, nil), resp.Header)
 		c.completeScope(scope, resp.StatusCode, providerErr, nil)
@@ -507,8 +782,16 @@ func (c *Client) DoStream(ctx context.Context, req Request) (io.ReadCloser, erro
 		resp.Request.GetBody = nil
 	}
 
+	release := timer.chainRelease(scope.release)
+	scope.release = nil
+	tokens := scope.tokens
+	scope.tokens = nil
 	c.completeScope(scope, resp.StatusCode, nil, nil)
-	return resp.Body, nil
+	body := resp.Body
+	if c.config.StreamIdleTimeout > 0 {
+		body = newIdleTimeoutBody(body, c.config.StreamIdleTimeout, c.streamIdleTimeoutError)
+	}
+	return releaseOnClose(settleOnStreamClose(body, tokens), release), nil
 }
 
 func canRetryPassthrough(req Request) bool {
@@ -555,15 +838,22 @@ func (c *Client) DoPassthrough(ctx context.Context, req Request) (*http.Response
 	if canRetryPassthrough(req) {
 		maxAttempts = c.maxAttempts()
 	}
+	var delay time.Duration
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
-		if err := c.waitForRetryAttempt(ctx, scope, attempt); err != nil {
+		if err := c.waitForRetryAttempt(ctx, scope, delay); err != nil {
 			closeRawBodyReader(req)
 			return nil, err
 		}
 
-		resp, err := c.doHTTPRequest(ctx, req)
+		scope.attemptStartedAt = time.Now()
+		attemptCtx, timer := startAttempt(ctx, req.Timeout)
+		resp, err := c.doHTTPRequest(attemptCtx, req)
+		if timer.disarm() && err != nil {
+			err = c.attemptTimeoutError(req.Timeout)
+		}
 		if err != nil {
+			timer.done()
 			statusCode := extractStatusCode(err)
 			// Caller-side build errors will repeat and never hit the upstream;
 			// skip the breaker entirely (cbErr=nil would otherwise record a
@@ -572,24 +862,44 @@ func (c *Client) DoPassthrough(ctx context.Context, req Request) (*http.Response
 				c.finishRequestWithoutBreaker(scope, statusCode, err)
 				return nil, err
 			}
-			if scope.halfOpenProbe || isClientTimeoutGatewayError(err) || attempt == maxAttempts-1 {
+			var retry bool
+			if !scope.halfOpenProbe && !isClientTimeoutGatewayError(err) {
+				delay, retry = c.nextRetry(scope, attempt, maxAttempts, -1)
+			}
+			if !retry {
 				c.completeScope(scope, statusCode, err, err)
 				return nil, err
 			}
 			continue
 		}
 
-		retryable := c.isRetryable(resp.StatusCode)
-		if retryable {
-			if scope.halfOpenProbe || attempt == maxAttempts-1 {
-				c.completeScope(scope, resp.StatusCode, nil, nil)
-				return resp, nil
+		if c.isRetryable(resp.StatusCode) && !scope.halfOpenProbe {
+			if next, retry := c.nextRetry(scope, attempt, maxAttempts, parseRetryAfter(resp.Header, time.Now())); retry {
+				delay = next
+				_ = resp.Body.Close()
+				timer.done()
+				continue
 			}
-			_ = resp.Body.Close()
-			continue
 		}
 
+		// The caller reads the body after we return, so the queue slot is
+		// held until it is closed. A streamed body settles its token
+		// reservation from the usage it reports; other successful bodies are
+		// proxied unread and keep the reservation as charged.
+		release := timer.chainRelease(scope.release)
+		scope.release = nil
+		tokens := scope.tokens
+		scope.tokens = nil
 		c.completeScope(scope, resp.StatusCode, nil, nil)
+		switch {
+		case resp.StatusCode != http.StatusOK:
+			tokens.refund()
+		case scope.requestInfo.Stream:
+			resp.Body = settleOnStreamClose(resp.Body, tokens)
+		default:
+			tokens.settle(0, 0)
+		}
+		resp.Body = releaseOnClose(resp.Body, release)
 		return resp, nil
 	}
 
@@ -655,9 +965,24 @@ func (c *Client) doHTTPRequest(ctx context.Context, req Request) (*http.Response
 	if err != nil {
 		return nil, core.NewProviderError(c.config.ProviderName, providerErrorStatusCode(err), "failed to send request: "+err.Error(), err)
 	}
+	core.RecordUpstreamRequestID(ctx, upstreamRequestID(resp.Header))
 	return resp, nil
 }
 
+// upstreamRequestIDHeaders lists the headers providers use to identify a
+// request for support tickets, in lookup order: OpenAI-compatible APIs send
+// x-request-id, Anthropic sends request-id, and AWS sends x-amzn-requestid.
+var upstreamRequestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Amzn-Requestid"}
+
+func upstreamRequestID(header http.Header) string {
+	for _, name := range upstreamRequestIDHeaders {
+		if id := strings.TrimSpace(header.Get(name)); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
 // closeRawBodyReader releases a caller-supplied streaming body when the
 // request fails before reaching the HTTP transport, which otherwise closes it
 // on every path. Pipe-backed uploads (files, audio transcription) rely on
@@ -677,6 +1002,7 @@ func (c *Client) doRequest(ctx context.Context, req Request) (*Response, error)
 	if err != nil {
 		return nil, err
 	}
+	decodeContentEncoding(resp)
 	defer func() {
 		_ = resp.Body.Close()
 	}()
@@ -692,6 +1018,9 @@ func (c *Client) doRequest(ctx context.Context, req Request) (*Response, error)
 	if err != nil {
 		return nil, core.NewProviderError(c.config.ProviderName, providerErrorStatusCode(err), "failed to read response: "+err.Error(), err)
 	}
+	if resp.StatusCode < http.StatusBadRequest {
+		core.RecordRawResponse(ctx, resp.Header.Get("Content-Type"), body)
+	}
 
 	return &Response{
 		StatusCode:  resp.StatusCode,
@@ -751,6 +1080,9 @@ func (c *Client) buildRequest(ctx context.Context, req Request) (*http.Request,
 	if err != nil {
 		return nil, core.NewInvalidRequestError("failed to create request", err)
 	}
+	if err := checkUpstreamHost(httpReq); err != nil {
+		return nil, err
+	}
 
 	// Set default content type for requests with body
 	if req.Body != nil {
@@ -790,8 +1122,35 @@ func (c *Client) calculateBackoff(attempt int) time.Duration {
 	return time.Duration(backoff)
 }
 
+// parseRetryAfter returns the wait requested by a Retry-After header, given
+// either as delay-seconds or as an HTTP-date. A date in the past yields 0. It
+// returns -1 when the header is absent or malformed so the caller falls back
+// to exponential backoff.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return -1
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return -1
+		}
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return time.Duration(math.MaxInt64)
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return -1
+}
+
 // isRetryable returns true if the status code indicates a retryable error
 func (c *Client) isRetryable(statusCode int) bool {
+	if codes := c.config.RetryableStatusCodes; len(codes) > 0 {
+		return slices.Contains(codes, statusCode)
+	}
 	// Retry on rate limits and specific server errors that are typically transient
 	return statusCode == http.StatusTooManyRequests ||
 		statusCode == http.StatusServiceUnavailable ||
@@ -799,6 +1158,32 @@ func (c *Client) isRetryable(statusCode int) bool {
 		statusCode == http.StatusGatewayTimeout
 }
 
+// isRetryableBodyError reports whether body carries an error.type or
+// error.code listed in Retry.BodyErrorCodes. Bodies are only inspected when
+// codes are configured.
+func (c *Client) isRetryableBodyError(body []byte) bool {
+	codes := c.config.Retry.BodyErrorCodes
+	if len(codes) == 0 {
+		return false
+	}
+	errObj := gjson.GetBytes(body, "error")
+	if !errObj.IsObject() {
+		return false
+	}
+	errType := strings.TrimSpace(errObj.Get("type").String())
+	errCode := strings.TrimSpace(errObj.Get("code").String())
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		if strings.EqualFold(code, errType) || strings.EqualFold(code, errCode) {
+			return true
+		}
+	}
+	return false
+}
+
 func providerErrorStatusCode(err error) int {
 	if isTimeoutError(err) {
 		return http.StatusGatewayTimeout
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -434,6 +436,105 @@ func TestClient_DoRaw_DoesNotRetryRawBodyReader(t *testing.T) {
 	}
 }
 
+func TestClient_DoRaw_RetriesConfiguredBodyErrorCodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		codes        []string
+		status       int
+		body         string
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "overloaded type on 200 retries",
+			codes:        []string{"overloaded_error"},
+			status:       http.StatusOK,
+			body:         `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+			wantAttempts: 2,
+		},
+		{
+			name:         "code on 400 retries",
+			codes:        []string{"server_busy"},
+			status:       http.StatusBadRequest,
+			body:         `{"error":{"code":"server_busy","message":"try again"}}`,
+			wantAttempts: 2,
+		},
+		{
+			name:         "body inspection off by default",
+			status:       http.StatusOK,
+			body:         `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+			wantAttempts: 1,
+		},
+		{
+			name:         "unlisted code is not retried",
+			codes:        []string{"overloaded_error"},
+			status:       http.StatusBadRequest,
+			body:         `{"error":{"type":"invalid_request_error","message":"bad"}}`,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+					return
+				}
+				_, _ = w.Write([]byte(`{"status":"ok"}`))
+			}))
+			defer server.Close()
+
+			config := DefaultConfig("test", server.URL)
+			config.Retry.MaxRetries = 3
+			config.Retry.InitialBackoff = time.Millisecond
+			config.Retry.JitterFactor = 0
+			config.Retry.BodyErrorCodes = tt.codes
+			client := New(config, nil)
+
+			_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DoRaw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_DoRaw_BodyErrorRetriesExhaustedReturnBadGateway(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 2
+	config.Retry.InitialBackoff = time.Millisecond
+	config.Retry.JitterFactor = 0
+	config.Retry.BodyErrorCodes = []string{"overloaded_error"}
+	client := New(config, nil)
+
+	_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		t.Fatalf("expected GatewayError, got %T: %v", err, err)
+	}
+	if gatewayErr.StatusCode != http.StatusBadGateway || gatewayErr.Message != "Overloaded" {
+		t.Fatalf("error = %d %q, want 502 \"Overloaded\"", gatewayErr.StatusCode, gatewayErr.Message)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+}
+
 func TestClient_DoPassthrough_WithRetries(t *testing.T) {
 	var attempts int32
 
@@ -947,6 +1048,210 @@ func TestCircuitBreaker_OpensAfterFailures(t *testing.T) {
 	}
 }
 
+func TestClient_ResetCircuitClosesOpenCircuit(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"message":"Server error"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 0
+	config.CircuitBreaker = goconfig.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		SuccessThreshold: 2,
+		Timeout:          time.Hour,
+	}
+	client := New(config, nil)
+
+	for range 2 {
+		_ = client.Do(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"}, nil)
+	}
+	if state := client.CircuitState(); state != "open" {
+		t.Fatalf("CircuitState() = %q, want open", state)
+	}
+
+	healthy.Store(true)
+	if state := client.ResetCircuit(); state != "closed" {
+		t.Fatalf("ResetCircuit() = %q, want closed", state)
+	}
+	if err := client.Do(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"}, nil); err != nil {
+		t.Fatalf("request after reset: %v", err)
+	}
+	if state := client.circuitBreaker.State(); state != "closed" {
+		t.Fatalf("circuit state after request = %q, want closed", state)
+	}
+}
+
+func TestClient_ResetCircuitWithoutBreaker(t *testing.T) {
+	config := DefaultConfig("test", "http://example.invalid")
+	config.CircuitBreaker = goconfig.CircuitBreakerConfig{}
+	if state := New(config, nil).ResetCircuit(); state != "" {
+		t.Fatalf("ResetCircuit() = %q, want empty when the breaker is disabled", state)
+	}
+}
+
+func TestClient_CircuitStateWithoutBreaker(t *testing.T) {
+	config := DefaultConfig("test", "http://example.invalid")
+	config.CircuitBreaker = goconfig.CircuitBreakerConfig{}
+	if state := New(config, nil).CircuitState(); state != "" {
+		t.Fatalf("CircuitState() = %q, want empty when the breaker is disabled", state)
+	}
+}
+
+func TestWorstCircuitState(t *testing.T) {
+	tests := []struct {
+		states []string
+		want   string
+	}{
+		{states: nil, want: ""},
+		{states: []string{"", ""}, want: ""},
+		{states: []string{"closed", ""}, want: "closed"},
+		{states: []string{"closed", "half-open"}, want: "half-open"},
+		{states: []string{"half-open", "open", "closed"}, want: "open"},
+	}
+	for _, tt := range tests {
+		if got := WorstCircuitState(tt.states...); got != tt.want {
+			t.Errorf("WorstCircuitState(%q) = %q, want %q", tt.states, got, tt.want)
+		}
+	}
+}
+
+// slowCallClient returns a client whose breaker counts responses slower than
+// 10ms as failures and opens after two of them.
+func slowCallClient(baseURL string) *Client {
+	config := DefaultConfig("test", baseURL)
+	config.Retry.MaxRetries = 0
+	config.CircuitBreaker = goconfig.CircuitBreakerConfig{
+		FailureThreshold:  2,
+		SuccessThreshold:  1,
+		Timeout:           time.Minute,
+		SlowCallThreshold: 10 * time.Millisecond,
+	}
+	return New(config, nil)
+}
+
+func chatRequest(model string) Request {
+	return Request{Method: http.MethodPost, Endpoint: "/chat/completions", Body: &core.ChatRequest{Model: model}}
+}
+
+func TestSlowBreaker_EvictsLeastRecentlyUsedModel(t *testing.T) {
+	client := slowCallClient("http://unused")
+
+	first := client.slowBreaker("model-0")
+	for i := 1; i < maxSlowBreakers; i++ {
+		client.slowBreaker(fmt.Sprintf("model-%d", i))
+	}
+	// Touch model-0 so model-1 becomes the least recently used.
+	if got := client.slowBreaker("model-0"); got != first {
+		t.Fatal("slowBreaker(model-0) returned a new breaker before the cap was reached")
+	}
+	client.slowBreaker("model-new")
+
+	if got := len(client.slowBreakers); got != maxSlowBreakers {
+		t.Fatalf("len(slowBreakers) = %d, want %d", got, maxSlowBreakers)
+	}
+	if _, ok := client.slowBreakers["model-1"]; ok {
+		t.Fatal("model-1 is still tracked, want it evicted as least recently used")
+	}
+	if got := client.slowBreaker("model-0"); got != first {
+		t.Fatal("slowBreaker(model-0) was evicted, want the recently used breaker kept")
+	}
+}
+
+func TestCircuitBreaker_OpensAfterSlowCalls(t *testing.T) {
+	var slow atomic.Bool
+	slow.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			time.Sleep(30 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := slowCallClient(server.URL)
+
+	req := chatRequest("slow-model")
+	if _, err := client.DoRaw(context.Background(), req); err != nil {
+		t.Fatalf("slow call should still succeed: %v", err)
+	}
+	if state := client.slowBreaker("slow-model").State(); state != "closed" {
+		t.Fatalf("expected closed after one slow call, got %s", state)
+	}
+	if _, err := client.DoRaw(context.Background(), req); err != nil {
+		t.Fatalf("slow call should still succeed: %v", err)
+	}
+	if state := client.slowBreaker("slow-model").State(); state != "open" {
+		t.Fatalf("expected open after consecutive slow calls, got %s", state)
+	}
+
+	slow.Store(false)
+	_, err := client.DoRaw(context.Background(), req)
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) || gatewayErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 circuit breaker error, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_SlowCallsOpenOnlyTheSlowModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "slow-model") {
+			time.Sleep(30 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := slowCallClient(server.URL)
+
+	for range 2 {
+		if _, err := client.DoRaw(context.Background(), chatRequest("slow-model")); err != nil {
+			t.Fatalf("slow call should still succeed: %v", err)
+		}
+	}
+	if _, err := client.DoRaw(context.Background(), chatRequest("slow-model")); err == nil {
+		t.Fatal("expected the slow model's circuit to be open")
+	}
+	if _, err := client.DoRaw(context.Background(), chatRequest("fast-model")); err != nil {
+		t.Fatalf("fast model on the same provider should still be served: %v", err)
+	}
+	if state := client.CircuitState(); state != "closed" {
+		t.Fatalf("provider circuit = %s, want closed", state)
+	}
+
+	client.ResetCircuit()
+	if _, err := client.DoRaw(context.Background(), chatRequest("slow-model")); err != nil {
+		t.Fatalf("reset should reopen the slow model: %v", err)
+	}
+}
+
+func TestCircuitBreaker_FastCallsResetSlowCount(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Alternate slow and fast responses.
+		if calls.Add(1)%2 == 1 {
+			time.Sleep(30 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := slowCallClient(server.URL)
+
+	for range 4 {
+		if _, err := client.DoRaw(context.Background(), chatRequest("model")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if state := client.slowBreaker("model").State(); state != "closed" {
+		t.Fatalf("interleaved fast calls must keep the circuit closed, got %s", state)
+	}
+}
+
 func TestCircuitBreaker_ClosesAfterTimeout(t *testing.T) {
 	var attempts int32
 	var shouldSucceed atomic.Bool
@@ -1405,6 +1710,47 @@ func TestCircuitBreaker_StateReportedToHooks(t *testing.T) {
 	}
 }
 
+// The gateway request ID on the context reaches both hooks, and the outbound
+// request carries the same context so header setters can forward it.
+func TestClient_RequestIDReportedToHooks(t *testing.T) {
+	t.Parallel()
+
+	var upstreamRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamRequestID = r.Header.Get("X-Request-ID")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var startID, endID string
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 0
+	config.Hooks = Hooks{
+		OnRequestStart: func(ctx context.Context, info RequestInfo) context.Context {
+			startID = info.RequestID
+			return ctx
+		},
+		OnRequestEnd: func(_ context.Context, info ResponseInfo) {
+			endID = info.RequestID
+		},
+	}
+	client := New(config, func(req *http.Request) {
+		req.Header.Set("X-Request-ID", core.GetRequestID(req.Context()))
+	})
+
+	ctx := core.WithRequestID(context.Background(), "req-abc")
+	if _, err := client.DoRaw(ctx, Request{Method: http.MethodGet, Endpoint: "/test"}); err == nil {
+		t.Fatal("expected upstream error")
+	}
+
+	if startID != "req-abc" || endID != "req-abc" {
+		t.Fatalf("hook request IDs = %q/%q, want req-abc", startID, endID)
+	}
+	if upstreamRequestID != "req-abc" {
+		t.Fatalf("upstream X-Request-ID = %q, want req-abc", upstreamRequestID)
+	}
+}
+
 // A caller cancellation that consumed the half-open probe slot must release
 // it; otherwise the breaker rejects every request until process restart.
 func TestCircuitBreaker_CanceledHalfOpenProbeReleasesSlot(t *testing.T) {
@@ -1804,6 +2150,207 @@ func TestBackoffCalculation(t *testing.T) {
 	}
 }
 
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "absent", value: "", want: -1},
+		{name: "delay seconds", value: "2", want: 2 * time.Second},
+		{name: "zero seconds", value: "0", want: 0},
+		{name: "whitespace trimmed", value: " 3 ", want: 3 * time.Second},
+		{name: "negative ignored", value: "-5", want: -1},
+		{name: "http date", value: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second},
+		{name: "past http date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "malformed", value: "soon", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			if got := parseRetryAfter(header, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// recordRetryDelays replaces the client's retry wait with one that records
+// each backoff delay and returns immediately.
+func recordRetryDelays(client *Client) *[]time.Duration {
+	var delays []time.Duration
+	client.wait = func(_ context.Context, delay time.Duration) error {
+		if delay > 0 {
+			delays = append(delays, delay)
+		}
+		return nil
+	}
+	return &delays
+}
+
+func TestClient_DoRaw_HonorsRetryAfter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"rate limited"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 1
+	config.Retry.InitialBackoff = time.Millisecond
+	config.Retry.MaxBackoff = 5 * time.Second
+	config.Retry.JitterFactor = 0
+	client := New(config, nil)
+	delays := recordRetryDelays(client)
+
+	if _, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"}); err != nil {
+		t.Fatalf("DoRaw() error = %v", err)
+	}
+	if !slices.Equal(*delays, []time.Duration{2 * time.Second}) {
+		t.Errorf("retry delays = %v, want the 2s Retry-After", *delays)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestClient_DoRaw_KeepsRetryAfterOnExhaustedRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"rate limited"}}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 0
+	client := New(config, nil)
+
+	_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		t.Fatalf("DoRaw() error = %v, want *core.GatewayError", err)
+	}
+	if gatewayErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, want 429", gatewayErr.StatusCode)
+	}
+	if gatewayErr.RetryAfter != 5*time.Second {
+		t.Errorf("RetryAfter = %v, want 5s", gatewayErr.RetryAfter)
+	}
+}
+
+func TestClient_DoRaw_CapsRetryAfterAtMaxBackoff(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"message":"overloaded"}}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 2
+	config.Retry.InitialBackoff = time.Millisecond
+	config.Retry.MaxBackoff = 20 * time.Millisecond
+	config.Retry.JitterFactor = 0
+	client := New(config, nil)
+	delays := recordRetryDelays(client)
+
+	if _, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"}); err == nil {
+		t.Fatal("expected error after retries")
+	}
+	if want := []time.Duration{20 * time.Millisecond, 20 * time.Millisecond}; !slices.Equal(*delays, want) {
+		t.Errorf("retry delays = %v, want Retry-After capped at MaxBackoff %v", *delays, want)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClient_DoRaw_StopsRetryingAtMaxElapsedTime(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"message":"overloaded"}}`))
+	}))
+	defer server.Close()
+
+	// Backoffs of 100ms, 200ms, 400ms, 800ms; the 250ms budget only leaves
+	// room for the first.
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 4
+	config.Retry.InitialBackoff = 100 * time.Millisecond
+	config.Retry.MaxBackoff = time.Second
+	config.Retry.BackoffFactor = 2
+	config.Retry.JitterFactor = 0
+	config.Retry.MaxElapsedTime = 250 * time.Millisecond
+	client := New(config, nil)
+
+	start := time.Now()
+	_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+	elapsed := time.Since(start)
+
+	var gwErr *core.GatewayError
+	if !errors.As(err, &gwErr) || gwErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want the last upstream 503", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+	if elapsed >= config.Retry.MaxElapsedTime {
+		t.Errorf("retries took %v, want under the %v budget", elapsed, config.Retry.MaxElapsedTime)
+	}
+}
+
+func TestClient_DoPassthrough_StopsRetryingAtMaxElapsedTime(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"attempt":` + strconv.FormatInt(int64(count), 10) + `}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 4
+	config.Retry.InitialBackoff = 100 * time.Millisecond
+	config.Retry.MaxBackoff = time.Second
+	config.Retry.BackoffFactor = 2
+	config.Retry.JitterFactor = 0
+	config.Retry.MaxElapsedTime = 250 * time.Millisecond
+	client := New(config, nil)
+
+	resp, err := client.DoPassthrough(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || string(body) != `{"attempt":2}` {
+		t.Fatalf("response = %d %q, want the second attempt's 503", resp.StatusCode, body)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
 // TestBackoffCalculation_WithJitter tests that jitter is applied correctly
 func TestBackoffCalculation_WithJitter(t *testing.T) {
 	config := DefaultConfig("test", "http://test.com")
@@ -1890,3 +2437,290 @@ func TestPreTransportErrorsCloseRawBodyReader(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_DoStream_IdleTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"chunk\":1}\n\n"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.StreamIdleTimeout = 50 * time.Millisecond
+	cfg.CircuitBreaker.FailureThreshold = 1
+	client := New(cfg, nil)
+
+	stream, err := client.DoStream(context.Background(), Request{
+		Method:   http.MethodPost,
+		Endpoint: "/stream",
+		Body:     map[string]bool{"stream": true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	done := make(chan struct{})
+	var body []byte
+	var readErr error
+	go func() {
+		body, readErr = io.ReadAll(stream)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream read hung past the idle timeout")
+	}
+
+	if !strings.Contains(string(body), `"chunk":1`) {
+		t.Errorf("expected the bytes sent before the stall, got %q", body)
+	}
+	if !errors.Is(readErr, ErrStreamIdleTimeout) {
+		t.Fatalf("expected ErrStreamIdleTimeout, got %v", readErr)
+	}
+	var gatewayErr *core.GatewayError
+	if !errors.As(readErr, &gatewayErr) || gatewayErr.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504 GatewayError, got %v", readErr)
+	}
+	if state := client.circuitBreaker.State(); state != "open" {
+		t.Errorf("expected idle timeout to charge the circuit breaker, state = %s", state)
+	}
+}
+
+func TestClient_DoStream_IdleTimeoutIgnoresSlowConsumer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"chunk\":1}\n\ndata: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.StreamIdleTimeout = 20 * time.Millisecond
+	client := New(cfg, nil)
+
+	stream, err := client.DoStream(context.Background(), Request{
+		Method:   http.MethodPost,
+		Endpoint: "/stream",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	// The timer only runs while a Read waits on the upstream, so pausing
+	// between reads must not abort a stream whose bytes already arrived.
+	buf := make([]byte, 8)
+	if _, err := stream.Read(buf); err != nil {
+		t.Fatalf("first read: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	rest, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("expected clean EOF after consumer pause, got %v", err)
+	}
+	if !strings.Contains(string(rest), "[DONE]") {
+		t.Errorf("expected remaining stream bytes, got %q", rest)
+	}
+}
+
+func TestClient_DoRaw_TimeoutRetriesSlowAttempt(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 1
+	config.Retry.InitialBackoff = time.Millisecond
+	config.Retry.JitterFactor = 0
+	client := New(config, nil)
+
+	start := time.Now()
+	resp, err := client.DoRaw(context.Background(), Request{
+		Method:   http.MethodGet,
+		Endpoint: "/models",
+		Timeout:  100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DoRaw() error = %v", err)
+	}
+	if string(resp.Body) != `{"ok":true}` {
+		t.Errorf("body = %s", resp.Body)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("DoRaw took %v, want the slow attempt abandoned after the timeout", elapsed)
+	}
+}
+
+func TestClient_DoRaw_TimeoutExhaustedReturnsAttemptTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 1
+	config.Retry.InitialBackoff = time.Millisecond
+	client := New(config, nil)
+
+	_, err := client.DoRaw(context.Background(), Request{
+		Method:   http.MethodGet,
+		Endpoint: "/models",
+		Timeout:  50 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrAttemptTimeout) {
+		t.Fatalf("error = %v, want ErrAttemptTimeout", err)
+	}
+	if got := extractStatusCode(err); got != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504", got)
+	}
+}
+
+func TestClient_DoRaw_ParentDeadlineShorterThanTimeoutWins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.Retry.MaxRetries = 3
+	client := New(config, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.DoRaw(ctx, Request{
+		Method:   http.MethodGet,
+		Endpoint: "/models",
+		Timeout:  5 * time.Second,
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if errors.Is(err, ErrAttemptTimeout) {
+		t.Errorf("error = %v, want the parent deadline rather than the attempt timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("DoRaw took %v, want it bounded by the parent deadline", elapsed)
+	}
+}
+
+func TestClient_DoStream_TimeoutCoversHeadersOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(150 * time.Millisecond):
+		}
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	client := New(DefaultConfig("test", server.URL), nil)
+	body, err := client.DoStream(context.Background(), Request{
+		Method:   http.MethodPost,
+		Endpoint: "/chat/completions",
+		Body:     map[string]any{"model": "m"},
+		Timeout:  50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DoStream() error = %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading stream after the attempt timeout: %v", err)
+	}
+	if string(data) != "data: [DONE]\n\n" {
+		t.Errorf("stream = %q", data)
+	}
+}
+
+func TestClient_DoRaw_RetryableStatusCodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		codes        []int
+		status       int
+		wantAttempts int32
+	}{
+		{name: "500 retried when configured", codes: []int{500, 408}, status: http.StatusInternalServerError, wantAttempts: 3},
+		{name: "408 retried when configured", codes: []int{500, 408}, status: http.StatusRequestTimeout, wantAttempts: 3},
+		{name: "custom set replaces defaults", codes: []int{500, 408}, status: http.StatusServiceUnavailable, wantAttempts: 1},
+		{name: "500 not retried by default", status: http.StatusInternalServerError, wantAttempts: 1},
+		{name: "503 retried by default", status: http.StatusServiceUnavailable, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"error":{"message":"transient"}}`))
+			}))
+			defer server.Close()
+
+			config := DefaultConfig("test", server.URL)
+			config.RetryableStatusCodes = tt.codes
+			config.Retry.MaxRetries = 2
+			config.Retry.InitialBackoff = time.Millisecond
+			config.Retry.MaxBackoff = time.Millisecond
+			config.Retry.JitterFactor = 0
+			config.CircuitBreaker = goconfig.CircuitBreakerConfig{}
+			client := New(config, nil)
+
+			if _, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"}); err == nil {
+				t.Fatal("DoRaw() error = nil, want upstream error")
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_RetryableStatusCodesTripCircuitBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestTimeout)
+		_, _ = w.Write([]byte(`{"error":{"message":"timeout"}}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test", server.URL)
+	config.RetryableStatusCodes = []int{http.StatusRequestTimeout}
+	config.Retry.MaxRetries = 0
+	config.CircuitBreaker = goconfig.CircuitBreakerConfig{
+		FailureThreshold: 1,
+		SuccessThreshold: 1,
+		Timeout:          time.Minute,
+	}
+	client := New(config, nil)
+
+	_, _ = client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+	if got := client.CircuitState(); got != "open" {
+		t.Fatalf("CircuitState() = %q, want open after a configured retryable status", got)
+	}
+}
@@ -0,0 +1,59 @@
+package llmclient
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodeContentEncoding makes resp.Body yield decoded bytes when the upstream
+// answered with a gzip or deflate Content-Encoding the transport left in
+// place. Go's transport already decodes gzip it asked for itself and strips
+// the header, so this covers transports with compression disabled, callers
+// that set Accept-Encoding explicitly, and proxies that compress unasked.
+// Unknown encodings are left untouched. Close still closes the original body.
+func decodeContentEncoding(resp *http.Response) {
+	var open func(io.Reader) (io.ReadCloser, error)
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		open = func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+	case "deflate":
+		// HTTP "deflate" is the zlib format (RFC 9110 section 8.4.1.2).
+		open = zlib.NewReader
+	default:
+		return
+	}
+	resp.Body = &decodedBody{body: resp.Body, open: open}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// decodedBody opens the decoder on first Read so an empty body reports a
+// plain io.EOF instead of a header error at construction time.
+type decodedBody struct {
+	body    io.ReadCloser
+	open    func(io.Reader) (io.ReadCloser, error)
+	decoder io.ReadCloser
+	err     error
+}
+
+func (b *decodedBody) Read(p []byte) (int, error) {
+	if b.decoder == nil && b.err == nil {
+		b.decoder, b.err = b.open(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.decoder.Read(p)
+}
+
+func (b *decodedBody) Close() error {
+	if b.decoder != nil {
+		_ = b.decoder.Close()
+	}
+	return b.body.Close()
+}
@@ -0,0 +1,160 @@
+package llmclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func gzipBytes(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(data)); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func zlibBytes(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write([]byte(data)); err != nil {
+		t.Fatalf("zlib write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("zlib close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// encodingServer always compresses, whether or not the client asked for it,
+// like a misconfigured proxy in front of a provider.
+func encodingServer(t *testing.T, status int, encoding string, body []byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", encoding)
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// noCompressionClient disables the transport's own gzip handling so decoding
+// has to happen in llmclient.
+func noCompressionClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{DisableCompression: true}}
+}
+
+func TestClient_Do_DecodesContentEncoding(t *testing.T) {
+	const payload = `{"id":"chatcmpl-1"}`
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{name: "gzip", encoding: "gzip", body: gzipBytes(t, payload)},
+		{name: "deflate", encoding: "deflate", body: zlibBytes(t, payload)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := encodingServer(t, http.StatusOK, tt.encoding, tt.body)
+			client := NewWithHTTPClient(noCompressionClient(), DefaultConfig("test", server.URL), nil)
+
+			resp, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+			if err != nil {
+				t.Fatalf("DoRaw() error = %v", err)
+			}
+			if string(resp.Body) != payload {
+				t.Fatalf("body = %q, want %q", resp.Body, payload)
+			}
+			if got := resp.Header.Get("Content-Encoding"); got != "" {
+				t.Errorf("Content-Encoding = %q, want it removed after decoding", got)
+			}
+		})
+	}
+}
+
+func TestClient_Do_DecodesCompressedErrorBody(t *testing.T) {
+	server := encodingServer(t, http.StatusBadRequest, "gzip", gzipBytes(t, `{"error":{"message":"bad model"}}`))
+	client := NewWithHTTPClient(noCompressionClient(), DefaultConfig("test", server.URL), nil)
+
+	_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/test"})
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		t.Fatalf("error = %v, want *core.GatewayError", err)
+	}
+	if !strings.Contains(gatewayErr.Message, "bad model") {
+		t.Fatalf("message = %q, want decoded upstream message", gatewayErr.Message)
+	}
+}
+
+func TestClient_DoStream_DecodesGzip(t *testing.T) {
+	const stream = "data: {\"id\":\"1\"}\n\ndata: [DONE]\n\n"
+	server := encodingServer(t, http.StatusOK, "gzip", gzipBytes(t, stream))
+	client := NewWithHTTPClient(noCompressionClient(), DefaultConfig("test", server.URL), nil)
+
+	body, err := client.DoStream(context.Background(), Request{Method: http.MethodPost, Endpoint: "/chat/completions", Body: map[string]any{"model": "m"}})
+	if err != nil {
+		t.Fatalf("DoStream() error = %v", err)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if string(data) != stream {
+		t.Fatalf("stream = %q, want %q", data, stream)
+	}
+}
+
+func TestDecodeContentEncoding(t *testing.T) {
+	t.Run("close reaches the underlying body", func(t *testing.T) {
+		underlying := &recordingReadCloser{Reader: bytes.NewReader(gzipBytes(t, "hello"))}
+		resp := &http.Response{Header: http.Header{"Content-Encoding": {"gzip"}}, Body: underlying}
+		decodeContentEncoding(resp)
+		data, err := io.ReadAll(resp.Body)
+		if err != nil || string(data) != "hello" {
+			t.Fatalf("ReadAll() = %q, %v", data, err)
+		}
+		_ = resp.Body.Close()
+		if !underlying.closed.Load() {
+			t.Fatal("underlying body was not closed")
+		}
+	})
+
+	t.Run("empty body reads as EOF", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Content-Encoding": {"gzip"}}, Body: io.NopCloser(strings.NewReader(""))}
+		decodeContentEncoding(resp)
+		data, err := io.ReadAll(resp.Body)
+		if err != nil || len(data) != 0 {
+			t.Fatalf("ReadAll() = %q, %v, want empty and no error", data, err)
+		}
+	})
+
+	t.Run("unknown encoding is left alone", func(t *testing.T) {
+		body := io.NopCloser(strings.NewReader("raw"))
+		resp := &http.Response{Header: http.Header{"Content-Encoding": {"br"}}, Body: body}
+		decodeContentEncoding(resp)
+		if resp.Body != body || resp.Header.Get("Content-Encoding") != "br" {
+			t.Fatal("response with an unsupported encoding was modified")
+		}
+	})
+}
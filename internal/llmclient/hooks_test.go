@@ -65,3 +65,24 @@ func TestJoinHooksSingleReusesCallback(t *testing.T) {
 		t.Fatalf("OnRequestEnd called %d times, want 1", called)
 	}
 }
+
+func TestJoinHooksEndReceivesThreadedContext(t *testing.T) {
+	type ctxKey string
+	tag := func(name string) Hooks {
+		return Hooks{
+			OnRequestStart: func(ctx context.Context, _ RequestInfo) context.Context {
+				return context.WithValue(ctx, ctxKey(name), true)
+			},
+			OnRequestEnd: func(ctx context.Context, _ ResponseInfo) {
+				for _, key := range []string{"metrics", "tracing", "logging"} {
+					if ctx.Value(ctxKey(key)) != true {
+						t.Errorf("%s OnRequestEnd missing %s context value", name, key)
+					}
+				}
+			},
+		}
+	}
+
+	joined := JoinHooks(tag("metrics"), tag("tracing"), tag("logging"))
+	joined.OnRequestEnd(joined.OnRequestStart(t.Context(), RequestInfo{}), ResponseInfo{})
+}
@@ -0,0 +1,160 @@
+package llmclient
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+var (
+	// ErrQueueFull is returned when a provider's request queue is at capacity.
+	ErrQueueFull = errors.New("provider request queue is full")
+	// ErrQueueWaitExceeded is returned when a queued request waits longer than
+	// the configured maximum for a free slot.
+	ErrQueueWaitExceeded = errors.New("timed out waiting in provider request queue")
+)
+
+// RequestQueue bounds concurrent upstream requests for one provider instance.
+// Requests over the concurrency limit wait in FIFO order, so under sustained
+// overload they are served in arrival order instead of racing for free slots.
+// A nil *RequestQueue admits every request immediately.
+type RequestQueue struct {
+	mu            sync.Mutex
+	maxConcurrent int
+	maxQueue      int
+	maxWait       time.Duration
+	active        int
+	waiters       *list.List // of chan struct{}; closed when the slot is handed over
+}
+
+// NewRequestQueue returns a queue for cfg, or nil when cfg.MaxConcurrent is not
+// positive (unbounded). One queue is shared by every client of a provider.
+func NewRequestQueue(cfg config.QueueConfig) *RequestQueue {
+	if cfg.MaxConcurrent <= 0 {
+		return nil
+	}
+	return &RequestQueue{
+		maxConcurrent: cfg.MaxConcurrent,
+		maxQueue:      max(cfg.MaxQueue, 0),
+		maxWait:       cfg.MaxWait,
+		waiters:       list.New(),
+	}
+}
+
+// Acquire waits for a request slot. On success the returned release function
+// must be called exactly once when the request no longer occupies the
+// upstream. It fails with ErrQueueFull, ErrQueueWaitExceeded, or the context
+// error.
+func (q *RequestQueue) Acquire(ctx context.Context) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	q.mu.Lock()
+	if q.active < q.maxConcurrent && q.waiters.Len() == 0 {
+		q.active++
+		q.mu.Unlock()
+		return q.releaseOnce(), nil
+	}
+	if q.waiters.Len() >= q.maxQueue {
+		q.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+	ready := make(chan struct{})
+	elem := q.waiters.PushBack(ready)
+	q.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if q.maxWait > 0 {
+		timer := time.NewTimer(q.maxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var waitErr error
+	select {
+	case <-ready:
+		return q.releaseOnce(), nil
+	case <-ctx.Done():
+		waitErr = ctx.Err()
+	case <-timeout:
+		waitErr = ErrQueueWaitExceeded
+	}
+
+	q.mu.Lock()
+	select {
+	case <-ready:
+		// The slot was handed over while we were giving up; pass it on.
+		q.mu.Unlock()
+		q.release()
+	default:
+		q.waiters.Remove(elem)
+		q.mu.Unlock()
+	}
+	return nil, waitErr
+}
+
+// Stats reports the number of in-flight and queued requests.
+func (q *RequestQueue) Stats() (active, queued int) {
+	if q == nil {
+		return 0, 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.active, q.waiters.Len()
+}
+
+func (q *RequestQueue) releaseOnce() func() {
+	var once sync.Once
+	return func() { once.Do(q.release) }
+}
+
+// release hands the slot to the oldest waiter, or frees it when none wait.
+func (q *RequestQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if front := q.waiters.Front(); front != nil {
+		q.waiters.Remove(front)
+		close(front.Value.(chan struct{}))
+		return
+	}
+	q.active--
+}
+
+// queueError maps a queue or token pacing admission failure to the error
+// returned to callers. Context errors pass through unchanged, like a cancelled
+// retry wait.
+func (c *Client) queueError(err error) error {
+	if !errors.Is(err, ErrQueueFull) && !errors.Is(err, ErrQueueWaitExceeded) && !errors.Is(err, ErrTokenBudgetWaitExceeded) {
+		return err
+	}
+	rateErr := core.NewRateLimitError(c.config.ProviderName, err.Error())
+	rateErr.Err = err
+	return rateErr
+}
+
+// releaseOnClose frees a queue slot when a returned body is closed. A nil
+// release returns body unchanged.
+func releaseOnClose(body io.ReadCloser, release func()) io.ReadCloser {
+	if release == nil {
+		return body
+	}
+	return &releasingBody{ReadCloser: body, release: release}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
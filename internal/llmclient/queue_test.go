@@ -0,0 +1,193 @@
+package llmclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	goconfig "github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestNewRequestQueue_DisabledWithoutConcurrencyLimit(t *testing.T) {
+	q := NewRequestQueue(goconfig.DefaultQueueConfig())
+	if q != nil {
+		t.Fatal("expected nil queue when max_concurrent is unset")
+	}
+	release, err := q.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("nil queue must admit requests, got %v", err)
+	}
+	release()
+}
+
+func TestRequestQueue_ServesWaitersInArrivalOrder(t *testing.T) {
+	q := NewRequestQueue(goconfig.QueueConfig{MaxConcurrent: 1, MaxQueue: 10, MaxWait: 5 * time.Second})
+
+	releaseFirst, err := q.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	const waiters = 5
+	order := make(chan int, waiters)
+	var wg sync.WaitGroup
+	for i := range waiters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := q.Acquire(context.Background())
+			if err != nil {
+				t.Errorf("waiter %d: %v", i, err)
+				return
+			}
+			order <- i
+			release()
+		}()
+		// Enqueue one at a time so arrival order is deterministic.
+		waitForQueued(t, q, i+1)
+	}
+
+	releaseFirst()
+	wg.Wait()
+	close(order)
+
+	want := 0
+	for got := range order {
+		if got != want {
+			t.Fatalf("waiter %d served out of order, want %d", got, want)
+		}
+		want++
+	}
+	if active, queued := q.Stats(); active != 0 || queued != 0 {
+		t.Fatalf("expected drained queue, got active=%d queued=%d", active, queued)
+	}
+}
+
+func TestRequestQueue_RejectsWhenFull(t *testing.T) {
+	q := NewRequestQueue(goconfig.QueueConfig{MaxConcurrent: 1, MaxQueue: 1, MaxWait: 5 * time.Second})
+
+	release, err := q.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if release, err := q.Acquire(ctx); err == nil {
+			release()
+		}
+	}()
+	waitForQueued(t, q, 1)
+
+	if _, err := q.Acquire(context.Background()); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestRequestQueue_MaxWaitExceeded(t *testing.T) {
+	q := NewRequestQueue(goconfig.QueueConfig{MaxConcurrent: 1, MaxQueue: 1, MaxWait: 20 * time.Millisecond})
+
+	release, err := q.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	if _, err := q.Acquire(context.Background()); !errors.Is(err, ErrQueueWaitExceeded) {
+		t.Fatalf("expected ErrQueueWaitExceeded, got %v", err)
+	}
+	if _, queued := q.Stats(); queued != 0 {
+		t.Fatalf("timed-out waiter must leave the queue, queued=%d", queued)
+	}
+
+	release()
+	if active, _ := q.Stats(); active != 0 {
+		t.Fatalf("expected slot to be freed, active=%d", active)
+	}
+}
+
+func TestClient_QueueFullReturnsRateLimitError(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.Queue = NewRequestQueue(goconfig.QueueConfig{MaxConcurrent: 1, MaxQueue: 0, MaxWait: time.Second})
+	client := New(cfg, nil)
+
+	go func() {
+		_, _ = client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/slow"})
+	}()
+	waitForActive(t, cfg.Queue, 1)
+
+	_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/slow"})
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		t.Fatalf("expected GatewayError, got %T: %v", err, err)
+	}
+	if gatewayErr.StatusCode != http.StatusTooManyRequests || gatewayErr.Type != core.ErrorTypeRateLimit {
+		t.Fatalf("expected 429 rate_limit_error, got %d %s", gatewayErr.StatusCode, gatewayErr.Type)
+	}
+	if client.circuitBreaker.State() != "closed" {
+		t.Fatal("queue rejection must not charge the circuit breaker")
+	}
+}
+
+func TestClient_StreamHoldsQueueSlotUntilClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.Queue = NewRequestQueue(goconfig.QueueConfig{MaxConcurrent: 1, MaxQueue: 1, MaxWait: time.Second})
+	client := New(cfg, nil)
+
+	stream, err := client.DoStream(context.Background(), Request{Method: http.MethodPost, Endpoint: "/stream"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = io.ReadAll(stream)
+	if active, _ := cfg.Queue.Stats(); active != 1 {
+		t.Fatalf("open stream must hold its slot, active=%d", active)
+	}
+	_ = stream.Close()
+	if active, _ := cfg.Queue.Stats(); active != 0 {
+		t.Fatalf("closing the stream must free its slot, active=%d", active)
+	}
+}
+
+func waitForQueued(t *testing.T, q *RequestQueue, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, queued := q.Stats(); queued == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("queue never reached %d waiters", want)
+}
+
+func waitForActive(t *testing.T, q *RequestQueue, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if active, _ := q.Stats(); active == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("queue never reached %d active requests", want)
+}
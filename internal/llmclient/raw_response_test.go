@@ -0,0 +1,44 @@
+package llmclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestClient_RecordsRawResponse(t *testing.T) {
+	const upstreamBody = `{"id":"chatcmpl-1","object":"chat.completion","service_tier":"flex","choices":[]}`
+	tests := []struct {
+		name     string
+		status   int
+		wantBody string
+	}{
+		{name: "success", status: http.StatusOK, wantBody: upstreamBody},
+		{name: "error", status: http.StatusBadRequest, wantBody: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(upstreamBody))
+			}))
+			defer server.Close()
+
+			client := New(DefaultConfig("test", server.URL), nil)
+			ctx, recorder := core.WithRawResponseRecorder(context.Background())
+			_, _ = client.DoRaw(ctx, Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(`{}`)})
+
+			body, contentType := recorder.Response()
+			if string(body) != tt.wantBody {
+				t.Fatalf("recorded body = %q, want %q", body, tt.wantBody)
+			}
+			if tt.wantBody != "" && contentType != "application/json" {
+				t.Fatalf("recorded content type = %q, want application/json", contentType)
+			}
+		})
+	}
+}
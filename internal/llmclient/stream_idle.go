@@ -0,0 +1,78 @@
+package llmclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// ErrStreamIdleTimeout is wrapped by the error a stream body returns when the
+// upstream stops sending bytes for longer than Config.StreamIdleTimeout.
+var ErrStreamIdleTimeout = errors.New("upstream stream idle timeout")
+
+// streamIdleTimeoutError builds the typed error for a stalled stream and
+// charges the failure to the circuit breaker: the connection was established
+// but the provider stopped answering, which is a provider health signal.
+func (c *Client) streamIdleTimeoutError() error {
+	if c.circuitBreaker != nil {
+		c.circuitBreaker.RecordFailure()
+	}
+	return core.NewProviderError(c.config.ProviderName, http.StatusGatewayTimeout,
+		"upstream stream idle for "+c.config.StreamIdleTimeout.String(), ErrStreamIdleTimeout)
+}
+
+// idleTimeoutBody aborts a stream whose upstream goes silent. The timer only
+// runs while a Read is waiting on the upstream, so a slow downstream consumer
+// never trips it. On expiry the underlying body is closed to unblock the
+// pending Read, which then reports the timeout error.
+type idleTimeoutBody struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	timer    *time.Timer
+	timedOut atomic.Bool
+	err      error
+	onExpire func() error
+}
+
+func newIdleTimeoutBody(body io.ReadCloser, timeout time.Duration, onExpire func() error) *idleTimeoutBody {
+	b := &idleTimeoutBody{
+		body:     body,
+		timeout:  timeout,
+		onExpire: onExpire,
+	}
+	b.timer = time.AfterFunc(timeout, b.expire)
+	b.timer.Stop()
+	return b
+}
+
+func (b *idleTimeoutBody) expire() {
+	if b.timedOut.CompareAndSwap(false, true) {
+		_ = b.body.Close()
+	}
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.timer.Reset(b.timeout)
+	n, err := b.body.Read(p)
+	b.timer.Stop()
+	if !b.timedOut.Load() {
+		return n, err
+	}
+	b.err = b.onExpire()
+	return n, b.err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	if b.timedOut.Load() {
+		return nil
+	}
+	return b.body.Close()
+}
@@ -0,0 +1,272 @@
+package llmclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/tidwall/gjson"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/streaming"
+)
+
+// ErrTokenBudgetWaitExceeded is returned when a request would wait longer than
+// the configured maximum for the provider's token budget to refill.
+var ErrTokenBudgetWaitExceeded = errors.New("timed out waiting for provider token budget")
+
+// completionEstimateWeight is the weight of each observed completion in the
+// running completion-token estimate.
+const completionEstimateWeight = 0.2
+
+// TokenPacer keeps upstream traffic for one provider instance under a
+// tokens-per-minute budget. It is a token bucket holding one minute of budget:
+// each request reserves its estimated prompt tokens plus the running average of
+// completion tokens and, when the bucket is short, sleeps until it refills.
+// Reservations are corrected with the usage the upstream reports, or refunded
+// when the request fails. A nil *TokenPacer admits every request immediately.
+type TokenPacer struct {
+	mu            sync.Mutex
+	perSecond     float64
+	capacity      float64
+	available     float64
+	updatedAt     time.Time
+	maxWait       time.Duration
+	completionAvg float64
+	samples       int
+}
+
+// NewTokenPacer returns a pacer for cfg, or nil when cfg.TokensPerMinute is not
+// positive. One pacer is shared by every client of a provider.
+func NewTokenPacer(cfg config.TokenPacingConfig) *TokenPacer {
+	if cfg.TokensPerMinute <= 0 {
+		return nil
+	}
+	capacity := float64(cfg.TokensPerMinute)
+	return &TokenPacer{
+		perSecond: capacity / 60,
+		capacity:  capacity,
+		available: capacity,
+		updatedAt: time.Now(),
+		maxWait:   cfg.MaxWait,
+	}
+}
+
+// Reserve charges promptTokens plus the completion estimate against the budget,
+// waiting until the budget covers it. It returns the tokens charged, which the
+// caller passes to Settle once actual usage is known. It fails with
+// ErrTokenBudgetWaitExceeded or the context error, charging nothing.
+func (p *TokenPacer) Reserve(ctx context.Context, promptTokens int) (int, error) {
+	if p == nil || promptTokens <= 0 {
+		return 0, nil
+	}
+
+	p.mu.Lock()
+	p.refillLocked(time.Now())
+	// A request larger than the whole budget waits for a full bucket rather
+	// than forever.
+	cost := min(float64(promptTokens)+p.completionAvg, p.capacity)
+	var wait time.Duration
+	if deficit := cost - p.available; deficit > 0 {
+		wait = time.Duration(deficit / p.perSecond * float64(time.Second))
+	}
+	if p.maxWait > 0 && wait > p.maxWait {
+		p.mu.Unlock()
+		return 0, ErrTokenBudgetWaitExceeded
+	}
+	// Charge up front so later callers queue behind this reservation.
+	p.available -= cost
+	p.mu.Unlock()
+
+	reserved := int(cost)
+	if wait <= 0 {
+		return reserved, nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return reserved, nil
+	case <-ctx.Done():
+		p.credit(cost)
+		return 0, ctx.Err()
+	}
+}
+
+// Settle replaces a reservation with the total tokens the upstream reported
+// and folds completionTokens into the running completion estimate. Unknown
+// usage (usedTokens <= 0) keeps the reservation as charged.
+func (p *TokenPacer) Settle(reserved, usedTokens, completionTokens int) {
+	if p == nil || reserved <= 0 || usedTokens <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refillLocked(time.Now())
+	p.available = min(p.available+float64(reserved-usedTokens), p.capacity)
+	if p.samples == 0 {
+		p.completionAvg = float64(completionTokens)
+	} else {
+		p.completionAvg += completionEstimateWeight * (float64(completionTokens) - p.completionAvg)
+	}
+	p.samples++
+}
+
+func (p *TokenPacer) credit(tokens float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refillLocked(time.Now())
+	p.available = min(p.available+tokens, p.capacity)
+}
+
+func (p *TokenPacer) refillLocked(now time.Time) {
+	if elapsed := now.Sub(p.updatedAt); elapsed > 0 {
+		p.available = min(p.available+elapsed.Seconds()*p.perSecond, p.capacity)
+		p.updatedAt = now
+	}
+}
+
+// tokenReservation tracks one request's charge against a TokenPacer until it
+// is either settled with reported usage or refunded. Whichever happens first
+// wins; later calls are no-ops.
+type tokenReservation struct {
+	pacer  *TokenPacer
+	tokens int
+	once   sync.Once
+}
+
+func (p *TokenPacer) reservation(tokens int) *tokenReservation {
+	if p == nil || tokens <= 0 {
+		return nil
+	}
+	return &tokenReservation{pacer: p, tokens: tokens}
+}
+
+// settle replaces the charge with the reported usage; unknown usage keeps it
+// as charged.
+func (r *tokenReservation) settle(usedTokens, completionTokens int) {
+	if r == nil {
+		return
+	}
+	r.once.Do(func() { r.pacer.Settle(r.tokens, usedTokens, completionTokens) })
+}
+
+// refund returns the whole charge, for requests that ended without a usable
+// response.
+func (r *tokenReservation) refund() {
+	if r == nil {
+		return
+	}
+	r.once.Do(func() { r.pacer.credit(float64(r.tokens)) })
+}
+
+// pacedRequestTokens returns the prompt-token estimate to reserve for req, or
+// zero when the client does not pace.
+func (c *Client) pacedRequestTokens(req Request) int {
+	if c.config.TokenPacer == nil {
+		return 0
+	}
+	return estimateRequestTokens(req)
+}
+
+// settleTokens corrects the scope's token reservation with the usage reported
+// in a successful response body.
+func (c *Client) settleTokens(scope requestScope, body []byte) {
+	if scope.tokens == nil {
+		return
+	}
+	total, completion := responseUsageTokens(body)
+	scope.tokens.settle(total, completion)
+}
+
+// settleOnStreamClose hands the reservation to the returned stream, which
+// settles it with the usage its events report once the caller closes it.
+func settleOnStreamClose(body io.ReadCloser, tokens *tokenReservation) io.ReadCloser {
+	if tokens == nil {
+		return body
+	}
+	return streaming.NewObservedSSEStream(body, &streamUsageObserver{tokens: tokens})
+}
+
+// streamUsageObserver collects token usage from OpenAI, Anthropic, Responses,
+// and Gemini stream events.
+type streamUsageObserver struct {
+	tokens                    *tokenReservation
+	prompt, completion, total int
+}
+
+func (o *streamUsageObserver) WantsJSONEvent(raw []byte) bool {
+	return bytes.Contains(raw, []byte(`"usage`))
+}
+
+func (o *streamUsageObserver) OnJSONEvent(event map[string]any) {
+	if usage, ok := event["usage"].(map[string]any); ok {
+		o.observe(usage)
+	}
+	for _, wrapper := range []string{"message", "response"} {
+		if inner, ok := event[wrapper].(map[string]any); ok {
+			if usage, ok := inner["usage"].(map[string]any); ok {
+				o.observe(usage)
+			}
+		}
+	}
+	if metadata, ok := event["usageMetadata"].(map[string]any); ok {
+		o.prompt = max(o.prompt, usageCount(metadata, "promptTokenCount"))
+		o.completion = max(o.completion, usageCount(metadata, "candidatesTokenCount"))
+		o.total = max(o.total, usageCount(metadata, "totalTokenCount"))
+	}
+}
+
+func (o *streamUsageObserver) observe(usage map[string]any) {
+	o.prompt = max(o.prompt, usageCount(usage, "prompt_tokens"), usageCount(usage, "input_tokens"))
+	o.completion = max(o.completion, usageCount(usage, "completion_tokens"), usageCount(usage, "output_tokens"))
+	o.total = max(o.total, usageCount(usage, "total_tokens"))
+}
+
+func (o *streamUsageObserver) OnStreamClose() {
+	total := max(o.total, o.prompt+o.completion)
+	o.tokens.settle(total, o.completion)
+}
+
+func usageCount(usage map[string]any, key string) int {
+	count, _ := usage[key].(float64)
+	return int(count)
+}
+
+// estimateRequestTokens approximates the prompt tokens of a request from its
+// body size (roughly four bytes per token). Bodyless and streamed-body
+// requests are not paced.
+func estimateRequestTokens(req Request) int {
+	size := len(req.RawBody)
+	if req.Body != nil {
+		body, err := json.Marshal(req.Body)
+		if err != nil {
+			return 0
+		}
+		size = len(body)
+	}
+	return (size + 3) / 4
+}
+
+// responseUsageTokens reads total and completion token counts from an
+// OpenAI, Anthropic/Responses, or Gemini shaped response body.
+func responseUsageTokens(body []byte) (total, completion int) {
+	usage := gjson.GetBytes(body, "usage")
+	if usage.Exists() {
+		completion = int(usage.Get("completion_tokens").Int())
+		if completion == 0 {
+			completion = int(usage.Get("output_tokens").Int())
+		}
+		total = int(usage.Get("total_tokens").Int())
+		if total == 0 {
+			total = int(usage.Get("prompt_tokens").Int() + usage.Get("input_tokens").Int() + int64(completion))
+		}
+		return total, completion
+	}
+	metadata := gjson.GetBytes(body, "usageMetadata")
+	return int(metadata.Get("totalTokenCount").Int()), int(metadata.Get("candidatesTokenCount").Int())
+}
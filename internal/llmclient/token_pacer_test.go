@@ -0,0 +1,221 @@
+package llmclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goconfig "github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestNewTokenPacer_DisabledWithoutBudget(t *testing.T) {
+	p := NewTokenPacer(goconfig.DefaultTokenPacingConfig())
+	if p != nil {
+		t.Fatal("expected nil pacer when tokens_per_minute is unset")
+	}
+	reserved, err := p.Reserve(context.Background(), 1_000_000)
+	if err != nil || reserved != 0 {
+		t.Fatalf("nil pacer must admit requests, got reserved=%d err=%v", reserved, err)
+	}
+}
+
+func TestTokenPacer_PacesRequestsOverBudget(t *testing.T) {
+	// 600k TPM refills 10k tokens per second.
+	p := NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 600_000, MaxWait: 5 * time.Second})
+
+	start := time.Now()
+	if _, err := p.Reserve(context.Background(), 600_000); err != nil {
+		t.Fatalf("first reserve: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("a full bucket must admit immediately, waited %v", elapsed)
+	}
+
+	start = time.Now()
+	if _, err := p.Reserve(context.Background(), 1_000); err != nil {
+		t.Fatalf("second reserve: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("expected ~100ms wait for the budget to refill, waited %v", elapsed)
+	}
+}
+
+func TestTokenPacer_MaxWaitExceeded(t *testing.T) {
+	p := NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 600_000, MaxWait: 10 * time.Millisecond})
+	if _, err := p.Reserve(context.Background(), 600_000); err != nil {
+		t.Fatalf("first reserve: %v", err)
+	}
+	if _, err := p.Reserve(context.Background(), 1_000); !errors.Is(err, ErrTokenBudgetWaitExceeded) {
+		t.Fatalf("expected ErrTokenBudgetWaitExceeded, got %v", err)
+	}
+}
+
+func TestTokenPacer_CancelledWaitRefundsReservation(t *testing.T) {
+	p := NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 600_000})
+	if _, err := p.Reserve(context.Background(), 600_000); err != nil {
+		t.Fatalf("first reserve: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Reserve(ctx, 300_000); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.available < 0 {
+		t.Fatalf("cancelled reservation must be refunded, available=%v", p.available)
+	}
+}
+
+func TestClient_TokenPacerSettlesUsageAndPacesNextRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Report usage far above the body estimate so the bucket is drained.
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":600000,"completion_tokens":0,"total_tokens":600000}}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.TokenPacer = NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 600_000, MaxWait: 5 * time.Second})
+	client := New(cfg, nil)
+
+	req := Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(strings.Repeat("a", 4_000))}
+	start := time.Now()
+	if _, err := client.DoRaw(context.Background(), req); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("first request must not be paced, waited %v", elapsed)
+	}
+
+	start = time.Now()
+	if _, err := client.DoRaw(context.Background(), req); err != nil {
+		t.Fatalf("second request: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("expected the second ~1k-token request to wait ~100ms, waited %v", elapsed)
+	}
+}
+
+// pacerAvailable reports the pacer's current budget.
+func pacerAvailable(p *TokenPacer) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.available
+}
+
+func TestClient_FailedRequestRefundsTokenReservation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad request"}}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.TokenPacer = NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 1_000, MaxWait: 10 * time.Millisecond})
+	client := New(cfg, nil)
+
+	req := Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(strings.Repeat("a", 3_600))}
+	if _, err := client.DoRaw(context.Background(), req); err == nil {
+		t.Fatal("expected the upstream 400")
+	}
+	if available := pacerAvailable(cfg.TokenPacer); available < 999 {
+		t.Fatalf("failed request must refund its reservation, available=%v", available)
+	}
+	// Without the refund the ~900-token reservation would leave the second
+	// request a minute short of budget.
+	_, err := client.DoRaw(context.Background(), req)
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) || gatewayErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("second request must reach the upstream unpaced, got %v", err)
+	}
+}
+
+func TestClient_StreamSettlesTokenReservationFromUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\n"))
+		_, _ = w.Write([]byte("data: {\"choices\":[],\"usage\":{\"prompt_tokens\":40,\"completion_tokens\":10,\"total_tokens\":50}}\n\n"))
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.TokenPacer = NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 1_000, MaxWait: 10 * time.Millisecond})
+	client := New(cfg, nil)
+
+	req := Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(strings.Repeat("a", 3_600))}
+	body, err := client.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoStream: %v", err)
+	}
+	if available := pacerAvailable(cfg.TokenPacer); available > 200 {
+		t.Fatalf("an open stream must hold its reservation, available=%v", available)
+	}
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("close stream: %v", err)
+	}
+	if available := pacerAvailable(cfg.TokenPacer); available < 949 || available > 960 {
+		t.Fatalf("stream must settle to the reported 50 tokens, available=%v", available)
+	}
+}
+
+func TestClient_TokenBudgetWaitExceededReturnsRateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.TokenPacer = NewTokenPacer(goconfig.TokenPacingConfig{TokensPerMinute: 1_000, MaxWait: 10 * time.Millisecond})
+	client := New(cfg, nil)
+
+	// ~1000 tokens drains the budget; the next request would wait a minute.
+	req := Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(strings.Repeat("a", 4_000))}
+	if _, err := client.DoRaw(context.Background(), req); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	_, err := client.DoRaw(context.Background(), req)
+	var gatewayErr *core.GatewayError
+	if !errors.As(err, &gatewayErr) {
+		t.Fatalf("expected GatewayError, got %T: %v", err, err)
+	}
+	if gatewayErr.StatusCode != http.StatusTooManyRequests || gatewayErr.Type != core.ErrorTypeRateLimit {
+		t.Fatalf("expected 429 rate_limit_error, got %d %s", gatewayErr.StatusCode, gatewayErr.Type)
+	}
+	if client.circuitBreaker.State() != "closed" {
+		t.Fatal("token pacing rejection must not charge the circuit breaker")
+	}
+}
+
+func TestResponseUsageTokens(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantTotal      int
+		wantCompletion int
+	}{
+		{name: "openai", body: `{"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`, wantTotal: 15, wantCompletion: 5},
+		{name: "anthropic", body: `{"usage":{"input_tokens":10,"output_tokens":7}}`, wantTotal: 17, wantCompletion: 7},
+		{name: "gemini", body: `{"usageMetadata":{"promptTokenCount":4,"candidatesTokenCount":3,"totalTokenCount":7}}`, wantTotal: 7, wantCompletion: 3},
+		{name: "missing", body: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, completion := responseUsageTokens([]byte(tt.body))
+			if total != tt.wantTotal || completion != tt.wantCompletion {
+				t.Fatalf("got total=%d completion=%d, want %d/%d", total, completion, tt.wantTotal, tt.wantCompletion)
+			}
+		})
+	}
+}
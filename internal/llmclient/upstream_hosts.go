@@ -0,0 +1,84 @@
+package llmclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// HostAllowList matches upstream hostnames against exact names ("api.openai.com"),
+// subdomain wildcards ("*.amazonaws.com", which does not match the bare
+// domain), or "*" for any host. A nil *HostAllowList allows every host.
+type HostAllowList struct {
+	any      bool
+	exact    map[string]struct{}
+	suffixes []string
+}
+
+// NewHostAllowList compiles patterns into an allow-list. Blank patterns are
+// ignored and matching is case-insensitive.
+func NewHostAllowList(patterns []string) *HostAllowList {
+	list := &HostAllowList{exact: make(map[string]struct{}, len(patterns))}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		switch {
+		case pattern == "":
+		case pattern == "*":
+			list.any = true
+		case strings.HasPrefix(pattern, "*."):
+			list.suffixes = append(list.suffixes, pattern[1:])
+		default:
+			list.exact[pattern] = struct{}{}
+		}
+	}
+	return list
+}
+
+// Allows reports whether host may be contacted.
+func (l *HostAllowList) Allows(host string) bool {
+	if l == nil || l.any {
+		return true
+	}
+	host = strings.ToLower(host)
+	if _, ok := l.exact[host]; ok {
+		return true
+	}
+	for _, suffix := range l.suffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+var allowedUpstreamHosts atomic.Pointer[HostAllowList]
+
+// SetAllowedUpstreamHosts installs the process-wide upstream host allow-list
+// checked by every client before a request is sent. It is called at startup
+// and whenever the provider set is reloaded; nil (the default) removes the
+// restriction.
+func SetAllowedUpstreamHosts(list *HostAllowList) {
+	allowedUpstreamHosts.Store(list)
+}
+
+// AllowedUpstreamHosts returns the allow-list currently in effect, so a caller
+// replacing it can put it back if the replacement does not go through.
+func AllowedUpstreamHosts() *HostAllowList {
+	return allowedUpstreamHosts.Load()
+}
+
+// checkUpstreamHost rejects requests whose final URL points at a host outside
+// the allow-list, including hosts smuggled in through the endpoint path (for
+// example "@attacker.example" appended to a path-less base URL).
+func checkUpstreamHost(req *http.Request) error {
+	host := req.URL.Hostname()
+	if allowedUpstreamHosts.Load().Allows(host) {
+		return nil
+	}
+	return core.NewInvalidRequestErrorWithStatus(http.StatusForbidden,
+		fmt.Sprintf("upstream host %q is not in allowed_upstream_hosts", host), nil).
+		WithCode("upstream_host_not_allowed")
+}
@@ -0,0 +1,86 @@
+package llmclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestHostAllowList_Allows(t *testing.T) {
+	list := NewHostAllowList([]string{"api.openai.com", " *.AmazonAWS.com ", ""})
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "api.openai.com", want: true},
+		{host: "API.OPENAI.COM", want: true},
+		{host: "bedrock-runtime.us-east-1.amazonaws.com", want: true},
+		{host: "amazonaws.com", want: false},
+		{host: "evilamazonaws.com", want: false},
+		{host: "api.openai.com.evil.example", want: false},
+		{host: "169.254.169.254", want: false},
+	}
+	for _, tt := range tests {
+		if got := list.Allows(tt.host); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+
+	if !NewHostAllowList([]string{"*"}).Allows("anything.example") {
+		t.Error("wildcard list must allow any host")
+	}
+	var unrestricted *HostAllowList
+	if !unrestricted.Allows("anything.example") {
+		t.Error("nil list must allow any host")
+	}
+}
+
+func TestClient_UpstreamHostAllowList(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	t.Cleanup(func() { SetAllowedUpstreamHosts(nil) })
+
+	cfg := DefaultConfig("test", server.URL)
+	cfg.Retry.MaxRetries = 2
+	client := New(cfg, nil)
+
+	SetAllowedUpstreamHosts(NewHostAllowList([]string{"127.0.0.1"}))
+	if _, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/ok"}); err != nil {
+		t.Fatalf("allowed host: DoRaw() error = %v", err)
+	}
+
+	// A path-less base URL plus "@host" in the endpoint would otherwise reach
+	// a different host through the userinfo syntax.
+	smuggled := New(DefaultConfig("test", "http://127.0.0.1:1"), nil)
+	if _, err := smuggled.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "@attacker.example/x"}); !isUpstreamHostNotAllowed(err) {
+		t.Fatalf("smuggled host: DoRaw() error = %v, want upstream_host_not_allowed", err)
+	}
+
+	SetAllowedUpstreamHosts(NewHostAllowList([]string{"api.openai.com"}))
+	_, err := client.DoRaw(context.Background(), Request{Method: http.MethodGet, Endpoint: "/blocked"})
+	if !isUpstreamHostNotAllowed(err) {
+		t.Fatalf("disallowed host: DoRaw() error = %v, want upstream_host_not_allowed", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1 (blocked requests must not be sent or retried)", got)
+	}
+	if client.circuitBreaker.State() != "closed" {
+		t.Fatal("blocked requests must not charge the circuit breaker")
+	}
+}
+
+func isUpstreamHostNotAllowed(err error) bool {
+	var gatewayErr *core.GatewayError
+	return errors.As(err, &gatewayErr) &&
+		gatewayErr.StatusCode == http.StatusForbidden &&
+		gatewayErr.Code != nil && *gatewayErr.Code == "upstream_host_not_allowed"
+}
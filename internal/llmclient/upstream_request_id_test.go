@@ -0,0 +1,58 @@
+package llmclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestClient_RecordsUpstreamRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   string
+	}{
+		{name: "openai", header: "X-Request-Id", value: "req_abc", want: "req_abc"},
+		{name: "anthropic", header: "Request-Id", value: "req_011CT", want: "req_011CT"},
+		{name: "bedrock", header: "X-Amzn-Requestid", value: "5f1c", want: "5f1c"},
+		{name: "missing", header: "X-Other", value: "ignored", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(tt.header, tt.value)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := New(DefaultConfig("test", server.URL), nil)
+			ctx, recorder := core.WithUpstreamRequestIDRecorder(context.Background())
+			if _, err := client.DoRaw(ctx, Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(`{}`)}); err != nil {
+				t.Fatalf("DoRaw: %v", err)
+			}
+			if got := recorder.ID(); got != tt.want {
+				t.Fatalf("recorded upstream request ID = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_UpstreamRequestIDWithoutRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_abc")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(DefaultConfig("test", server.URL), nil)
+	if _, err := client.DoRaw(context.Background(), Request{Method: http.MethodPost, Endpoint: "/chat", RawBody: []byte(`{}`)}); err != nil {
+		t.Fatalf("DoRaw: %v", err)
+	}
+	if got := core.GetUpstreamRequestID(context.Background()); got != "" {
+		t.Fatalf("expected no upstream request ID without a recorder, got %q", got)
+	}
+}
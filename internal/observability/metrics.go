@@ -181,4 +181,6 @@ func ResetMetrics() {
 	InFlightRequests.Reset()
 	ResponseSnapshotStoreFailures.Reset()
 	CircuitBreakerState.Reset()
+	TokensTotal.Reset()
+	CostTotal.Reset()
 }
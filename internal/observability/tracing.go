@@ -0,0 +1,77 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/enterpilot/gomodel/internal/llmclient"
+)
+
+// tracerName identifies GoModel's provider-call spans.
+const tracerName = "github.com/enterpilot/gomodel/internal/llmclient"
+
+// NewOTelHooks returns hooks that wrap each LLM provider request in an
+// OpenTelemetry client span, using the globally registered TracerProvider.
+// Spans carry provider, model, endpoint, stream, and request ID attributes and
+// end with the response status code and error. They combine with the
+// Prometheus hooks through llmclient.JoinHooks.
+//
+// The standard gateway does not use them: it ships no exporter, so spans
+// would be discarded. A program embedding GoModel installs a TracerProvider
+// with an exporter (otel.SetTracerProvider) and adds these hooks to its
+// provider factory with ProviderFactory.AddHooks.
+func NewOTelHooks() llmclient.Hooks {
+	return newOTelHooks(otel.GetTracerProvider())
+}
+
+// otelSpanKey holds the span started by OnRequestStart, so OnRequestEnd never
+// ends a caller's span when no provider span was started.
+type otelSpanKey struct{}
+
+func newOTelHooks(provider trace.TracerProvider) llmclient.Hooks {
+	tracer := provider.Tracer(tracerName)
+	return llmclient.Hooks{
+		OnRequestStart: func(ctx context.Context, info llmclient.RequestInfo) context.Context {
+			ctx, span := tracer.Start(ctx, "llm "+info.Provider+" "+info.Endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("gomodel.provider", info.Provider),
+					attribute.String("gomodel.model", info.Model),
+					attribute.String("gomodel.endpoint", info.Endpoint),
+					attribute.String("http.request.method", info.Method),
+					attribute.Bool("gomodel.stream", info.Stream),
+				),
+			)
+			if info.RequestID != "" {
+				span.SetAttributes(attribute.String("gomodel.request_id", info.RequestID))
+			}
+			return context.WithValue(ctx, otelSpanKey{}, span)
+		},
+		OnRequestEnd: func(ctx context.Context, info llmclient.ResponseInfo) {
+			span, ok := ctx.Value(otelSpanKey{}).(trace.Span)
+			if !ok {
+				return
+			}
+			if info.StatusCode != 0 {
+				span.SetAttributes(attribute.Int("http.response.status_code", info.StatusCode))
+			}
+			if info.CircuitState != "" {
+				span.SetAttributes(attribute.String("gomodel.circuit_state", info.CircuitState))
+			}
+			switch {
+			case info.Error != nil:
+				span.RecordError(info.Error)
+				span.SetStatus(codes.Error, info.Error.Error())
+			case info.StatusCode >= http.StatusBadRequest:
+				span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(info.StatusCode))
+			}
+			span.End()
+		},
+	}
+}
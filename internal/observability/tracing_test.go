@@ -0,0 +1,153 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/enterpilot/gomodel/internal/llmclient"
+)
+
+func newTestTracerProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider, exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestOTelHooks_Success(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+	hooks := newOTelHooks(provider)
+
+	ctx := hooks.OnRequestStart(context.Background(), llmclient.RequestInfo{
+		Provider:  "openai",
+		Model:     "gpt-4o",
+		Endpoint:  "/chat/completions",
+		Method:    http.MethodPost,
+		RequestID: "req-123",
+	})
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		t.Fatal("OnRequestStart should return a context carrying the span")
+	}
+	hooks.OnRequestEnd(ctx, llmclient.ResponseInfo{
+		Provider:   "openai",
+		Model:      "gpt-4o",
+		Endpoint:   "/chat/completions",
+		StatusCode: http.StatusOK,
+		Duration:   50 * time.Millisecond,
+	})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "llm openai /chat/completions" {
+		t.Errorf("span name = %q", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span kind = %v, want client", span.SpanKind)
+	}
+	attrs := spanAttributes(span)
+	for key, want := range map[attribute.Key]string{
+		"gomodel.provider":    "openai",
+		"gomodel.model":       "gpt-4o",
+		"gomodel.endpoint":    "/chat/completions",
+		"http.request.method": http.MethodPost,
+		"gomodel.request_id":  "req-123",
+	} {
+		if got := attrs[key].AsString(); got != want {
+			t.Errorf("attribute %s = %q, want %q", key, got, want)
+		}
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("status code attribute = %d, want 200", got)
+	}
+	if span.Status.Code != codes.Unset {
+		t.Errorf("span status = %v, want unset", span.Status.Code)
+	}
+}
+
+func TestOTelHooks_RecordsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		err        error
+		wantEvents int
+	}{
+		{name: "network error", err: errors.New("connection refused"), wantEvents: 1},
+		{name: "upstream error", statusCode: http.StatusServiceUnavailable, err: errors.New("unavailable"), wantEvents: 1},
+		{name: "error status without error", statusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, exporter := newTestTracerProvider(t)
+			hooks := newOTelHooks(provider)
+
+			ctx := hooks.OnRequestStart(context.Background(), llmclient.RequestInfo{Provider: "anthropic", Endpoint: "/messages"})
+			hooks.OnRequestEnd(ctx, llmclient.ResponseInfo{Provider: "anthropic", Endpoint: "/messages", StatusCode: tt.statusCode, Error: tt.err})
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+			if spans[0].Status.Code != codes.Error {
+				t.Errorf("span status = %v, want error", spans[0].Status.Code)
+			}
+			if len(spans[0].Events) != tt.wantEvents {
+				t.Errorf("span events = %d, want %d", len(spans[0].Events), tt.wantEvents)
+			}
+			_, hasStatus := spanAttributes(spans[0])["http.response.status_code"]
+			if hasStatus != (tt.statusCode != 0) {
+				t.Errorf("status code attribute present = %v, want %v", hasStatus, tt.statusCode != 0)
+			}
+		})
+	}
+}
+
+func TestOTelHooks_EndWithoutStartLeavesParentSpan(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	newOTelHooks(provider).OnRequestEnd(ctx, llmclient.ResponseInfo{StatusCode: http.StatusOK})
+
+	if got := len(exporter.GetSpans()); got != 0 {
+		t.Fatalf("OnRequestEnd ended %d spans it did not start", got)
+	}
+	parent.End()
+}
+
+func TestOTelHooks_JoinWithPrometheusHooks(t *testing.T) {
+	ResetMetrics()
+	provider, exporter := newTestTracerProvider(t)
+	hooks := llmclient.JoinHooks(NewPrometheusHooks(), newOTelHooks(provider))
+
+	ctx := hooks.OnRequestStart(context.Background(), llmclient.RequestInfo{Provider: "openai", Model: "gpt-4o", Endpoint: "/chat/completions"})
+	hooks.OnRequestEnd(ctx, llmclient.ResponseInfo{Provider: "openai", Model: "gpt-4o", Endpoint: "/chat/completions", StatusCode: http.StatusOK})
+
+	if got := len(exporter.GetSpans()); got != 1 {
+		t.Fatalf("got %d spans, want 1", got)
+	}
+	count := testutil.ToFloat64(RequestsTotal.WithLabelValues("openai", "gpt-4o", "/chat/completions", "200", "success", "false"))
+	if count != 1 {
+		t.Fatalf("requests counter = %v, want 1", count)
+	}
+}
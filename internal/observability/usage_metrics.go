@@ -0,0 +1,78 @@
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/enterpilot/gomodel/internal/usage"
+)
+
+var (
+	// TokensTotal counts tokens reported by providers, split into prompt
+	// (input) and completion (output) tokens. Cache hits are excluded.
+	TokensTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gomodel_tokens_total",
+			Help: "Total number of LLM tokens by type (prompt, completion)",
+		},
+		[]string{"provider", "model", "type"},
+	)
+
+	// CostTotal accumulates estimated spend in USD for entries whose model has
+	// pricing (model registry metadata or pricing overrides).
+	CostTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gomodel_cost_usd_total",
+			Help: "Estimated LLM spend in USD",
+		},
+		[]string{"provider", "model"},
+	)
+)
+
+// UsageMetricsTap decorates a usage logger so every recorded entry also
+// feeds the token and cost counters. Usage extraction and pricing already
+// run for every modality before Logger.Write, so one tap covers them all.
+type UsageMetricsTap struct {
+	inner usage.LoggerInterface
+}
+
+// NewUsageMetricsTap wraps the logger, or returns nil when there is none.
+func NewUsageMetricsTap(inner usage.LoggerInterface) usage.LoggerInterface {
+	if inner == nil {
+		return nil
+	}
+	return &UsageMetricsTap{inner: inner}
+}
+
+func (t *UsageMetricsTap) Write(entry *usage.UsageEntry) {
+	recordUsageMetrics(entry)
+	t.inner.Write(entry)
+}
+
+func (t *UsageMetricsTap) Config() usage.Config {
+	return t.inner.Config()
+}
+
+func (t *UsageMetricsTap) Close() error {
+	return t.inner.Close()
+}
+
+func recordUsageMetrics(entry *usage.UsageEntry) {
+	// Cache hits are served without a provider call and cost nothing upstream.
+	if entry == nil || entry.CacheType != "" {
+		return
+	}
+	provider := entry.ProviderName
+	if provider == "" {
+		provider = entry.Provider
+	}
+	if entry.InputTokens > 0 {
+		TokensTotal.WithLabelValues(provider, entry.Model, "prompt").Add(float64(entry.InputTokens))
+	}
+	if entry.OutputTokens > 0 {
+		TokensTotal.WithLabelValues(provider, entry.Model, "completion").Add(float64(entry.OutputTokens))
+	}
+	if entry.TotalCost != nil && *entry.TotalCost > 0 {
+		CostTotal.WithLabelValues(provider, entry.Model).Add(*entry.TotalCost)
+	}
+}
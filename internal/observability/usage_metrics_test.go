@@ -0,0 +1,69 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/enterpilot/gomodel/internal/usage"
+)
+
+type recordingUsageLogger struct {
+	entries []*usage.UsageEntry
+}
+
+func (l *recordingUsageLogger) Write(entry *usage.UsageEntry) { l.entries = append(l.entries, entry) }
+func (l *recordingUsageLogger) Config() usage.Config          { return usage.Config{Enabled: true} }
+func (l *recordingUsageLogger) Close() error                  { return nil }
+
+func TestUsageMetricsTap_CountsTokensAndCost(t *testing.T) {
+	ResetMetrics()
+	inner := &recordingUsageLogger{}
+	tap := NewUsageMetricsTap(inner)
+
+	cost := 0.0125
+	tap.Write(&usage.UsageEntry{Provider: "openai", ProviderName: "openai-east", Model: "gpt-4o", InputTokens: 100, OutputTokens: 40, TotalCost: &cost})
+	tap.Write(&usage.UsageEntry{Provider: "openai", ProviderName: "openai-east", Model: "gpt-4o", InputTokens: 50, OutputTokens: 10, TotalCost: &cost})
+	tap.Write(&usage.UsageEntry{Provider: "anthropic", Model: "claude-sonnet-4", InputTokens: 7})
+
+	if got := testutil.ToFloat64(TokensTotal.WithLabelValues("openai-east", "gpt-4o", "prompt")); got != 150 {
+		t.Errorf("prompt tokens = %v, want 150", got)
+	}
+	if got := testutil.ToFloat64(TokensTotal.WithLabelValues("openai-east", "gpt-4o", "completion")); got != 50 {
+		t.Errorf("completion tokens = %v, want 50", got)
+	}
+	if got := testutil.ToFloat64(TokensTotal.WithLabelValues("anthropic", "claude-sonnet-4", "prompt")); got != 7 {
+		t.Errorf("provider type fallback prompt tokens = %v, want 7", got)
+	}
+	if got := testutil.ToFloat64(CostTotal.WithLabelValues("openai-east", "gpt-4o")); got != 0.025 {
+		t.Errorf("cost = %v, want 0.025", got)
+	}
+	if got := testutil.CollectAndCount(CostTotal); got != 1 {
+		t.Errorf("cost series = %d, want 1 (unpriced entries add none)", got)
+	}
+	if len(inner.entries) != 3 {
+		t.Fatalf("inner logger received %d entries, want 3", len(inner.entries))
+	}
+}
+
+func TestUsageMetricsTap_SkipsCacheHits(t *testing.T) {
+	ResetMetrics()
+	tap := NewUsageMetricsTap(&recordingUsageLogger{})
+
+	cost := 1.0
+	tap.Write(&usage.UsageEntry{Provider: "openai", Model: "gpt-4o", CacheType: usage.CacheTypeExact, InputTokens: 100, OutputTokens: 40, TotalCost: &cost})
+	tap.Write(nil)
+
+	if got := testutil.CollectAndCount(TokensTotal); got != 0 {
+		t.Errorf("token series = %d, want 0 for cache hits", got)
+	}
+	if got := testutil.CollectAndCount(CostTotal); got != 0 {
+		t.Errorf("cost series = %d, want 0 for cache hits", got)
+	}
+}
+
+func TestNewUsageMetricsTap_NilLogger(t *testing.T) {
+	if NewUsageMetricsTap(nil) != nil {
+		t.Fatal("NewUsageMetricsTap(nil) should return nil")
+	}
+}
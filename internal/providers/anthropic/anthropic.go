@@ -4,10 +4,13 @@ package anthropic
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"maps"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -46,6 +49,9 @@ var allowedAnthropicImageMediaTypes = map[string]struct{}{
 type Provider struct {
 	client *llmclient.Client
 	keys   *providers.Keyring
+	// defaultMaxTokens is the configured max_tokens for requests that omit
+	// it; 0 falls back to ANTHROPIC_DEFAULT_MAX_TOKENS.
+	defaultMaxTokens int
 
 	batchEndpointsMu sync.RWMutex
 	// batchResultEndpoints keeps endpoint hints by provider batch id and custom_id.
@@ -57,6 +63,7 @@ type Provider struct {
 func New(providerCfg providers.ProviderConfig, opts providers.ProviderOptions) core.Provider {
 	p := &Provider{
 		keys:                 opts.Keyring(providerCfg.APIKey),
+		defaultMaxTokens:     providerCfg.DefaultMaxTokens,
 		batchResultEndpoints: make(map[string]map[string]string),
 	}
 	clientCfg := llmclient.Config{
@@ -65,6 +72,9 @@ func New(providerCfg providers.ProviderConfig, opts providers.ProviderOptions) c
 		Retry:          opts.Resilience.Retry,
 		Hooks:          opts.Hooks,
 		CircuitBreaker: opts.Resilience.CircuitBreaker,
+		Queue:          opts.Queue,
+		TokenPacer:     opts.TokenPacer,
+		HTTPClient:     opts.HTTPClient,
 	}
 	p.client = llmclient.New(clientCfg, p.setHeaders)
 	return p
@@ -91,6 +101,16 @@ func (p *Provider) SetBaseURL(url string) {
 	p.client.SetBaseURL(url)
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	return p.client.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.client.CircuitState()
+}
+
 func cloneBatchResultEndpoints(endpoints map[string]string) map[string]string {
 	if len(endpoints) == 0 {
 		return nil
@@ -221,35 +241,85 @@ func normalizeEffort(effort string) string {
 	}
 }
 
-// ListModels retrieves the list of available models from Anthropic's /v1/models endpoint
+// fallbackModels is returned by ListModels alongside the error when
+// /v1/models cannot be reached, so a registry with nothing cached can still
+// start offline. The IDs are the current models in Anthropic's published
+// models overview; deprecated models are left out. The live endpoint is the
+// source of truth whenever it answers.
+var fallbackModels = []string{
+	"claude-opus-4-6",
+	"claude-sonnet-4-6",
+	"claude-opus-4-5-20251101",
+	"claude-sonnet-4-5-20250929",
+	"claude-haiku-4-5-20251001",
+	"claude-opus-4-1-20250805",
+	"claude-opus-4-20250514",
+	"claude-sonnet-4-20250514",
+}
+
+// ListModels retrieves the list of available models from Anthropic's /v1/models endpoint,
+// following after_id pagination until has_more is false. When the endpoint
+// cannot be reached at all it returns fallbackModels together with the
+// error, leaving the choice to the caller: the model registry keeps any
+// inventory it already has and uses the list only when it has none. Errors
+// the API does answer with, such as a rejected key, come without a list.
 func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
-	var anthropicResp anthropicModelsResponse
-	err := p.client.Do(ctx, llmclient.Request{
-		Method:   http.MethodGet,
-		Endpoint: "/models?limit=1000",
-	}, &anthropicResp)
-	if err != nil {
-		return nil, err
-	}
+	var models []core.Model
+	afterID := ""
+	for {
+		endpoint := "/models?limit=1000"
+		if afterID != "" {
+			endpoint += "&after_id=" + url.QueryEscape(afterID)
+		}
 
-	// Convert to core.Model format
-	models := make([]core.Model, 0, len(anthropicResp.Data))
-	for _, m := range anthropicResp.Data {
-		created := parseCreatedAt(m.CreatedAt)
-		models = append(models, core.Model{
-			ID:      m.ID,
-			Object:  "model",
-			OwnedBy: "anthropic",
-			Created: created,
-		})
+		var anthropicResp anthropicModelsResponse
+		err := p.client.Do(ctx, llmclient.Request{
+			Method:   http.MethodGet,
+			Endpoint: endpoint,
+		}, &anthropicResp)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && ctx.Err() == nil {
+				return fallbackModelsResponse(), err
+			}
+			return nil, err
+		}
+
+		// Convert to core.Model format
+		for _, m := range anthropicResp.Data {
+			created := parseCreatedAt(m.CreatedAt)
+			models = append(models, core.Model{
+				ID:      m.ID,
+				Object:  "model",
+				OwnedBy: "anthropic",
+				Created: created,
+			})
+		}
+
+		// A page that does not advance the cursor would loop forever.
+		if !anthropicResp.HasMore || anthropicResp.LastID == "" || anthropicResp.LastID == afterID {
+			break
+		}
+		afterID = anthropicResp.LastID
 	}
 
+	if models == nil {
+		models = []core.Model{}
+	}
 	return &core.ModelsResponse{
 		Object: "list",
 		Data:   models,
 	}, nil
 }
 
+func fallbackModelsResponse() *core.ModelsResponse {
+	models := make([]core.Model, 0, len(fallbackModels))
+	for _, id := range fallbackModels {
+		models = append(models, core.Model{ID: id, Object: "model", OwnedBy: "anthropic"})
+	}
+	return &core.ModelsResponse{Object: "list", Data: models}
+}
+
 // parseCreatedAt parses an RFC3339 timestamp string to Unix timestamp
 func parseCreatedAt(createdAt string) int64 {
 	t, err := time.Parse(time.RFC3339, createdAt)
@@ -353,20 +423,26 @@ func buildAnthropicRawUsage(u anthropicUsage) map[string]any {
 	return raw
 }
 
+// anthropicPromptTokensDetails mirrors cache reads into the OpenAI-style
+// prompt_tokens_details.cached_tokens so clients reading the standard field see
+// Anthropic cache hits. Anthropic's input_tokens excludes cached tokens, so the
+// count is not part of prompt_tokens here.
+func anthropicPromptTokensDetails(u anthropicUsage) *core.PromptTokensDetails {
+	if u.CacheReadInputTokens <= 0 {
+		return nil
+	}
+	return &core.PromptTokensDetails{CachedTokens: u.CacheReadInputTokens}
+}
+
 func malformedAnthropicStreamError(err error) error {
 	return core.NewProviderError("anthropic", http.StatusBadGateway, "failed to decode anthropic stream event: "+err.Error(), err)
 }
 
-func consumeAnthropicSSELine(p []byte, line []byte, body io.ReadCloser, buffer *streaming.StreamBuffer, convert func(*anthropicStreamEvent) string) (n int, handled bool, err error) {
-	line = bytes.TrimSpace(line)
-	if len(line) == 0 || bytes.HasPrefix(line, []byte("event:")) {
+func consumeAnthropicSSEEvent(p []byte, data []byte, body io.ReadCloser, buffer *streaming.StreamBuffer, convert func(*anthropicStreamEvent) string) (n int, handled bool, err error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
 		return 0, false, nil
 	}
-	if !bytes.HasPrefix(line, []byte("data:")) {
-		return 0, false, nil
-	}
-
-	data := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data:")))
 
 	var event anthropicStreamEvent
 	if err := json.Unmarshal(data, &event); err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/enterpilot/gomodel/internal/core"
 	"github.com/enterpilot/gomodel/internal/llmclient"
 	"github.com/enterpilot/gomodel/internal/providers"
+	"github.com/enterpilot/gomodel/internal/streaming"
 )
 
 func TestNew(t *testing.T) {
@@ -40,7 +41,7 @@ func TestNew_ReturnsProvider(t *testing.T) {
 }
 
 func TestStreamConverter_DrainsBufferedDoneMessage(t *testing.T) {
-	stream := newStreamConverter(io.NopCloser(strings.NewReader("")), "claude-sonnet-4-5-20250929")
+	stream := newStreamConverter(context.Background(), io.NopCloser(strings.NewReader("")), "claude-sonnet-4-5-20250929")
 	defer func() { _ = stream.Close() }()
 
 	buf := make([]byte, 4)
@@ -64,6 +65,121 @@ func TestStreamConverter_DrainsBufferedDoneMessage(t *testing.T) {
 	}
 }
 
+func TestStreamConverter_FailsOnOversizedEvent(t *testing.T) {
+	body := &closeTrackingBody{Reader: strings.NewReader(
+		"event: content_block_delta\ndata: {\"text\":\"" + strings.Repeat("a", streaming.DefaultMaxEventBytes) + "\"}\n\n",
+	)}
+	stream := newStreamConverter(context.Background(), body, "claude-sonnet-4-5-20250929")
+
+	_, err := stream.Read(make([]byte, 1024))
+	if !errors.Is(err, streaming.ErrEventTooLarge) {
+		t.Fatalf("Read() error = %v, want ErrEventTooLarge", err)
+	}
+	if !body.closed {
+		t.Fatal("upstream body was not closed after an oversized event")
+	}
+	if _, err := stream.Read(make([]byte, 1024)); err != io.EOF {
+		t.Fatalf("Read() after failure error = %v, want io.EOF", err)
+	}
+}
+
+func TestStreamConverters_StopOnContextCancel(t *testing.T) {
+	events := "event: message_start\n" +
+		`data: {"type":"message_start","message":{"id":"msg_1","role":"assistant"}}` + "\n\n" +
+		"event: content_block_delta\n" +
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hi"}}` + "\n\n"
+
+	converters := map[string]func(context.Context, io.ReadCloser) io.ReadCloser{
+		"chat": func(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+			return newStreamConverter(ctx, body, "claude-sonnet-4-5")
+		},
+		"responses": func(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+			return newResponsesStreamConverter(ctx, body, "claude-sonnet-4-5")
+		},
+	}
+	for name, newConverter := range converters {
+		t.Run(name, func(t *testing.T) {
+			body := &closeTrackingBody{Reader: strings.NewReader(events)}
+			ctx, cancel := context.WithCancel(context.Background())
+			stream := newConverter(ctx, body)
+
+			if n, err := stream.Read(make([]byte, 4096)); err != nil || n == 0 {
+				t.Fatalf("first Read() = %d, %v; want data", n, err)
+			}
+			cancel()
+
+			var err error
+			for range 4 {
+				if _, err = stream.Read(make([]byte, 4096)); err != nil {
+					break
+				}
+			}
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Read() after cancel error = %v, want context.Canceled", err)
+			}
+			if !body.closed {
+				t.Fatal("upstream body was not closed after context cancellation")
+			}
+		})
+	}
+}
+
+func TestStreamConverter_SSEFramingVariantsMatchPlainOutput(t *testing.T) {
+	plain := "event: message_start\n" +
+		`data: {"type":"message_start","message":{"id":"msg_1","usage":{"input_tokens":3}}}` + "\n\n" +
+		"event: content_block_delta\n" +
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hi"}}` + "\n\n" +
+		"event: message_delta\n" +
+		`data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":1}}` + "\n\n"
+
+	variants := map[string]string{
+		"crlf": strings.ReplaceAll(plain, "\n", "\r\n"),
+		"multi-line data": "event: message_start\n" +
+			`data: {"type":"message_start",` + "\n" +
+			`data: "message":{"id":"msg_1","usage":{"input_tokens":3}}}` + "\n\n" +
+			"event: content_block_delta\n" +
+			`data: {"type":"content_block_delta","index":0,` + "\n" +
+			`data: "delta":{"type":"text_delta","text":"Hi"}}` + "\n\n" +
+			"event: message_delta\n" +
+			`data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":1}}` + "\n\n",
+		"comments and heartbeats": ": connected\n\n" + strings.Replace(plain,
+			"event: content_block_delta\n", ": keep-alive\nevent: content_block_delta\n", 1) + ":\n\n",
+	}
+
+	readAll := func(body string) string {
+		t.Helper()
+		stream := newStreamConverter(context.Background(), io.NopCloser(strings.NewReader(body)), "claude-sonnet-4-5-20250929")
+		stream.created = 1700000000
+		out, err := io.ReadAll(stream)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		return string(out)
+	}
+
+	want := readAll(plain)
+	if !strings.Contains(want, `"content":"Hi"`) || !strings.HasSuffix(want, "data: [DONE]\n\n") {
+		t.Fatalf("plain output = %q, want a text chunk and [DONE]", want)
+	}
+	for name, body := range variants {
+		t.Run(name, func(t *testing.T) {
+			if got := readAll(body); got != want {
+				t.Fatalf("output = %q\nwant %q", got, want)
+			}
+		})
+	}
+}
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
 func TestSetBatchResultEndpoints_PreservesOlderBatches(t *testing.T) {
 	provider := &Provider{
 		batchResultEndpoints: make(map[string]map[string]string),
@@ -616,6 +732,9 @@ data: {"type":"message_stop"}
 	if !strings.Contains(responseStr, `"cache_read_input_tokens":6`) {
 		t.Fatalf("expected cache_read_input_tokens in streamed usage, got %q", responseStr)
 	}
+	if !strings.Contains(responseStr, `"prompt_tokens_details":{"cached_tokens":6}`) {
+		t.Fatalf("expected prompt_tokens_details.cached_tokens in streamed usage, got %q", responseStr)
+	}
 }
 
 func TestStreamChatCompletion_WithToolCalls(t *testing.T) {
@@ -1093,6 +1212,38 @@ func TestListModels(t *testing.T) {
 	}
 }
 
+func TestListModels_FollowsPagination(t *testing.T) {
+	var afterIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		afterID := r.URL.Query().Get("after_id")
+		afterIDs = append(afterIDs, afterID)
+		switch afterID {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"claude-opus-4-5-20251101","created_at":"2025-11-01T00:00:00Z"}],"has_more":true,"last_id":"claude-opus-4-5-20251101"}`))
+		case "claude-opus-4-5-20251101":
+			_, _ = w.Write([]byte(`{"data":[{"id":"claude-3-haiku-20240307","created_at":"2024-03-07T00:00:00Z"}],"has_more":false,"last_id":"claude-3-haiku-20240307"}`))
+		default:
+			t.Errorf("unexpected after_id %q", afterID)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].ID != "claude-opus-4-5-20251101" || resp.Data[1].ID != "claude-3-haiku-20240307" {
+		t.Fatalf("Data = %+v, want both pages in order", resp.Data)
+	}
+	if len(afterIDs) != 2 {
+		t.Fatalf("requests = %d (after_id %q), want 2", len(afterIDs), afterIDs)
+	}
+}
+
 func TestListModels_APIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusUnauthorized)
@@ -1109,6 +1260,31 @@ func TestListModels_APIError(t *testing.T) {
 	}
 }
 
+func TestListModels_FallsBackToBuiltInListWhenOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	baseURL := server.URL
+	server.Close()
+
+	// Zero resilience settings mean no retries, so the dial fails at once.
+	provider := New(providers.ProviderConfig{APIKey: "test-api-key", BaseURL: baseURL}, providers.ProviderOptions{})
+
+	resp, err := provider.ListModels(context.Background())
+	if err == nil {
+		t.Fatal("ListModels() error = nil, want the network error alongside the built-in list")
+	}
+	if resp == nil {
+		t.Fatal("ListModels() response = nil, want the built-in list")
+	}
+	if len(resp.Data) != len(fallbackModels) {
+		t.Fatalf("len(Data) = %d, want %d", len(resp.Data), len(fallbackModels))
+	}
+	for i, model := range resp.Data {
+		if model.ID != fallbackModels[i] || model.OwnedBy != "anthropic" || model.Object != "model" {
+			t.Errorf("Data[%d] = %+v, want %s owned by anthropic", i, model, fallbackModels[i])
+		}
+	}
+}
+
 func TestParseCreatedAt(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1450,6 +1626,35 @@ func TestConvertToAnthropicRequest_RejectsUnsupportedChatExtras(t *testing.T) {
 	}
 }
 
+func TestConvertToAnthropicRequest_RejectsAudioOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  core.ChatRequest
+	}{
+		{name: "audio modality", req: core.ChatRequest{Modalities: []string{"text", "audio"}}},
+		{name: "audio config", req: core.ChatRequest{Audio: &core.ChatAudioConfig{Voice: "alloy", Format: "wav"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			req.Model = "claude-sonnet-4-5-20250929"
+			req.Messages = []core.Message{{Role: "user", Content: "hi"}}
+			_, err := convertToAnthropicRequest(&req)
+			var gatewayErr *core.GatewayError
+			if !errors.As(err, &gatewayErr) {
+				t.Fatalf("error = %v, want *core.GatewayError", err)
+			}
+			if gatewayErr.Type != core.ErrorTypeInvalidRequest {
+				t.Fatalf("error type = %q, want %q", gatewayErr.Type, core.ErrorTypeInvalidRequest)
+			}
+			if !strings.Contains(gatewayErr.Message, "audio output") {
+				t.Fatalf("error message = %q, want mention of audio output", gatewayErr.Message)
+			}
+		})
+	}
+}
+
 func TestConvertToAnthropicRequest_IgnoresNoopChatExtras(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -1940,6 +2145,69 @@ func TestConvertToAnthropicRequest_InvalidToolChoice(t *testing.T) {
 	}
 }
 
+func TestConvertToAnthropicRequest_ToolChoiceForms(t *testing.T) {
+	tools := []map[string]any{
+		{"type": "function", "function": map[string]any{"name": "lookup_weather"}},
+		{"type": "function", "function": map[string]any{"name": "lookup_time"}},
+	}
+
+	tests := []struct {
+		name       string
+		toolChoice any
+		wantChoice *anthropicToolChoice
+		wantTools  int
+		wantErr    string
+	}{
+		{name: "unset", toolChoice: nil, wantTools: 2},
+		{name: "auto", toolChoice: "auto", wantChoice: &anthropicToolChoice{Type: "auto"}, wantTools: 2},
+		{name: "required", toolChoice: "required", wantChoice: &anthropicToolChoice{Type: "any"}, wantTools: 2},
+		{name: "none drops tools", toolChoice: "none", wantTools: 0},
+		{
+			name:       "named function",
+			toolChoice: map[string]any{"type": "function", "function": map[string]any{"name": "lookup_time"}},
+			wantChoice: &anthropicToolChoice{Type: "tool", Name: "lookup_time"},
+			wantTools:  2,
+		},
+		{
+			name:       "named function not declared",
+			toolChoice: map[string]any{"type": "function", "function": map[string]any{"name": "book_flight"}},
+			wantErr:    `tool_choice forces tool "book_flight"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := convertToAnthropicRequest(&core.ChatRequest{
+				Model:      "claude-sonnet-4-5-20250929",
+				Messages:   []core.Message{{Role: "user", Content: "Hello"}},
+				Tools:      tools,
+				ToolChoice: tt.toolChoice,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(req.Tools) != tt.wantTools {
+				t.Fatalf("len(Tools) = %d, want %d", len(req.Tools), tt.wantTools)
+			}
+			if tt.wantChoice == nil {
+				if req.ToolChoice != nil {
+					t.Fatalf("ToolChoice = %+v, want nil", req.ToolChoice)
+				}
+				return
+			}
+			if req.ToolChoice == nil || req.ToolChoice.Type != tt.wantChoice.Type || req.ToolChoice.Name != tt.wantChoice.Name {
+				t.Fatalf("ToolChoice = %+v, want %+v", req.ToolChoice, tt.wantChoice)
+			}
+		})
+	}
+}
+
 func TestConvertToAnthropicRequest_ToolMessageRequiresToolCallID(t *testing.T) {
 	_, err := convertToAnthropicRequest(&core.ChatRequest{
 		Model: "claude-sonnet-4-5-20250929",
@@ -2231,6 +2499,9 @@ func TestConvertFromAnthropicResponse_WithCacheFields(t *testing.T) {
 	if result.Usage.RawUsage["cache_read_input_tokens"] != 30 {
 		t.Errorf("RawUsage[cache_read_input_tokens] = %v, want 30", result.Usage.RawUsage["cache_read_input_tokens"])
 	}
+	if result.Usage.PromptTokensDetails == nil || result.Usage.PromptTokensDetails.CachedTokens != 30 {
+		t.Errorf("PromptTokensDetails = %+v, want cached_tokens 30", result.Usage.PromptTokensDetails)
+	}
 }
 
 func TestConvertFromAnthropicResponse_NoCacheFields(t *testing.T) {
@@ -2254,6 +2525,9 @@ func TestConvertFromAnthropicResponse_NoCacheFields(t *testing.T) {
 	if result.Usage.RawUsage != nil {
 		t.Errorf("expected RawUsage to be nil when no cache fields, got %v", result.Usage.RawUsage)
 	}
+	if result.Usage.PromptTokensDetails != nil {
+		t.Errorf("expected PromptTokensDetails to be nil when no cache fields, got %+v", result.Usage.PromptTokensDetails)
+	}
 }
 
 func TestConvertAnthropicResponseToResponses_WithCacheFields(t *testing.T) {
@@ -2288,6 +2562,9 @@ func TestConvertAnthropicResponseToResponses_WithCacheFields(t *testing.T) {
 	if result.Usage.RawUsage["cache_read_input_tokens"] != 60 {
 		t.Errorf("RawUsage[cache_read_input_tokens] = %v, want 60", result.Usage.RawUsage["cache_read_input_tokens"])
 	}
+	if result.Usage.PromptTokensDetails == nil || result.Usage.PromptTokensDetails.CachedTokens != 60 {
+		t.Errorf("PromptTokensDetails = %+v, want cached_tokens 60", result.Usage.PromptTokensDetails)
+	}
 }
 
 func TestConvertFromAnthropicResponse_WithThinkingBlocks(t *testing.T) {
@@ -3004,6 +3281,222 @@ data: {"type":"message_stop"}
 	}
 }
 
+func TestResponsesStreamConverter_WebSearchServerTool(t *testing.T) {
+	stream := `event: message_start
+data: {"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","content":[],"usage":{"input_tokens":10,"output_tokens":0}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"server_tool_use","id":"srvtoolu_1","name":"web_search","input":{}}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"query\":\"go 1.25"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":" release\"}"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: content_block_start
+data: {"type":"content_block_start","index":1,"content_block":{"type":"web_search_tool_result","tool_use_id":"srvtoolu_1","content":[{"type":"web_search_result","url":"https://go.dev/doc/go1.25","title":"Go 1.25 Release Notes","encrypted_content":"abc"}]}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":1}
+
+event: content_block_start
+data: {"type":"content_block_start","index":2,"content_block":{"type":"text","text":""}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":2,"delta":{"type":"text_delta","text":"Go 1.25 shipped in August."}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":2}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":12}}
+
+event: message_stop
+data: {"type":"message_stop"}
+`
+	converter := newResponsesStreamConverter(context.Background(), io.NopCloser(strings.NewReader(stream)), "claude-sonnet-4-5")
+	raw, err := io.ReadAll(converter)
+	if err != nil {
+		t.Fatalf("failed to read converted stream: %v", err)
+	}
+
+	var names []string
+	var searchDone, assistantDone map[string]any
+	for _, event := range parseTestSSEEvents(t, string(raw)) {
+		if event.Done {
+			continue
+		}
+		names = append(names, event.Name)
+		if event.Name != "response.output_item.done" {
+			continue
+		}
+		item, _ := event.Payload["item"].(map[string]any)
+		switch item["type"] {
+		case "web_search_call":
+			if event.Payload["output_index"] != float64(0) {
+				t.Fatalf("web_search_call output_index = %v, want 0", event.Payload["output_index"])
+			}
+			searchDone = item
+		case "message":
+			if event.Payload["output_index"] != float64(1) {
+				t.Fatalf("assistant message output_index = %v, want 1", event.Payload["output_index"])
+			}
+			assistantDone = item
+		}
+	}
+
+	wantOrder := []string{
+		"response.created",
+		"response.output_item.added",
+		"response.web_search_call.in_progress",
+		"response.web_search_call.searching",
+		"response.web_search_call.completed",
+		"response.output_item.done",
+		"response.output_item.added",
+		"response.output_text.delta",
+		"response.output_item.done",
+		"response.completed",
+	}
+	if strings.Join(names, ",") != strings.Join(wantOrder, ",") {
+		t.Fatalf("events = %v, want %v", names, wantOrder)
+	}
+	if searchDone == nil {
+		t.Fatal("expected response.output_item.done for web_search_call")
+	}
+	if searchDone["id"] != "ws_srvtoolu_1" || searchDone["status"] != "completed" {
+		t.Fatalf("web_search_call item = %v, want id ws_srvtoolu_1 completed", searchDone)
+	}
+	action, _ := searchDone["action"].(map[string]any)
+	if action["type"] != "search" || action["query"] != "go 1.25 release" {
+		t.Fatalf("web_search_call action = %v, want search for the streamed query", action)
+	}
+	sources, _ := action["sources"].([]any)
+	if len(sources) != 1 || sources[0].(map[string]any)["url"] != "https://go.dev/doc/go1.25" {
+		t.Fatalf("web_search_call sources = %v, want the result URL", action["sources"])
+	}
+	if assistantDone == nil {
+		t.Fatal("expected response.output_item.done for the assistant message")
+	}
+	content, _ := assistantDone["content"].([]any)
+	if len(content) != 1 || content[0].(map[string]any)["text"] != "Go 1.25 shipped in August." {
+		t.Fatalf("assistant content = %v, want text after the search", assistantDone["content"])
+	}
+}
+
+func TestResponsesStreamConverter_CodeExecutionServerTool(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     string
+		wantStatus string
+		wantLogs   string
+	}{
+		{
+			name:       "success",
+			result:     `{"type":"code_execution_result","stdout":"4\n","stderr":"","return_code":0,"content":[]}`,
+			wantStatus: "completed",
+			wantLogs:   "4\n",
+		},
+		{
+			name:       "error",
+			result:     `{"type":"code_execution_tool_result_error","error_code":"unavailable"}`,
+			wantStatus: "failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := `event: message_start
+data: {"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","content":[],"usage":{"input_tokens":10,"output_tokens":0}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Running it."}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: content_block_start
+data: {"type":"content_block_start","index":1,"content_block":{"type":"server_tool_use","id":"srvtoolu_2","name":"code_execution","input":{}}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"code\":\"print(2+2)\"}"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":1}
+
+event: content_block_start
+data: {"type":"content_block_start","index":2,"content_block":{"type":"code_execution_tool_result","tool_use_id":"srvtoolu_2","content":` + tt.result + `}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":2}
+
+event: message_stop
+data: {"type":"message_stop"}
+`
+			converter := newResponsesStreamConverter(context.Background(), io.NopCloser(strings.NewReader(stream)), "claude-sonnet-4-5")
+			raw, err := io.ReadAll(converter)
+			if err != nil {
+				t.Fatalf("failed to read converted stream: %v", err)
+			}
+
+			var codeDone string
+			var item map[string]any
+			seen := map[string]bool{}
+			for _, event := range parseTestSSEEvents(t, string(raw)) {
+				if event.Done {
+					continue
+				}
+				seen[event.Name] = true
+				if event.Name == "response.code_interpreter_call_code.done" {
+					codeDone, _ = event.Payload["code"].(string)
+				}
+				if event.Name == "response.output_item.done" {
+					if done, _ := event.Payload["item"].(map[string]any); done["type"] == "code_interpreter_call" {
+						if event.Payload["output_index"] != float64(1) {
+							t.Fatalf("code_interpreter_call output_index = %v, want 1", event.Payload["output_index"])
+						}
+						item = done
+					}
+				}
+			}
+
+			for _, name := range []string{
+				"response.code_interpreter_call.in_progress",
+				"response.code_interpreter_call.interpreting",
+				"response.code_interpreter_call.completed",
+			} {
+				if !seen[name] {
+					t.Fatalf("missing %s event", name)
+				}
+			}
+			if codeDone != "print(2+2)" {
+				t.Fatalf("code_interpreter_call_code.done code = %q, want print(2+2)", codeDone)
+			}
+			if item == nil {
+				t.Fatal("expected response.output_item.done for code_interpreter_call")
+			}
+			if item["id"] != "ci_srvtoolu_2" || item["status"] != tt.wantStatus || item["code"] != "print(2+2)" {
+				t.Fatalf("code_interpreter_call item = %v, want ci_srvtoolu_2 %s", item, tt.wantStatus)
+			}
+			outputs, _ := item["outputs"].([]any)
+			if tt.wantLogs == "" {
+				if len(outputs) != 0 {
+					t.Fatalf("outputs = %v, want none", outputs)
+				}
+				return
+			}
+			if len(outputs) != 1 || outputs[0].(map[string]any)["logs"] != tt.wantLogs {
+				t.Fatalf("outputs = %v, want logs %q", outputs, tt.wantLogs)
+			}
+		})
+	}
+}
+
 func TestStreamResponses_WithEmptyToolArguments(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -3427,7 +3920,7 @@ func TestBuildAnthropicBatchCreateRequest_PreservesGatewayErrorDetails(t *testin
 		},
 	}
 
-	_, _, err := buildAnthropicBatchCreateRequest(req)
+	_, _, err := buildAnthropicBatchCreateRequest(req, 0)
 	if err == nil {
 		t.Fatal("expected invalid request error, got nil")
 	}
@@ -3466,7 +3959,7 @@ func TestBuildAnthropicBatchCreateRequest_PrefixesToolArgumentErrors(t *testing.
 		},
 	}
 
-	_, _, err := buildAnthropicBatchCreateRequest(req)
+	_, _, err := buildAnthropicBatchCreateRequest(req, 0)
 	if err == nil {
 		t.Fatal("expected invalid request error, got nil")
 	}
@@ -3497,7 +3990,7 @@ func TestBuildAnthropicBatchCreateRequest_NormalizesFullURLResponsesEndpoint(t *
 		},
 	}
 
-	anthropicReq, endpointByCustomID, err := buildAnthropicBatchCreateRequest(req)
+	anthropicReq, endpointByCustomID, err := buildAnthropicBatchCreateRequest(req, 0)
 	if err != nil {
 		t.Fatalf("buildAnthropicBatchCreateRequest() error = %v", err)
 	}
@@ -3540,7 +4033,7 @@ func TestBuildAnthropicBatchCreateRequest_RejectsDuplicateCustomIDs(t *testing.T
 		},
 	}
 
-	_, _, err := buildAnthropicBatchCreateRequest(req)
+	_, _, err := buildAnthropicBatchCreateRequest(req, 0)
 	if err == nil {
 		t.Fatal("expected error for duplicate custom_id")
 	}
@@ -5098,3 +5591,133 @@ func TestConvertToAnthropicRequest_HonoursDefaultMaxTokensEnv(t *testing.T) {
 		t.Errorf("MaxTokens = %d, want 32768", got.MaxTokens)
 	}
 }
+
+func TestChatCompletion_DefaultMaxTokens(t *testing.T) {
+	explicit := 512
+	tests := []struct {
+		name             string
+		defaultMaxTokens int
+		maxTokens        *int
+		want             int
+	}{
+		{name: "provider default fills omitted max_tokens", defaultMaxTokens: 16000, want: 16000},
+		{name: "explicit max_tokens wins over provider default", defaultMaxTokens: 16000, maxTokens: &explicit, want: 512},
+		{name: "unset provider default uses fallback", want: fallbackMaxTokens},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(defaultMaxTokensEnvVar, "")
+			var gotMaxTokens int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body anthropicRequest
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode request body: %v", err)
+				}
+				gotMaxTokens = body.MaxTokens
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-6","content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`))
+			}))
+			defer server.Close()
+
+			provider := New(providers.ProviderConfig{
+				APIKey:           "test-api-key",
+				BaseURL:          server.URL,
+				DefaultMaxTokens: tt.defaultMaxTokens,
+			}, providers.ProviderOptions{}).(*Provider)
+
+			req := &core.ChatRequest{
+				Model:     "claude-sonnet-4-6",
+				Messages:  []core.Message{{Role: "user", Content: "Hello"}},
+				MaxTokens: tt.maxTokens,
+			}
+			if _, err := provider.ChatCompletion(context.Background(), req); err != nil {
+				t.Fatalf("ChatCompletion() error = %v", err)
+			}
+			if gotMaxTokens != tt.want {
+				t.Fatalf("max_tokens = %d, want %d", gotMaxTokens, tt.want)
+			}
+			if tt.maxTokens == nil && req.MaxTokens != nil {
+				t.Fatal("caller request was mutated")
+			}
+		})
+	}
+}
+
+func TestChatCompletion_SamplingParamsInUpstreamBody(t *testing.T) {
+	var body map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-6","content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	provider := New(providers.ProviderConfig{
+		APIKey:  "test-api-key",
+		BaseURL: server.URL,
+	}, providers.ProviderOptions{}).(*Provider)
+
+	topP := 0.9
+	_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "claude-sonnet-4-6",
+		Messages: []core.Message{{Role: "user", Content: "Hello"}},
+		TopP:     &topP,
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"frequency_penalty": json.RawMessage("0.5"),
+			"presence_penalty":  json.RawMessage("0.25"),
+			"seed":              json.RawMessage("42"),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+
+	if got := string(body["top_p"]); got != "0.9" {
+		t.Fatalf("top_p = %q, want 0.9", got)
+	}
+	// Anthropic has no penalty or seed parameters and rejects unknown fields,
+	// so they must be dropped rather than forwarded.
+	for _, field := range []string{"frequency_penalty", "presence_penalty", "seed"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("%s was forwarded to Anthropic: %s", field, body[field])
+		}
+	}
+}
+
+func TestBuildAnthropicBatchCreateRequest_AppliesDefaultMaxTokens(t *testing.T) {
+	req := &core.BatchRequest{
+		Requests: []core.BatchRequestItem{
+			{
+				CustomID: "chat-1",
+				Method:   http.MethodPost,
+				URL:      "/v1/chat/completions",
+				Body:     json.RawMessage(`{"model":"claude-sonnet-4-6","messages":[{"role":"user","content":"hi"}]}`),
+			},
+			{
+				CustomID: "chat-2",
+				Method:   http.MethodPost,
+				URL:      "/v1/chat/completions",
+				Body:     json.RawMessage(`{"model":"claude-sonnet-4-6","max_tokens":256,"messages":[{"role":"user","content":"hi"}]}`),
+			},
+			{
+				CustomID: "resp-1",
+				Method:   http.MethodPost,
+				URL:      "/v1/responses",
+				Body:     json.RawMessage(`{"model":"claude-sonnet-4-6","input":"hi"}`),
+			},
+		},
+	}
+
+	anthropicReq, _, err := buildAnthropicBatchCreateRequest(req, 12000)
+	if err != nil {
+		t.Fatalf("buildAnthropicBatchCreateRequest() error = %v", err)
+	}
+	want := []int{12000, 256, 12000}
+	for i, item := range anthropicReq.Requests {
+		if item.Params.MaxTokens != want[i] {
+			t.Errorf("Requests[%d].Params.MaxTokens = %d, want %d", i, item.Params.MaxTokens, want[i])
+		}
+	}
+}
@@ -70,7 +70,10 @@ func mapAnthropicBatchResponse(resp *anthropicBatchResponse) *core.BatchResponse
 	}
 }
 
-func buildAnthropicBatchCreateRequest(req *core.BatchRequest) (*anthropicBatchCreateRequest, map[string]string, error) {
+// buildAnthropicBatchCreateRequest converts a batch into Anthropic's format.
+// defaultMaxTokens fills max_tokens on items that omit it; 0 leaves the
+// conversion default in place.
+func buildAnthropicBatchCreateRequest(req *core.BatchRequest, defaultMaxTokens int) (*anthropicBatchCreateRequest, map[string]string, error) {
 	const maxAnthropicBatchRequests = 10000
 
 	if req == nil {
@@ -95,6 +98,13 @@ func buildAnthropicBatchCreateRequest(req *core.BatchRequest) (*anthropicBatchCr
 			return nil, nil, core.NewInvalidRequestError(fmt.Sprintf("batch item %d: %s", i, err.Error()), err)
 		}
 
+		switch itemReq := decoded.Request.(type) {
+		case *core.ChatRequest:
+			decoded.Request = withDefaultMaxTokens(itemReq, defaultMaxTokens)
+		case *core.ResponsesRequest:
+			decoded.Request = withDefaultMaxOutputTokens(itemReq, defaultMaxTokens)
+		}
+
 		params, err := convertDecodedBatchItemToAnthropic(decoded)
 		if err != nil {
 			return nil, nil, prefixAnthropicBatchItemError(i, err)
@@ -122,7 +132,7 @@ func buildAnthropicBatchCreateRequest(req *core.BatchRequest) (*anthropicBatchCr
 }
 
 func (p *Provider) createBatch(ctx context.Context, req *core.BatchRequest) (*core.BatchResponse, map[string]string, error) {
-	anthropicReq, endpointByCustomID, err := buildAnthropicBatchCreateRequest(req)
+	anthropicReq, endpointByCustomID, err := buildAnthropicBatchCreateRequest(req, p.defaultMaxTokens)
 	if err != nil {
 		return nil, nil, err
 	}
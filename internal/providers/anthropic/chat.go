@@ -23,9 +23,10 @@ func convertFromAnthropicResponse(resp *anthropicResponse) *core.ChatResponse {
 	}
 
 	usage := core.Usage{
-		PromptTokens:     resp.Usage.InputTokens,
-		CompletionTokens: resp.Usage.OutputTokens,
-		TotalTokens:      resp.Usage.InputTokens + resp.Usage.OutputTokens,
+		PromptTokens:        resp.Usage.InputTokens,
+		CompletionTokens:    resp.Usage.OutputTokens,
+		TotalTokens:         resp.Usage.InputTokens + resp.Usage.OutputTokens,
+		PromptTokensDetails: anthropicPromptTokensDetails(resp.Usage),
 	}
 
 	rawUsage := buildAnthropicRawUsage(resp.Usage)
@@ -68,7 +69,7 @@ func convertFromAnthropicResponse(resp *anthropicResponse) *core.ChatResponse {
 
 // ChatCompletion sends a chat completion request to Anthropic
 func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
-	anthropicReq, err := convertToAnthropicRequest(req)
+	anthropicReq, err := convertToAnthropicRequest(withDefaultMaxTokens(req, p.defaultMaxTokens))
 	if err != nil {
 		return nil, err
 	}
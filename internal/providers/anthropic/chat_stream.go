@@ -1,7 +1,6 @@
 package anthropic
 
 import (
-	"bufio"
 	"context"
 	"io"
 	"net/http"
@@ -16,7 +15,7 @@ import (
 
 // StreamChatCompletion returns a raw response body for streaming (caller must close)
 func (p *Provider) StreamChatCompletion(ctx context.Context, req *core.ChatRequest) (io.ReadCloser, error) {
-	anthropicReq, err := convertToAnthropicRequest(req)
+	anthropicReq, err := convertToAnthropicRequest(withDefaultMaxTokens(req, p.defaultMaxTokens))
 	if err != nil {
 		return nil, err
 	}
@@ -32,12 +31,13 @@ func (p *Provider) StreamChatCompletion(ctx context.Context, req *core.ChatReque
 	}
 
 	// Return a reader that converts Anthropic SSE format to OpenAI format
-	return newStreamConverter(stream, req.Model), nil
+	return newStreamConverter(ctx, stream, req.Model), nil
 }
 
 // streamConverter wraps an Anthropic stream and converts it to OpenAI format
 type streamConverter struct {
-	reader            *bufio.Reader
+	ctx               context.Context
+	events            *streaming.SSEReader
 	body              io.ReadCloser
 	model             string
 	msgID             string
@@ -63,9 +63,12 @@ type streamToolCallState struct {
 	PlaceholderObject bool
 }
 
-func newStreamConverter(body io.ReadCloser, model string) *streamConverter {
+// newStreamConverter converts body until EOF or until ctx (the client
+// request's context) is cancelled, whichever comes first.
+func newStreamConverter(ctx context.Context, body io.ReadCloser, model string) *streamConverter {
 	return &streamConverter{
-		reader:         bufio.NewReader(body),
+		ctx:            ctx,
+		events:         streaming.NewSSEReader(body),
 		body:           body,
 		model:          model,
 		created:        time.Now().Unix(),
@@ -87,6 +90,7 @@ func anthropicChatUsagePayload(usage *anthropicUsage) map[string]any {
 	}
 	if usage.CacheReadInputTokens > 0 {
 		payload["cache_read_input_tokens"] = usage.CacheReadInputTokens
+		payload["prompt_tokens_details"] = map[string]any{"cached_tokens": usage.CacheReadInputTokens}
 	}
 	if usage.CacheCreationInputTokens > 0 {
 		payload["cache_creation_input_tokens"] = usage.CacheCreationInputTokens
@@ -107,7 +111,14 @@ func (sc *streamConverter) Read(p []byte) (n int, err error) {
 
 	// Read the next SSE event from Anthropic
 	for {
-		line, err := sc.reader.ReadBytes('\n')
+		if ctxErr := sc.ctx.Err(); ctxErr != nil {
+			// The client is gone; stop draining the upstream call.
+			sc.closed = true
+			sc.releaseBuffer()
+			_ = sc.body.Close() //nolint:errcheck
+			return 0, ctxErr
+		}
+		event, err := sc.events.Next()
 		if err != nil {
 			if err == io.EOF {
 				// Send final [DONE] message
@@ -117,10 +128,13 @@ func (sc *streamConverter) Read(p []byte) (n int, err error) {
 				_ = sc.body.Close() //nolint:errcheck
 				return n, nil
 			}
+			sc.closed = true
+			sc.releaseBuffer()
+			_ = sc.body.Close() //nolint:errcheck
 			return 0, err
 		}
 
-		n, handled, err := consumeAnthropicSSELine(p, line, sc.body, &sc.buffer, sc.convertEvent)
+		n, handled, err := consumeAnthropicSSEEvent(p, event.Data, sc.body, &sc.buffer, sc.convertEvent)
 		if err != nil {
 			sc.closed = true
 			sc.releaseBuffer()
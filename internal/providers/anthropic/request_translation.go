@@ -45,6 +45,30 @@ func resolveDefaultMaxTokens() int {
 	return n
 }
 
+// withDefaultMaxTokens returns req with MaxTokens set to defaultMaxTokens when
+// the client omitted it and a provider default is configured. Requests through
+// the router already carry the provider default, clamped to the model's
+// max_output_tokens metadata, so this covers direct calls such as batches.
+// req itself is never mutated.
+func withDefaultMaxTokens(req *core.ChatRequest, defaultMaxTokens int) *core.ChatRequest {
+	if req == nil || req.MaxTokens != nil || defaultMaxTokens <= 0 {
+		return req
+	}
+	cp := *req
+	cp.MaxTokens = &defaultMaxTokens
+	return &cp
+}
+
+// withDefaultMaxOutputTokens is withDefaultMaxTokens for Responses requests.
+func withDefaultMaxOutputTokens(req *core.ResponsesRequest, defaultMaxTokens int) *core.ResponsesRequest {
+	if req == nil || req.MaxOutputTokens != nil || defaultMaxTokens <= 0 {
+		return req
+	}
+	cp := *req
+	cp.MaxOutputTokens = &defaultMaxTokens
+	return &cp
+}
+
 // applyReasoning configures thinking and effort on an anthropicRequest.
 // Adaptive-thinking models (Opus 4.6+) use adaptive thinking with
 // output_config.effort. Older models use manual thinking with budget_tokens.
@@ -292,6 +316,9 @@ func convertToAnthropicRequest(req *core.ChatRequest) (*anthropicRequest, error)
 	if err := validateAnthropicUnsupportedChatExtras(req.ExtraFields); err != nil {
 		return nil, err
 	}
+	if req.RequestsAudioOutput() {
+		return nil, core.NewInvalidRequestError("chat audio output is not supported by Anthropic translation", nil)
+	}
 
 	anthropicReq := &anthropicRequest{
 		Model:         req.Model,
@@ -299,7 +326,7 @@ func convertToAnthropicRequest(req *core.ChatRequest) (*anthropicRequest, error)
 		Temperature:   req.Temperature,
 		TopP:          resolveAnthropicTopP(req),
 		Stream:        req.Stream,
-		StopSequences: stopSequencesFromExtra(req.ExtraFields),
+		StopSequences: req.StopSequences(),
 	}
 
 	if req.MaxTokens != nil {
@@ -610,42 +637,6 @@ func resolveAnthropicTopP(req *core.ChatRequest) *float64 {
 	return &topP
 }
 
-// stopSequencesFromExtra maps the OpenAI-compatible stop field (a string or an
-// array of strings, carried in the request's extra fields) to Anthropic's
-// stop_sequences. Empty or malformed values yield no sequences.
-func stopSequencesFromExtra(extraFields core.UnknownJSONFields) []string {
-	raw := bytes.TrimSpace(extraFields.Lookup("stop"))
-	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
-		return nil
-	}
-
-	switch raw[0] {
-	case '"':
-		var single string
-		if err := json.Unmarshal(raw, &single); err != nil || single == "" {
-			return nil
-		}
-		return []string{single}
-	case '[':
-		var list []string
-		if err := json.Unmarshal(raw, &list); err != nil {
-			return nil
-		}
-		sequences := make([]string, 0, len(list))
-		for _, item := range list {
-			if item != "" {
-				sequences = append(sequences, item)
-			}
-		}
-		if len(sequences) == 0 {
-			return nil
-		}
-		return sequences
-	default:
-		return nil
-	}
-}
-
 func convertMessageContentToAnthropic(content any) (any, error) {
 	if !core.HasStructuredContent(content) {
 		return core.ExtractTextContent(content), nil
@@ -769,10 +760,21 @@ func normalizeAnthropicRequestError(err error) error {
 }
 
 func validateAnthropicToolChoice(toolChoice *anthropicToolChoice, tools []anthropicTool, disableTools bool) error {
-	if disableTools || toolChoice == nil || len(tools) > 0 {
+	if disableTools || toolChoice == nil {
 		return nil
 	}
-	return core.NewInvalidRequestError("tool_choice requires at least one tool", nil)
+	if len(tools) == 0 {
+		return core.NewInvalidRequestError("tool_choice requires at least one tool", nil)
+	}
+	if toolChoice.Type != "tool" {
+		return nil
+	}
+	for _, tool := range tools {
+		if tool.Name == toolChoice.Name {
+			return nil
+		}
+	}
+	return core.NewInvalidRequestError(fmt.Sprintf("tool_choice forces tool %q, which is not declared in tools", toolChoice.Name), nil)
 }
 
 func prefixAnthropicBatchItemError(index int, err error) error {
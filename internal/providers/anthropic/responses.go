@@ -1,7 +1,6 @@
 package anthropic
 
 import (
-	"bufio"
 	"context"
 	"io"
 	"log/slog"
@@ -48,9 +47,10 @@ func convertAnthropicResponseToResponses(resp *anthropicResponse, model string)
 // buildAnthropicResponsesUsage creates a ResponsesUsage from anthropicUsage, including RawUsage.
 func buildAnthropicResponsesUsage(u anthropicUsage) *core.ResponsesUsage {
 	usage := &core.ResponsesUsage{
-		InputTokens:  u.InputTokens,
-		OutputTokens: u.OutputTokens,
-		TotalTokens:  u.InputTokens + u.OutputTokens,
+		InputTokens:         u.InputTokens,
+		OutputTokens:        u.OutputTokens,
+		TotalTokens:         u.InputTokens + u.OutputTokens,
+		PromptTokensDetails: anthropicPromptTokensDetails(u),
 	}
 	rawUsage := buildAnthropicRawUsage(u)
 	if len(rawUsage) > 0 {
@@ -71,6 +71,7 @@ func anthropicResponsesUsagePayload(usage *anthropicUsage) map[string]any {
 	}
 	if usage.CacheReadInputTokens > 0 {
 		payload["cache_read_input_tokens"] = usage.CacheReadInputTokens
+		payload["input_tokens_details"] = map[string]any{"cached_tokens": usage.CacheReadInputTokens}
 	}
 	if usage.CacheCreationInputTokens > 0 {
 		payload["cache_creation_input_tokens"] = usage.CacheCreationInputTokens
@@ -80,7 +81,7 @@ func anthropicResponsesUsagePayload(usage *anthropicUsage) map[string]any {
 
 // Responses sends a Responses API request to Anthropic (converted to messages format)
 func (p *Provider) Responses(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesResponse, error) {
-	anthropicReq, err := convertResponsesRequestToAnthropic(req)
+	anthropicReq, err := convertResponsesRequestToAnthropic(withDefaultMaxOutputTokens(req, p.defaultMaxTokens))
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +101,7 @@ func (p *Provider) Responses(ctx context.Context, req *core.ResponsesRequest) (*
 
 // StreamResponses returns a raw response body for streaming Responses API (caller must close)
 func (p *Provider) StreamResponses(ctx context.Context, req *core.ResponsesRequest) (io.ReadCloser, error) {
-	anthropicReq, err := convertResponsesRequestToAnthropic(req)
+	anthropicReq, err := convertResponsesRequestToAnthropic(withDefaultMaxOutputTokens(req, p.defaultMaxTokens))
 	if err != nil {
 		return nil, err
 	}
@@ -116,39 +117,50 @@ func (p *Provider) StreamResponses(ctx context.Context, req *core.ResponsesReque
 	}
 
 	// Return a reader that converts Anthropic SSE format to Responses API format
-	return newResponsesStreamConverter(stream, req.Model), nil
+	return newResponsesStreamConverter(ctx, stream, req.Model), nil
 }
 
 // responsesStreamConverter wraps an Anthropic stream and converts it to Responses API format
 type responsesStreamConverter struct {
-	reader          *bufio.Reader
+	ctx             context.Context
+	events          *streaming.SSEReader
 	body            io.ReadCloser
 	model           string
 	responseID      string
 	createdAt       int64
 	output          *providers.ResponsesOutputEventState
 	nextOutputIndex int
-	toolCalls       map[int]*providers.ResponsesOutputToolCallState
-	thinkingBlocks  map[int]bool // tracks which content block indices are thinking blocks
-	buffer          streaming.StreamBuffer
-	closed          bool
-	sentDone        bool
-	usage           anthropicUsage
-	hasUsage        bool
+	// assistantOutputIndex is the output index reserved for the assistant
+	// message; server tool items may precede it.
+	assistantOutputIndex int
+	toolCalls            map[int]*providers.ResponsesOutputToolCallState
+	serverTools          map[int]*serverToolCallState    // by content block index
+	serverToolsByID      map[string]*serverToolCallState // by server_tool_use ID, for result blocks
+	thinkingBlocks       map[int]bool                    // tracks which content block indices are thinking blocks
+	buffer               streaming.StreamBuffer
+	closed               bool
+	sentDone             bool
+	usage                anthropicUsage
+	hasUsage             bool
 }
 
-func newResponsesStreamConverter(body io.ReadCloser, model string) *responsesStreamConverter {
+// newResponsesStreamConverter converts body until EOF or until ctx (the
+// client request's context) is cancelled, whichever comes first.
+func newResponsesStreamConverter(ctx context.Context, body io.ReadCloser, model string) *responsesStreamConverter {
 	responseID := "resp_" + uuid.New().String()
 	return &responsesStreamConverter{
-		reader:         bufio.NewReader(body),
-		body:           body,
-		model:          model,
-		responseID:     responseID,
-		createdAt:      time.Now().Unix(),
-		output:         providers.NewResponsesOutputEventState(responseID),
-		toolCalls:      make(map[int]*providers.ResponsesOutputToolCallState),
-		thinkingBlocks: make(map[int]bool),
-		buffer:         streaming.NewStreamBuffer(1024),
+		ctx:             ctx,
+		events:          streaming.NewSSEReader(body),
+		body:            body,
+		model:           model,
+		responseID:      responseID,
+		createdAt:       time.Now().Unix(),
+		output:          providers.NewResponsesOutputEventState(responseID),
+		toolCalls:       make(map[int]*providers.ResponsesOutputToolCallState),
+		serverTools:     make(map[int]*serverToolCallState),
+		serverToolsByID: make(map[string]*serverToolCallState),
+		thinkingBlocks:  make(map[int]bool),
+		buffer:          streaming.NewStreamBuffer(1024),
 	}
 }
 
@@ -165,13 +177,20 @@ func (sc *responsesStreamConverter) Read(p []byte) (n int, err error) {
 
 	// Read the next SSE event from Anthropic
 	for {
-		line, err := sc.reader.ReadBytes('\n')
+		if ctxErr := sc.ctx.Err(); ctxErr != nil {
+			// The client is gone; stop draining the upstream call.
+			sc.closed = true
+			sc.releaseBuffer()
+			_ = sc.body.Close() //nolint:errcheck
+			return 0, ctxErr
+		}
+		event, err := sc.events.Next()
 		if err != nil {
 			if err == io.EOF {
 				// Send final done event and [DONE] message
 				if !sc.sentDone {
 					sc.sentDone = true
-					prefix := sc.output.CompleteAssistantOutput(0)
+					prefix := sc.output.CompleteAssistantOutput(sc.assistantOutputIndex)
 					responseData := map[string]any{
 						"id":         sc.responseID,
 						"object":     "response",
@@ -207,10 +226,13 @@ func (sc *responsesStreamConverter) Read(p []byte) (n int, err error) {
 				_ = sc.body.Close() //nolint:errcheck
 				return 0, io.EOF
 			}
+			sc.closed = true
+			sc.releaseBuffer()
+			_ = sc.body.Close() //nolint:errcheck
 			return 0, err
 		}
 
-		n, handled, err := consumeAnthropicSSELine(p, line, sc.body, &sc.buffer, sc.convertEvent)
+		n, handled, err := consumeAnthropicSSEEvent(p, event.Data, sc.body, &sc.buffer, sc.convertEvent)
 		if err != nil {
 			sc.closed = true
 			sc.releaseBuffer()
@@ -244,6 +266,7 @@ func (sc *responsesStreamConverter) reserveAssistantMessageOutput() {
 		return
 	}
 	sc.output.ReserveAssistant()
+	sc.assistantOutputIndex = sc.nextOutputIndex
 	sc.nextOutputIndex++
 }
 
@@ -294,9 +317,15 @@ func (sc *responsesStreamConverter) convertEvent(event *anthropicStreamEvent) st
 			sc.thinkingBlocks[event.Index] = true
 			return ""
 		}
+		if event.ContentBlock != nil && event.ContentBlock.Type == "server_tool_use" {
+			return sc.startServerToolCall(event.Index, event.ContentBlock)
+		}
+		if event.ContentBlock != nil && isServerToolResultBlock(event.ContentBlock.Type) {
+			return sc.completeServerToolCall(event.ContentBlock)
+		}
 		if event.ContentBlock != nil && event.ContentBlock.Type == "tool_use" {
 			if sc.output.AssistantStarted() && !sc.output.AssistantDone() {
-				prefix := sc.output.CompleteAssistantOutput(0)
+				prefix := sc.output.CompleteAssistantOutput(sc.assistantOutputIndex)
 				state := sc.newResponsesToolCallState(event.ContentBlock)
 				sc.toolCalls[event.Index] = state
 				return prefix + sc.output.StartToolCall(state, true)
@@ -320,7 +349,7 @@ func (sc *responsesStreamConverter) convertEvent(event *anthropicStreamEvent) st
 		case "text_delta":
 			if event.Delta.Text != "" {
 				sc.reserveAssistantMessageOutput()
-				prefix := sc.output.StartAssistantOutput(0)
+				prefix := sc.output.StartAssistantOutput(sc.assistantOutputIndex)
 				sc.output.AppendAssistantText(event.Delta.Text)
 				return prefix + sc.output.WriteEvent("response.output_text.delta", map[string]any{
 					"type":  "response.output_text.delta",
@@ -331,6 +360,10 @@ func (sc *responsesStreamConverter) convertEvent(event *anthropicStreamEvent) st
 			if event.Delta.PartialJSON == "" {
 				return ""
 			}
+			if st := sc.serverTools[event.Index]; st != nil {
+				_, _ = st.input.WriteString(event.Delta.PartialJSON)
+				return ""
+			}
 			state := sc.toolCalls[event.Index]
 			if state == nil {
 				return ""
@@ -350,6 +383,9 @@ func (sc *responsesStreamConverter) convertEvent(event *anthropicStreamEvent) st
 		return ""
 
 	case "content_block_stop":
+		if st := sc.serverTools[event.Index]; st != nil {
+			return sc.finishServerToolInput(st)
+		}
 		state := sc.toolCalls[event.Index]
 		return sc.output.CompleteToolCall(state, true)
 
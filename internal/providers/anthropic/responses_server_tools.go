@@ -0,0 +1,234 @@
+package anthropic
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// Responses API output item types used for Anthropic server tools.
+const (
+	responsesWebSearchCallItem       = "web_search_call"
+	responsesCodeInterpreterCallItem = "code_interpreter_call"
+)
+
+// serverToolCallState tracks one Anthropic server tool invocation
+// (server_tool_use block plus its *_tool_result block) rendered as a built-in
+// Responses output item.
+type serverToolCallState struct {
+	itemID      string
+	itemType    string
+	name        string
+	outputIndex int
+	input       strings.Builder
+	completed   bool
+}
+
+// serverToolItemType maps an Anthropic server tool name to the Responses
+// output item type that represents it, or "" when there is no equivalent.
+func serverToolItemType(name string) string {
+	switch name {
+	case "web_search", "web_fetch":
+		return responsesWebSearchCallItem
+	case "code_execution", "bash_code_execution", "text_editor_code_execution":
+		return responsesCodeInterpreterCallItem
+	default:
+		return ""
+	}
+}
+
+// isServerToolResultBlock reports whether an Anthropic content block type
+// carries a server tool result, e.g. web_search_tool_result.
+func isServerToolResultBlock(blockType string) bool {
+	return blockType != "tool_result" && strings.HasSuffix(blockType, "_tool_result")
+}
+
+func serverToolItemID(itemType, toolUseID string) string {
+	if itemType == responsesWebSearchCallItem {
+		return "ws_" + toolUseID
+	}
+	return "ci_" + toolUseID
+}
+
+// serverToolInput is the union of the server tool inputs the converter reads.
+type serverToolInput struct {
+	Query   string `json:"query"`
+	URL     string `json:"url"`
+	Code    string `json:"code"`
+	Command string `json:"command"`
+}
+
+func (st *serverToolCallState) parsedInput() serverToolInput {
+	var input serverToolInput
+	if raw := strings.TrimSpace(st.input.String()); raw != "" {
+		_ = json.Unmarshal([]byte(raw), &input) //nolint:errcheck
+	}
+	return input
+}
+
+// code returns the source a code execution tool ran: the code itself, the
+// bash command, or the raw text editor input.
+func (st *serverToolCallState) code() string {
+	input := st.parsedInput()
+	switch {
+	case input.Code != "":
+		return input.Code
+	case st.name == "bash_code_execution" && input.Command != "":
+		return input.Command
+	case st.name == "text_editor_code_execution":
+		return strings.TrimSpace(st.input.String())
+	default:
+		return ""
+	}
+}
+
+// serverToolResult is the union of the server tool result payloads the
+// converter reads. Web search results arrive as an array of these; every
+// other result (and every error) is a single object.
+type serverToolResult struct {
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	ErrorCode string `json:"error_code"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	Content   any    `json:"content"`
+}
+
+func parseServerToolResults(raw json.RawMessage) []serverToolResult {
+	trimmed := strings.TrimSpace(string(raw))
+	if trimmed == "" || trimmed == "null" {
+		return nil
+	}
+	if strings.HasPrefix(trimmed, "[") {
+		var results []serverToolResult
+		if err := json.Unmarshal(raw, &results); err != nil {
+			return nil
+		}
+		return results
+	}
+	var result serverToolResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
+	}
+	return []serverToolResult{result}
+}
+
+func (st *serverToolCallState) item(status string, results []serverToolResult) map[string]any {
+	item := map[string]any{
+		"id":     st.itemID,
+		"type":   st.itemType,
+		"status": status,
+	}
+	if st.itemType == responsesWebSearchCallItem {
+		item["action"] = st.webSearchAction(results)
+		return item
+	}
+	item["code"] = st.code()
+	outputs := make([]map[string]any, 0, 1)
+	for _, result := range results {
+		logs := result.Stdout + result.Stderr
+		if text, ok := result.Content.(string); ok && logs == "" {
+			logs = text
+		}
+		if logs != "" {
+			outputs = append(outputs, map[string]any{"type": "logs", "logs": logs})
+		}
+	}
+	item["outputs"] = outputs
+	return item
+}
+
+func (st *serverToolCallState) webSearchAction(results []serverToolResult) map[string]any {
+	input := st.parsedInput()
+	if st.name == "web_fetch" {
+		return map[string]any{"type": "open_page", "url": input.URL}
+	}
+	action := map[string]any{"type": "search", "query": input.Query}
+	sources := make([]map[string]any, 0, len(results))
+	for _, result := range results {
+		if result.URL != "" {
+			sources = append(sources, map[string]any{"type": "url", "url": result.URL})
+		}
+	}
+	if len(sources) > 0 {
+		action["sources"] = sources
+	}
+	return action
+}
+
+func (sc *responsesStreamConverter) writeServerToolEvent(eventName string, st *serverToolCallState, extra map[string]any) string {
+	payload := map[string]any{
+		"type":         eventName,
+		"item_id":      st.itemID,
+		"output_index": st.outputIndex,
+	}
+	for key, value := range extra {
+		payload[key] = value
+	}
+	return sc.output.WriteEvent(eventName, payload)
+}
+
+// startServerToolCall opens the Responses output item for a server_tool_use
+// block. Unlike client tool calls it leaves the assistant message open, since
+// Anthropic keeps writing text after the server tool result.
+func (sc *responsesStreamConverter) startServerToolCall(index int, block *anthropicContent) string {
+	itemType := serverToolItemType(block.Name)
+	if itemType == "" || strings.TrimSpace(block.ID) == "" {
+		slog.Debug("dropping anthropic server tool without a responses equivalent", "name", block.Name)
+		return ""
+	}
+	st := &serverToolCallState{
+		itemID:      serverToolItemID(itemType, block.ID),
+		itemType:    itemType,
+		name:        block.Name,
+		outputIndex: sc.nextOutputIndex,
+	}
+	sc.nextOutputIndex++
+	if initial := extractInitialToolArguments(block.Input); initial != "{}" {
+		_, _ = st.input.WriteString(initial)
+	}
+	sc.serverTools[index] = st
+	sc.serverToolsByID[block.ID] = st
+
+	return sc.output.WriteEvent("response.output_item.added", map[string]any{
+		"type":         "response.output_item.added",
+		"item":         st.item("in_progress", nil),
+		"output_index": st.outputIndex,
+	}) + sc.writeServerToolEvent("response."+itemType+".in_progress", st, nil)
+}
+
+// finishServerToolInput reports that the server tool's input is complete and
+// the tool is running upstream.
+func (sc *responsesStreamConverter) finishServerToolInput(st *serverToolCallState) string {
+	if st.itemType == responsesWebSearchCallItem {
+		return sc.writeServerToolEvent("response.web_search_call.searching", st, nil)
+	}
+	return sc.writeServerToolEvent("response.code_interpreter_call_code.done", st, map[string]any{"code": st.code()}) +
+		sc.writeServerToolEvent("response.code_interpreter_call.interpreting", st, nil)
+}
+
+// completeServerToolCall closes the output item opened for the server tool a
+// *_tool_result block answers.
+func (sc *responsesStreamConverter) completeServerToolCall(block *anthropicContent) string {
+	st := sc.serverToolsByID[block.ToolUseID]
+	if st == nil || st.completed {
+		return ""
+	}
+	st.completed = true
+
+	results := parseServerToolResults(block.Content)
+	status := "completed"
+	for _, result := range results {
+		if result.ErrorCode != "" {
+			status = "failed"
+			break
+		}
+	}
+	return sc.writeServerToolEvent("response."+st.itemType+".completed", st, nil) +
+		sc.output.WriteEvent("response.output_item.done", map[string]any{
+			"type":         "response.output_item.done",
+			"item":         st.item(status, results),
+			"output_index": st.outputIndex,
+		})
+}
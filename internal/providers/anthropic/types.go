@@ -91,6 +91,10 @@ type anthropicContent struct {
 	ID        string          `json:"id,omitempty"`
 	Name      string          `json:"name,omitempty"`
 	Input     json.RawMessage `json:"input,omitempty"`
+	// ToolUseID and Content are set on server tool result blocks such as
+	// web_search_tool_result and code_execution_tool_result.
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   json.RawMessage `json:"content,omitempty"`
 }
 
 // anthropicUsage represents token usage in Anthropic response
@@ -75,6 +75,25 @@ func (p *Provider) SetBaseURL(baseURL string) {
 	p.openAIResourceProvider.SetBaseURL(resourceRoot + "/openai")
 }
 
+// ResetCircuit force-closes the circuit breakers of the deployment and
+// resource-level clients.
+func (p *Provider) ResetCircuit() string {
+	state := p.CompatibleProvider.ResetCircuit()
+	p.resourceProvider.ResetCircuit()
+	p.openAIResourceProvider.ResetCircuit()
+	return state
+}
+
+// CircuitState reports the worst circuit breaker state across the deployment
+// and resource-level clients.
+func (p *Provider) CircuitState() string {
+	return llmclient.WorstCircuitState(
+		p.CompatibleProvider.CircuitState(),
+		p.resourceProvider.CircuitState(),
+		p.openAIResourceProvider.CircuitState(),
+	)
+}
+
 func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
 	var resp core.ModelsResponse
 	if err := p.resourceProvider.Do(ctx, llmclient.Request{
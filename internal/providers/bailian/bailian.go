@@ -86,6 +86,16 @@ func (p *Provider) SetBaseURL(url string) {
 	p.compatible.SetBaseURL(url)
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	return p.compatible.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.compatible.CircuitState()
+}
+
 // ChatCompletion sends a chat completion request to Bailian.
 func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	return p.compatible.ChatCompletion(ctx, req)
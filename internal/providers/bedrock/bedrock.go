@@ -23,6 +23,7 @@ import (
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/bedrock"
 	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrock/types"
@@ -56,7 +57,7 @@ type Provider struct {
 // BaseURL is interpreted as either an AWS region ("us-east-1") or a fully
 // qualified endpoint URL. When empty, the region is resolved from the
 // standard AWS environment variables / shared config.
-func New(providerCfg providers.ProviderConfig, _ providers.ProviderOptions) core.Provider {
+func New(providerCfg providers.ProviderConfig, opts providers.ProviderOptions) core.Provider {
 	p := &Provider{}
 
 	region, endpoint := parseBaseURL(providerCfg.BaseURL)
@@ -64,6 +65,9 @@ func New(providerCfg providers.ProviderConfig, _ providers.ProviderOptions) core
 	if region != "" {
 		loadOpts = append(loadOpts, awsconfig.WithRegion(region))
 	}
+	if opts.HTTPClient != nil {
+		loadOpts = append(loadOpts, awsconfig.WithHTTPClient(awsHTTPClient(opts.HTTPClient)))
+	}
 
 	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), loadOpts...)
 	if err != nil {
@@ -91,6 +95,21 @@ func New(providerCfg providers.ProviderConfig, _ providers.ProviderOptions) core
 	return p
 }
 
+// awsHTTPClient adapts the provider's HTTP client, which carries its
+// configured proxy, for the AWS SDK. The proxy is moved onto an SDK buildable
+// client rather than handing the SDK the client itself, so settings the SDK
+// applies to its transport (a custom CA bundle from AWS_CA_BUNDLE, for one)
+// keep working.
+func awsHTTPClient(client *http.Client) awsconfig.HTTPClient {
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		return client
+	}
+	return awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		tr.Proxy = transport.Proxy
+	})
+}
+
 // parseBaseURL splits the operator-provided BaseURL into (region, endpoint).
 // Region-only strings ("us-east-1") configure region without a custom endpoint.
 // URLs ("https://...") configure an endpoint and try to derive the region from
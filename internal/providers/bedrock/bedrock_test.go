@@ -3,14 +3,20 @@ package bedrock
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	brtypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/httpclient"
 	"github.com/enterpilot/gomodel/internal/providers"
 )
 
@@ -270,6 +276,26 @@ func TestBuildConverseParts_TopPFromExtraFields(t *testing.T) {
 	}
 }
 
+func TestBuildConverseParts_StopSequencesFromExtraFields(t *testing.T) {
+	req := &core.ChatRequest{
+		Model:    "anthropic.claude-3-5-haiku-20241022-v1:0",
+		Messages: []core.Message{{Role: "user", Content: "hi"}},
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"stop": json.RawMessage(`["END","STOP"]`),
+		}),
+	}
+	parts, err := buildConverseParts(req)
+	if err != nil {
+		t.Fatalf("buildConverseParts: %v", err)
+	}
+	if parts.infCfg == nil {
+		t.Fatal("stop was not forwarded to InferenceConfiguration")
+	}
+	if got := parts.infCfg.StopSequences; len(got) != 2 || got[0] != "END" || got[1] != "STOP" {
+		t.Errorf("StopSequences = %v, want [END STOP]", got)
+	}
+}
+
 func TestBuildConverseParts_TopPFromTypedField(t *testing.T) {
 	topP := 0.8
 	req := &core.ChatRequest{
@@ -658,6 +684,40 @@ func TestNew_BearerTokenOnly(t *testing.T) {
 	}
 }
 
+func TestNew_SendsRequestsThroughProviderProxy(t *testing.T) {
+	t.Setenv("AWS_BEARER_TOKEN_BEDROCK", "ABSKBedrockTestToken-fake")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	proxyURL, err := url.Parse("http://proxy.internal:3128")
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpCfg := httpclient.DefaultConfig()
+	httpCfg.Proxy = proxyURL
+	p := New(providers.ProviderConfig{BaseURL: "us-east-1"}, providers.ProviderOptions{
+		HTTPClient: httpclient.NewHTTPClient(&httpCfg),
+	}).(*Provider)
+	if p.configErr != nil {
+		t.Fatalf("configErr = %v, want nil", p.configErr)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "https://bedrock-runtime.us-east-1.amazonaws.com/", nil)
+	for name, client := range map[string]awsconfig.HTTPClient{
+		"runtime": p.runtime.Options().HTTPClient,
+		"control": p.control.Options().HTTPClient,
+	} {
+		buildable, ok := client.(*awshttp.BuildableClient)
+		if !ok {
+			t.Fatalf("%s HTTPClient = %T, want *awshttp.BuildableClient", name, client)
+		}
+		got, err := buildable.GetTransport().Proxy(req)
+		if err != nil || got == nil || got.String() != proxyURL.String() {
+			t.Errorf("%s proxy = %v, %v; want %s", name, got, err, proxyURL)
+		}
+	}
+}
+
 func TestRegistration(t *testing.T) {
 	if Registration.Type != providerName {
 		t.Errorf("Registration.Type = %q, want %q", Registration.Type, providerName)
@@ -64,6 +64,9 @@ func buildConverseParts(req *core.ChatRequest) (converseParts, error) {
 	if model == "" {
 		return converseParts{}, core.NewInvalidRequestError("bedrock chat request requires a model", nil)
 	}
+	if req.RequestsAudioOutput() {
+		return converseParts{}, core.NewInvalidRequestError("chat audio output is not supported by Bedrock translation", nil)
+	}
 
 	system, messages, err := convertMessages(req.Messages)
 	if err != nil {
@@ -96,6 +99,10 @@ func buildConverseParts(req *core.ChatRequest) (converseParts, error) {
 		infCfg.TopP = awssdk.Float32(float32(topP))
 		hasInfCfg = true
 	}
+	if stops := req.StopSequences(); len(stops) > 0 {
+		infCfg.StopSequences = stops
+		hasInfCfg = true
+	}
 	if hasInfCfg {
 		parts.infCfg = infCfg
 	}
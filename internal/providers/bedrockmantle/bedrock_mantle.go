@@ -63,7 +63,11 @@ func New(cfg providers.ProviderConfig, opts providers.ProviderOptions) core.Prov
 		credentials = awsCfg.Credentials
 	}
 
-	client := authenticatedClient(httpclient.NewDefaultHTTPClient(), keys, credentials, endpoint.region)
+	base := opts.HTTPClient
+	if base == nil {
+		base = httpclient.NewDefaultHTTPClient()
+	}
+	client := authenticatedClient(base, keys, credentials, endpoint.region)
 	return newProvider(endpoint, cfg, opts, client)
 }
 
@@ -97,6 +101,22 @@ func (p *Provider) CheckAvailability(ctx context.Context) error {
 	return err
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	if p.compatible == nil {
+		return ""
+	}
+	return p.compatible.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	if p.compatible == nil {
+		return ""
+	}
+	return p.compatible.CircuitState()
+}
+
 func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	if err := p.ready(); err != nil {
 		return nil, err
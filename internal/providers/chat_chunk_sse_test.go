@@ -44,6 +44,9 @@ func TestFormatChatChunkSSE(t *testing.T) {
 	if _, present := chunk["usage"]; present {
 		t.Fatal("usage present, want omitted when nil")
 	}
+	if _, present := chunk["system_fingerprint"]; present {
+		t.Fatal("system_fingerprint present, want omitted for native converters without one")
+	}
 
 	choices, ok := chunk["choices"].([]any)
 	if !ok || len(choices) != 1 {
@@ -30,7 +30,7 @@ func (r *errAfterReadCloser) Close() error { return nil }
 
 func TestEnsureChatCompletionSSE_ConvertsBufferedJSON(t *testing.T) {
 	// Upstream ignored stream:true and returned a buffered, non-SSE completion.
-	body := `{"id":"x","object":"chat.completion","choices":[{"finish_reason":"stop","message":{"role":"assistant","content":"Hi there"}}]}`
+	body := `{"id":"x","object":"chat.completion","system_fingerprint":"fp_abc123","choices":[{"finish_reason":"stop","message":{"role":"assistant","content":"Hi there"}}]}`
 	stream := io.NopCloser(strings.NewReader(body))
 
 	got, err := io.ReadAll(EnsureChatCompletionSSE(stream))
@@ -51,6 +51,9 @@ func TestEnsureChatCompletionSSE_ConvertsBufferedJSON(t *testing.T) {
 	if !strings.Contains(out, `"delta":`) || strings.Contains(out, `"message":`) {
 		t.Fatalf("expected message rewritten to delta, got %q", out)
 	}
+	if !strings.Contains(out, `"system_fingerprint":"fp_abc123"`) {
+		t.Fatalf("expected system_fingerprint preserved, got %q", out)
+	}
 }
 
 func TestEnsureChatCompletionSSE_PassesThroughRealSSE(t *testing.T) {
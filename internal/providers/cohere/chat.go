@@ -0,0 +1,376 @@
+package cohere
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+)
+
+// ChatCompletion sends a chat completion request to Cohere
+func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	cohereReq, err := convertToCohereRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cohereResp cohereResponse
+	err = p.client.Do(ctx, llmclient.Request{
+		Method:   http.MethodPost,
+		Endpoint: "/v2/chat",
+		Body:     cohereReq,
+	}, &cohereResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertFromCohereResponse(&cohereResp, req.Model), nil
+}
+
+// convertToCohereRequest translates an OpenAI-compatible chat request into
+// Cohere's v2 /chat payload. System and developer messages are folded into a
+// single leading system message, which is v2's replacement for the v1
+// preamble.
+func convertToCohereRequest(req *core.ChatRequest) (*cohereRequest, error) {
+	if req == nil {
+		return nil, core.NewInvalidRequestError("cohere chat request is required", nil)
+	}
+	if req.RequestsAudioOutput() {
+		return nil, core.NewInvalidRequestError("chat audio output is not supported by Cohere translation", nil)
+	}
+
+	messages, err := convertMessages(req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	cohereReq := &cohereRequest{
+		Model:            req.Model,
+		Messages:         messages,
+		Stream:           req.Stream,
+		MaxTokens:        resolveMaxTokens(req),
+		Temperature:      req.Temperature,
+		P:                req.TopP,
+		StopSequences:    req.StopSequences(),
+		Seed:             extraNumber(req.ExtraFields, "seed"),
+		FrequencyPenalty: extraNumber(req.ExtraFields, "frequency_penalty"),
+		PresencePenalty:  extraNumber(req.ExtraFields, "presence_penalty"),
+	}
+
+	responseFormat, err := convertResponseFormat(req.ExtraFields.Lookup("response_format"))
+	if err != nil {
+		return nil, err
+	}
+	cohereReq.ResponseFormat = responseFormat
+
+	toolChoice, disableTools, err := convertToolChoice(req.ToolChoice)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Tools) > 0 && !disableTools {
+		for _, tool := range req.Tools {
+			if toolType, _ := tool["type"].(string); toolType != "function" {
+				return nil, core.NewInvalidRequestError("unsupported tool type: "+toolType, nil)
+			}
+		}
+		cohereReq.Tools = req.Tools
+		cohereReq.ToolChoice = toolChoice
+	}
+
+	return cohereReq, nil
+}
+
+func convertMessages(messages []core.Message) ([]cohereMessage, error) {
+	out := make([]cohereMessage, 0, len(messages)+1)
+	var system []string
+	for _, msg := range messages {
+		switch msg.Role {
+		case "system", "developer":
+			if text := core.ExtractTextContent(msg.Content); text != "" {
+				system = append(system, text)
+			}
+		case "user":
+			content, err := convertUserContent(msg.Content)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, cohereMessage{Role: "user", Content: content})
+		case "assistant":
+			out = append(out, convertAssistantMessage(msg))
+		case "tool":
+			toolCallID := strings.TrimSpace(msg.ToolCallID)
+			if toolCallID == "" {
+				return nil, core.NewInvalidRequestError("tool message is missing tool_call_id", nil)
+			}
+			out = append(out, cohereMessage{
+				Role:       "tool",
+				ToolCallID: toolCallID,
+				Content:    core.ExtractTextContent(msg.Content),
+			})
+		default:
+			return nil, core.NewInvalidRequestError("unsupported message role: "+msg.Role, nil)
+		}
+	}
+	if len(system) > 0 {
+		out = append([]cohereMessage{{Role: "system", Content: strings.Join(system, "\n\n")}}, out...)
+	}
+	return out, nil
+}
+
+// convertUserContent keeps plain text as a string and maps structured
+// content to Cohere's text and image_url parts.
+func convertUserContent(content any) (any, error) {
+	if !core.HasStructuredContent(content) {
+		return core.ExtractTextContent(content), nil
+	}
+	parts, ok := core.NormalizeContentParts(content)
+	if !ok {
+		return nil, core.NewInvalidRequestError("invalid user message content", nil)
+	}
+	out := make([]cohereContentPart, 0, len(parts))
+	for _, part := range parts {
+		switch part.Type {
+		case "text":
+			out = append(out, cohereContentPart{Type: "text", Text: part.Text})
+		case "image_url":
+			if part.ImageURL == nil || part.ImageURL.URL == "" {
+				return nil, core.NewInvalidRequestError("image_url content part requires a url", nil)
+			}
+			out = append(out, cohereContentPart{
+				Type:     "image_url",
+				ImageURL: &cohereImageURL{URL: part.ImageURL.URL, Detail: part.ImageURL.Detail},
+			})
+		default:
+			return nil, core.NewInvalidRequestError("content part type "+part.Type+" is not supported by Cohere translation", nil)
+		}
+	}
+	return out, nil
+}
+
+func convertAssistantMessage(msg core.Message) cohereMessage {
+	out := cohereMessage{Role: "assistant"}
+	if text := core.ExtractTextContent(msg.Content); text != "" {
+		out.Content = text
+	}
+	for _, call := range msg.ToolCalls {
+		arguments := call.Function.Arguments
+		if strings.TrimSpace(arguments) == "" {
+			arguments = "{}"
+		}
+		out.ToolCalls = append(out.ToolCalls, cohereToolCall{
+			ID:   call.ID,
+			Type: "function",
+			Function: cohereFunctionCall{
+				Name:      call.Function.Name,
+				Arguments: arguments,
+			},
+		})
+	}
+	return out
+}
+
+// convertToolChoice maps OpenAI tool_choice onto Cohere's REQUIRED/NONE
+// values. "auto" and an omitted choice leave Cohere's default in place; a
+// forced function choice has no Cohere equivalent.
+func convertToolChoice(choice any) (toolChoice string, disableTools bool, err error) {
+	mode := ""
+	switch c := choice.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		mode = strings.TrimSpace(c)
+	case map[string]any:
+		mode, _ = c["type"].(string)
+	}
+	switch mode {
+	case "auto":
+		return "", false, nil
+	case "required", "any":
+		return "REQUIRED", false, nil
+	case "none":
+		return "", true, nil
+	case "function":
+		return "", false, core.NewInvalidRequestError("tool_choice forcing a specific function is not supported by Cohere translation", nil)
+	default:
+		return "", false, core.NewInvalidRequestError("unsupported tool_choice: "+mode, nil)
+	}
+}
+
+// convertResponseFormat maps OpenAI response_format onto Cohere's JSON mode.
+// json_schema formats forward their schema as Cohere's json_schema.
+func convertResponseFormat(raw json.RawMessage) (*cohereResponseFormat, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	var format struct {
+		Type       string `json:"type"`
+		JSONSchema *struct {
+			Schema json.RawMessage `json:"schema"`
+		} `json:"json_schema"`
+	}
+	if err := json.Unmarshal(raw, &format); err != nil {
+		return nil, core.NewInvalidRequestError("invalid response_format", err)
+	}
+	switch format.Type {
+	case "", "text":
+		return nil, nil
+	case "json_object":
+		return &cohereResponseFormat{Type: "json_object"}, nil
+	case "json_schema":
+		out := &cohereResponseFormat{Type: "json_object"}
+		if format.JSONSchema != nil {
+			out.JSONSchema = format.JSONSchema.Schema
+		}
+		return out, nil
+	default:
+		return nil, core.NewInvalidRequestError("response_format type "+format.Type+" is not supported by Cohere translation", nil)
+	}
+}
+
+func resolveMaxTokens(req *core.ChatRequest) *int {
+	if req.MaxTokens != nil {
+		return req.MaxTokens
+	}
+	if raw := req.ExtraFields.Lookup("max_completion_tokens"); len(raw) > 0 {
+		var n int
+		if err := json.Unmarshal(raw, &n); err == nil && n > 0 {
+			return &n
+		}
+	}
+	return nil
+}
+
+// extraNumber forwards a numeric extra field verbatim, dropping null and
+// non-numeric values.
+func extraNumber(extraFields core.UnknownJSONFields, name string) json.RawMessage {
+	raw := bytes.TrimSpace(extraFields.Lookup(name))
+	if len(raw) == 0 {
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return nil
+	}
+	return raw
+}
+
+// convertFromCohereResponse converts a Cohere v2 response to core.ChatResponse.
+// Cohere does not echo the model, so the requested one is reported.
+func convertFromCohereResponse(resp *cohereResponse, model string) *core.ChatResponse {
+	var content, reasoning strings.Builder
+	for _, part := range resp.Message.Content {
+		switch part.Type {
+		case "text":
+			content.WriteString(part.Text)
+		case "thinking":
+			reasoning.WriteString(part.Thinking)
+		}
+	}
+	if reasoning.Len() == 0 && resp.Message.ToolPlan != "" {
+		reasoning.WriteString(resp.Message.ToolPlan)
+	}
+
+	msg := core.ResponseMessage{
+		Role:      "assistant",
+		Content:   content.String(),
+		ToolCalls: convertToolCalls(resp.Message.ToolCalls),
+	}
+	if reasoning.Len() > 0 {
+		if raw, err := json.Marshal(reasoning.String()); err == nil {
+			msg.ExtraFields = core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+				"reasoning_content": raw,
+			})
+		}
+	}
+
+	return &core.ChatResponse{
+		ID:      resp.ID,
+		Object:  "chat.completion",
+		Model:   model,
+		Created: time.Now().Unix(),
+		Choices: []core.Choice{
+			{
+				Index:        0,
+				Message:      msg,
+				FinishReason: normalizeFinishReason(resp.FinishReason),
+			},
+		},
+		Usage: convertUsage(resp.Usage),
+	}
+}
+
+func convertToolCalls(calls []cohereToolCall) []core.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+	out := make([]core.ToolCall, 0, len(calls))
+	for _, call := range calls {
+		arguments := call.Function.Arguments
+		if strings.TrimSpace(arguments) == "" {
+			arguments = "{}"
+		}
+		out = append(out, core.ToolCall{
+			ID:   call.ID,
+			Type: "function",
+			Function: core.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: arguments,
+			},
+		})
+	}
+	return out
+}
+
+// usageTokens prefers the model's token counts and falls back to billed units.
+func usageTokens(usage *cohereUsage) (input, output int, ok bool) {
+	if usage == nil {
+		return 0, 0, false
+	}
+	counts := usage.Tokens
+	if counts == nil {
+		counts = usage.BilledUnits
+	}
+	if counts == nil {
+		return 0, 0, false
+	}
+	return int(counts.InputTokens), int(counts.OutputTokens), true
+}
+
+func convertUsage(usage *cohereUsage) core.Usage {
+	input, output, ok := usageTokens(usage)
+	if !ok {
+		return core.Usage{}
+	}
+	out := core.Usage{
+		PromptTokens:     input,
+		CompletionTokens: output,
+		TotalTokens:      input + output,
+	}
+	if usage.BilledUnits != nil {
+		out.RawUsage = map[string]any{
+			"billed_input_tokens":  int(usage.BilledUnits.InputTokens),
+			"billed_output_tokens": int(usage.BilledUnits.OutputTokens),
+		}
+	}
+	return out
+}
+
+func normalizeFinishReason(reason string) string {
+	switch reason {
+	case "", "COMPLETE", "STOP_SEQUENCE":
+		return "stop"
+	case "MAX_TOKENS":
+		return "length"
+	case "TOOL_CALL":
+		return "tool_calls"
+	default:
+		return strings.ToLower(reason)
+	}
+}
@@ -0,0 +1,229 @@
+package cohere
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+	"github.com/enterpilot/gomodel/internal/providers"
+	"github.com/enterpilot/gomodel/internal/streaming"
+)
+
+// StreamChatCompletion returns a raw response body for streaming (caller must close)
+func (p *Provider) StreamChatCompletion(ctx context.Context, req *core.ChatRequest) (io.ReadCloser, error) {
+	cohereReq, err := convertToCohereRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	cohereReq.Stream = true
+
+	stream, err := p.client.DoStream(ctx, llmclient.Request{
+		Method:   http.MethodPost,
+		Endpoint: "/v2/chat",
+		Body:     cohereReq,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return a reader that converts Cohere SSE events to OpenAI format
+	return newStreamConverter(stream, req.Model), nil
+}
+
+// streamConverter wraps a Cohere v2 chat stream and converts its events into
+// OpenAI chat.completion.chunk lines.
+type streamConverter struct {
+	reader           *bufio.Reader
+	body             io.ReadCloser
+	model            string
+	msgID            string
+	created          int64
+	toolCallIndexes  map[int]int // Cohere tool call index -> emitted tool_calls index
+	emittedToolCalls bool
+	buffer           streaming.StreamBuffer
+	closed           bool
+}
+
+func newStreamConverter(body io.ReadCloser, model string) *streamConverter {
+	return &streamConverter{
+		reader:          bufio.NewReader(body),
+		body:            body,
+		model:           model,
+		created:         time.Now().Unix(),
+		toolCallIndexes: make(map[int]int),
+		buffer:          streaming.NewStreamBuffer(1024),
+	}
+}
+
+func (sc *streamConverter) Read(p []byte) (n int, err error) {
+	if sc.buffer.Len() > 0 {
+		return sc.buffer.Read(p), nil
+	}
+
+	if sc.closed {
+		sc.buffer.Release()
+		return 0, io.EOF
+	}
+
+	for {
+		line, err := streaming.ReadLine(sc.reader)
+		if len(line) > 0 {
+			chunk, convErr := sc.convertLine(line)
+			if convErr != nil {
+				sc.closed = true
+				sc.buffer.Release()
+				_ = sc.body.Close() //nolint:errcheck
+				return 0, convErr
+			}
+			if chunk != "" {
+				sc.buffer.AppendString(chunk)
+				return sc.buffer.Read(p), nil
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				sc.buffer.AppendString("data: [DONE]\n\n")
+				n = sc.buffer.Read(p)
+				sc.closed = true
+				_ = sc.body.Close() //nolint:errcheck
+				return n, nil
+			}
+			sc.closed = true
+			sc.buffer.Release()
+			_ = sc.body.Close() //nolint:errcheck
+			return 0, err
+		}
+	}
+}
+
+func (sc *streamConverter) Close() error {
+	sc.buffer.Release()
+	if sc.closed {
+		return nil
+	}
+	sc.closed = true
+	return sc.body.Close()
+}
+
+// convertLine converts one SSE line. Cohere repeats the event type inside the
+// data payload, so event: lines are ignored.
+func (sc *streamConverter) convertLine(line []byte) (string, error) {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return "", nil
+	}
+	data := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data:")))
+	if len(data) == 0 || bytes.Equal(data, []byte("[DONE]")) {
+		return "", nil
+	}
+
+	var event cohereStreamEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", core.NewProviderError("cohere", http.StatusBadGateway, "failed to decode cohere stream event: "+err.Error(), err)
+	}
+	return sc.convertEvent(&event), nil
+}
+
+func (sc *streamConverter) formatChatChunk(delta map[string]any, finishReason any, usage map[string]any) string {
+	return providers.FormatChatChunkSSE(sc.msgID, sc.created, sc.model, "cohere", delta, finishReason, usage)
+}
+
+func (sc *streamConverter) convertEvent(event *cohereStreamEvent) string {
+	var message *cohereStreamMessage
+	if event.Delta != nil {
+		message = event.Delta.Message
+	}
+
+	switch event.Type {
+	case "message-start":
+		sc.msgID = event.ID
+		return sc.formatChatChunk(map[string]any{"role": "assistant"}, nil, nil)
+
+	case "content-delta":
+		part := message.contentPart()
+		if part == nil {
+			return ""
+		}
+		if part.Text != "" {
+			return sc.formatChatChunk(map[string]any{"content": part.Text}, nil, nil)
+		}
+		if part.Thinking != "" {
+			return sc.formatChatChunk(map[string]any{"reasoning_content": part.Thinking}, nil, nil)
+		}
+
+	case "tool-plan-delta":
+		if message != nil && message.ToolPlan != "" {
+			return sc.formatChatChunk(map[string]any{"reasoning_content": message.ToolPlan}, nil, nil)
+		}
+
+	case "tool-call-start":
+		call := message.toolCall()
+		if call == nil {
+			return ""
+		}
+		index := len(sc.toolCallIndexes)
+		sc.toolCallIndexes[event.Index] = index
+		sc.emittedToolCalls = true
+		return sc.formatChatChunk(map[string]any{
+			"tool_calls": []map[string]any{
+				{
+					"index": index,
+					"id":    call.ID,
+					"type":  "function",
+					"function": map[string]any{
+						"name":      call.Function.Name,
+						"arguments": call.Function.Arguments,
+					},
+				},
+			},
+		}, nil, nil)
+
+	case "tool-call-delta":
+		call := message.toolCall()
+		if call == nil || call.Function.Arguments == "" {
+			return ""
+		}
+		index, ok := sc.toolCallIndexes[event.Index]
+		if !ok {
+			return ""
+		}
+		return sc.formatChatChunk(map[string]any{
+			"tool_calls": []map[string]any{
+				{
+					"index": index,
+					"function": map[string]any{
+						"arguments": call.Function.Arguments,
+					},
+				},
+			},
+		}, nil, nil)
+
+	case "message-end":
+		if event.Delta == nil {
+			return ""
+		}
+		finishReason := normalizeFinishReason(event.Delta.FinishReason)
+		if finishReason == "tool_calls" && !sc.emittedToolCalls {
+			// Never claim tool calls the stream did not deliver.
+			finishReason = "stop"
+		}
+		var usage map[string]any
+		if input, output, ok := usageTokens(event.Delta.Usage); ok {
+			usage = map[string]any{
+				"prompt_tokens":     input,
+				"completion_tokens": output,
+				"total_tokens":      input + output,
+			}
+		}
+		return sc.formatChatChunk(map[string]any{}, finishReason, usage)
+	}
+
+	return ""
+}
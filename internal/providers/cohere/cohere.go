@@ -0,0 +1,150 @@
+// Package cohere provides Cohere API integration for the LLM gateway.
+package cohere
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+	"github.com/enterpilot/gomodel/internal/providers"
+)
+
+// Registration provides factory registration for the Cohere provider.
+var Registration = providers.Registration{
+	Type: "cohere",
+	New:  New,
+	Discovery: providers.DiscoveryConfig{
+		DefaultBaseURL: defaultBaseURL,
+	},
+}
+
+// defaultBaseURL carries no API version: chat lives under /v2 while the
+// model catalog is only served under /v1.
+const defaultBaseURL = "https://api.cohere.com"
+
+// maxModelPages bounds how many /v1/models pages ListModels follows.
+const maxModelPages = 20
+
+// Provider implements the core.Provider interface for Cohere. Chat requests
+// are translated to Cohere's native v2 /chat API; the Responses API is served
+// by converting to the chat path.
+type Provider struct {
+	client *llmclient.Client
+	keys   *providers.Keyring
+}
+
+// New creates a new Cohere provider.
+func New(providerCfg providers.ProviderConfig, opts providers.ProviderOptions) core.Provider {
+	p := &Provider{keys: opts.Keyring(providerCfg.APIKey)}
+	clientCfg := llmclient.Config{
+		ProviderName:   "cohere",
+		BaseURL:        providers.ResolveBaseURL(providerCfg.BaseURL, defaultBaseURL),
+		Retry:          opts.Resilience.Retry,
+		Hooks:          opts.Hooks,
+		CircuitBreaker: opts.Resilience.CircuitBreaker,
+		Queue:          opts.Queue,
+		TokenPacer:     opts.TokenPacer,
+		HTTPClient:     opts.HTTPClient,
+	}
+	p.client = llmclient.New(clientCfg, p.setHeaders)
+	return p
+}
+
+// NewWithHTTPClient creates a new Cohere provider with a custom HTTP client.
+// If httpClient is nil, http.DefaultClient is used.
+func NewWithHTTPClient(apiKey string, httpClient *http.Client, hooks llmclient.Hooks) *Provider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	p := &Provider{keys: providers.NewKeyring(apiKey)}
+	cfg := llmclient.DefaultConfig("cohere", defaultBaseURL)
+	cfg.Hooks = hooks
+	p.client = llmclient.NewWithHTTPClient(httpClient, cfg, p.setHeaders)
+	return p
+}
+
+// SetBaseURL allows configuring a custom base URL for the provider
+func (p *Provider) SetBaseURL(url string) {
+	p.client.SetBaseURL(url)
+}
+
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	return p.client.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.client.CircuitState()
+}
+
+// setHeaders sets the required headers for Cohere API requests. It runs once
+// per outbound request, so p.keys.Next advances the rotation per call.
+func (p *Provider) setHeaders(req *http.Request) {
+	providers.SetAuthHeaders(req, p.keys.Next(), providers.AuthHeaderConfig{
+		AuthScheme:      "Bearer ",
+		RequestIDHeader: "X-Request-Id",
+	})
+}
+
+// ListModels retrieves the chat-capable models from Cohere's /v1/models
+// endpoint. Embedding, rerank, and classification models are skipped.
+func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
+	models := make([]core.Model, 0)
+	pageToken := ""
+	for range maxModelPages {
+		query := url.Values{"endpoint": {"chat"}, "page_size": {"1000"}}
+		if pageToken != "" {
+			query.Set("page_token", pageToken)
+		}
+		var cohereResp cohereModelsResponse
+		err := p.client.Do(ctx, llmclient.Request{
+			Method:   http.MethodGet,
+			Endpoint: "/v1/models?" + query.Encode(),
+		}, &cohereResp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range cohereResp.Models {
+			if m.Name == "" || !slices.Contains(m.Endpoints, "chat") {
+				continue
+			}
+			models = append(models, core.Model{
+				ID:      m.Name,
+				Object:  "model",
+				OwnedBy: "cohere",
+			})
+		}
+
+		if cohereResp.NextPageToken == "" || cohereResp.NextPageToken == pageToken {
+			break
+		}
+		pageToken = cohereResp.NextPageToken
+	}
+
+	return &core.ModelsResponse{
+		Object: "list",
+		Data:   models,
+	}, nil
+}
+
+// Responses sends a Responses API request to Cohere (converted to chat format)
+func (p *Provider) Responses(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesResponse, error) {
+	return providers.ResponsesViaChat(ctx, p, req)
+}
+
+// StreamResponses returns a raw response body for streaming Responses API (caller must close)
+func (p *Provider) StreamResponses(ctx context.Context, req *core.ResponsesRequest) (io.ReadCloser, error) {
+	return providers.StreamResponsesViaChat(ctx, p, req, "cohere")
+}
+
+// Embeddings returns an error because the gateway does not translate to
+// Cohere's native /v2/embed API yet.
+func (p *Provider) Embeddings(_ context.Context, _ *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	return nil, core.NewInvalidRequestError("cohere embeddings are not supported", nil)
+}
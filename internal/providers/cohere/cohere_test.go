@@ -0,0 +1,393 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+	"github.com/enterpilot/gomodel/internal/providers"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	provider := NewWithHTTPClient("co-test", server.Client(), llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+	return provider
+}
+
+func TestNew_ReturnsProvider(t *testing.T) {
+	provider := New(providers.ProviderConfig{APIKey: "co-test"}, providers.ProviderOptions{})
+	if provider == nil {
+		t.Fatal("provider should not be nil")
+	}
+}
+
+func TestConvertToCohereRequest(t *testing.T) {
+	temperature := 0.3
+	topP := 0.9
+	req := &core.ChatRequest{
+		Model:       "command-a-03-2025",
+		Temperature: &temperature,
+		TopP:        &topP,
+		Messages: []core.Message{
+			{Role: "system", Content: "Be brief."},
+			{Role: "user", Content: "What is the weather in Paris?"},
+			{Role: "developer", Content: "Answer in French."},
+			{Role: "assistant", ToolCalls: []core.ToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: core.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "call_1", Content: "18C"},
+		},
+		Tools: []map[string]any{{
+			"type":     "function",
+			"function": map[string]any{"name": "get_weather", "parameters": map[string]any{"type": "object"}},
+		}},
+		ToolChoice: "required",
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"stop":                  json.RawMessage(`["END"]`),
+			"seed":                  json.RawMessage(`42`),
+			"max_completion_tokens": json.RawMessage(`256`),
+			"response_format":       json.RawMessage(`{"type":"json_schema","json_schema":{"name":"w","schema":{"type":"object"}}}`),
+		}),
+	}
+
+	got, err := convertToCohereRequest(req)
+	if err != nil {
+		t.Fatalf("convertToCohereRequest() error = %v", err)
+	}
+
+	if len(got.Messages) != 4 {
+		t.Fatalf("len(Messages) = %d, want 4 (system folded into one leading message)", len(got.Messages))
+	}
+	if got.Messages[0].Role != "system" || got.Messages[0].Content != "Be brief.\n\nAnswer in French." {
+		t.Errorf("leading system message = %+v, want combined preamble", got.Messages[0])
+	}
+	if got.Messages[2].ToolCalls[0].Function.Arguments != `{"city":"Paris"}` {
+		t.Errorf("assistant tool call = %+v", got.Messages[2].ToolCalls[0])
+	}
+	if got.Messages[3].Role != "tool" || got.Messages[3].ToolCallID != "call_1" || got.Messages[3].Content != "18C" {
+		t.Errorf("tool message = %+v", got.Messages[3])
+	}
+	if got.P == nil || *got.P != 0.9 || got.Temperature == nil || *got.Temperature != 0.3 {
+		t.Errorf("sampling params = p:%v temperature:%v", got.P, got.Temperature)
+	}
+	if got.MaxTokens == nil || *got.MaxTokens != 256 {
+		t.Errorf("MaxTokens = %v, want 256 from max_completion_tokens", got.MaxTokens)
+	}
+	if len(got.StopSequences) != 1 || got.StopSequences[0] != "END" {
+		t.Errorf("StopSequences = %v, want [END]", got.StopSequences)
+	}
+	if string(got.Seed) != "42" {
+		t.Errorf("Seed = %s, want 42", got.Seed)
+	}
+	if got.ToolChoice != "REQUIRED" || len(got.Tools) != 1 {
+		t.Errorf("tools = %v, tool_choice = %q", got.Tools, got.ToolChoice)
+	}
+	if got.ResponseFormat == nil || got.ResponseFormat.Type != "json_object" || string(got.ResponseFormat.JSONSchema) != `{"type":"object"}` {
+		t.Errorf("ResponseFormat = %+v", got.ResponseFormat)
+	}
+}
+
+func TestConvertToCohereRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *core.ChatRequest
+	}{
+		{name: "nil request"},
+		{name: "unknown role", req: &core.ChatRequest{Model: "m", Messages: []core.Message{{Role: "robot", Content: "hi"}}}},
+		{name: "tool message without id", req: &core.ChatRequest{Model: "m", Messages: []core.Message{{Role: "tool", Content: "42"}}}},
+		{name: "forced function", req: &core.ChatRequest{
+			Model:      "m",
+			Messages:   []core.Message{{Role: "user", Content: "hi"}},
+			Tools:      []map[string]any{{"type": "function", "function": map[string]any{"name": "f"}}},
+			ToolChoice: map[string]any{"type": "function", "function": map[string]any{"name": "f"}},
+		}},
+		{name: "audio input", req: &core.ChatRequest{Model: "m", Messages: []core.Message{{Role: "user", Content: []core.ContentPart{
+			{Type: "input_audio", InputAudio: &core.InputAudioContent{Data: "AAAA", Format: "wav"}},
+		}}}}},
+		{name: "audio output", req: &core.ChatRequest{Model: "m", Modalities: []string{"text", "audio"}, Messages: []core.Message{{Role: "user", Content: "hi"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := convertToCohereRequest(tt.req); err == nil {
+				t.Fatal("convertToCohereRequest() error = nil, want invalid request")
+			}
+		})
+	}
+}
+
+func TestConvertToCohereRequest_ToolChoiceNoneDropsTools(t *testing.T) {
+	got, err := convertToCohereRequest(&core.ChatRequest{
+		Model:      "m",
+		Messages:   []core.Message{{Role: "user", Content: "hi"}},
+		Tools:      []map[string]any{{"type": "function", "function": map[string]any{"name": "f"}}},
+		ToolChoice: "none",
+	})
+	if err != nil {
+		t.Fatalf("convertToCohereRequest() error = %v", err)
+	}
+	if got.Tools != nil || got.ToolChoice != "" {
+		t.Fatalf("tools = %v, tool_choice = %q, want none sent", got.Tools, got.ToolChoice)
+	}
+}
+
+func TestChatCompletion(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/chat" || r.Method != http.MethodPost {
+			t.Errorf("request = %s %s, want POST /v2/chat", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer co-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body["model"] != "command-a-03-2025" {
+			t.Errorf("model = %v", body["model"])
+		}
+		_, _ = w.Write([]byte(`{
+			"id": "c14c80c3",
+			"finish_reason": "TOOL_CALL",
+			"message": {
+				"role": "assistant",
+				"tool_plan": "I will look up the weather.",
+				"content": [{"type": "text", "text": "Checking."}],
+				"tool_calls": [{"id": "get_weather_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}]
+			},
+			"usage": {"billed_units": {"input_tokens": 5, "output_tokens": 7}, "tokens": {"input_tokens": 12, "output_tokens": 7}}
+		}`))
+	})
+
+	resp, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "command-a-03-2025",
+		Messages: []core.Message{{Role: "user", Content: "Weather in Paris?"}},
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+
+	if resp.ID != "c14c80c3" || resp.Model != "command-a-03-2025" || resp.Object != "chat.completion" {
+		t.Errorf("response envelope = id:%q model:%q object:%q", resp.ID, resp.Model, resp.Object)
+	}
+	choice := resp.Choices[0]
+	if choice.FinishReason != "tool_calls" {
+		t.Errorf("FinishReason = %q, want tool_calls", choice.FinishReason)
+	}
+	if choice.Message.Content != "Checking." {
+		t.Errorf("Content = %v", choice.Message.Content)
+	}
+	if len(choice.Message.ToolCalls) != 1 || choice.Message.ToolCalls[0].Function.Name != "get_weather" {
+		t.Errorf("ToolCalls = %+v", choice.Message.ToolCalls)
+	}
+	if got := string(choice.Message.ExtraFields.Lookup("reasoning_content")); got != `"I will look up the weather."` {
+		t.Errorf("reasoning_content = %s, want tool plan", got)
+	}
+	if resp.Usage.PromptTokens != 12 || resp.Usage.CompletionTokens != 7 || resp.Usage.TotalTokens != 19 {
+		t.Errorf("Usage = %+v", resp.Usage)
+	}
+	if resp.Usage.RawUsage["billed_input_tokens"] != 5 {
+		t.Errorf("RawUsage = %v, want billed units", resp.Usage.RawUsage)
+	}
+}
+
+func TestChatCompletion_APIError(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"invalid api token"}`))
+	})
+
+	_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "command-a-03-2025",
+		Messages: []core.Message{{Role: "user", Content: "hi"}},
+	})
+	gatewayErr, ok := err.(*core.GatewayError)
+	if !ok {
+		t.Fatalf("error = %T %v, want *core.GatewayError", err, err)
+	}
+	if gatewayErr.StatusCode != http.StatusUnauthorized || gatewayErr.Provider != "cohere" {
+		t.Errorf("error = status %d provider %q", gatewayErr.StatusCode, gatewayErr.Provider)
+	}
+}
+
+const cohereStreamFixture = `event: message-start
+data: {"id":"29f14a5a","type":"message-start","delta":{"message":{"role":"assistant","content":[],"tool_plan":"","tool_calls":[],"citations":[]}}}
+
+event: content-start
+data: {"type":"content-start","index":0,"delta":{"message":{"content":{"type":"text","text":""}}}}
+
+event: content-delta
+data: {"type":"content-delta","index":0,"delta":{"message":{"content":{"text":"Hello"}}}}
+
+event: content-end
+data: {"type":"content-end","index":0}
+
+event: tool-call-start
+data: {"type":"tool-call-start","index":0,"delta":{"message":{"tool_calls":{"id":"get_weather_1","type":"function","function":{"name":"get_weather","arguments":""}}}}}
+
+event: tool-call-delta
+data: {"type":"tool-call-delta","index":0,"delta":{"message":{"tool_calls":{"function":{"arguments":"{\"city\":\"Paris\"}"}}}}}
+
+event: tool-call-end
+data: {"type":"tool-call-end","index":0}
+
+event: message-end
+data: {"type":"message-end","delta":{"finish_reason":"TOOL_CALL","usage":{"billed_units":{"input_tokens":5,"output_tokens":9},"tokens":{"input_tokens":11,"output_tokens":9}}}}
+
+`
+
+func TestStreamChatCompletion(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body["stream"] != true {
+			t.Errorf("stream = %v, want true", body["stream"])
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(cohereStreamFixture))
+	})
+
+	stream, err := provider.StreamChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "command-a-03-2025",
+		Messages: []core.Message{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChatCompletion() error = %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+	raw, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	var chunks []map[string]any
+	for _, line := range strings.Split(string(raw), "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+		var chunk map[string]any
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("chunk %q: %v", data, err)
+		}
+		if chunk["object"] != "chat.completion.chunk" || chunk["id"] != "29f14a5a" || chunk["model"] != "command-a-03-2025" {
+			t.Errorf("chunk envelope = %v", chunk)
+		}
+		chunks = append(chunks, chunk)
+	}
+	if !strings.HasSuffix(string(raw), "data: [DONE]\n\n") {
+		t.Error("stream did not end with [DONE]")
+	}
+	if len(chunks) != 5 {
+		t.Fatalf("got %d chunks, want role, content, tool start, tool delta, finish:\n%s", len(chunks), raw)
+	}
+
+	delta := func(i int) map[string]any {
+		return chunks[i]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)
+	}
+	if delta(0)["role"] != "assistant" || delta(1)["content"] != "Hello" {
+		t.Errorf("leading deltas = %v, %v", delta(0), delta(1))
+	}
+	start := delta(2)["tool_calls"].([]any)[0].(map[string]any)
+	if start["id"] != "get_weather_1" || start["function"].(map[string]any)["name"] != "get_weather" {
+		t.Errorf("tool call start = %v", start)
+	}
+	args := delta(3)["tool_calls"].([]any)[0].(map[string]any)["function"].(map[string]any)["arguments"]
+	if args != `{"city":"Paris"}` {
+		t.Errorf("tool call arguments = %v", args)
+	}
+	final := chunks[4]
+	if reason := final["choices"].([]any)[0].(map[string]any)["finish_reason"]; reason != "tool_calls" {
+		t.Errorf("finish_reason = %v, want tool_calls", reason)
+	}
+	usage := final["usage"].(map[string]any)
+	if usage["prompt_tokens"] != float64(11) || usage["total_tokens"] != float64(20) {
+		t.Errorf("usage = %v", usage)
+	}
+}
+
+func TestStreamConverter_MalformedEventReturnsError(t *testing.T) {
+	stream := newStreamConverter(io.NopCloser(strings.NewReader("data: {not json}\n\n")), "command-a-03-2025")
+	defer func() { _ = stream.Close() }()
+
+	_, err := io.ReadAll(stream)
+	gatewayErr, ok := err.(*core.GatewayError)
+	if !ok || gatewayErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("ReadAll() error = %v, want 502 provider error", err)
+	}
+}
+
+func TestListModels(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" || r.Method != http.MethodGet {
+			t.Errorf("request = %s %s, want GET /v1/models", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("endpoint"); got != "chat" {
+			t.Errorf("endpoint query = %q, want chat", got)
+		}
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			_, _ = w.Write([]byte(`{"models":[
+				{"name":"command-a-03-2025","endpoints":["generate","chat","summarize"]},
+				{"name":"embed-v4.0","endpoints":["embed"]}
+			],"next_page_token":"p2"}`))
+		case "p2":
+			_, _ = w.Write([]byte(`{"models":[
+				{"name":"command-r7b-12-2024","endpoints":["chat"]},
+				{"name":"rerank-v3.5","endpoints":["rerank"]}
+			]}`))
+		default:
+			t.Errorf("unexpected page_token %q", r.URL.Query().Get("page_token"))
+		}
+	})
+
+	resp, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	var ids []string
+	for _, model := range resp.Data {
+		ids = append(ids, model.ID)
+		if model.OwnedBy != "cohere" || model.Object != "model" {
+			t.Errorf("model = %+v", model)
+		}
+	}
+	if strings.Join(ids, ",") != "command-a-03-2025,command-r7b-12-2024" {
+		t.Fatalf("models = %v, want only chat models across pages", ids)
+	}
+}
+
+func TestResponses_ConvertsThroughChat(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/chat" {
+			t.Errorf("Path = %q, want /v2/chat", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"r1","finish_reason":"COMPLETE","message":{"role":"assistant","content":[{"type":"text","text":"Bonjour"}]},"usage":{"tokens":{"input_tokens":3,"output_tokens":1}}}`))
+	})
+
+	resp, err := provider.Responses(context.Background(), &core.ResponsesRequest{
+		Model: "command-a-03-2025",
+		Input: "Say hello in French",
+	})
+	if err != nil {
+		t.Fatalf("Responses() error = %v", err)
+	}
+	if resp.Object != "response" || resp.Status != "completed" {
+		t.Fatalf("response = object %q status %q", resp.Object, resp.Status)
+	}
+	if len(resp.Output) == 0 || len(resp.Output[0].Content) == 0 || resp.Output[0].Content[0].Text != "Bonjour" {
+		t.Fatalf("Output = %+v, want Bonjour", resp.Output)
+	}
+}
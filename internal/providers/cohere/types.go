@@ -0,0 +1,141 @@
+package cohere
+
+import "github.com/goccy/go-json"
+
+// cohereRequest is the body of a Cohere v2 /chat request.
+type cohereRequest struct {
+	Model            string                `json:"model"`
+	Messages         []cohereMessage       `json:"messages"`
+	Tools            []map[string]any      `json:"tools,omitempty"`
+	ToolChoice       string                `json:"tool_choice,omitempty"`
+	Stream           bool                  `json:"stream,omitempty"`
+	MaxTokens        *int                  `json:"max_tokens,omitempty"`
+	Temperature      *float64              `json:"temperature,omitempty"`
+	P                *float64              `json:"p,omitempty"`
+	StopSequences    []string              `json:"stop_sequences,omitempty"`
+	Seed             json.RawMessage       `json:"seed,omitempty"`
+	FrequencyPenalty json.RawMessage       `json:"frequency_penalty,omitempty"`
+	PresencePenalty  json.RawMessage       `json:"presence_penalty,omitempty"`
+	ResponseFormat   *cohereResponseFormat `json:"response_format,omitempty"`
+}
+
+// cohereMessage is a single v2 chat message. Content is either a string or a
+// list of cohereContentPart values.
+type cohereMessage struct {
+	Role       string           `json:"role"`
+	Content    any              `json:"content,omitempty"`
+	ToolCalls  []cohereToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type cohereContentPart struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	Thinking string          `json:"thinking,omitempty"`
+	ImageURL *cohereImageURL `json:"image_url,omitempty"`
+}
+
+type cohereImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type cohereToolCall struct {
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function cohereFunctionCall `json:"function"`
+}
+
+type cohereFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+type cohereResponseFormat struct {
+	Type       string          `json:"type"`
+	JSONSchema json.RawMessage `json:"json_schema,omitempty"`
+}
+
+// cohereResponse is the body of a non-streaming v2 /chat response.
+type cohereResponse struct {
+	ID           string                `json:"id"`
+	FinishReason string                `json:"finish_reason"`
+	Message      cohereResponseMessage `json:"message"`
+	Usage        *cohereUsage          `json:"usage,omitempty"`
+}
+
+type cohereResponseMessage struct {
+	Role      string              `json:"role"`
+	Content   []cohereContentPart `json:"content,omitempty"`
+	ToolPlan  string              `json:"tool_plan,omitempty"`
+	ToolCalls []cohereToolCall    `json:"tool_calls,omitempty"`
+}
+
+type cohereUsage struct {
+	BilledUnits *cohereTokenCounts `json:"billed_units,omitempty"`
+	Tokens      *cohereTokenCounts `json:"tokens,omitempty"`
+}
+
+type cohereTokenCounts struct {
+	InputTokens  float64 `json:"input_tokens"`
+	OutputTokens float64 `json:"output_tokens"`
+}
+
+// cohereStreamEvent is the data payload of one v2 /chat SSE event.
+type cohereStreamEvent struct {
+	Type  string             `json:"type"`
+	ID    string             `json:"id,omitempty"`
+	Index int                `json:"index"`
+	Delta *cohereStreamDelta `json:"delta,omitempty"`
+}
+
+type cohereStreamDelta struct {
+	Message      *cohereStreamMessage `json:"message,omitempty"`
+	FinishReason string               `json:"finish_reason,omitempty"`
+	Usage        *cohereUsage         `json:"usage,omitempty"`
+}
+
+// cohereStreamMessage keeps content and tool_calls raw: message-start sends
+// them as empty arrays while delta events send single objects.
+type cohereStreamMessage struct {
+	Role      string          `json:"role,omitempty"`
+	Content   json.RawMessage `json:"content,omitempty"`
+	ToolPlan  string          `json:"tool_plan,omitempty"`
+	ToolCalls json.RawMessage `json:"tool_calls,omitempty"`
+}
+
+// contentPart decodes a delta's content object, or returns nil.
+func (m *cohereStreamMessage) contentPart() *cohereContentPart {
+	if m == nil || len(m.Content) == 0 || m.Content[0] != '{' {
+		return nil
+	}
+	var part cohereContentPart
+	if err := json.Unmarshal(m.Content, &part); err != nil {
+		return nil
+	}
+	return &part
+}
+
+// toolCall decodes a delta's tool_calls object, or returns nil.
+func (m *cohereStreamMessage) toolCall() *cohereToolCall {
+	if m == nil || len(m.ToolCalls) == 0 || m.ToolCalls[0] != '{' {
+		return nil
+	}
+	var call cohereToolCall
+	if err := json.Unmarshal(m.ToolCalls, &call); err != nil {
+		return nil
+	}
+	return &call
+}
+
+// cohereModelsResponse is the body of a /v1/models response.
+type cohereModelsResponse struct {
+	Models        []cohereModel `json:"models"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+type cohereModel struct {
+	Name          string   `json:"name"`
+	Endpoints     []string `json:"endpoints"`
+	ContextLength float64  `json:"context_length,omitempty"`
+}
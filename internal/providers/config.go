@@ -3,11 +3,14 @@ package providers
 import (
 	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 
+	"github.com/goccy/go-json"
+
 	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/core"
 )
@@ -42,6 +45,28 @@ type ProviderConfig struct {
 	// win. Empty/nil when no per-model metadata is declared in YAML.
 	ModelMetadataOverrides map[string]*core.ModelMetadata
 	Resilience             config.ResilienceConfig
+	// DefaultParams holds the JSON-encoded default_params fields the router
+	// merges into chat and Responses requests the client left unset.
+	DefaultParams map[string]json.RawMessage
+	// Priority ranks the provider for bare model IDs it shares with others
+	// under the "priority" duplicate model policy. Higher wins.
+	Priority int
+	// DefaultMaxTokens is the output-token limit used when a client omits
+	// max_tokens for providers that require one. 0 means unset.
+	DefaultMaxTokens int
+	// Weight is the provider's share of round-robin traffic for bare model
+	// IDs it shares with others under the "all" policy. 0 means 1.
+	Weight int
+	// Proxy is the http:// or https:// proxy URL upstream requests go
+	// through. Empty falls back to the HTTP_PROXY environment variables.
+	Proxy string
+	// SiteURL and AppName are OpenRouter's attribution headers (HTTP-Referer
+	// and X-OpenRouter-Title). Empty means the provider's default.
+	SiteURL string
+	AppName string
+	// ModelPrefixes limits which upstream model IDs a Gemini provider exposes.
+	// Empty means the provider's default families.
+	ModelPrefixes []string
 }
 
 // resolveProviders applies env var overrides to the raw YAML provider map, filters
@@ -830,6 +855,14 @@ func buildProviderConfig(raw config.RawProviderConfig, global config.ResilienceC
 		Models:                   config.ProviderModelIDs(raw.Models),
 		ModelMetadataOverrides:   config.ProviderModelMetadataOverrides(raw.Models),
 		Resilience:               global,
+		DefaultParams:            encodeDefaultParams(raw.DefaultParams),
+		Priority:                 raw.Priority,
+		DefaultMaxTokens:         max(raw.DefaultMaxTokens, 0),
+		Weight:                   max(raw.Weight, 0),
+		Proxy:                    strings.TrimSpace(raw.Proxy),
+		SiteURL:                  strings.TrimSpace(raw.SiteURL),
+		AppName:                  strings.TrimSpace(raw.AppName),
+		ModelPrefixes:            slices.Clone(raw.ModelPrefixes),
 	}
 
 	if raw.Resilience == nil {
@@ -852,6 +885,12 @@ func buildProviderConfig(raw config.RawProviderConfig, global config.ResilienceC
 		if r.JitterFactor != nil {
 			resolved.Resilience.Retry.JitterFactor = *r.JitterFactor
 		}
+		if r.BodyErrorCodes != nil {
+			resolved.Resilience.Retry.BodyErrorCodes = r.BodyErrorCodes
+		}
+		if r.MaxElapsedTime != nil {
+			resolved.Resilience.Retry.MaxElapsedTime = *r.MaxElapsedTime
+		}
 	}
 
 	if cb := raw.Resilience.CircuitBreaker; cb != nil {
@@ -864,6 +903,30 @@ func buildProviderConfig(raw config.RawProviderConfig, global config.ResilienceC
 		if cb.Timeout != nil {
 			resolved.Resilience.CircuitBreaker.Timeout = *cb.Timeout
 		}
+		if cb.SlowCallThreshold != nil {
+			resolved.Resilience.CircuitBreaker.SlowCallThreshold = *cb.SlowCallThreshold
+		}
+	}
+
+	if q := raw.Resilience.Queue; q != nil {
+		if q.MaxConcurrent != nil {
+			resolved.Resilience.Queue.MaxConcurrent = *q.MaxConcurrent
+		}
+		if q.MaxQueue != nil {
+			resolved.Resilience.Queue.MaxQueue = *q.MaxQueue
+		}
+		if q.MaxWait != nil {
+			resolved.Resilience.Queue.MaxWait = *q.MaxWait
+		}
+	}
+
+	if tp := raw.Resilience.TokenPacing; tp != nil {
+		if tp.TokensPerMinute != nil {
+			resolved.Resilience.TokenPacing.TokensPerMinute = *tp.TokensPerMinute
+		}
+		if tp.MaxWait != nil {
+			resolved.Resilience.TokenPacing.MaxWait = *tp.MaxWait
+		}
 	}
 
 	return resolved
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"maps"
+	"reflect"
 	"slices"
 	"testing"
 	"time"
@@ -82,7 +84,7 @@ func TestBuildProviderConfig_InheritsGlobal(t *testing.T) {
 	if got.Type != "openai" {
 		t.Errorf("Type = %q, want openai", got.Type)
 	}
-	if got.Resilience.Retry != globalRetry {
+	if !reflect.DeepEqual(got.Resilience.Retry, globalRetry) {
 		t.Errorf("expected global retry to be inherited\ngot:  %+v\nwant: %+v", got.Resilience.Retry, globalRetry)
 	}
 }
@@ -91,7 +93,7 @@ func TestBuildProviderConfig_NilResilience(t *testing.T) {
 	raw := config.RawProviderConfig{Type: "openai", APIKey: "sk", Resilience: nil}
 	got := buildProviderConfig(raw, globalResilience)
 
-	if got.Resilience.Retry != globalRetry {
+	if !reflect.DeepEqual(got.Resilience.Retry, globalRetry) {
 		t.Error("nil Resilience should inherit global")
 	}
 }
@@ -104,7 +106,7 @@ func TestBuildProviderConfig_NilRetry(t *testing.T) {
 	}
 	got := buildProviderConfig(raw, globalResilience)
 
-	if got.Resilience.Retry != globalRetry {
+	if !reflect.DeepEqual(got.Resilience.Retry, globalRetry) {
 		t.Error("nil Retry should inherit global")
 	}
 }
@@ -143,6 +145,8 @@ func TestBuildProviderConfig_FullOverride(t *testing.T) {
 				MaxBackoff:     new(10 * time.Second),
 				BackoffFactor:  new(1.5),
 				JitterFactor:   new(0.3),
+				BodyErrorCodes: []string{"overloaded_error"},
+				MaxElapsedTime: new(45 * time.Second),
 			},
 		},
 	}
@@ -164,6 +168,12 @@ func TestBuildProviderConfig_FullOverride(t *testing.T) {
 	if r.JitterFactor != 0.3 {
 		t.Errorf("JitterFactor = %f, want 0.3", r.JitterFactor)
 	}
+	if len(r.BodyErrorCodes) != 1 || r.BodyErrorCodes[0] != "overloaded_error" {
+		t.Errorf("BodyErrorCodes = %v, want [overloaded_error]", r.BodyErrorCodes)
+	}
+	if r.MaxElapsedTime != 45*time.Second {
+		t.Errorf("MaxElapsedTime = %v, want 45s", r.MaxElapsedTime)
+	}
 }
 
 func TestBuildProviderConfig_ZeroValueOverride(t *testing.T) {
@@ -183,6 +193,56 @@ func TestBuildProviderConfig_ZeroValueOverride(t *testing.T) {
 	}
 }
 
+func TestBuildProviderConfig_QueueOverride(t *testing.T) {
+	global := globalResilience
+	global.Queue = config.DefaultQueueConfig()
+	raw := config.RawProviderConfig{
+		Type:   "openai",
+		APIKey: "sk",
+		Resilience: &config.RawResilienceConfig{
+			Queue: &config.RawQueueConfig{
+				MaxConcurrent: new(4),
+				MaxWait:       new(5 * time.Second),
+			},
+		},
+	}
+	got := buildProviderConfig(raw, global)
+
+	q := got.Resilience.Queue
+	if q.MaxConcurrent != 4 {
+		t.Errorf("MaxConcurrent = %d, want 4", q.MaxConcurrent)
+	}
+	if q.MaxWait != 5*time.Second {
+		t.Errorf("MaxWait = %v, want 5s", q.MaxWait)
+	}
+	if q.MaxQueue != global.Queue.MaxQueue {
+		t.Errorf("MaxQueue should be inherited, got %d", q.MaxQueue)
+	}
+}
+
+func TestBuildProviderConfig_TokenPacingOverride(t *testing.T) {
+	global := globalResilience
+	global.TokenPacing = config.DefaultTokenPacingConfig()
+	raw := config.RawProviderConfig{
+		Type:   "openai",
+		APIKey: "sk",
+		Resilience: &config.RawResilienceConfig{
+			TokenPacing: &config.RawTokenPacingConfig{
+				TokensPerMinute: new(90000),
+			},
+		},
+	}
+	got := buildProviderConfig(raw, global)
+
+	tp := got.Resilience.TokenPacing
+	if tp.TokensPerMinute != 90000 {
+		t.Errorf("TokensPerMinute = %d, want 90000", tp.TokensPerMinute)
+	}
+	if tp.MaxWait != global.TokenPacing.MaxWait {
+		t.Errorf("MaxWait should be inherited, got %v", tp.MaxWait)
+	}
+}
+
 func TestBuildProviderConfig_PreservesFields(t *testing.T) {
 	raw := config.RawProviderConfig{
 		Type:               "gemini",
@@ -353,6 +413,29 @@ func TestFilterEmptyProviders_VLLMAllowsKeylessConfig(t *testing.T) {
 	}
 }
 
+func TestFilterEmptyProviders_KeylessTypeKeptAlongsideUnresolvedKeyedProvider(t *testing.T) {
+	discovery := maps.Clone(testDiscoveryConfigs)
+	discovery["local-llm"] = DiscoveryConfig{AllowAPIKeyless: true}
+
+	got := filterEmptyProviders(map[string]config.RawProviderConfig{
+		"local":  {Type: "local-llm", BaseURL: "http://localhost:9000/v1"},
+		"ollama": {Type: "ollama", APIKey: "${OLLAMA_API_KEY}"},
+		"openai": {Type: "openai", APIKey: "${OPENAI_API_KEY}"},
+		"groq":   {Type: "groq", APIKey: ""},
+	}, discovery)
+
+	for _, name := range []string{"local", "ollama"} {
+		if _, exists := got[name]; !exists {
+			t.Errorf("expected keyless provider %q to be kept", name)
+		}
+	}
+	for _, name := range []string{"openai", "groq"} {
+		if _, exists := got[name]; exists {
+			t.Errorf("expected keyed provider %q without a resolved key to be removed", name)
+		}
+	}
+}
+
 func TestSkippedProviderNames_ListsDeclaredButUnresolved(t *testing.T) {
 	declared := map[string]config.RawProviderConfig{
 		"openai":    {Type: "openai", APIKey: "${OPENAI_API_KEY}"},
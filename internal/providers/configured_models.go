@@ -161,10 +161,12 @@ func modelInfoMapFromResponse(resp *core.ModelsResponse, provider core.Provider,
 		}
 		model.ID = modelID
 		out[modelID] = &ModelInfo{
-			Model:        model,
-			Provider:     provider,
-			ProviderName: providerName,
-			ProviderType: providerType,
+			Model:            model,
+			Provider:         provider,
+			ProviderName:     providerName,
+			ProviderType:     providerType,
+			Source:           ModelSourceConfigured,
+			ReportedMetadata: model.Metadata.Clone(),
 		}
 	}
 	return out
@@ -172,10 +174,20 @@ func modelInfoMapFromResponse(resp *core.ModelsResponse, provider core.Provider,
 
 func rebuildGlobalModelMap(modelsByProvider map[string]map[string]*ModelInfo, providerOrderNames []string) map[string]*ModelInfo {
 	global := make(map[string]*ModelInfo)
+	for _, providerName := range inventoryProviderOrder(modelsByProvider, providerOrderNames) {
+		addProviderModels(global, modelsByProvider[providerName])
+	}
+	return global
+}
+
+// inventoryProviderOrder returns providerOrderNames followed by any other
+// providers present in modelsByProvider, sorted by name.
+func inventoryProviderOrder(modelsByProvider map[string]map[string]*ModelInfo, providerOrderNames []string) []string {
+	order := make([]string, 0, len(modelsByProvider))
 	seenProvider := make(map[string]struct{}, len(providerOrderNames))
 	for _, providerName := range providerOrderNames {
 		seenProvider[providerName] = struct{}{}
-		addProviderModels(global, modelsByProvider[providerName])
+		order = append(order, providerName)
 	}
 
 	remaining := make([]string, 0, len(modelsByProvider))
@@ -186,10 +198,7 @@ func rebuildGlobalModelMap(modelsByProvider map[string]map[string]*ModelInfo, pr
 		remaining = append(remaining, providerName)
 	}
 	sort.Strings(remaining)
-	for _, providerName := range remaining {
-		addProviderModels(global, modelsByProvider[providerName])
-	}
-	return global
+	return append(order, remaining...)
 }
 
 func addProviderModels(global map[string]*ModelInfo, providerModels map[string]*ModelInfo) {
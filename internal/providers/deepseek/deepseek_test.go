@@ -152,6 +152,60 @@ func TestResponses_TranslatesToChatCompletions(t *testing.T) {
 	}
 }
 
+func TestStreamChatCompletion_PreservesReasoningContent(t *testing.T) {
+	const chunk = `{"id":"chatcmpl-deepseek","object":"chat.completion.chunk","created":1677652288,"model":"deepseek-reasoner","choices":[{"index":0,"delta":{"reasoning_content":"thinking..."},"finish_reason":null}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: " + chunk + "\n\n"))
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("deepseek-key", server.URL, server.Client(), llmclient.Hooks{})
+
+	stream, err := provider.StreamChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "deepseek-reasoner",
+		Messages: []core.Message{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChatCompletion() error = %v", err)
+	}
+	defer stream.Close()
+
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if got, want := string(body), "data: "+chunk+"\n\ndata: [DONE]\n\n"; got != want {
+		t.Fatalf("stream = %q, want upstream chunks forwarded verbatim %q", got, want)
+	}
+}
+
+func TestListModels_UsesModelsEndpoint(t *testing.T) {
+	var gotPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"deepseek-chat","object":"model","owned_by":"deepseek"},{"id":"deepseek-reasoner","object":"model","owned_by":"deepseek"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("deepseek-key", server.URL, server.Client(), llmclient.Hooks{})
+
+	resp, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if gotPath != "/models" {
+		t.Fatalf("path = %q, want /models", gotPath)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].ID != "deepseek-chat" || resp.Data[1].ID != "deepseek-reasoner" {
+		t.Fatalf("Data = %+v, want deepseek-chat and deepseek-reasoner", resp.Data)
+	}
+}
+
 func TestStreamResponses_TranslatesToChatCompletions(t *testing.T) {
 	var gotPath string
 	var gotBody map[string]any
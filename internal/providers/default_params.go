@@ -0,0 +1,155 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// systemPromptPrefixParam is the default_params key holding text prepended to
+// the system prompt of every request routed to the provider. Unlike the other
+// keys it is not a request field: it is combined with the client's system
+// prompt rather than filling it in only when absent.
+const systemPromptPrefixParam = "system_prompt_prefix"
+
+// providerDefaults is the request-time form of a provider's default_params
+// and default_max_tokens.
+type providerDefaults struct {
+	params             map[string]json.RawMessage
+	systemPromptPrefix string
+	maxTokens          int
+}
+
+// encodeDefaultParams JSON-encodes a provider's default_params once at startup
+// so request-time merging only copies raw bytes. Fields that cannot be encoded
+// are dropped with a warning.
+func encodeDefaultParams(params map[string]any) map[string]json.RawMessage {
+	if len(params) == 0 {
+		return nil
+	}
+	encoded := make(map[string]json.RawMessage, len(params))
+	for key, value := range params {
+		switch key {
+		case "model", "provider", "messages", "input", "stream":
+			slog.Warn("ignoring default_params field that selects or shapes the request", "field", key)
+			continue
+		}
+		if key == systemPromptPrefixParam {
+			if prefix, ok := value.(string); !ok || strings.TrimSpace(prefix) == "" {
+				slog.Warn("ignoring default_params system_prompt_prefix that is not a non-empty string")
+				continue
+			}
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			slog.Warn("ignoring default_params field that cannot be encoded", "field", key, "error", err)
+			continue
+		}
+		encoded[key] = raw
+	}
+	if len(encoded) == 0 {
+		return nil
+	}
+	return encoded
+}
+
+// SetDefaultParams records each configured provider's default request params
+// and default output-token limit, keyed by provider name. It is safe to call while the router serves traffic;
+// requests already being forwarded keep the params they started with.
+func (r *Router) SetDefaultParams(configs map[string]ProviderConfig) {
+	defaults := make(map[string]providerDefaults, len(configs))
+	for name, cfg := range configs {
+		if len(cfg.DefaultParams) == 0 && cfg.DefaultMaxTokens <= 0 {
+			continue
+		}
+		params := maps.Clone(cfg.DefaultParams)
+		var prefix string
+		if raw, ok := params[systemPromptPrefixParam]; ok {
+			delete(params, systemPromptPrefixParam)
+			if err := json.Unmarshal(raw, &prefix); err != nil {
+				slog.Warn("ignoring default_params system_prompt_prefix", "provider", name, "error", err)
+			}
+		}
+		defaults[name] = providerDefaults{params: params, systemPromptPrefix: prefix, maxTokens: cfg.DefaultMaxTokens}
+	}
+	r.defaultParams.Store(&defaults)
+}
+
+// HasDefaultParams reports whether requests routed to providerName are merged
+// with configured default params, which rules out verbatim body passthrough.
+func (r *Router) HasDefaultParams(providerName string) bool {
+	defaults := r.providerDefaultParams(providerName)
+	return len(defaults.params) > 0 || defaults.systemPromptPrefix != ""
+}
+
+func (r *Router) providerDefaultParams(providerName string) providerDefaults {
+	defaults := r.defaultParams.Load()
+	if defaults == nil {
+		return providerDefaults{}
+	}
+	return (*defaults)[providerName]
+}
+
+// withDefaultParams returns req with the provider's default params filled in
+// for every top-level field the client left unset or null. The request
+// round-trips through JSON so typed fields and passthrough extras are handled
+// alike. On failure the request is forwarded unchanged.
+func withDefaultParams[T any](req *T, defaults map[string]json.RawMessage) *T {
+	if len(defaults) == 0 {
+		return req
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		slog.Warn("failed to apply provider default params", "error", err)
+		return req
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		slog.Warn("failed to apply provider default params", "error", err)
+		return req
+	}
+	applied := false
+	for key, value := range defaults {
+		if existing, ok := fields[key]; ok && !core.IsJSONNull(bytes.TrimSpace(existing)) {
+			continue
+		}
+		fields[key] = value
+		applied = true
+	}
+	if !applied {
+		return req
+	}
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		slog.Warn("failed to apply provider default params", "error", err)
+		return req
+	}
+	var out T
+	if err := json.Unmarshal(merged, &out); err != nil {
+		slog.Warn("failed to apply provider default params", "error", err)
+		return req
+	}
+	return &out
+}
+
+func (r *Router) forwardChatRequest(ctx context.Context, req *core.ChatRequest, selector core.ModelSelector) *core.ChatRequest {
+	defaults := r.providerDefaultParams(selector.Provider)
+	forwardReq := withDefaultParams(forwardChatRequest(req, selector), defaults.params).WithSystemPromptPrefix(defaults.systemPromptPrefix)
+	r.applySamplingPolicy(ctx, &forwardReq.Temperature, &forwardReq.TopP)
+	r.applyModelMaxOutputTokens(&forwardReq.MaxTokens, selector, defaults.maxTokens)
+	return forwardReq
+}
+
+func (r *Router) forwardResponsesRequest(ctx context.Context, req *core.ResponsesRequest, selector core.ModelSelector) *core.ResponsesRequest {
+	defaults := r.providerDefaultParams(selector.Provider)
+	forwardReq := withDefaultParams(forwardResponsesRequest(req, selector), defaults.params).WithInstructionsPrefix(defaults.systemPromptPrefix)
+	r.applySamplingPolicy(ctx, &forwardReq.Temperature, &forwardReq.TopP)
+	r.applyModelMaxOutputTokens(&forwardReq.MaxOutputTokens, selector, defaults.maxTokens)
+	return forwardReq
+}
@@ -0,0 +1,168 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func newDefaultParamsRouter(t *testing.T, provider *mockProvider, defaults map[string]any) *Router {
+	t.Helper()
+	lookup := newMockLookup()
+	lookup.addModel("openai-east/gpt-4o", provider, "openai")
+	router, err := NewRouter(lookup)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	router.SetDefaultParams(map[string]ProviderConfig{
+		"openai-east": buildProviderConfig(config.RawProviderConfig{Type: "openai", DefaultParams: defaults}, config.ResilienceConfig{}),
+	})
+	return router
+}
+
+func TestRouterChatCompletion_AppliesDefaultParams(t *testing.T) {
+	provider := &mockProvider{name: "openai-east", chatResponse: &core.ChatResponse{ID: "east"}}
+	router := newDefaultParamsRouter(t, provider, map[string]any{
+		"temperature": 0.2,
+		"top_p":       0.9,
+		"seed_hint":   "fixed",
+	})
+
+	clientTopP := 0.5
+	_, err := router.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Provider: "openai-east",
+		Messages: []core.Message{{Role: "user", Content: "Hello"}},
+		TopP:     &clientTopP,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := provider.lastChatReq
+	if got == nil {
+		t.Fatal("expected upstream chat request")
+	}
+	if got.Temperature == nil || *got.Temperature != 0.2 {
+		t.Fatalf("Temperature = %v, want default 0.2", got.Temperature)
+	}
+	if got.TopP == nil || *got.TopP != 0.5 {
+		t.Fatalf("TopP = %v, want client value 0.5", got.TopP)
+	}
+	if raw := got.ExtraFields.Lookup("seed_hint"); string(raw) != `"fixed"` {
+		t.Fatalf("seed_hint = %s, want default passthrough field", raw)
+	}
+	if got.Model != "gpt-4o" || got.Provider != "" || len(got.Messages) != 1 {
+		t.Fatalf("forwarded request = %+v, want unqualified model and original messages", got)
+	}
+}
+
+func TestRouterResponses_AppliesDefaultParams(t *testing.T) {
+	provider := &mockProvider{name: "openai-east", responsesResponse: &core.ResponsesResponse{ID: "resp"}}
+	router := newDefaultParamsRouter(t, provider, map[string]any{"temperature": 0.2})
+
+	clientTemperature := 1.0
+	_, err := router.Responses(context.Background(), &core.ResponsesRequest{
+		Model:       "gpt-4o",
+		Provider:    "openai-east",
+		Input:       "Hello",
+		Temperature: &clientTemperature,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := provider.lastResponsesReq.Temperature; got == nil || *got != 1.0 {
+		t.Fatalf("Temperature = %v, want client value 1.0", got)
+	}
+
+	_, err = router.Responses(context.Background(), &core.ResponsesRequest{
+		Model:    "gpt-4o",
+		Provider: "openai-east",
+		Input:    "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := provider.lastResponsesReq.Temperature; got == nil || *got != 0.2 {
+		t.Fatalf("Temperature = %v, want default 0.2", got)
+	}
+}
+
+func TestEncodeDefaultParams_SkipsRoutingFields(t *testing.T) {
+	encoded := encodeDefaultParams(map[string]any{
+		"model":                "gpt-4o-mini",
+		"stream":               true,
+		"temperature":          0.2,
+		"system_prompt_prefix": 42,
+	})
+	if len(encoded) != 1 {
+		t.Fatalf("encoded = %v, want only temperature", encoded)
+	}
+	if string(encoded["temperature"]) != "0.2" {
+		t.Fatalf("temperature = %s, want 0.2", encoded["temperature"])
+	}
+	if encodeDefaultParams(nil) != nil {
+		t.Fatal("expected nil for empty default params")
+	}
+}
+
+func TestRouterChatCompletion_PrependsSystemPromptPrefix(t *testing.T) {
+	provider := &mockProvider{name: "openai-east", chatResponse: &core.ChatResponse{ID: "east"}}
+	router := newDefaultParamsRouter(t, provider, map[string]any{"system_prompt_prefix": "Answer in English."})
+
+	messages := []core.Message{
+		{Role: "system", Content: "You are terse."},
+		{Role: "user", Content: "Hello"},
+	}
+	if _, err := router.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Provider: "openai-east",
+		Messages: messages,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := provider.lastChatReq.Messages
+	if len(got) != 2 || got[0].Content != "Answer in English.\nYou are terse." {
+		t.Fatalf("messages = %+v, want prefix prepended to the client system prompt", got)
+	}
+	if messages[0].Content != "You are terse." {
+		t.Fatalf("client messages were modified: %+v", messages)
+	}
+	if raw := provider.lastChatReq.ExtraFields.Lookup("system_prompt_prefix"); raw != nil {
+		t.Fatalf("system_prompt_prefix forwarded as a request field: %s", raw)
+	}
+
+	if _, err := router.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Provider: "openai-east",
+		Messages: []core.Message{{Role: "user", Content: "Hello"}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got = provider.lastChatReq.Messages
+	if len(got) != 2 || got[0].Role != "system" || got[0].Content != "Answer in English." {
+		t.Fatalf("messages = %+v, want a leading system message with the prefix", got)
+	}
+}
+
+func TestRouterResponses_PrependsSystemPromptPrefixToInstructions(t *testing.T) {
+	provider := &mockProvider{name: "openai-east", responsesResponse: &core.ResponsesResponse{ID: "resp"}}
+	router := newDefaultParamsRouter(t, provider, map[string]any{"system_prompt_prefix": "Answer in English."})
+
+	if _, err := router.Responses(context.Background(), &core.ResponsesRequest{
+		Model:        "gpt-4o",
+		Provider:     "openai-east",
+		Input:        "Hello",
+		Instructions: "You are terse.",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := provider.lastResponsesReq.Instructions; got != "Answer in English.\nYou are terse." {
+		t.Fatalf("Instructions = %q, want prefix prepended", got)
+	}
+	if !router.HasDefaultParams("openai-east") {
+		t.Fatal("expected a system prompt prefix to count as default params")
+	}
+}
@@ -4,11 +4,13 @@ package providers
 import (
 	"fmt"
 	"maps"
+	"net/http"
 	"sort"
 	"sync"
 
 	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/httpclient"
 	"github.com/enterpilot/gomodel/internal/llmclient"
 )
 
@@ -21,6 +23,16 @@ type ProviderOptions struct {
 	// nil for keyless providers and for constructors invoked outside the
 	// factory; use the Keyring method rather than reading it directly.
 	Keys *Keyring
+	// Queue bounds concurrent upstream requests for this provider instance.
+	// Every client the provider builds shares it; nil means unbounded.
+	Queue *llmclient.RequestQueue
+	// TokenPacer paces upstream requests to the provider's tokens-per-minute
+	// budget. Every client the provider builds shares it; nil disables pacing.
+	TokenPacer *llmclient.TokenPacer
+	// HTTPClient is the base HTTP client for upstream requests, built from the
+	// provider's transport settings (proxy). Every client the provider builds
+	// shares it; nil means the default client.
+	HTTPClient *http.Client
 }
 
 // Keyring returns the key source a provider should authenticate with, falling
@@ -64,6 +76,9 @@ type ProviderFactory struct {
 	discoveryConfigs     map[string]DiscoveryConfig
 	passthroughEnrichers map[string]core.PassthroughSemanticEnricher
 	hooks                llmclient.Hooks
+	// proxyClients holds the proxy HTTP client built for each provider
+	// instance, so Release can close its pooled connections.
+	proxyClients map[core.Provider]*http.Client
 }
 
 // NewProviderFactory creates a new provider factory instance.
@@ -129,9 +144,47 @@ func (f *ProviderFactory) Create(cfg ProviderConfig) (core.Provider, error) {
 		Models:     cfg.Models,
 		Resilience: cfg.Resilience,
 		Keys:       NewKeyring(cfg.APIKeys...),
+		Queue:      llmclient.NewRequestQueue(cfg.Resilience.Queue),
+		TokenPacer: llmclient.NewTokenPacer(cfg.Resilience.TokenPacing),
+	}
+	if cfg.Proxy != "" {
+		proxyURL, err := httpclient.ParseProxyURL(cfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		httpCfg := httpclient.DefaultConfig()
+		httpCfg.Proxy = proxyURL
+		opts.HTTPClient = httpclient.NewHTTPClient(&httpCfg)
 	}
 
-	return builder(cfg, opts), nil
+	provider := builder(cfg, opts)
+	if opts.HTTPClient != nil && provider != nil {
+		f.mu.Lock()
+		if f.proxyClients == nil {
+			f.proxyClients = make(map[core.Provider]*http.Client)
+		}
+		f.proxyClients[provider] = opts.HTTPClient
+		f.mu.Unlock()
+	}
+	return provider, nil
+}
+
+// Release frees the resources Create allocated for a provider instance that
+// is no longer registered: the idle connections of its proxy transport are
+// closed. Its request queue and token pacer hold no goroutines or timers of
+// their own, so they go away with the instance once in-flight requests
+// finish. Releasing an instance the factory did not create is a no-op.
+func (f *ProviderFactory) Release(provider core.Provider) {
+	if provider == nil {
+		return
+	}
+	f.mu.Lock()
+	client := f.proxyClients[provider]
+	delete(f.proxyClients, provider)
+	f.mu.Unlock()
+	if client != nil {
+		client.CloseIdleConnections()
+	}
 }
 
 // discoveryConfigsSnapshot returns provider discovery metadata keyed by provider type.
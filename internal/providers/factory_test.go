@@ -3,6 +3,10 @@ package providers
 import (
 	"context"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -305,6 +309,35 @@ func TestProviderFactory_HooksPassedToBuilder(t *testing.T) {
 	}
 }
 
+func TestProviderFactory_AddHooksComposesWithSetHooks(t *testing.T) {
+	factory := NewProviderFactory()
+
+	var order []string
+	factory.SetHooks(llmclient.Hooks{
+		OnRequestEnd: func(context.Context, llmclient.ResponseInfo) { order = append(order, "metrics") },
+	})
+	factory.AddHooks(llmclient.Hooks{
+		OnRequestEnd: func(context.Context, llmclient.ResponseInfo) { order = append(order, "tracing") },
+	})
+
+	var receivedOpts ProviderOptions
+	factory.Add(Registration{
+		Type: "test",
+		New: func(cfg ProviderConfig, opts ProviderOptions) core.Provider {
+			receivedOpts = opts
+			return &factoryMockProvider{}
+		},
+	})
+	if _, err := factory.Create(ProviderConfig{Type: "test", APIKey: "test-key"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	receivedOpts.Hooks.OnRequestEnd(context.Background(), llmclient.ResponseInfo{})
+	if len(order) != 2 || order[0] != "metrics" || order[1] != "tracing" {
+		t.Fatalf("hook order = %v, want [metrics tracing]", order)
+	}
+}
+
 func TestProviderFactory_ZeroHooks(t *testing.T) {
 	factory := NewProviderFactory()
 
@@ -383,6 +416,81 @@ func TestProviderFactory_Create_PassesResilienceConfig(t *testing.T) {
 	}
 }
 
+func TestProviderFactory_Create_PerProviderRetryOverrides(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.URL.Path]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = io.WriteString(w, `{"error":{"message":"overloaded"}}`)
+	}))
+	defer server.Close()
+
+	global := config.ResilienceConfig{
+		Retry: config.RetryConfig{
+			MaxRetries:     3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			BackoffFactor:  1,
+		},
+		CircuitBreaker: config.DefaultCircuitBreakerConfig(),
+	}
+	noRetries, manyRetries, threshold := 0, 5, 10
+	resolved := buildProviderConfigs(map[string]config.RawProviderConfig{
+		"flaky": {
+			Type:    "test",
+			BaseURL: server.URL + "/flaky",
+			Resilience: &config.RawResilienceConfig{
+				Retry: &config.RawRetryConfig{MaxRetries: &noRetries},
+			},
+		},
+		"sturdy": {
+			Type:    "test",
+			BaseURL: server.URL + "/sturdy",
+			Resilience: &config.RawResilienceConfig{
+				Retry:          &config.RawRetryConfig{MaxRetries: &manyRetries},
+				CircuitBreaker: &config.RawCircuitBreakerConfig{FailureThreshold: &threshold},
+			},
+		},
+	}, global)
+
+	factory := NewProviderFactory()
+	clients := map[string]*llmclient.Client{}
+	factory.Add(Registration{
+		Type: "test",
+		New: func(cfg ProviderConfig, opts ProviderOptions) core.Provider {
+			clients[cfg.BaseURL] = llmclient.New(llmclient.Config{
+				ProviderName:   "test",
+				BaseURL:        cfg.BaseURL,
+				Retry:          opts.Resilience.Retry,
+				CircuitBreaker: opts.Resilience.CircuitBreaker,
+			}, nil)
+			return &factoryMockProvider{}
+		},
+	})
+
+	for _, name := range []string{"flaky", "sturdy"} {
+		if _, err := factory.Create(resolved[name]); err != nil {
+			t.Fatalf("Create(%s) error = %v", name, err)
+		}
+		client := clients[resolved[name].BaseURL]
+		if _, err := client.DoRaw(context.Background(), llmclient.Request{Method: http.MethodGet, Endpoint: "/models"}); err == nil {
+			t.Fatalf("%s: DoRaw() error = nil, want upstream 503", name)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := attempts["/flaky/models"]; got != 1 {
+		t.Errorf("flaky attempts = %d, want 1 (max_retries: 0)", got)
+	}
+	if got := attempts["/sturdy/models"]; got != 6 {
+		t.Errorf("sturdy attempts = %d, want 6 (max_retries: 5)", got)
+	}
+}
+
 func TestProviderFactory_Create_PassesConfiguredModels(t *testing.T) {
 	factory := NewProviderFactory()
 
@@ -413,3 +521,76 @@ func TestProviderFactory_Create_PassesConfiguredModels(t *testing.T) {
 		t.Fatalf("receivedOpts.Models = %v, want [model-a model-b]", receivedOpts.Models)
 	}
 }
+
+func TestProviderFactory_Create_RoutesThroughConfiguredProxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"object":"list","data":[]}`)
+	}))
+	defer proxy.Close()
+
+	factory := NewProviderFactory()
+	var receivedOpts ProviderOptions
+	factory.Add(Registration{
+		Type: "test",
+		New: func(_ ProviderConfig, opts ProviderOptions) core.Provider {
+			receivedOpts = opts
+			return &factoryMockProvider{}
+		},
+	})
+
+	if _, err := factory.Create(ProviderConfig{Type: "test", Proxy: proxy.URL}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if receivedOpts.HTTPClient == nil {
+		t.Fatal("receivedOpts.HTTPClient = nil, want proxy-aware client")
+	}
+
+	client := llmclient.New(llmclient.Config{
+		ProviderName: "test",
+		BaseURL:      "http://upstream.invalid/v1",
+		HTTPClient:   receivedOpts.HTTPClient,
+	}, nil)
+	if _, err := client.DoRaw(context.Background(), llmclient.Request{Method: http.MethodGet, Endpoint: "/models"}); err != nil {
+		t.Fatalf("DoRaw() error = %v", err)
+	}
+	if proxiedURL != "http://upstream.invalid/v1/models" {
+		t.Fatalf("proxy saw %q, want absolute upstream URL", proxiedURL)
+	}
+}
+
+func TestProviderFactory_Create_RejectsInvalidProxy(t *testing.T) {
+	factory := NewProviderFactory()
+	factory.Add(Registration{
+		Type: "test",
+		New: func(_ ProviderConfig, _ ProviderOptions) core.Provider {
+			return &factoryMockProvider{}
+		},
+	})
+
+	_, err := factory.Create(ProviderConfig{Type: "test", Proxy: "socks5://proxy.internal:1080"})
+	if err == nil || !strings.Contains(err.Error(), "proxy") {
+		t.Fatalf("Create() error = %v, want invalid proxy error", err)
+	}
+}
+
+func TestProviderFactory_Create_NoProxyLeavesDefaultClient(t *testing.T) {
+	factory := NewProviderFactory()
+	var receivedOpts ProviderOptions
+	factory.Add(Registration{
+		Type: "test",
+		New: func(_ ProviderConfig, opts ProviderOptions) core.Provider {
+			receivedOpts = opts
+			return &factoryMockProvider{}
+		},
+	})
+
+	if _, err := factory.Create(ProviderConfig{Type: "test"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if receivedOpts.HTTPClient != nil {
+		t.Fatal("receivedOpts.HTTPClient set without a proxy, want nil")
+	}
+}
@@ -54,6 +54,9 @@ type Provider struct {
 	useNativeAPI bool
 	modelsURL    string
 	configErr    error
+	// modelPrefixes is the configured model_prefixes allowlist for
+	// ListModels; empty means the default families.
+	modelPrefixes []string
 }
 
 // New creates a new Gemini provider.
@@ -75,14 +78,18 @@ func newProvider(providerCfg providers.ProviderConfig, opts providers.ProviderOp
 	baseURL, nativeBaseURL := geminiBaseURLs(providerCfg, backend)
 	modelsURL := geminiModelsBaseURL(backend, nativeBaseURL)
 	p := &Provider{
-		keys:         opts.Keyring(providerCfg.APIKey),
-		backend:      backend,
-		authType:     authType,
-		useNativeAPI: useNativeAPI(providerCfg.APIMode),
-		modelsURL:    modelsURL,
+		keys:          opts.Keyring(providerCfg.APIKey),
+		backend:       backend,
+		authType:      authType,
+		useNativeAPI:  useNativeAPI(providerCfg.APIMode),
+		modelsURL:     modelsURL,
+		modelPrefixes: providerCfg.ModelPrefixes,
 	}
 	p.validateConfig(providerCfg)
 	if !preauthenticated {
+		if httpClient == nil {
+			httpClient = opts.HTTPClient
+		}
 		httpClient = p.authHTTPClient(providerCfg, httpClient)
 	}
 
@@ -96,6 +103,9 @@ func newProvider(providerCfg providers.ProviderConfig, opts providers.ProviderOp
 		Retry:          opts.Resilience.Retry,
 		Hooks:          opts.Hooks,
 		CircuitBreaker: opts.Resilience.CircuitBreaker,
+		Queue:          opts.Queue,
+		TokenPacer:     opts.TokenPacer,
+		HTTPClient:     opts.HTTPClient,
 	}
 	nativeCfg := clientCfg
 	nativeCfg.BaseURL = nativeBaseURL
@@ -155,6 +165,33 @@ func (p *Provider) SetBaseURL(url string) {
 	}
 }
 
+// ResetCircuit force-closes the circuit breakers of the OpenAI-compatible,
+// native, and model-listing clients.
+func (p *Provider) ResetCircuit() string {
+	state := p.client.ResetCircuit()
+	for _, client := range []*llmclient.Client{p.nativeClient, p.modelsClient} {
+		if client == nil {
+			continue
+		}
+		if s := client.ResetCircuit(); state == "" {
+			state = s
+		}
+	}
+	return state
+}
+
+// CircuitState reports the worst circuit breaker state across the
+// OpenAI-compatible, native, and model-listing clients.
+func (p *Provider) CircuitState() string {
+	states := []string{p.client.CircuitState()}
+	for _, client := range []*llmclient.Client{p.nativeClient, p.modelsClient} {
+		if client != nil {
+			states = append(states, client.CircuitState())
+		}
+	}
+	return llmclient.WorstCircuitState(states...)
+}
+
 // SetModelsURL allows configuring a custom models API base URL.
 // This is primarily useful for tests and local emulators.
 func (p *Provider) SetModelsURL(url string) {
@@ -376,12 +413,52 @@ func nativeBaseURLFromOpenAICompatibleBaseURL(baseURL string) (string, bool) {
 // Gemini uses "reasoning_effort" as a top-level string (e.g. "low", "medium", "high"),
 // not the nested "reasoning": {"effort": "..."} format.
 func adaptChatRequest(req *core.ChatRequest) (*core.ChatRequest, error) {
+	req, err := adaptCachedContent(req)
+	if err != nil {
+		return nil, err
+	}
 	if req.Reasoning == nil || req.Reasoning.Effort == "" {
 		return req, nil
 	}
 	return providers.AdaptReasoningEffortRequest(req, req.Reasoning.Effort)
 }
 
+// adaptCachedContent moves a top-level "cached_content" reference (the field
+// the native generateContent path reads) into the extra_body.google envelope
+// that Gemini's OpenAI-compatible endpoint expects, so clients can reference a
+// cachedContents resource regardless of the configured API mode.
+func adaptCachedContent(req *core.ChatRequest) (*core.ChatRequest, error) {
+	cached := geminiCachedContent(req)
+	if cached == "" {
+		return req, nil
+	}
+	extraBody := map[string]any{}
+	if raw := req.ExtraFields.Lookup("extra_body"); len(raw) > 0 {
+		if err := json.Unmarshal(raw, &extraBody); err != nil || extraBody == nil {
+			return nil, core.NewInvalidRequestError("extra_body must be a JSON object", err)
+		}
+	}
+	google, _ := extraBody["google"].(map[string]any)
+	if google == nil {
+		google = map[string]any{}
+	}
+	google["cached_content"] = cached
+	extraBody["google"] = google
+	encoded, err := json.Marshal(extraBody)
+	if err != nil {
+		return nil, core.NewInvalidRequestError("failed to adapt cached_content: "+err.Error(), err)
+	}
+	extra, err := core.MergeUnknownJSONFields(req.ExtraFields.Without("cached_content"), map[string]json.RawMessage{
+		"extra_body": encoded,
+	})
+	if err != nil {
+		return nil, core.NewInvalidRequestError("failed to adapt cached_content: "+err.Error(), err)
+	}
+	adapted := *req
+	adapted.ExtraFields = extra
+	return &adapted, nil
+}
+
 func (p *Provider) openAICompatibleChatBody(req *core.ChatRequest) (any, error) {
 	if p.backend == geminiBackendVertex {
 		if model := vertexOpenAIModelID(req.Model); strings.TrimSpace(model) != "" {
@@ -520,6 +597,24 @@ type geminiModelsResponse struct {
 	PublisherModels []geminiModel `json:"publisherModels"`
 }
 
+// geminiModelMetadata carries the token limits Gemini reports per model into
+// core metadata, or returns nil when the entry reports none.
+func geminiModelMetadata(gm geminiModel) *core.ModelMetadata {
+	if gm.InputTokenLimit <= 0 && gm.OutputTokenLimit <= 0 {
+		return nil
+	}
+	meta := &core.ModelMetadata{}
+	if gm.InputTokenLimit > 0 {
+		contextWindow := gm.InputTokenLimit
+		meta.ContextWindow = &contextWindow
+	}
+	if gm.OutputTokenLimit > 0 {
+		maxOutputTokens := gm.OutputTokenLimit
+		meta.MaxOutputTokens = &maxOutputTokens
+	}
+	return meta
+}
+
 func geminiModelSupportedMethods(modelID string, methods []string) (supportsGenerate, supportsEmbed bool) {
 	if len(methods) == 0 {
 		normalized := normalizeGeminiModelID(modelID)
@@ -547,6 +642,7 @@ func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error)
 	}
 
 	now := time.Now().Unix()
+	prefixes := geminiModelPrefixes(p.modelPrefixes)
 
 	// Preferred path: native Gemini models response.
 	// If the payload contains an explicit "models" field with an empty array,
@@ -576,13 +672,14 @@ func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error)
 			// Only include models that support generateContent (chat/completion)
 			supportsGenerate, supportsEmbed := geminiModelSupportedMethods(modelID, gm.SupportedMethods)
 
-			isOpenAICompatModel := isGeminiExposedModel(modelID)
+			isOpenAICompatModel := isGeminiExposedModel(modelID, prefixes)
 			if (supportsGenerate || supportsEmbed) && isOpenAICompatModel {
 				models = append(models, core.Model{
-					ID:      modelID,
-					Object:  "model",
-					OwnedBy: "google",
-					Created: now,
+					ID:       modelID,
+					Object:   "model",
+					OwnedBy:  "google",
+					Created:  now,
+					Metadata: geminiModelMetadata(gm),
 				})
 			}
 		}
@@ -599,7 +696,7 @@ func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error)
 		models := make([]core.Model, 0, len(openAIResp.Data))
 		for _, m := range openAIResp.Data {
 			modelID := displayModelIDFromGemini(m.ID, p.backend)
-			isOpenAICompatModel := isGeminiExposedModel(modelID)
+			isOpenAICompatModel := isGeminiExposedModel(modelID, prefixes)
 			if !isOpenAICompatModel {
 				continue
 			}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 
@@ -994,6 +995,91 @@ func TestChatCompletion_UsesNativeGenerateContentByDefault(t *testing.T) {
 	}
 }
 
+func TestChatCompletion_ReferencesCachedContent(t *testing.T) {
+	const cachedContent = "cachedContents/corpus-123"
+
+	tests := []struct {
+		name      string
+		native    string
+		path      string
+		response  string
+		checkBody func(*testing.T, map[string]any)
+	}{
+		{
+			name:     "native generateContent",
+			native:   "true",
+			path:     "/models/gemini-2.5-flash:generateContent",
+			response: `{"candidates":[{"content":{"role":"model","parts":[{"text":"ok"}]},"finishReason":"STOP"}]}`,
+			checkBody: func(t *testing.T, payload map[string]any) {
+				if got := payload["cachedContent"]; got != cachedContent {
+					t.Fatalf("cachedContent = %#v, want %q", got, cachedContent)
+				}
+				if _, ok := payload["cached_content"]; ok {
+					t.Fatal("native request must not forward the snake_case passthrough field")
+				}
+			},
+		},
+		{
+			name:     "openai compatible",
+			native:   "false",
+			path:     "/chat/completions",
+			response: `{"id":"gemini-123","object":"chat.completion","model":"gemini-2.5-flash","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`,
+			checkBody: func(t *testing.T, payload map[string]any) {
+				if _, ok := payload["cached_content"]; ok {
+					t.Fatal("cached_content must be moved under extra_body.google")
+				}
+				extraBody, _ := payload["extra_body"].(map[string]any)
+				google, _ := extraBody["google"].(map[string]any)
+				if got := google["cached_content"]; got != cachedContent {
+					t.Fatalf("extra_body.google.cached_content = %#v, want %q", got, cachedContent)
+				}
+				if got := google["thinking_config"]; got == nil {
+					t.Fatal("existing extra_body.google members must be preserved")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(useNativeAPIEnvVar, tt.native)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.path {
+					t.Errorf("Path = %q, want %q", r.URL.Path, tt.path)
+				}
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("failed to read request body: %v", err)
+				}
+				var payload map[string]any
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("failed to unmarshal request: %v", err)
+				}
+				tt.checkBody(t, payload)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+			provider.SetBaseURL(server.URL)
+			provider.SetModelsURL(server.URL)
+
+			_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+				Model:    "gemini-2.5-flash",
+				Messages: []core.Message{{Role: "user", Content: "Summarize the corpus"}},
+				ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+					"cached_content": json.RawMessage(`"` + cachedContent + `"`),
+					"extra_body":     json.RawMessage(`{"google":{"thinking_config":{"include_thoughts":true}}}`),
+				}),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestGeminiGenerationConfig_UsesTypedTopP(t *testing.T) {
 	topP := 0.8
 	cfg := geminiGenerationConfig(&core.ChatRequest{
@@ -1028,6 +1114,29 @@ func TestConvertResponsesRequestToGeminiPreservesTopP(t *testing.T) {
 	}
 }
 
+func TestConvertResponsesRequestToGeminiPreservesStop(t *testing.T) {
+	chatReq, err := providers.ConvertResponsesRequestToChat(&core.ResponsesRequest{
+		Model: "gemini-2.5-flash",
+		Input: "hi",
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"stop": json.RawMessage(`"END"`),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("ConvertResponsesRequestToChat() error = %v", err)
+	}
+
+	geminiReq, err := convertChatRequestToGemini(chatReq)
+	if err != nil {
+		t.Fatalf("convertChatRequestToGemini() error = %v", err)
+	}
+
+	got, ok := geminiReq.GenerationConfig["stopSequences"].([]string)
+	if !ok || len(got) != 1 || got[0] != "END" {
+		t.Fatalf("stopSequences = %#v, want [END]", geminiReq.GenerationConfig["stopSequences"])
+	}
+}
+
 func TestChatCompletion_NativeUsageMetadata(t *testing.T) {
 	t.Setenv(useNativeAPIEnvVar, "true")
 
@@ -1163,8 +1272,11 @@ func TestChatCompletion_NativeBlockedPromptReturnsError(t *testing.T) {
 	if !errors.As(err, &gatewayErr) {
 		t.Fatalf("error = %T %[1]v, want *core.GatewayError", err)
 	}
-	if gatewayErr.Type != core.ErrorTypeProvider {
-		t.Fatalf("error type = %q, want provider_error", gatewayErr.Type)
+	if gatewayErr.Type != core.ErrorTypeInvalidRequest || gatewayErr.HTTPStatusCode() != http.StatusBadRequest {
+		t.Fatalf("error = %q/%d, want invalid_request_error/400", gatewayErr.Type, gatewayErr.HTTPStatusCode())
+	}
+	if gatewayErr.Code == nil || *gatewayErr.Code != "content_filter" {
+		t.Fatalf("code = %v, want content_filter", gatewayErr.Code)
 	}
 	if !strings.Contains(gatewayErr.Message, "SAFETY: unsafe prompt") {
 		t.Fatalf("message = %q, want block reason", gatewayErr.Message)
@@ -1446,6 +1558,9 @@ data: {"responseId":"gemini-stream-123","candidates":[{"content":{"role":"model"
 	if !strings.Contains(stream, `"finish_reason":"stop"`) {
 		t.Fatalf("stream = %q, want stop finish reason", stream)
 	}
+	if strings.Contains(stream, "system_fingerprint") {
+		t.Fatalf("stream = %q, want system_fingerprint omitted (Gemini does not report one)", stream)
+	}
 	if !strings.Contains(stream, `"usage"`) || !strings.Contains(stream, `"total_tokens":6`) {
 		t.Fatalf("stream = %q, want usage chunk", stream)
 	}
@@ -1566,8 +1681,8 @@ func TestStreamChatCompletion_NativeBlockedPromptEmitsError(t *testing.T) {
 		t.Fatalf("failed to read stream: %v", err)
 	}
 	stream := string(raw)
-	if !strings.Contains(stream, `"type":"provider_error"`) || !strings.Contains(stream, "Gemini blocked prompt: SAFETY: unsafe prompt") {
-		t.Fatalf("stream = %q, want normalized provider error", stream)
+	if !strings.Contains(stream, `"type":"invalid_request_error"`) || !strings.Contains(stream, `"code":"content_filter"`) || !strings.Contains(stream, "Gemini blocked prompt: SAFETY: unsafe prompt") {
+		t.Fatalf("stream = %q, want normalized content filter error", stream)
 	}
 	if !strings.Contains(stream, "data: [DONE]") {
 		t.Fatalf("stream = %q, want [DONE]", stream)
@@ -1630,6 +1745,13 @@ func TestListModels(t *testing.T) {
 				if resp.Data[1].ID != "gemini-1.5-pro" {
 					t.Errorf("Data[1].ID = %q, want %q", resp.Data[1].ID, "gemini-1.5-pro")
 				}
+				meta := resp.Data[1].Metadata
+				if meta == nil || meta.ContextWindow == nil || meta.MaxOutputTokens == nil {
+					t.Fatalf("Data[1].Metadata = %+v, want token limits", meta)
+				}
+				if *meta.ContextWindow != 1048576 || *meta.MaxOutputTokens != 8192 {
+					t.Errorf("token limits = %d/%d, want 1048576/8192", *meta.ContextWindow, *meta.MaxOutputTokens)
+				}
 			},
 		},
 		{
@@ -1681,6 +1803,51 @@ func TestListModels(t *testing.T) {
 	}
 }
 
+func TestListModels_ModelPrefixes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"models": [
+				{"name": "models/gemini-2.5-flash", "supportedGenerationMethods": ["generateContent"]},
+				{"name": "models/gemma-3-27b-it", "supportedGenerationMethods": ["generateContent"], "inputTokenLimit": 131072, "outputTokenLimit": 8192},
+				{"name": "models/imagen-4.0-generate-001", "supportedGenerationMethods": ["predict"]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		prefixes []string
+		want     []string
+	}{
+		{name: "default", want: []string{"gemini-2.5-flash"}},
+		{name: "blank entries fall back to default", prefixes: []string{" ", ""}, want: []string{"gemini-2.5-flash"}},
+		{name: "configured", prefixes: []string{"gemini-", " gemma-"}, want: []string{"gemini-2.5-flash", "gemma-3-27b-it"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := New(providers.ProviderConfig{
+				APIKey:        "test-api-key",
+				ModelPrefixes: tt.prefixes,
+			}, providers.ProviderOptions{}).(*Provider)
+			provider.SetModelsURL(server.URL)
+
+			resp, err := provider.ListModels(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]string, 0, len(resp.Data))
+			for _, model := range resp.Data {
+				got = append(got, model.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("model IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestChatCompletionWithContext(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		<-r.Context().Done()
@@ -1996,3 +2163,37 @@ data: {"responseId":"gemini-native-stream-response","candidates":[{"content":{"r
 		t.Fatalf("stream = %q, want [DONE]", stream)
 	}
 }
+
+func TestGeminiGenerationConfig_ResponseFormat(t *testing.T) {
+	tests := []struct {
+		name         string
+		format       string
+		wantMimeType string
+	}{
+		{name: "explicit text is the default", format: `{"type":"text"}`},
+		{name: "null", format: `null`},
+		{name: "json object", format: `{"type":"json_object"}`, wantMimeType: "application/json"},
+		{name: "json schema", format: `{"type":"json_schema","json_schema":{"name":"a","schema":{"type":"object"}}}`, wantMimeType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := geminiGenerationConfig(&core.ChatRequest{
+				Model:    "gemini-2.5-flash",
+				Messages: []core.Message{{Role: "user", Content: "hi"}},
+				ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+					"response_format": json.RawMessage(tt.format),
+				}),
+			})
+			if tt.wantMimeType == "" {
+				if cfg != nil {
+					t.Fatalf("generationConfig = %v, want nil", cfg)
+				}
+				return
+			}
+			if got := cfg["responseMimeType"]; got != tt.wantMimeType {
+				t.Fatalf("responseMimeType = %v, want %q", got, tt.wantMimeType)
+			}
+		})
+	}
+}
@@ -130,6 +130,9 @@ func convertChatRequestToGemini(req *core.ChatRequest) (*geminiGenerateContentRe
 	if req == nil {
 		return nil, core.NewInvalidRequestError("chat request is required", nil)
 	}
+	if req.RequestsAudioOutput() {
+		return nil, core.NewInvalidRequestError("chat audio output is not supported by Gemini native translation", nil)
+	}
 
 	out := &geminiGenerateContentRequest{
 		Contents: make([]geminiContent, 0, len(req.Messages)),
@@ -491,7 +494,9 @@ func geminiGenerationConfig(req *core.ChatRequest) map[string]any {
 	copyJSONNumber(req.ExtraFields.Lookup("candidate_count"), cfg, "candidateCount")
 	copyJSONNumber(req.ExtraFields.Lookup("presence_penalty"), cfg, "presencePenalty")
 	copyJSONNumber(req.ExtraFields.Lookup("frequency_penalty"), cfg, "frequencyPenalty")
-	copyStopSequences(req.ExtraFields.Lookup("stop"), cfg)
+	if stops := req.StopSequences(); len(stops) > 0 {
+		cfg["stopSequences"] = stops
+	}
 	copyResponseFormat(req.ExtraFields.Lookup("response_format"), cfg)
 	copyGoogleThinkingConfig(req.ExtraFields.Lookup("extra_body"), cfg)
 	if req.Reasoning != nil && strings.TrimSpace(req.Reasoning.Effort) != "" {
@@ -531,21 +536,6 @@ func copyJSONNumber(raw json.RawMessage, cfg map[string]any, key string) {
 	}
 }
 
-func copyStopSequences(raw json.RawMessage, cfg map[string]any) {
-	if len(raw) == 0 {
-		return
-	}
-	var one string
-	if err := json.Unmarshal(raw, &one); err == nil && one != "" {
-		cfg["stopSequences"] = []string{one}
-		return
-	}
-	var many []string
-	if err := json.Unmarshal(raw, &many); err == nil && len(many) > 0 {
-		cfg["stopSequences"] = many
-	}
-}
-
 func copyResponseFormat(raw json.RawMessage, cfg map[string]any) {
 	if len(raw) == 0 {
 		return
@@ -692,7 +682,14 @@ func geminiBlockedPromptError(resp *geminiGenerateContentResponse, providerName
 	if reason == "" {
 		return nil
 	}
-	return nativeProviderError(providerName, "Gemini blocked prompt: "+reason, nil)
+	// A blocked prompt is a property of the request, not an upstream fault, so
+	// it is reported as a 400 that failover and retries leave alone.
+	if providerName == "" {
+		providerName = "gemini"
+	}
+	gatewayErr := core.NewInvalidRequestError("Gemini blocked prompt: "+reason, nil).WithCode("content_filter")
+	gatewayErr.Provider = providerName
+	return gatewayErr
 }
 
 func geminiPromptBlockReason(raw json.RawMessage) string {
@@ -898,12 +895,36 @@ func displayModelIDFromGemini(model, backend string) string {
 	return model
 }
 
-// isGeminiExposedModel normalizes provider model names and exposes only the
-// model families reachable through Gemini/OpenAI-compatible text endpoints.
-// Families such as imagen-* use different upstream endpoints.
-func isGeminiExposedModel(modelID string) bool {
+// defaultGeminiModelPrefixes are the model families reachable through
+// Gemini/OpenAI-compatible text endpoints. Families such as imagen-* use
+// different upstream endpoints.
+var defaultGeminiModelPrefixes = []string{"gemini-", "text-embedding-"}
+
+// geminiModelPrefixes returns the configured model_prefixes with blanks
+// dropped, or the defaults when none are set.
+func geminiModelPrefixes(configured []string) []string {
+	prefixes := make([]string, 0, len(configured))
+	for _, prefix := range configured {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return defaultGeminiModelPrefixes
+	}
+	return prefixes
+}
+
+// isGeminiExposedModel normalizes provider model names and reports whether the
+// model belongs to one of the exposed families.
+func isGeminiExposedModel(modelID string, prefixes []string) bool {
 	modelID = normalizeGeminiModelID(modelID)
-	return strings.HasPrefix(modelID, "gemini-") || strings.HasPrefix(modelID, "text-embedding-")
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(modelID, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func nativeProviderError(providerName, message string, err error) *core.GatewayError {
@@ -79,6 +79,16 @@ func (p *Provider) SetBaseURL(url string) {
 	p.compat.SetBaseURL(url)
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	return p.compat.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.compat.CircuitState()
+}
+
 // ChatCompletion sends a chat completion request to Groq
 func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	return p.compat.ChatCompletion(ctx, req)
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -161,6 +162,169 @@ func TestChatCompletion(t *testing.T) {
 	}
 }
 
+func TestChatCompletion_PassesToolCallingFieldsThrough(t *testing.T) {
+	var upstream map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&upstream); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"id": "chatcmpl-tools",
+			"object": "chat.completion",
+			"model": "llama-3.3-70b-versatile",
+			"choices": [{
+				"index": 0,
+				"message": {
+					"role": "assistant",
+					"content": null,
+					"tool_calls": [{"id": "call_2", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Oslo\"}"}}]
+				},
+				"finish_reason": "tool_calls"
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model: "llama-3.3-70b-versatile",
+		Messages: []core.Message{
+			{Role: "user", Content: "Weather in Paris, then Oslo?"},
+			{Role: "assistant", ToolCalls: []core.ToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: core.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "call_1", Content: "18C"},
+		},
+		Tools: []map[string]any{{
+			"type": "function",
+			"function": map[string]any{
+				"name":       "get_weather",
+				"parameters": map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+			},
+		}},
+		ToolChoice: map[string]any{"type": "function", "function": map[string]any{"name": "get_weather"}},
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+
+	if got := string(upstream["tool_choice"]); got != `{"function":{"name":"get_weather"},"type":"function"}` {
+		t.Errorf("upstream tool_choice = %s", got)
+	}
+	if got := string(upstream["tools"]); !strings.Contains(got, `"name":"get_weather"`) || !strings.Contains(got, `"city":{"type":"string"}`) {
+		t.Errorf("upstream tools = %s", got)
+	}
+	var messages []map[string]any
+	if err := json.Unmarshal(upstream["messages"], &messages); err != nil || len(messages) != 3 {
+		t.Fatalf("upstream messages = %s (%v)", upstream["messages"], err)
+	}
+	if calls, _ := messages[1]["tool_calls"].([]any); len(calls) != 1 {
+		t.Errorf("assistant tool_calls = %v", messages[1]["tool_calls"])
+	}
+	if messages[2]["tool_call_id"] != "call_1" {
+		t.Errorf("tool message tool_call_id = %v", messages[2]["tool_call_id"])
+	}
+
+	choice := resp.Choices[0]
+	if choice.FinishReason != "tool_calls" || len(choice.Message.ToolCalls) != 1 || choice.Message.ToolCalls[0].Function.Arguments != `{"city":"Oslo"}` {
+		t.Errorf("response choice = %+v", choice)
+	}
+}
+
+func TestChatCompletion_PassesResponseFormatThrough(t *testing.T) {
+	formats := []string{
+		`{"type":"json_object"}`,
+		`{"type":"json_schema","json_schema":{"name":"answer","schema":{"type":"object","properties":{"answer":{"type":"string"}}}}}`,
+	}
+	for _, format := range formats {
+		var upstream map[string]json.RawMessage
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&upstream); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"chatcmpl-json","object":"chat.completion","model":"llama-3.3-70b-versatile","choices":[{"index":0,"message":{"role":"assistant","content":"{}"},"finish_reason":"stop"}]}`))
+		}))
+
+		provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+		provider.SetBaseURL(server.URL)
+
+		_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+			Model:    "llama-3.3-70b-versatile",
+			Messages: []core.Message{{Role: "user", Content: "Answer in JSON."}},
+			ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+				"response_format": json.RawMessage(format),
+			}),
+		})
+		server.Close()
+		if err != nil {
+			t.Fatalf("ChatCompletion() error = %v", err)
+		}
+
+		var want, got any
+		_ = json.Unmarshal([]byte(format), &want)
+		if err := json.Unmarshal(upstream["response_format"], &got); err != nil {
+			t.Fatalf("upstream response_format = %s (%v)", upstream["response_format"], err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("upstream response_format = %s, want %s", upstream["response_format"], format)
+		}
+	}
+}
+
+func TestChatCompletion_PreservesImageContentParts(t *testing.T) {
+	var upstream struct {
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&upstream); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-vision","object":"chat.completion","model":"meta-llama/llama-4-scout-17b-16e-instruct","choices":[{"index":0,"message":{"role":"assistant","content":"A cat."},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model: "meta-llama/llama-4-scout-17b-16e-instruct",
+		Messages: []core.Message{{
+			Role: "user",
+			Content: []core.ContentPart{
+				{Type: "text", Text: "What is in this image?"},
+				{Type: "image_url", ImageURL: &core.ImageURLContent{URL: "https://example.com/cat.png", Detail: "low"}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+
+	if len(upstream.Messages) != 1 {
+		t.Fatalf("upstream messages = %d, want 1", len(upstream.Messages))
+	}
+	var parts []map[string]any
+	if err := json.Unmarshal(upstream.Messages[0].Content, &parts); err != nil {
+		t.Fatalf("upstream content %s is not a parts array: %v", upstream.Messages[0].Content, err)
+	}
+	if len(parts) != 2 || parts[1]["type"] != "image_url" {
+		t.Fatalf("upstream parts = %v, want text and image_url", parts)
+	}
+	image, _ := parts[1]["image_url"].(map[string]any)
+	if image["url"] != "https://example.com/cat.png" || image["detail"] != "low" {
+		t.Errorf("upstream image_url = %v", parts[1]["image_url"])
+	}
+}
+
 func TestStreamChatCompletion(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -255,6 +419,42 @@ data: [DONE]
 	}
 }
 
+func TestStreamChatCompletion_ForwardsStreamOptions(t *testing.T) {
+	var upstream core.ChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&upstream); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data: {\"id\":\"chatcmpl-1\",\"object\":\"chat.completion.chunk\",\"model\":\"llama-3.3-70b-versatile\",\"choices\":[],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":1,\"total_tokens\":4}}\n\ndata: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	body, err := provider.StreamChatCompletion(context.Background(), &core.ChatRequest{
+		Model:         "llama-3.3-70b-versatile",
+		Messages:      []core.Message{{Role: "user", Content: "Hello"}},
+		StreamOptions: &core.StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		t.Fatalf("StreamChatCompletion() error = %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	respBody, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+
+	if upstream.StreamOptions == nil || !upstream.StreamOptions.IncludeUsage {
+		t.Fatalf("upstream stream_options = %+v, want include_usage", upstream.StreamOptions)
+	}
+	if !strings.Contains(string(respBody), `"total_tokens":4`) {
+		t.Errorf("usage chunk missing from stream: %s", respBody)
+	}
+}
+
 func TestListModels(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -921,3 +1121,40 @@ func TestCreateTranscription_UpstreamError(t *testing.T) {
 		t.Errorf("error = %v, want upstream message propagated", err)
 	}
 }
+
+func TestEmbeddings_PassesThroughToEmbeddingsEndpoint(t *testing.T) {
+	var gotPath string
+	var upstream map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&upstream); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"object":"list","model":"nomic-embed-text-v1.5","data":[{"object":"embedding","index":0,"embedding":[0.1,0.2]}],"usage":{"prompt_tokens":3,"total_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.Embeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "nomic-embed-text-v1.5",
+		Input: "hello",
+	})
+	if err != nil {
+		t.Fatalf("Embeddings() error = %v", err)
+	}
+	if gotPath != "/embeddings" {
+		t.Errorf("path = %q, want /embeddings", gotPath)
+	}
+	if upstream["model"] != "nomic-embed-text-v1.5" || upstream["input"] != "hello" {
+		t.Errorf("upstream body = %v", upstream)
+	}
+	if len(resp.Data) != 1 || string(resp.Data[0].Embedding) != "[0.1,0.2]" {
+		t.Fatalf("data = %+v", resp.Data)
+	}
+	if resp.Usage.PromptTokens != 3 {
+		t.Errorf("PromptTokens = %d, want 3", resp.Usage.PromptTokens)
+	}
+}
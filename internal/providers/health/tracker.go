@@ -1,8 +1,8 @@
 // Package health tracks recent request outcomes per provider and model.
 //
 // A Tracker is fed through llmclient observability hooks and keeps a sliding
-// window of per-model request/error counts plus each provider's last observed
-// circuit-breaker state. The admin provider-status endpoint folds its
+// window of per-model request outcomes and latencies plus each provider's last
+// observed circuit-breaker state. The admin provider-status endpoint folds its
 // snapshots into the dashboard so real-traffic failures are visible even when
 // model discovery still succeeds.
 //
@@ -15,6 +15,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -74,11 +75,20 @@ type ProviderHealth struct {
 	// model it came from.
 	LastError      *ErrorInfo `json:"last_error,omitempty"`
 	LastErrorModel string     `json:"last_error_model,omitempty"`
+	// SuccessRate is the fraction of windowed requests that succeeded, in
+	// [0, 1]; nil when the window holds no requests.
+	SuccessRate *float64 `json:"success_rate,omitempty"`
+	// LatencyP50Ms and LatencyP95Ms are windowed request latency percentiles
+	// in milliseconds over every tracked model, failures included; zero when
+	// no request reported a duration.
+	LatencyP50Ms int64 `json:"latency_p50_ms,omitempty"`
+	LatencyP95Ms int64 `json:"latency_p95_ms,omitempty"`
 }
 
 type event struct {
-	at     time.Time
-	failed bool
+	at       time.Time
+	failed   bool
+	duration time.Duration
 }
 
 type modelState struct {
@@ -118,6 +128,25 @@ func (t *Tracker) Hooks() llmclient.Hooks {
 	}
 }
 
+// RecordCircuitState overwrites a provider's last observed circuit state, so
+// an operator reset shows up before the next request reports it. Empty states
+// are ignored.
+func (t *Tracker) RecordCircuitState(providerName, state string) {
+	if providerName == "" || state == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	provider := t.providers[providerName]
+	if provider == nil {
+		provider = &providerState{models: make(map[string]*modelState)}
+		t.providers[providerName] = provider
+	}
+	provider.circuitState = state
+}
+
 // Record ingests one completed request. Requests without a model (e.g. model
 // discovery) only update the provider's circuit state.
 func (t *Tracker) Record(info llmclient.ResponseInfo) {
@@ -160,7 +189,7 @@ func (t *Tracker) Record(info llmclient.ResponseInfo) {
 	}
 
 	failed := info.Error != nil || info.StatusCode >= 400
-	model.events = append(model.events, event{at: now, failed: failed})
+	model.events = append(model.events, event{at: now, failed: failed, duration: info.Duration})
 	model.lastActivity = now
 	if failed {
 		model.lastError = &ErrorInfo{
@@ -185,6 +214,7 @@ func (t *Tracker) Snapshot() map[string]ProviderHealth {
 			CircuitState:  provider.circuitState,
 			WindowSeconds: int(Window / time.Second),
 		}
+		var durations []time.Duration
 		for modelName, model := range provider.models {
 			model.prune(now)
 			requests, errors := model.counts()
@@ -208,6 +238,16 @@ func (t *Tracker) Snapshot() map[string]ProviderHealth {
 			snapshot.Requests += requests
 			snapshot.Errors += errors
 			snapshot.Models = append(snapshot.Models, row)
+			durations = model.appendDurations(durations)
+		}
+		if snapshot.Requests > 0 {
+			rate := float64(snapshot.Requests-snapshot.Errors) / float64(snapshot.Requests)
+			snapshot.SuccessRate = &rate
+		}
+		if len(durations) > 0 {
+			slices.Sort(durations)
+			snapshot.LatencyP50Ms = percentile(durations, 50).Milliseconds()
+			snapshot.LatencyP95Ms = percentile(durations, 95).Milliseconds()
 		}
 		sortModelHealth(snapshot.Models)
 		if len(snapshot.Models) > maxSnapshotModels {
@@ -257,6 +297,25 @@ func (m *modelState) counts() (requests, errors int) {
 	return requests, errors
 }
 
+// appendDurations appends the durations of windowed events that reported one.
+func (m *modelState) appendDurations(durations []time.Duration) []time.Duration {
+	for _, e := range m.events {
+		if e.duration > 0 {
+			durations = append(durations, e.duration)
+		}
+	}
+	return durations
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
 func evictStalestModel(models map[string]*modelState) {
 	var stalest string
 	var stalestAt time.Time
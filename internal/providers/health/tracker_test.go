@@ -204,6 +204,54 @@ func TestTrackerSnapshot(t *testing.T) {
 	}
 }
 
+func TestTrackerSnapshotSuccessRateAndLatency(t *testing.T) {
+	tracker, now := newTestTracker(time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC))
+	hooks := tracker.Hooks()
+
+	// Twenty requests at 10ms..200ms across two models; the last four fail.
+	for i := 1; i <= 20; i++ {
+		info := llmclient.ResponseInfo{
+			Provider:     "openai",
+			Model:        "gpt-4o",
+			StatusCode:   200,
+			Duration:     time.Duration(i*10) * time.Millisecond,
+			CircuitState: "closed",
+		}
+		if i%2 == 0 {
+			info.Model = "gpt-4o-mini"
+		}
+		if i > 16 {
+			info.StatusCode = 502
+			info.Error = errors.New("bad gateway")
+		}
+		hooks.OnRequestEnd(t.Context(), info)
+	}
+
+	got := tracker.Snapshot()["openai"]
+	if got.SuccessRate == nil || *got.SuccessRate != 0.8 {
+		t.Fatalf("SuccessRate = %v, want 0.8", got.SuccessRate)
+	}
+	if got.LatencyP50Ms != 100 {
+		t.Fatalf("LatencyP50Ms = %d, want 100", got.LatencyP50Ms)
+	}
+	if got.LatencyP95Ms != 190 {
+		t.Fatalf("LatencyP95Ms = %d, want 190", got.LatencyP95Ms)
+	}
+	if got.LastError == nil || got.LastError.Message != "bad gateway" {
+		t.Fatalf("LastError = %+v, want bad gateway", got.LastError)
+	}
+
+	// Once the window rolls past every event, rates and latencies clear.
+	*now = now.Add(Window + time.Minute)
+	got, ok := tracker.Snapshot()["openai"]
+	if !ok {
+		t.Fatal("openai missing from snapshot, want circuit state kept")
+	}
+	if got.SuccessRate != nil || got.LatencyP50Ms != 0 || got.LatencyP95Ms != 0 {
+		t.Fatalf("expired snapshot = %+v, want no rate or latency", got)
+	}
+}
+
 func TestTrackerHooksFeedRecord(t *testing.T) {
 	tracker, _ := newTestTracker(time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC))
 	hooks := tracker.Hooks()
@@ -215,6 +263,22 @@ func TestTrackerHooksFeedRecord(t *testing.T) {
 	}
 }
 
+func TestTrackerRecordCircuitStateOverridesLastObservedState(t *testing.T) {
+	tracker, _ := newTestTracker(time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC))
+	tracker.Record(llmclient.ResponseInfo{Provider: "openai", Model: "gpt-4o", StatusCode: 503, Error: errors.New("down"), CircuitState: "open"})
+
+	tracker.RecordCircuitState("openai", "closed")
+	tracker.RecordCircuitState("openai", "")
+
+	snapshot := tracker.Snapshot()
+	if got := snapshot["openai"].CircuitState; got != "closed" {
+		t.Fatalf("Snapshot()[openai].CircuitState = %q, want closed", got)
+	}
+	if got := snapshot["openai"].Requests; got != 1 {
+		t.Fatalf("Snapshot()[openai].Requests = %d, want the request history kept", got)
+	}
+}
+
 func TestTrackerEvictsStalestModel(t *testing.T) {
 	tracker, now := newTestTracker(time.Date(2026, 7, 12, 12, 0, 0, 0, time.UTC))
 	for i := range maxTrackedModels {
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/enterpilot/gomodel/internal/cache"
 	"github.com/enterpilot/gomodel/internal/cache/modelcache"
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
 	"github.com/enterpilot/gomodel/internal/modeldata"
 	"github.com/enterpilot/gomodel/internal/platformdir"
 )
@@ -33,6 +35,11 @@ type InitResult struct {
 	// map (same keys as Router). Keys match top-level providers YAML names.
 	CredentialResolvedProviders map[string]config.RawProviderConfig
 
+	// providerConfigs is the resolved provider set currently registered, kept
+	// so Reload can tell unchanged providers from updated ones.
+	providerConfigs map[string]ProviderConfig
+	reloadMu        sync.Mutex
+
 	// stopRefresh is called to stop the background refresh goroutine
 	stopRefresh func()
 
@@ -64,7 +71,8 @@ func (r *InitResult) Close() error {
 //  1. Provider config resolution (env var overlay, filtering, resilience merging)
 //  2. Cache initialization (local or Redis based on config)
 //  3. Provider instantiation and registration
-//  4. Async model loading (from cache first, then network refresh)
+//  4. Async model loading (from cache first, then network refresh); blocking
+//     under the "error" duplicate model policy so conflicts fail startup
 //  5. Best-effort background model-list fetch (goroutine with ~45s timeout that
 //     calls modeldata.Fetch, registry.EnrichModels, and SaveToCache)
 //  6. Background refresh scheduling (interval from cfg.Cache.RefreshInterval)
@@ -81,8 +89,12 @@ func Init(ctx context.Context, result *config.LoadResult, factory *ProviderFacto
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if err := config.ValidateProviderTypes(result.RawProviders, factory.RegisteredTypes()); err != nil {
+		return nil, fmt.Errorf("invalid provider config: %w", err)
+	}
 
-	providerMap, credentialResolved := resolveProviders(result.RawProviders, result.Config.Resilience, factory.discoveryConfigsSnapshot())
+	discovery := factory.discoveryConfigsSnapshot()
+	providerMap, credentialResolved := resolveProviders(result.RawProviders, result.Config.Resilience, discovery)
 	fromFile, fromEnv := providerOrigins(result.RawProviders, providerMap)
 	slog.Info("providers resolved",
 		"total", len(providerMap),
@@ -95,6 +107,10 @@ func Init(ctx context.Context, result *config.LoadResult, factory *ProviderFacto
 			"providers", skipped)
 	}
 
+	upstreamHosts := upstreamHostPatterns(result.Config.HTTP.AllowedUpstreamHosts, providerMap, discovery)
+	llmclient.SetAllowedUpstreamHosts(llmclient.NewHostAllowList(upstreamHosts))
+	slog.Debug("upstream host allow-list installed", "hosts", upstreamHosts)
+
 	modelCache, err := initCache(result.Config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize cache: %w", err)
@@ -103,6 +119,15 @@ func Init(ctx context.Context, result *config.LoadResult, factory *ProviderFacto
 	registry := NewModelRegistry()
 	registry.SetCache(modelCache)
 	registry.SetConfiguredProviderModelsMode(result.Config.Models.ConfiguredProviderModelsMode)
+	registry.SetPreferredProvider(result.Config.Models.PreferredProvider)
+	registry.SetDuplicateModelPolicy(result.Config.Models.DuplicateModelPolicy)
+	selector, err := NewSelector(result.Config.Routing.Strategy, providerWeights(providerMap))
+	if err != nil {
+		modelCache.Close()
+		return nil, fmt.Errorf("routing.strategy: %w", err)
+	}
+	registry.SetSelector(selector)
+	registry.SetRefreshConcurrency(result.Config.Models.RefreshConcurrency)
 
 	count, err := initializeProviders(ctx, providerMap, factory, registry)
 	if err != nil {
@@ -114,8 +139,17 @@ func Init(ctx context.Context, result *config.LoadResult, factory *ProviderFacto
 		return nil, fmt.Errorf("no providers were successfully registered")
 	}
 
-	slog.Info("starting non-blocking model registry initialization...")
-	registry.InitializeAsync(ctx)
+	if registry.DuplicateModelPolicy() == config.DuplicateModelPolicyError {
+		// The error policy must see the full inventory before serving, so
+		// duplicate model IDs fail startup instead of a later refresh.
+		if err := initializeRegistryBlocking(ctx, registry); err != nil {
+			modelCache.Close()
+			return nil, err
+		}
+	} else {
+		slog.Info("starting non-blocking model registry initialization...")
+		registry.InitializeAsync(ctx)
+	}
 
 	slog.Info("model registry configured",
 		"cached_models", registry.ModelCount(),
@@ -167,6 +201,9 @@ func Init(ctx context.Context, result *config.LoadResult, factory *ProviderFacto
 		modelCache.Close()
 		return nil, fmt.Errorf("failed to create router: %w", err)
 	}
+	router.SetDefaultParams(providerMap)
+	router.SetSamplingPolicy(samplingPolicyFromConfig(result.Config.Sampling))
+	router.SetStreamingFallback(result.Config.Models.StreamingFallback)
 
 	return &InitResult{
 		ConfiguredProviders:         SanitizeProviderConfigs(providerMap),
@@ -175,10 +212,33 @@ func Init(ctx context.Context, result *config.LoadResult, factory *ProviderFacto
 		Cache:                       modelCache,
 		Factory:                     factory,
 		CredentialResolvedProviders: credentialResolved,
+		providerConfigs:             providerMap,
 		stopRefresh:                 stopRefresh,
 	}, nil
 }
 
+// initializeRegistryBlocking populates the registry synchronously. Only a
+// duplicate model ID conflict is fatal: any other failure falls back to the
+// cache-first asynchronous path, which keeps retrying in the background.
+func initializeRegistryBlocking(ctx context.Context, registry *ModelRegistry) error {
+	slog.Info("initializing model registry before serving (duplicate_model_policy=error)...")
+	initCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	err := registry.Initialize(initCtx)
+	if errors.Is(err, ErrDuplicateModelIDs) {
+		return fmt.Errorf("model registry: %w", err)
+	}
+	if err != nil {
+		slog.Warn("blocking model initialization failed, continuing in background", "error", err)
+		registry.InitializeAsync(ctx)
+		return nil
+	}
+	if err := registry.SaveToCache(initCtx); err != nil {
+		slog.Warn("failed to save models to cache", "error", err)
+	}
+	return nil
+}
+
 // initCache initializes the appropriate cache backend based on configuration.
 func initCache(cfg *config.Config) (modelcache.Cache, error) {
 	m := cfg.Cache.Model
@@ -194,7 +254,7 @@ func initCache(cfg *config.Config) (modelcache.Cache, error) {
 		}
 		mc, err := modelcache.NewRedisModelCache(redisCfg)
 		if err != nil {
-			return nil, err
+			return fallbackToLocalCache(m, "redis", err)
 		}
 		key := m.Redis.Key
 		if key == "" {
@@ -203,16 +263,52 @@ func initCache(cfg *config.Config) (modelcache.Cache, error) {
 		slog.Info("using redis cache", "key", key)
 		return mc, nil
 	}
+	if m.Memcached != nil {
+		ttl := time.Duration(m.Memcached.TTL) * time.Second
+		mc, err := modelcache.NewMemcachedModelCache(modelcache.MemcachedModelCacheConfig{
+			Servers: m.Memcached.Servers,
+			Key:     m.Memcached.Key,
+			TTL:     ttl,
+		})
+		if err != nil {
+			return fallbackToLocalCache(m, "memcached", err)
+		}
+		key := m.Memcached.Key
+		if key == "" {
+			key = modelcache.DefaultMemcachedKey
+		}
+		slog.Info("using memcached cache", "key", key)
+		return mc, nil
+	}
+	if m.Memory != nil {
+		slog.Info("using in-memory model cache", "ttl_seconds", m.Memory.TTL)
+		return modelcache.NewMemoryCache(time.Duration(m.Memory.TTL) * time.Second), nil
+	}
 	if m.Local != nil {
 		cacheDir := m.Local.CacheDir
 		if cacheDir == "" {
 			cacheDir = defaultModelCacheDir()
 		}
 		cacheFile := filepath.Join(cacheDir, "models.json")
-		slog.Info("using local file cache", "path", cacheFile)
-		return modelcache.NewLocalCache(cacheFile), nil
+		slog.Info("using local file cache", "path", cacheFile, "max_age_seconds", m.Local.MaxAge)
+		return modelcache.NewLocalCacheWithMaxAge(cacheFile, time.Duration(m.Local.MaxAge)*time.Second), nil
 	}
-	return nil, fmt.Errorf("cache.model: must have either local or redis configured")
+	return nil, fmt.Errorf("cache.model: must have one of local, redis, memory, or memcached configured")
+}
+
+// fallbackToLocalCache returns the local file cache in place of a shared
+// backend that failed to connect, or err when cache.model.fallback_to_local
+// is off.
+func fallbackToLocalCache(m config.ModelCacheConfig, backend string, err error) (modelcache.Cache, error) {
+	if !m.FallbackToLocal {
+		return nil, err
+	}
+	cacheFile := filepath.Join(defaultModelCacheDir(), "models.json")
+	slog.Warn(backend+" cache unavailable, falling back to local file cache",
+		"path", cacheFile,
+		"error", err,
+	)
+	return modelcache.NewLocalCache(cacheFile), nil
 }
 
 // defaultModelCacheDir keeps the historical ./.cache location whenever it
@@ -229,6 +325,18 @@ func defaultModelCacheDir() string {
 	return dir
 }
 
+// providerWeights collects the configured round-robin weights by provider
+// instance name.
+func providerWeights(providerMap map[string]ProviderConfig) map[string]int {
+	weights := make(map[string]int)
+	for name, cfg := range providerMap {
+		if cfg.Weight > 0 {
+			weights[name] = cfg.Weight
+		}
+	}
+	return weights
+}
+
 // initializeProviders instantiates and registers all resolved providers.
 // Returns the count of successfully registered providers.
 func initializeProviders(ctx context.Context, providerMap map[string]ProviderConfig, factory *ProviderFactory, registry *ModelRegistry) (int, error) {
@@ -274,6 +382,9 @@ func initializeProviders(ctx context.Context, providerMap map[string]ProviderCon
 		if len(pCfg.ModelMetadataOverrides) > 0 {
 			registry.SetProviderMetadataOverrides(name, pCfg.ModelMetadataOverrides)
 		}
+		if pCfg.Priority != 0 {
+			registry.SetProviderPriority(name, pCfg.Priority)
+		}
 		count++
 		slog.Info("provider registered", "name", name, "type", pCfg.Type)
 	}
@@ -9,14 +9,17 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/cache/cachetest"
 	"github.com/enterpilot/gomodel/internal/cache/modelcache"
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
 )
 
 type mockInitCache struct {
@@ -164,6 +167,7 @@ func TestInit_AllowsStartupWhenProviderIsUnavailable(t *testing.T) {
 	}
 	t.Cleanup(func() {
 		_ = result.Close()
+		llmclient.SetAllowedUpstreamHosts(nil)
 	})
 
 	if got := result.Registry.ProviderCount(); got != 1 {
@@ -174,6 +178,33 @@ func TestInit_AllowsStartupWhenProviderIsUnavailable(t *testing.T) {
 	}
 }
 
+func TestInit_RejectsUnknownProviderType(t *testing.T) {
+	factory := NewProviderFactory()
+	factory.Add(Registration{
+		Type: "test",
+		New: func(ProviderConfig, ProviderOptions) core.Provider {
+			return &initTestProvider{}
+		},
+	})
+
+	_, err := Init(t.Context(), &config.LoadResult{
+		Config: &config.Config{},
+		RawProviders: map[string]config.RawProviderConfig{
+			"good":  {Type: "test", APIKey: "sk-test"},
+			"typo":  {Type: "tset", APIKey: "sk-test"},
+			"blank": {APIKey: "sk-test"},
+		},
+	}, factory)
+	if err == nil {
+		t.Fatal("Init() error = nil, want unknown provider types rejected")
+	}
+	for _, want := range []string{"providers.typo: unknown type \"tset\"", "providers.blank: type is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Init() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
 func TestInit_NormalizesNilContext(t *testing.T) {
 	nilInitContext := func() context.Context {
 		return nil
@@ -241,6 +272,7 @@ func TestInit_NormalizesNilContext(t *testing.T) {
 	}
 	defer func() {
 		_ = result.Close()
+		llmclient.SetAllowedUpstreamHosts(nil)
 	}()
 
 	select {
@@ -353,3 +385,69 @@ func TestInitializeProviders_AvailabilityCheckUsesCallerContext(t *testing.T) {
 		t.Fatalf("CheckAvailability() context error = %v, want %v", checkErr, context.Canceled)
 	}
 }
+
+func TestInitCache_FallsBackToLocalWhenRedisUnreachable(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Cache.Model.FallbackToLocal = true
+	cfg.Cache.Model.Redis = &config.RedisModelConfig{URL: cachetest.UnreachableRedisURL(t)}
+
+	mc, err := initCache(cfg)
+	if err != nil {
+		t.Fatalf("initCache() error = %v, want local fallback", err)
+	}
+	defer mc.Close()
+	if _, ok := mc.(*modelcache.LocalCache); !ok {
+		t.Fatalf("initCache() = %T, want *modelcache.LocalCache", mc)
+	}
+}
+
+func TestInitCache_FailsWhenRedisUnreachableAndFallbackDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Cache.Model.Redis = &config.RedisModelConfig{URL: cachetest.UnreachableRedisURL(t)}
+
+	mc, err := initCache(cfg)
+	if err == nil {
+		mc.Close()
+		t.Fatal("initCache() error = nil, want redis connection error")
+	}
+}
+
+func TestInitCache_MemoryBackend(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Cache.Model.Memory = &config.MemoryCacheConfig{TTL: 60}
+
+	mc, err := initCache(cfg)
+	if err != nil {
+		t.Fatalf("initCache() error = %v", err)
+	}
+	defer mc.Close()
+	if _, ok := mc.(*modelcache.MemoryCache); !ok {
+		t.Fatalf("initCache() = %T, want *modelcache.MemoryCache", mc)
+	}
+}
+
+func TestInitCache_FallsBackToLocalWhenMemcachedUnreachable(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Cache.Model.FallbackToLocal = true
+	cfg.Cache.Model.Memcached = &config.MemcachedModelConfig{Servers: []string{cachetest.UnreachableAddr(t)}}
+
+	mc, err := initCache(cfg)
+	if err != nil {
+		t.Fatalf("initCache() error = %v, want local fallback", err)
+	}
+	defer mc.Close()
+	if _, ok := mc.(*modelcache.LocalCache); !ok {
+		t.Fatalf("initCache() = %T, want *modelcache.LocalCache", mc)
+	}
+}
+
+func TestInitCache_FailsWhenMemcachedUnreachableAndFallbackDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Cache.Model.Memcached = &config.MemcachedModelConfig{Servers: []string{cachetest.UnreachableAddr(t)}}
+
+	mc, err := initCache(cfg)
+	if err == nil {
+		mc.Close()
+		t.Fatal("initCache() error = nil, want memcached connection error")
+	}
+}
@@ -0,0 +1,37 @@
+package providers
+
+import "github.com/enterpilot/gomodel/internal/core"
+
+// maxTokensRequiredProviderTypes lists provider types whose APIs reject
+// requests without an output-token limit. Their limit is derived from model
+// metadata so each model gets a default it can honor.
+var maxTokensRequiredProviderTypes = map[string]struct{}{
+	"anthropic": {},
+}
+
+// applyModelMaxOutputTokens fills an omitted output-token limit with the
+// provider's default_max_tokens, or failing that with the model's
+// max_output_tokens metadata, and clamps larger values to the metadata limit.
+// Without either the provider applies its own fallback.
+func (r *Router) applyModelMaxOutputTokens(maxTokens **int, selector core.ModelSelector, providerDefault int) {
+	// Resolve the type from the provider name first: the qualified selector
+	// string is only built for the few providers that need the limit.
+	providerType := r.lookup.GetProviderTypeForName(selector.Provider)
+	if selector.Provider == "" {
+		providerType = r.lookup.GetProviderType(selector.Model)
+	}
+	if _, ok := maxTokensRequiredProviderTypes[providerType]; !ok {
+		return
+	}
+	if *maxTokens == nil && providerDefault > 0 {
+		*maxTokens = &providerDefault
+	}
+	meta := r.modelMetadata(selector.QualifiedModel())
+	if meta == nil || meta.MaxOutputTokens == nil || *meta.MaxOutputTokens <= 0 {
+		return
+	}
+	limit := *meta.MaxOutputTokens
+	if *maxTokens == nil || **maxTokens > limit {
+		*maxTokens = &limit
+	}
+}
@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func newMaxOutputTokensRouter(t *testing.T, provider core.Provider, providerType string) *Router {
+	t.Helper()
+	registry := newTestRegistryWithModels(
+		registryModelEntry{provider: provider, providerName: "main", providerType: providerType, modelID: "claude-sonnet-4-6"},
+		registryModelEntry{provider: provider, providerName: "main", providerType: providerType, modelID: "claude-3-haiku"},
+		registryModelEntry{provider: provider, providerName: "main", providerType: providerType, modelID: "claude-unlisted"},
+	)
+	for model, limit := range map[string]int{"claude-sonnet-4-6": 64000, "claude-3-haiku": 4096} {
+		registry.models[model].Model.Metadata = &core.ModelMetadata{MaxOutputTokens: &limit}
+	}
+	router, err := NewRouter(registry)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	return router
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestRouterChatCompletion_AppliesModelMaxOutputTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     string
+		maxTokens *int
+		want      *int
+	}{
+		{name: "sonnet default", model: "claude-sonnet-4-6", want: intPtr(64000)},
+		{name: "sonnet within limit", model: "claude-sonnet-4-6", maxTokens: intPtr(16000), want: intPtr(16000)},
+		{name: "haiku default", model: "claude-3-haiku", want: intPtr(4096)},
+		{name: "haiku clamped", model: "claude-3-haiku", maxTokens: intPtr(16000), want: intPtr(4096)},
+		{name: "no metadata keeps omitted value", model: "claude-unlisted"},
+		{name: "no metadata keeps client value", model: "claude-unlisted", maxTokens: intPtr(16000), want: intPtr(16000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockProvider{name: "main", chatResponse: &core.ChatResponse{ID: "chat"}}
+			router := newMaxOutputTokensRouter(t, provider, "anthropic")
+
+			req := &core.ChatRequest{
+				Model:     tt.model,
+				Messages:  []core.Message{{Role: "user", Content: "Hello"}},
+				MaxTokens: tt.maxTokens,
+			}
+			if _, err := router.ChatCompletion(context.Background(), req); err != nil {
+				t.Fatalf("ChatCompletion() error = %v", err)
+			}
+
+			got := provider.lastChatReq.MaxTokens
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("MaxTokens = %v, want %v", got, tt.want)
+			}
+			if tt.maxTokens != nil && req.MaxTokens != tt.maxTokens {
+				t.Fatal("client request was mutated")
+			}
+		})
+	}
+}
+
+func TestRouterResponses_ClampsModelMaxOutputTokens(t *testing.T) {
+	provider := &mockProvider{name: "main", responsesResponse: &core.ResponsesResponse{ID: "resp"}}
+	router := newMaxOutputTokensRouter(t, provider, "anthropic")
+
+	_, err := router.Responses(context.Background(), &core.ResponsesRequest{
+		Model:           "claude-3-haiku",
+		Input:           "Hello",
+		MaxOutputTokens: intPtr(16000),
+	})
+	if err != nil {
+		t.Fatalf("Responses() error = %v", err)
+	}
+	if got := provider.lastResponsesReq.MaxOutputTokens; got == nil || *got != 4096 {
+		t.Fatalf("MaxOutputTokens = %v, want 4096", got)
+	}
+}
+
+func TestRouterChatCompletion_LeavesMaxTokensForOptionalProviders(t *testing.T) {
+	provider := &mockProvider{name: "main", chatResponse: &core.ChatResponse{ID: "chat"}}
+	router := newMaxOutputTokensRouter(t, provider, "openai")
+
+	_, err := router.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:     "claude-3-haiku",
+		Messages:  []core.Message{{Role: "user", Content: "Hello"}},
+		MaxTokens: intPtr(16000),
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+	if got := provider.lastChatReq.MaxTokens; got == nil || *got != 16000 {
+		t.Fatalf("MaxTokens = %v, want client value 16000", got)
+	}
+}
+
+func TestRouterChatCompletion_ProviderDefaultMaxTokensBeforeMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     string
+		maxTokens *int
+		want      int
+	}{
+		{name: "provider default below metadata", model: "claude-sonnet-4-6", want: 8192},
+		{name: "provider default clamped to metadata", model: "claude-3-haiku", want: 4096},
+		{name: "provider default without metadata", model: "claude-unlisted", want: 8192},
+		{name: "client value wins", model: "claude-sonnet-4-6", maxTokens: intPtr(1000), want: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockProvider{name: "main", chatResponse: &core.ChatResponse{ID: "chat"}}
+			router := newMaxOutputTokensRouter(t, provider, "anthropic")
+			router.SetDefaultParams(map[string]ProviderConfig{"main": {DefaultMaxTokens: 8192}})
+
+			_, err := router.ChatCompletion(context.Background(), &core.ChatRequest{
+				Model:     tt.model,
+				Messages:  []core.Message{{Role: "user", Content: "Hello"}},
+				MaxTokens: tt.maxTokens,
+			})
+			if err != nil {
+				t.Fatalf("ChatCompletion() error = %v", err)
+			}
+			if got := provider.lastChatReq.MaxTokens; got == nil || *got != tt.want {
+				t.Fatalf("MaxTokens = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Package mistral provides Mistral AI API integration for the LLM gateway.
+package mistral
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+	"github.com/enterpilot/gomodel/internal/providers"
+	"github.com/enterpilot/gomodel/internal/providers/openai"
+)
+
+const defaultBaseURL = "https://api.mistral.ai/v1"
+
+// Registration provides factory registration for the Mistral provider.
+var Registration = providers.Registration{
+	Type: "mistral",
+	New:  New,
+	Discovery: providers.DiscoveryConfig{
+		DefaultBaseURL: defaultBaseURL,
+	},
+}
+
+// Provider implements the core.Provider interface for Mistral AI. Chat and
+// embeddings go through the shared compatible provider and streams pass
+// through unchanged because Mistral already emits OpenAI-format SSE; the
+// Responses API is translated via chat. ListModels is the one native piece:
+// Mistral's /v1/models reports per-model capabilities, which are used to
+// keep embedding, OCR, and moderation models out of the chat catalog.
+type Provider struct {
+	compat *openai.CompatibleProvider
+}
+
+var _ core.Provider = (*Provider)(nil)
+
+// New creates a new Mistral provider.
+func New(cfg providers.ProviderConfig, opts providers.ProviderOptions) core.Provider {
+	return &Provider{
+		compat: openai.NewCompatibleProvider(cfg.APIKey, opts, compatibleConfig(providers.ResolveBaseURL(cfg.BaseURL, defaultBaseURL))),
+	}
+}
+
+// NewWithHTTPClient creates a new Mistral provider with a custom HTTP client.
+// If httpClient is nil, http.DefaultClient is used.
+func NewWithHTTPClient(apiKey string, httpClient *http.Client, hooks llmclient.Hooks) *Provider {
+	return &Provider{
+		compat: openai.NewCompatibleProviderWithHTTPClient(apiKey, httpClient, hooks, compatibleConfig(defaultBaseURL)),
+	}
+}
+
+func compatibleConfig(baseURL string) openai.CompatibleProviderConfig {
+	return openai.CompatibleProviderConfig{
+		ProviderName: "mistral",
+		BaseURL:      baseURL,
+		SetHeaders:   setHeaders,
+	}
+}
+
+func setHeaders(req *http.Request, apiKey string) {
+	providers.SetAuthHeaders(req, apiKey, providers.AuthHeaderConfig{AuthScheme: "Bearer "})
+}
+
+// SetBaseURL allows configuring a custom base URL for the provider
+func (p *Provider) SetBaseURL(url string) {
+	p.compat.SetBaseURL(url)
+}
+
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	return p.compat.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.compat.CircuitState()
+}
+
+// ChatCompletion sends a chat completion request to Mistral
+func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return p.compat.ChatCompletion(ctx, req)
+}
+
+// StreamChatCompletion returns a raw response body for streaming (caller must close)
+func (p *Provider) StreamChatCompletion(ctx context.Context, req *core.ChatRequest) (io.ReadCloser, error) {
+	return p.compat.StreamChatCompletion(ctx, req)
+}
+
+// mistralModel is one entry of Mistral's /v1/models response. Only the fields
+// the gateway uses are decoded.
+type mistralModel struct {
+	ID           string `json:"id"`
+	Object       string `json:"object"`
+	Created      int64  `json:"created"`
+	OwnedBy      string `json:"owned_by"`
+	Capabilities *struct {
+		CompletionChat bool `json:"completion_chat"`
+	} `json:"capabilities"`
+}
+
+type mistralModelsResponse struct {
+	Object string         `json:"object"`
+	Data   []mistralModel `json:"data"`
+}
+
+// ListModels retrieves the chat-capable models from Mistral's /v1/models.
+// Models whose capabilities report completion_chat=false (embeddings, OCR,
+// moderation) are dropped; entries without a capabilities object are kept.
+// Embedding models such as mistral-embed can still be served by listing them
+// in MISTRAL_MODELS.
+func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
+	var resp mistralModelsResponse
+	err := p.compat.Do(ctx, llmclient.Request{
+		Method:   http.MethodGet,
+		Endpoint: "/models",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]core.Model, 0, len(resp.Data))
+	for _, m := range resp.Data {
+		if m.Capabilities != nil && !m.Capabilities.CompletionChat {
+			continue
+		}
+		object := m.Object
+		if object == "" {
+			object = "model"
+		}
+		models = append(models, core.Model{
+			ID:      m.ID,
+			Object:  object,
+			OwnedBy: m.OwnedBy,
+			Created: m.Created,
+		})
+	}
+
+	return &core.ModelsResponse{
+		Object: "list",
+		Data:   models,
+	}, nil
+}
+
+// Responses sends a Responses API request to Mistral (converted to chat format)
+func (p *Provider) Responses(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesResponse, error) {
+	return providers.ResponsesViaChat(ctx, p, req)
+}
+
+// StreamResponses returns a raw response body for streaming Responses API (caller must close)
+func (p *Provider) StreamResponses(ctx context.Context, req *core.ResponsesRequest) (io.ReadCloser, error) {
+	return providers.StreamResponsesViaChat(ctx, p, req, "mistral")
+}
+
+// Embeddings sends an embeddings request to Mistral
+func (p *Provider) Embeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	return p.compat.Embeddings(ctx, req)
+}
@@ -0,0 +1,399 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+	"github.com/enterpilot/gomodel/internal/providers"
+)
+
+func TestNew(t *testing.T) {
+	// Use NewWithHTTPClient to get concrete type for internal testing
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+
+	if provider.compat == nil {
+		t.Error("compat provider should not be nil")
+	}
+}
+
+func TestNew_ReturnsProvider(t *testing.T) {
+	provider := New(providers.ProviderConfig{APIKey: "test-api-key"}, providers.ProviderOptions{})
+
+	if provider == nil {
+		t.Error("provider should not be nil")
+	}
+}
+
+func TestChatCompletion(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		responseBody  string
+		expectedError bool
+		checkResponse func(*testing.T, *core.ChatResponse)
+	}{
+		{
+			name:       "successful request",
+			statusCode: http.StatusOK,
+			responseBody: `{
+				"id": "cmpl-123",
+				"object": "chat.completion",
+				"created": 1677652288,
+				"model": "mistral-large-latest",
+				"choices": [{
+					"index": 0,
+					"message": {
+						"role": "assistant",
+						"content": "Bonjour!"
+					},
+					"finish_reason": "stop"
+				}],
+				"usage": {
+					"prompt_tokens": 10,
+					"completion_tokens": 5,
+					"total_tokens": 15
+				}
+			}`,
+			checkResponse: func(t *testing.T, resp *core.ChatResponse) {
+				if resp.ID != "cmpl-123" {
+					t.Errorf("ID = %q, want %q", resp.ID, "cmpl-123")
+				}
+				if resp.Model != "mistral-large-latest" {
+					t.Errorf("Model = %q, want %q", resp.Model, "mistral-large-latest")
+				}
+				if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "Bonjour!" {
+					t.Fatalf("Choices = %+v, want one choice with Bonjour!", resp.Choices)
+				}
+				if resp.Usage.TotalTokens != 15 {
+					t.Errorf("TotalTokens = %d, want 15", resp.Usage.TotalTokens)
+				}
+			},
+		},
+		{
+			name:          "API error",
+			statusCode:    http.StatusUnauthorized,
+			responseBody:  `{"message": "Unauthorized"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/chat/completions" {
+					t.Errorf("Path = %q, want %q", r.URL.Path, "/chat/completions")
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer test-api-key" {
+					t.Errorf("Authorization = %q, want %q", got, "Bearer test-api-key")
+				}
+
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+			provider.SetBaseURL(server.URL)
+
+			resp, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+				Model: "mistral-large-latest",
+				Messages: []core.Message{
+					{Role: "user", Content: "Hello"},
+				},
+			})
+
+			if tt.expectedError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
+
+func TestStreamChatCompletion(t *testing.T) {
+	responseBody := `data: {"id":"cmpl-123","object":"chat.completion.chunk","created":1677652288,"model":"mistral-large-latest","choices":[{"index":0,"delta":{"content":"Bon"},"finish_reason":null}]}
+
+data: {"id":"cmpl-123","object":"chat.completion.chunk","created":1677652288,"model":"mistral-large-latest","choices":[{"index":0,"delta":{"content":"jour"},"finish_reason":"stop"}]}
+
+data: [DONE]
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+		var req core.ChatRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+		if !req.Stream {
+			t.Error("Stream should be true in request")
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	body, err := provider.StreamChatCompletion(context.Background(), &core.ChatRequest{
+		Model: "mistral-large-latest",
+		Messages: []core.Message{
+			{Role: "user", Content: "Hello"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	respBody, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+	// Mistral streams OpenAI-format SSE, so it is forwarded unchanged.
+	if string(respBody) != responseBody {
+		t.Errorf("response body = %q, want %q", string(respBody), responseBody)
+	}
+}
+
+func TestListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method = %q, want %q", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != "/models" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/models")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"object": "list",
+			"data": [
+				{"id": "mistral-large-latest", "object": "model", "created": 1711929600, "owned_by": "mistralai", "capabilities": {"completion_chat": true, "function_calling": true}},
+				{"id": "mistral-embed", "object": "model", "created": 1711929600, "owned_by": "mistralai", "capabilities": {"completion_chat": false}},
+				{"id": "ft:open-mistral-7b:abc", "object": "model", "created": 1711929601, "owned_by": "user"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Object != "list" {
+		t.Errorf("Object = %q, want %q", resp.Object, "list")
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("len(Data) = %d, want 2 (embedding model filtered): %+v", len(resp.Data), resp.Data)
+	}
+	if resp.Data[0].ID != "mistral-large-latest" || resp.Data[0].OwnedBy != "mistralai" || resp.Data[0].Created != 1711929600 {
+		t.Errorf("Data[0] = %+v, want mistral-large-latest owned by mistralai", resp.Data[0])
+	}
+	if resp.Data[1].ID != "ft:open-mistral-7b:abc" {
+		t.Errorf("Data[1].ID = %q, want model without capabilities kept", resp.Data[1].ID)
+	}
+}
+
+func TestListModels_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	if _, err := provider.ListModels(context.Background()); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestChatCompletionWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulate a slow response
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusRequestTimeout)
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately
+
+	_, err := provider.ChatCompletion(ctx, &core.ChatRequest{
+		Model: "mistral-large-latest",
+		Messages: []core.Message{
+			{Role: "user", Content: "Hello"},
+		},
+	})
+	if err == nil {
+		t.Error("expected error when context is cancelled, got nil")
+	}
+}
+
+func TestResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Mistral has no native Responses API; requests become chat completions.
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/chat/completions")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"id": "cmpl-123",
+			"object": "chat.completion",
+			"created": 1677652288,
+			"model": "mistral-large-latest",
+			"choices": [{
+				"index": 0,
+				"message": {"role": "assistant", "content": "Bonjour!"},
+				"finish_reason": "stop"
+			}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.Responses(context.Background(), &core.ResponsesRequest{
+		Model: "mistral-large-latest",
+		Input: "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Object != "response" {
+		t.Errorf("Object = %q, want %q", resp.Object, "response")
+	}
+	if resp.Status != "completed" {
+		t.Errorf("Status = %q, want %q", resp.Status, "completed")
+	}
+	if len(resp.Output) != 1 || len(resp.Output[0].Content) != 1 {
+		t.Fatalf("Output = %+v, want one message with one content part", resp.Output)
+	}
+	if resp.Output[0].Content[0].Text != "Bonjour!" {
+		t.Errorf("Output text = %q, want %q", resp.Output[0].Content[0].Text, "Bonjour!")
+	}
+	if resp.Usage == nil || resp.Usage.InputTokens != 10 || resp.Usage.OutputTokens != 5 {
+		t.Errorf("Usage = %+v, want 10 input and 5 output tokens", resp.Usage)
+	}
+}
+
+func TestStreamResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`data: {"id":"cmpl-123","object":"chat.completion.chunk","created":1677652288,"model":"mistral-large-latest","choices":[{"index":0,"delta":{"content":"Bonjour"},"finish_reason":null}]}
+
+data: [DONE]
+`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	body, err := provider.StreamResponses(context.Background(), &core.ResponsesRequest{
+		Model: "mistral-large-latest",
+		Input: "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	respBody, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+
+	responseStr := string(respBody)
+	if !strings.Contains(responseStr, "response.created") {
+		t.Error("response should contain response.created event")
+	}
+	if !strings.Contains(responseStr, "response.output_text.delta") {
+		t.Error("response should contain response.output_text.delta event")
+	}
+	if !strings.Contains(responseStr, "[DONE]") {
+		t.Error("response should end with [DONE]")
+	}
+}
+
+func TestResponsesWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulate a slow response
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusRequestTimeout)
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel immediately
+
+	_, err := provider.Responses(ctx, &core.ResponsesRequest{
+		Model: "mistral-large-latest",
+		Input: "Hello",
+	})
+	if err == nil {
+		t.Error("expected error when context is cancelled, got nil")
+	}
+}
+
+func TestEmbeddings_PassesThroughToEmbeddingsEndpoint(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"object":"list","model":"mistral-embed","data":[{"object":"embedding","index":0,"embedding":[0.1,0.2]}],"usage":{"prompt_tokens":3,"total_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.Embeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "mistral-embed",
+		Input: "hello",
+	})
+	if err != nil {
+		t.Fatalf("Embeddings() error = %v", err)
+	}
+	if gotPath != "/embeddings" {
+		t.Errorf("path = %q, want /embeddings", gotPath)
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("data = %+v", resp.Data)
+	}
+}
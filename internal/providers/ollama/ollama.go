@@ -57,6 +57,9 @@ func New(providerCfg providers.ProviderConfig, opts providers.ProviderOptions) c
 		Retry:          opts.Resilience.Retry,
 		Hooks:          opts.Hooks,
 		CircuitBreaker: opts.Resilience.CircuitBreaker,
+		Queue:          opts.Queue,
+		TokenPacer:     opts.TokenPacer,
+		HTTPClient:     opts.HTTPClient,
 	}
 	p.nativeClient = llmclient.New(nativeCfg, p.setNativeHeaders)
 	p.SetBaseURL(providers.ResolveBaseURL(providerCfg.BaseURL, defaultBaseURL))
@@ -95,6 +98,22 @@ func (p *Provider) SetBaseURL(url string) {
 	p.nativeClient.SetBaseURL(normalized)
 }
 
+// ResetCircuit force-closes the circuit breakers of the OpenAI-compatible and
+// native clients.
+func (p *Provider) ResetCircuit() string {
+	state := p.compat.ResetCircuit()
+	if s := p.nativeClient.ResetCircuit(); state == "" {
+		state = s
+	}
+	return state
+}
+
+// CircuitState reports the worst circuit breaker state across the
+// OpenAI-compatible and native clients.
+func (p *Provider) CircuitState() string {
+	return llmclient.WorstCircuitState(p.compat.CircuitState(), p.nativeClient.CircuitState())
+}
+
 // CheckAvailability verifies that Ollama is running and accessible.
 // Makes a lightweight request to the models endpoint.
 func (p *Provider) CheckAvailability(ctx context.Context) error {
@@ -130,9 +149,48 @@ func (p *Provider) StreamChatCompletion(ctx context.Context, req *core.ChatReque
 	return p.compat.StreamChatCompletion(ctx, req)
 }
 
-// ListModels retrieves the list of available models from Ollama
+type ollamaTagsResponse struct {
+	Models []ollamaTag `json:"models"`
+}
+
+type ollamaTag struct {
+	Name       string `json:"name"`
+	ModifiedAt string `json:"modified_at"`
+}
+
+// ListModels retrieves the locally installed models from Ollama's native
+// /api/tags endpoint, which lists every pulled model tag.
 func (p *Provider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
-	return p.compat.ListModels(ctx)
+	var tagsResp ollamaTagsResponse
+	err := p.nativeClient.Do(ctx, llmclient.Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/tags",
+	}, &tagsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]core.Model, 0, len(tagsResp.Models))
+	for _, tag := range tagsResp.Models {
+		if tag.Name == "" {
+			continue
+		}
+		var created int64
+		if modifiedAt, err := time.Parse(time.RFC3339Nano, tag.ModifiedAt); err == nil {
+			created = modifiedAt.Unix()
+		}
+		models = append(models, core.Model{
+			ID:      tag.Name,
+			Object:  "model",
+			OwnedBy: "ollama",
+			Created: created,
+		})
+	}
+
+	return &core.ModelsResponse{
+		Object: "list",
+		Data:   models,
+	}, nil
 }
 
 // Responses sends a Responses API request to Ollama (converted to chat format)
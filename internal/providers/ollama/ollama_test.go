@@ -338,20 +338,19 @@ func TestListModels(t *testing.T) {
 			name:       "successful request",
 			statusCode: http.StatusOK,
 			responseBody: `{
-				"object": "list",
-				"data": [
+				"models": [
 					{
-						"id": "llama3.2",
-						"object": "model",
-						"created": 1687882411,
-						"owned_by": "library"
+						"name": "llama3.2:latest",
+						"model": "llama3.2:latest",
+						"modified_at": "2025-05-04T17:37:44.706015396-07:00",
+						"size": 2019393189
 					},
 					{
-						"id": "mistral:7b-instruct",
-						"object": "model",
-						"created": 1687882410,
-						"owned_by": "library"
-					}
+						"name": "mistral:7b-instruct",
+						"model": "mistral:7b-instruct",
+						"modified_at": "not-a-time"
+					},
+					{"name": ""}
 				]
 			}`,
 			expectedError: false,
@@ -362,11 +361,17 @@ func TestListModels(t *testing.T) {
 				if len(resp.Data) != 2 {
 					t.Fatalf("len(Data) = %d, want 2", len(resp.Data))
 				}
-				if resp.Data[0].ID != "llama3.2" {
-					t.Errorf("Data[0].ID = %q, want %q", resp.Data[0].ID, "llama3.2")
+				if resp.Data[0].ID != "llama3.2:latest" {
+					t.Errorf("Data[0].ID = %q, want %q", resp.Data[0].ID, "llama3.2:latest")
+				}
+				if resp.Data[0].OwnedBy != "ollama" {
+					t.Errorf("Data[0].OwnedBy = %q, want %q", resp.Data[0].OwnedBy, "ollama")
+				}
+				if resp.Data[0].Created != 1746405464 {
+					t.Errorf("Data[0].Created = %d, want 1746405464", resp.Data[0].Created)
 				}
-				if resp.Data[0].OwnedBy != "library" {
-					t.Errorf("Data[0].OwnedBy = %q, want %q", resp.Data[0].OwnedBy, "library")
+				if resp.Data[1].Created != 0 {
+					t.Errorf("Data[1].Created = %d, want 0 for an unparseable modified_at", resp.Data[1].Created)
 				}
 			},
 		},
@@ -385,8 +390,8 @@ func TestListModels(t *testing.T) {
 				if r.Method != http.MethodGet {
 					t.Errorf("Method = %q, want %q", r.Method, http.MethodGet)
 				}
-				if r.URL.Path != "/models" {
-					t.Errorf("Path = %q, want %q", r.URL.Path, "/models")
+				if r.URL.Path != "/api/tags" {
+					t.Errorf("Path = %q, want %q", r.URL.Path, "/api/tags")
 				}
 
 				w.WriteHeader(tt.statusCode)
@@ -741,7 +746,7 @@ func TestSetBaseURL(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"object":"list","data":[]}`))
+		_, _ = w.Write([]byte(`{"models":[]}`))
 	}))
 	defer server.Close()
 
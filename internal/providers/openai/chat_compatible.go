@@ -68,6 +68,16 @@ func (c *ChatCompatible) GetBaseURL() string {
 	return c.compatible.GetBaseURL()
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (c *ChatCompatible) ResetCircuit() string {
+	return c.compatible.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (c *ChatCompatible) CircuitState() string {
+	return c.compatible.CircuitState()
+}
+
 // ChatCompletion sends a chat completion request to the provider.
 func (c *ChatCompatible) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	return c.compatible.ChatCompletion(ctx, req)
@@ -92,6 +92,9 @@ func NewCompatibleProvider(apiKey string, opts providers.ProviderOptions, cfg Co
 		Retry:          opts.Resilience.Retry,
 		Hooks:          opts.Hooks,
 		CircuitBreaker: opts.Resilience.CircuitBreaker,
+		Queue:          opts.Queue,
+		TokenPacer:     opts.TokenPacer,
+		HTTPClient:     opts.HTTPClient,
 	}
 	// Resolved per request, not captured: with several keys configured this is
 	// what spreads successive calls across them.
@@ -140,6 +143,16 @@ func (p *CompatibleProvider) GetBaseURL() string {
 	return p.client.BaseURL()
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *CompatibleProvider) ResetCircuit() string {
+	return p.client.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *CompatibleProvider) CircuitState() string {
+	return p.client.CircuitState()
+}
+
 func (p *CompatibleProvider) SetRequestMutator(mutator RequestMutator) {
 	p.requestMutator = mutator
 }
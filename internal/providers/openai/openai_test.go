@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
@@ -566,6 +567,154 @@ func TestChatCompletion_PreservesUnknownTopLevelFields(t *testing.T) {
 	}
 }
 
+func TestChatCompletion_ForwardsStopUpstream(t *testing.T) {
+	var gotStop any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		gotStop = req["stop"]
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-4o-mini","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", server.Client(), llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o-mini",
+		Messages: []core.Message{{Role: "user", Content: "Count to ten."}},
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"stop": json.RawMessage(`["5","6"]`),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stops, ok := gotStop.([]any)
+	if !ok || len(stops) != 2 || stops[0] != "5" || stops[1] != "6" {
+		t.Fatalf("upstream stop = %#v, want [5 6]", gotStop)
+	}
+}
+
+func TestChatCompletion_ForwardsSamplingParamsUpstream(t *testing.T) {
+	var body map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-4o-mini","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", server.Client(), llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	topP := 0.9
+	_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o-mini",
+		Messages: []core.Message{{Role: "user", Content: "hi"}},
+		TopP:     &topP,
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"frequency_penalty": json.RawMessage("0.5"),
+			"presence_penalty":  json.RawMessage("0.25"),
+			"seed":              json.RawMessage("42"),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"top_p":             "0.9",
+		"frequency_penalty": "0.5",
+		"presence_penalty":  "0.25",
+		"seed":              "42",
+	}
+	for field, value := range want {
+		if got := string(body[field]); got != value {
+			t.Errorf("%s = %q, want %q", field, got, value)
+		}
+	}
+}
+
+func TestChatCompletion_ForwardsAudioOutputAndPreservesAudioResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+
+		var req struct {
+			Modalities []string          `json:"modalities"`
+			Audio      map[string]string `json:"audio"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+		if !slices.Equal(req.Modalities, []string{"text", "audio"}) {
+			t.Fatalf("modalities = %v, want [text audio]", req.Modalities)
+		}
+		if req.Audio["voice"] != "alloy" || req.Audio["format"] != "wav" {
+			t.Fatalf("audio = %v, want voice alloy and format wav", req.Audio)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"id": "chatcmpl-audio",
+			"object": "chat.completion",
+			"created": 1677652288,
+			"model": "gpt-4o-audio-preview",
+			"choices": [{
+				"index": 0,
+				"message": {
+					"role": "assistant",
+					"content": null,
+					"audio": {
+						"id": "audio_abc123",
+						"data": "UklGRg==",
+						"expires_at": 1729234747,
+						"transcript": "Hello there"
+					}
+				},
+				"finish_reason": "stop"
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", server.Client(), llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+		Model:      "gpt-4o-audio-preview",
+		Messages:   []core.Message{{Role: "user", Content: "Say hello."}},
+		Modalities: []string{"text", "audio"},
+		Audio:      &core.ChatAudioConfig{Voice: "alloy", Format: "wav"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	audio := resp.Choices[0].Message.Audio
+	if audio == nil {
+		t.Fatal("response audio = nil, want preserved audio output")
+	}
+	if audio.ID != "audio_abc123" || audio.Data != "UklGRg==" || audio.ExpiresAt != 1729234747 || audio.Transcript != "Hello there" {
+		t.Fatalf("response audio = %#v", audio)
+	}
+
+	body, err := json.Marshal(resp.Choices[0].Message)
+	if err != nil {
+		t.Fatalf("failed to marshal response message: %v", err)
+	}
+	if !strings.Contains(string(body), `"content":null`) || !strings.Contains(string(body), `"data":"UklGRg=="`) {
+		t.Fatalf("response message = %s, want null content and audio data", body)
+	}
+}
+
 func TestChatCompletion_PreservesUnknownNestedFields(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, err := io.ReadAll(r.Body)
@@ -757,7 +906,7 @@ func TestStreamChatCompletion(t *testing.T) {
 		{
 			name:       "successful streaming request",
 			statusCode: http.StatusOK,
-			responseBody: `data: {"id":"chatcmpl-123","object":"chat.completion.chunk","created":1677652288,"model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hello"},"finish_reason":null}]}
+			responseBody: `data: {"id":"chatcmpl-123","object":"chat.completion.chunk","created":1677652288,"model":"gpt-4o","system_fingerprint":"fp_44709d6fcb","choices":[{"index":0,"delta":{"content":"Hello"},"finish_reason":null}]}
 
 data: {"id":"chatcmpl-123","object":"chat.completion.chunk","created":1677652288,"model":"gpt-4o","choices":[{"index":0,"delta":{"content":"!"},"finish_reason":null}]}
 
@@ -797,6 +946,13 @@ data: [DONE]
 				if !req.Stream {
 					t.Error("Stream should be true in request")
 				}
+				var raw map[string]any
+				if err := json.Unmarshal(body, &raw); err != nil {
+					t.Fatalf("failed to unmarshal request: %v", err)
+				}
+				if got := raw["seed"]; got != float64(42) {
+					t.Errorf("seed = %v, want 42 forwarded upstream", got)
+				}
 
 				w.WriteHeader(tt.statusCode)
 				_, _ = w.Write([]byte(tt.responseBody))
@@ -806,14 +962,12 @@ data: [DONE]
 			provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
 			provider.SetBaseURL(server.URL)
 
-			req := &core.ChatRequest{
-				Model: "gpt-4o",
-				Messages: []core.Message{
-					{Role: "user", Content: "Hello"},
-				},
+			var req core.ChatRequest
+			if err := json.Unmarshal([]byte(`{"model":"gpt-4o","seed":42,"messages":[{"role":"user","content":"Hello"}]}`), &req); err != nil {
+				t.Fatalf("failed to unmarshal request: %v", err)
 			}
 
-			body, err := provider.StreamChatCompletion(context.Background(), req)
+			body, err := provider.StreamChatCompletion(context.Background(), &req)
 
 			if tt.expectedError {
 				if err == nil {
@@ -963,6 +1117,40 @@ func TestChatCompletionWithContext(t *testing.T) {
 	}
 }
 
+func TestChatCompletion_ForwardsRequestIDUpstream(t *testing.T) {
+	var gotRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = r.Header.Get("X-Client-Request-Id")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-4o","choices":[]}`))
+	}))
+	defer server.Close()
+
+	var hookRequestID string
+	provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{
+		OnRequestEnd: func(_ context.Context, info llmclient.ResponseInfo) {
+			hookRequestID = info.RequestID
+		},
+	})
+	provider.SetBaseURL(server.URL)
+
+	ctx := core.WithRequestID(context.Background(), "req-123")
+	req := &core.ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []core.Message{{Role: "user", Content: "Hello"}},
+	}
+	if _, err := provider.ChatCompletion(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotRequestID != "req-123" {
+		t.Errorf("X-Client-Request-Id = %q, want %q", gotRequestID, "req-123")
+	}
+	if hookRequestID != "req-123" {
+		t.Errorf("hook RequestID = %q, want %q", hookRequestID, "req-123")
+	}
+}
+
 func TestResponses(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1882,6 +2070,58 @@ func TestChatCompletion_NonReasoningModel_PreservesToolConfiguration(t *testing.
 	}
 }
 
+func TestChatCompletion_PassesThroughToolChoiceForms(t *testing.T) {
+	tests := []struct {
+		name       string
+		toolChoice any
+		want       string
+	}{
+		{name: "none", toolChoice: "none", want: `"none"`},
+		{name: "auto", toolChoice: "auto", want: `"auto"`},
+		{name: "required", toolChoice: "required", want: `"required"`},
+		{
+			name:       "named function",
+			toolChoice: map[string]any{"type": "function", "function": map[string]any{"name": "lookup_weather"}},
+			want:       `{"function":{"name":"lookup_weather"},"type":"function"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("failed to read request body: %v", err)
+				}
+				var raw map[string]json.RawMessage
+				if err := json.Unmarshal(body, &raw); err != nil {
+					t.Fatalf("failed to unmarshal request: %v", err)
+				}
+				if got := string(raw["tool_choice"]); got != tt.want {
+					t.Errorf("tool_choice = %s, want %s", got, tt.want)
+				}
+				_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-4o-mini","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+			}))
+			defer server.Close()
+
+			provider := NewWithHTTPClient("test-api-key", nil, llmclient.Hooks{})
+			provider.SetBaseURL(server.URL)
+
+			_, err := provider.ChatCompletion(context.Background(), &core.ChatRequest{
+				Model:    "gpt-4o-mini",
+				Messages: []core.Message{{Role: "user", Content: "What's the weather?"}},
+				Tools: []map[string]any{
+					{"type": "function", "function": map[string]any{"name": "lookup_weather"}},
+				},
+				ToolChoice: tt.toolChoice,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestStreamChatCompletion_ReasoningModel_AdaptsParameters(t *testing.T) {
 	maxTokens := 2000
 
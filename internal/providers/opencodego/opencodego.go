@@ -130,6 +130,28 @@ func (p *Provider) usesMessages(model string) bool {
 	return ok
 }
 
+// ResetCircuit force-closes the circuit breakers of both the chat completions
+// and the /messages clients.
+func (p *Provider) ResetCircuit() string {
+	state := p.ChatCompatible.ResetCircuit()
+	if resetter, ok := p.messages.(core.CircuitResetter); ok {
+		if s := resetter.ResetCircuit(); state == "" {
+			state = s
+		}
+	}
+	return state
+}
+
+// CircuitState reports the worst circuit breaker state across the chat
+// completions and /messages clients.
+func (p *Provider) CircuitState() string {
+	states := []string{p.ChatCompatible.CircuitState()}
+	if reporter, ok := p.messages.(core.CircuitStateReporter); ok {
+		states = append(states, reporter.CircuitState())
+	}
+	return llmclient.WorstCircuitState(states...)
+}
+
 // ChatCompletion routes Anthropic-only models to /messages and everything else
 // to /chat/completions.
 func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
@@ -35,8 +35,8 @@ type Provider struct {
 func New(cfg providers.ProviderConfig, opts providers.ProviderOptions) core.Provider {
 	baseURL := providers.ResolveBaseURL(cfg.BaseURL, defaultBaseURL)
 	p := &Provider{
-		siteURL: envOrDefault("OPENROUTER_SITE_URL", defaultSiteURL),
-		appName: envOrDefault("OPENROUTER_APP_NAME", defaultAppName),
+		siteURL: attributionValue(cfg.SiteURL, "OPENROUTER_SITE_URL", defaultSiteURL),
+		appName: attributionValue(cfg.AppName, "OPENROUTER_APP_NAME", defaultAppName),
 	}
 	p.CompatibleProvider = openai.NewCompatibleProvider(cfg.APIKey, opts, openai.CompatibleProviderConfig{
 		ProviderName: "openrouter",
@@ -49,8 +49,8 @@ func New(cfg providers.ProviderConfig, opts providers.ProviderOptions) core.Prov
 
 func NewWithHTTPClient(apiKey string, httpClient *http.Client, hooks llmclient.Hooks) *Provider {
 	p := &Provider{
-		siteURL: envOrDefault("OPENROUTER_SITE_URL", defaultSiteURL),
-		appName: envOrDefault("OPENROUTER_APP_NAME", defaultAppName),
+		siteURL: attributionValue("", "OPENROUTER_SITE_URL", defaultSiteURL),
+		appName: attributionValue("", "OPENROUTER_APP_NAME", defaultAppName),
 	}
 	p.CompatibleProvider = openai.NewCompatibleProviderWithHTTPClient(apiKey, httpClient, hooks, openai.CompatibleProviderConfig{
 		ProviderName: "openrouter",
@@ -83,8 +83,13 @@ func setHeaders(req *http.Request, apiKey string) {
 	})
 }
 
-func envOrDefault(key, fallback string) string {
-	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+// attributionValue picks an attribution header value: the provider's YAML
+// setting first, then the env var, then GoModel's own default.
+func attributionValue(configured, envKey, fallback string) string {
+	if value := strings.TrimSpace(configured); value != "" {
+		return value
+	}
+	if value := strings.TrimSpace(os.Getenv(envKey)); value != "" {
 		return value
 	}
 	return fallback
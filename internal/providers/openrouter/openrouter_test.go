@@ -2,6 +2,7 @@ package openrouter
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/enterpilot/gomodel/internal/core"
 	"github.com/enterpilot/gomodel/internal/llmclient"
+	"github.com/enterpilot/gomodel/internal/providers"
 )
 
 func TestChatCompletion_AddsDefaultAttributionHeaders(t *testing.T) {
@@ -139,3 +141,96 @@ func TestPassthrough_PreservesUserProvidedAttributionHeaders(t *testing.T) {
 		t.Fatalf("X-OpenRouter-Title = %q, want empty when caller provided X-Title", gotTitle)
 	}
 }
+
+func TestNew_UsesConfiguredAttributionHeaders(t *testing.T) {
+	t.Setenv("OPENROUTER_SITE_URL", "https://env.example")
+	t.Setenv("OPENROUTER_APP_NAME", "Env App")
+
+	var gotReferer string
+	var gotTitle string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotReferer = r.Header.Get("HTTP-Referer")
+		gotTitle = r.Header.Get("X-OpenRouter-Title")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[]}`))
+	}))
+	defer server.Close()
+
+	provider := New(providers.ProviderConfig{
+		APIKey:  "test-api-key",
+		BaseURL: server.URL,
+		SiteURL: "https://config.example",
+		AppName: "Config App",
+	}, providers.ProviderOptions{})
+
+	if _, err := provider.ListModels(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotReferer != "https://config.example" {
+		t.Fatalf("HTTP-Referer = %q, want https://config.example", gotReferer)
+	}
+	if gotTitle != "Config App" {
+		t.Fatalf("X-OpenRouter-Title = %q, want Config App", gotTitle)
+	}
+}
+
+func TestListModels_SlashedIDsRouteThroughRegistry(t *testing.T) {
+	var gotModel string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models":
+			_, _ = w.Write([]byte(`{"object":"list","data":[
+				{"id":"anthropic/claude-3.5-sonnet","object":"model","owned_by":"openrouter"},
+				{"id":"openai/gpt-4o-mini","object":"model","owned_by":"openrouter"}
+			]}`))
+		case "/chat/completions":
+			var req core.ChatRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			gotModel = req.Model
+			_, _ = w.Write([]byte(`{"id":"chatcmpl-123","object":"chat.completion","created":1677652288,"model":"anthropic/claude-3.5-sonnet",
+				"choices":[{"index":0,"message":{"role":"assistant","content":"hello"},"finish_reason":"stop"}]}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewWithHTTPClient("test-api-key", server.Client(), llmclient.Hooks{})
+	provider.SetBaseURL(server.URL)
+
+	resp, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].ID != "anthropic/claude-3.5-sonnet" {
+		t.Fatalf("Data = %+v, want slash-prefixed IDs kept intact", resp.Data)
+	}
+
+	registry := providers.NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(provider, "openrouter", "openrouter")
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	router, err := providers.NewRouter(registry)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	for _, model := range []string{"anthropic/claude-3.5-sonnet", "openrouter/anthropic/claude-3.5-sonnet"} {
+		gotModel = ""
+		if _, err := router.ChatCompletion(context.Background(), &core.ChatRequest{
+			Model:    model,
+			Messages: []core.Message{{Role: "user", Content: "hi"}},
+		}); err != nil {
+			t.Fatalf("ChatCompletion(%q) error = %v", model, err)
+		}
+		if gotModel != "anthropic/claude-3.5-sonnet" {
+			t.Fatalf("upstream model for %q = %q, want anthropic/claude-3.5-sonnet", model, gotModel)
+		}
+	}
+}
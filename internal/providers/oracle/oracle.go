@@ -50,6 +50,15 @@ func (p *Provider) SetBaseURL(baseURL string) {
 	p.compat.SetBaseURL(baseURL)
 }
 
+func (p *Provider) ResetCircuit() string {
+	return p.compat.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.compat.CircuitState()
+}
+
 func (p *Provider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	return p.compat.ChatCompletion(ctx, req)
 }
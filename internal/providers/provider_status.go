@@ -4,15 +4,19 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/enterpilot/gomodel/internal/core"
 )
 
 // SanitizedRetryConfig exposes effective retry settings without secrets.
 type SanitizedRetryConfig struct {
-	MaxRetries     int     `json:"max_retries"`
-	InitialBackoff string  `json:"initial_backoff"`
-	MaxBackoff     string  `json:"max_backoff"`
-	BackoffFactor  float64 `json:"backoff_factor"`
-	JitterFactor   float64 `json:"jitter_factor"`
+	MaxRetries     int      `json:"max_retries"`
+	InitialBackoff string   `json:"initial_backoff"`
+	MaxBackoff     string   `json:"max_backoff"`
+	BackoffFactor  float64  `json:"backoff_factor"`
+	JitterFactor   float64  `json:"jitter_factor"`
+	BodyErrorCodes []string `json:"body_error_codes,omitempty"`
+	MaxElapsedTime string   `json:"max_elapsed_time"`
 }
 
 // SanitizedCircuitBreakerConfig exposes effective circuit-breaker settings.
@@ -53,6 +57,9 @@ type ProviderRuntimeSnapshot struct {
 	LastAvailabilityOKAt    *time.Time `json:"last_availability_ok_at,omitempty"`
 	LastAvailabilityError   string     `json:"last_availability_error,omitempty"`
 	InventoryStale          bool       `json:"inventory_stale,omitempty"`
+	// CircuitState is the provider's live circuit breaker state, read from
+	// its clients at snapshot time; empty when no breaker is enabled.
+	CircuitState string `json:"circuit_state,omitempty"`
 }
 
 type providerRuntimeState struct {
@@ -109,6 +116,8 @@ func SanitizeProviderConfigs(configs map[string]ProviderConfig) []SanitizedProvi
 					MaxBackoff:     cfg.Resilience.Retry.MaxBackoff.String(),
 					BackoffFactor:  cfg.Resilience.Retry.BackoffFactor,
 					JitterFactor:   cfg.Resilience.Retry.JitterFactor,
+					BodyErrorCodes: cfg.Resilience.Retry.BodyErrorCodes,
+					MaxElapsedTime: cfg.Resilience.Retry.MaxElapsedTime.String(),
 				},
 				CircuitBreaker: SanitizedCircuitBreakerConfig{
 					FailureThreshold: cfg.Resilience.CircuitBreaker.FailureThreshold,
@@ -129,3 +138,13 @@ func timePtrUTC(t time.Time) *time.Time {
 	value := t.UTC()
 	return &value
 }
+
+// providerCircuitState reads the live circuit breaker state from providers
+// that report one.
+func providerCircuitState(provider core.Provider) string {
+	reporter, ok := provider.(core.CircuitStateReporter)
+	if !ok {
+		return ""
+	}
+	return reporter.CircuitState()
+}
@@ -23,14 +23,36 @@ type ModelInfo struct {
 	Provider     core.Provider
 	ProviderName string
 	ProviderType string
+	Source       ModelSource
+	// ReportedMetadata is the metadata the provider's own model list returned
+	// (e.g. Gemini's token limits). Registry enrichment merges it over the
+	// remote model list instead of discarding it; config overrides still win.
+	ReportedMetadata *core.ModelMetadata
 }
 
+// ModelSource records where a registered model entry came from.
+type ModelSource string
+
+const (
+	// ModelSourceLive marks models returned by the provider's upstream model list.
+	ModelSourceLive ModelSource = "live"
+	// ModelSourceCache marks models loaded from the model cache at startup and
+	// not yet confirmed by a live fetch.
+	ModelSourceCache ModelSource = "cache"
+	// ModelSourceConfigured marks models taken from the provider's configured
+	// model list, either as an allowlist or as a fallback for a failed fetch.
+	ModelSourceConfigured ModelSource = "configured"
+	// ModelSourceBuiltIn marks models from a provider's built-in list, used
+	// when its live list failed and nothing was cached.
+	ModelSourceBuiltIn ModelSource = "built_in"
+)
+
 // ModelRegistry manages the mapping of models to their providers.
 // It fetches models from providers on startup and caches them in memory.
 // Supports loading from a cache (local file or Redis) for instant startup.
 type ModelRegistry struct {
 	mu               sync.RWMutex
-	models           map[string]*ModelInfo            // model ID -> model info (first provider wins)
+	models           map[string]*ModelInfo            // model ID -> model info (owner picked by duplicateModelPolicy)
 	modelsByProvider map[string]map[string]*ModelInfo // provider instance name -> model ID -> model info
 	providers        []core.Provider
 	providerTypes    map[core.Provider]string // provider -> type string
@@ -52,6 +74,21 @@ type ModelRegistry struct {
 	// are fallback-only or an allowlist over the discovered upstream inventory.
 	configuredProviderModels     map[string][]string
 	configuredProviderModelsMode config.ConfiguredProviderModelsMode
+	// preferredProvider, when set, owns bare model IDs shared with other
+	// providers regardless of registration order.
+	preferredProvider string
+	// duplicateModelPolicy decides which provider owns a bare model ID that
+	// several providers expose. providerPriority feeds the "priority" policy.
+	duplicateModelPolicy config.DuplicateModelPolicy
+	providerPriority     map[string]int
+	// modelOwners lists, under the "all" policy only, every fresh provider
+	// entry for bare model IDs shared by two or more providers. Unqualified
+	// routing asks selector to pick among them.
+	modelOwners map[string][]*ModelInfo
+	selector    Selector
+	// refreshConcurrency caps concurrent provider ListModels calls during an
+	// inventory sweep. 0 means no cap.
+	refreshConcurrency int
 
 	// Cached sorted slices, rebuilt lazily after models change.
 	// nil means cache needs rebuilding. Protected by mu.
@@ -92,6 +129,8 @@ func NewModelRegistry() *ModelRegistry {
 		providerRuntime:              make(map[string]providerRuntimeState),
 		refreshCh:                    make(chan struct{}, 1),
 		configuredProviderModelsMode: config.ConfiguredProviderModelsModeFallback,
+		duplicateModelPolicy:         config.DuplicateModelPolicyFirstWins,
+		selector:                     NewRoundRobinSelector(),
 	}
 }
 
@@ -240,6 +279,72 @@ func (r *ModelRegistry) SetConfiguredProviderModelsMode(mode config.ConfiguredPr
 	r.configuredProviderModelsMode = config.ResolveConfiguredProviderModelsMode(mode)
 }
 
+// SetPreferredProvider makes providerName win bare-model-ID conflicts, so its
+// model objects back unqualified lookups instead of the first registered
+// provider's. Takes effect on the next inventory load; an empty name restores
+// registration order.
+func (r *ModelRegistry) SetPreferredProvider(providerName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.preferredProvider = strings.TrimSpace(providerName)
+}
+
+// SetDuplicateModelPolicy selects how bare model IDs exposed by several
+// providers are resolved. It takes effect on the next inventory rebuild.
+func (r *ModelRegistry) SetDuplicateModelPolicy(policy config.DuplicateModelPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.duplicateModelPolicy = config.ResolveDuplicateModelPolicy(policy)
+}
+
+// DuplicateModelPolicy returns the active duplicate model policy.
+func (r *ModelRegistry) DuplicateModelPolicy() config.DuplicateModelPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.duplicateModelPolicy == "" {
+		return config.DuplicateModelPolicyFirstWins
+	}
+	return r.duplicateModelPolicy
+}
+
+// SetSelector installs the strategy used to pick among providers sharing a
+// bare model ID. nil restores the default round-robin selector.
+func (r *ModelRegistry) SetSelector(selector Selector) {
+	if selector == nil {
+		selector = NewRoundRobinSelector()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.selector = selector
+}
+
+// SetProviderPriority records the rank used by the "priority" duplicate model
+// policy for a configured provider instance. Higher values win.
+func (r *ModelRegistry) SetProviderPriority(providerName string, priority int) {
+	providerName = strings.TrimSpace(providerName)
+	if providerName == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if priority == 0 {
+		delete(r.providerPriority, providerName)
+		return
+	}
+	if r.providerPriority == nil {
+		r.providerPriority = make(map[string]int)
+	}
+	r.providerPriority[providerName] = priority
+}
+
+// SetRefreshConcurrency caps how many providers are listed at once during
+// Initialize and refresh sweeps. Values <= 0 remove the cap.
+func (r *ModelRegistry) SetRefreshConcurrency(limit int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshConcurrency = max(limit, 0)
+}
+
 // SetProviderConfiguredModels records the explicit model inventory declared for
 // a configured provider instance. Call with an empty/nil slice to clear it.
 func (r *ModelRegistry) SetProviderConfiguredModels(providerName string, models []string) {
@@ -724,10 +829,14 @@ func (r *ModelRegistry) hasConfiguredProviderNameLocked(providerName string) boo
 
 // ModelWithProvider holds a model alongside provider metadata and its public selector.
 type ModelWithProvider struct {
-	Model        core.Model `json:"model"`
-	ProviderType string     `json:"provider_type"`
-	ProviderName string     `json:"provider_name"`
-	Selector     string     `json:"selector"`
+	Model        core.Model  `json:"model"`
+	ProviderType string      `json:"provider_type"`
+	ProviderName string      `json:"provider_name"`
+	Selector     string      `json:"selector"`
+	Source       ModelSource `json:"source,omitempty"`
+	// UnqualifiedOwner reports whether this provider serves the bare model ID
+	// after first-provider-wins dedup.
+	UnqualifiedOwner bool `json:"unqualified_owner"`
 }
 
 // ListModelsWithProvider returns all provider-backed models with provider metadata,
@@ -761,10 +870,12 @@ func (r *ModelRegistry) ListModelsWithProvider() []ModelWithProvider {
 				publicProviderName = info.ProviderName
 			}
 			result = append(result, ModelWithProvider{
-				Model:        info.Model,
-				ProviderType: info.ProviderType,
-				ProviderName: publicProviderName,
-				Selector:     qualifyPublicModelID(publicProviderName, modelID),
+				Model:            info.Model,
+				ProviderType:     info.ProviderType,
+				ProviderName:     publicProviderName,
+				Selector:         qualifyPublicModelID(publicProviderName, modelID),
+				Source:           info.Source,
+				UnqualifiedOwner: r.ownsUnqualifiedIDLocked(publicProviderName, modelID),
 			})
 		}
 	}
@@ -774,6 +885,13 @@ func (r *ModelRegistry) ListModelsWithProvider() []ModelWithProvider {
 	return append([]ModelWithProvider(nil), result...)
 }
 
+// ownsUnqualifiedIDLocked reports whether providerName holds the bare-ID slot
+// for modelID. Callers must hold r.mu.
+func (r *ModelRegistry) ownsUnqualifiedIDLocked(providerName, modelID string) bool {
+	owner, ok := r.models[modelID]
+	return ok && owner != nil && owner.ProviderName == providerName
+}
+
 // cacheableCategory reports whether category is a known value that should be cached.
 // CategoryAll is handled separately (delegates to ListModelsWithProvider).
 var cacheableCategories = map[core.ModelCategory]struct{}{
@@ -823,10 +941,12 @@ func (r *ModelRegistry) ListModelsWithProviderByCategory(category core.ModelCate
 				continue
 			}
 			result = append(result, ModelWithProvider{
-				Model:        info.Model,
-				ProviderType: info.ProviderType,
-				ProviderName: info.ProviderName,
-				Selector:     qualifyPublicModelID(info.ProviderName, modelID),
+				Model:            info.Model,
+				ProviderType:     info.ProviderType,
+				ProviderName:     info.ProviderName,
+				Selector:         qualifyPublicModelID(info.ProviderName, modelID),
+				Source:           info.Source,
+				UnqualifiedOwner: r.ownsUnqualifiedIDLocked(info.ProviderName, modelID),
 			})
 		}
 	}
@@ -975,7 +1095,7 @@ func (r *ModelRegistry) markProviderInventoryStale(providerName string) {
 	state := r.providerRuntime[providerName]
 	state.inventoryStale = true
 	r.providerRuntime[providerName] = state
-	r.models = rebuildGlobalModelMap(r.modelsByProvider, r.freshFirstProviderOrderLocked())
+	r.rebuildModelIndexLocked()
 	r.invalidateSortedCaches()
 }
 
@@ -1028,6 +1148,7 @@ func (r *ModelRegistry) ProviderRuntimeSnapshots() []ProviderRuntimeSnapshot {
 			LastAvailabilityOKAt:    timePtrUTC(state.lastAvailabilityOKAt),
 			LastAvailabilityError:   strings.TrimSpace(state.lastAvailabilityError),
 			InventoryStale:          state.inventoryStale,
+			CircuitState:            providerCircuitState(provider),
 		})
 	}
 	r.mu.RUnlock()
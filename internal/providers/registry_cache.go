@@ -38,22 +38,14 @@ func (r *ModelRegistry) LoadFromCache(ctx context.Context) (int, error) {
 	r.mu.RLock()
 	nameToProvider := make(map[string]core.Provider, len(r.providerNames))
 	nameToProviderType := make(map[string]string, len(r.providerNames))
-	providerOrderNames := make([]string, 0, len(r.providers))
-	for _, provider := range r.providers {
-		providerName := r.providerNames[provider]
-		if providerName == "" {
-			continue
-		}
-		providerOrderNames = append(providerOrderNames, providerName)
-	}
 	for provider, pName := range r.providerNames {
 		nameToProvider[pName] = provider
 		nameToProviderType[pName] = r.providerTypes[provider]
 	}
 	r.mu.RUnlock()
 
-	// Populate model maps from grouped cache structure. Unqualified lookups keep "first provider wins".
-	newModels := make(map[string]*ModelInfo)
+	// Populate per-provider model maps from the grouped cache structure. The
+	// bare-ID index is rebuilt from them under the duplicate model policy.
 	newModelsByProvider := make(map[string]map[string]*ModelInfo)
 	cachedProviderTypes := make(map[string]string, len(modelCache.Providers))
 	for providerName, cachedProv := range modelCache.Providers {
@@ -79,11 +71,9 @@ func (r *ModelRegistry) LoadFromCache(ctx context.Context) (int, error) {
 				Provider:     provider,
 				ProviderName: providerName,
 				ProviderType: providerType,
+				Source:       ModelSourceCache,
 			}
 			providerModels[cached.ID] = info
-			if _, exists := newModels[cached.ID]; !exists {
-				newModels[cached.ID] = info
-			}
 		}
 		newModelsByProvider[providerName] = providerModels
 	}
@@ -108,7 +98,6 @@ func (r *ModelRegistry) LoadFromCache(ctx context.Context) (int, error) {
 			newModelsByProvider[providerName] = modelInfoMapFromResponse(resp, provider, providerName, providerType)
 		}
 	}
-	newModels = rebuildGlobalModelMap(newModelsByProvider, providerOrderNames)
 
 	// Load model list data from cache if available
 	var list *modeldata.ModelList
@@ -130,8 +119,9 @@ func (r *ModelRegistry) LoadFromCache(ctx context.Context) (int, error) {
 	metadataStats.Enriched += applyConfigMetadataOverrides(configOverrides, newModelsByProvider, nil)
 
 	r.mu.Lock()
-	r.models = newModels
 	r.modelsByProvider = newModelsByProvider
+	r.rebuildModelIndexLocked()
+	loaded := len(r.models)
 	r.invalidateSortedCaches()
 	if list != nil {
 		r.modelList = list
@@ -140,13 +130,13 @@ func (r *ModelRegistry) LoadFromCache(ctx context.Context) (int, error) {
 	r.mu.Unlock()
 
 	attrs := []any{
-		"models", len(newModels),
+		"models", loaded,
 		"cache_updated_at", modelCache.UpdatedAt,
 	}
 	attrs = append(attrs, metadataStats.slogAttrs()...)
 	slog.Info("loaded models from cache", attrs...)
 
-	return len(newModels), nil
+	return loaded, nil
 }
 
 // SaveToCache saves the current model list to the cache backend.
@@ -3,6 +3,7 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -147,6 +148,43 @@ func TestCacheFile(t *testing.T) {
 		}
 	})
 
+	t.Run("LoadFromCacheSkipsStaleLocalFile", func(t *testing.T) {
+		cacheFile := filepath.Join(t.TempDir(), "models.json")
+		data, _ := json.Marshal(modelcache.ModelCache{
+			UpdatedAt: time.Now().UTC().Add(-48 * time.Hour),
+			Providers: map[string]modelcache.CachedProvider{
+				"openai": {
+					ProviderType: "openai",
+					OwnedBy:      "openai",
+					Models:       []modelcache.CachedModel{{ID: "gpt-4o"}},
+				},
+			},
+		})
+		if err := os.WriteFile(cacheFile, data, 0o644); err != nil {
+			t.Fatalf("failed to write cache file: %v", err)
+		}
+
+		registry := NewModelRegistry()
+		registry.SetCache(modelcache.NewLocalCacheWithMaxAge(cacheFile, 24*time.Hour))
+		registry.RegisterProviderWithNameAndType(&registryMockProvider{name: "openai"}, "openai", "openai")
+
+		loaded, err := registry.LoadFromCache(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loaded != 0 {
+			t.Fatalf("expected stale cache to load 0 models, got %d", loaded)
+		}
+
+		router, err := NewRouter(registry)
+		if err != nil {
+			t.Fatalf("NewRouter() error = %v", err)
+		}
+		if err := router.checkReady(); !errors.Is(err, ErrRegistryNotInitialized) {
+			t.Fatalf("checkReady() = %v, want ErrRegistryNotInitialized", err)
+		}
+	})
+
 	t.Run("LoadFromCachePreservesProviderInstancesWithSameType", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		cacheFile := filepath.Join(tmpDir, "models.json")
@@ -832,3 +870,73 @@ func TestRegisterProviderWithType(t *testing.T) {
 		t.Errorf("expected 1 provider, got %d", registry.ProviderCount())
 	}
 }
+
+func TestListModelsWithProvider_ReportsModelSource(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "models.json")
+	modelCache := modelcache.ModelCache{
+		UpdatedAt: time.Now().UTC(),
+		Providers: map[string]modelcache.CachedProvider{
+			"openai-main": {
+				ProviderType: "openai",
+				OwnedBy:      "openai",
+				Models:       []modelcache.CachedModel{{ID: "gpt-4o"}},
+			},
+			"backup": {
+				ProviderType: "openai",
+				OwnedBy:      "openai",
+				Models:       []modelcache.CachedModel{{ID: "gpt-4o"}},
+			},
+		},
+	}
+	data, _ := json.Marshal(modelCache)
+	if err := os.WriteFile(cacheFile, data, 0o644); err != nil {
+		t.Fatalf("failed to write cache file: %v", err)
+	}
+
+	registry := NewModelRegistry()
+	registry.SetCache(modelcache.NewLocalCache(cacheFile))
+	live := &registryMockProvider{
+		name: "openai",
+		modelsResponse: &core.ModelsResponse{Object: "list", Data: []core.Model{
+			{ID: "gpt-4o", Object: "model"},
+		}},
+	}
+	failing := &registryMockProvider{name: "backup", err: context.DeadlineExceeded}
+	registry.RegisterProviderWithNameAndType(live, "openai-main", "openai")
+	registry.RegisterProviderWithNameAndType(failing, "backup", "openai")
+
+	if _, err := registry.LoadFromCache(context.Background()); err != nil {
+		t.Fatalf("LoadFromCache: %v", err)
+	}
+	for _, model := range registry.ListModelsWithProvider() {
+		if model.Source != ModelSourceCache {
+			t.Fatalf("%s source = %q before refresh, want cache", model.Selector, model.Source)
+		}
+	}
+
+	_ = registry.Initialize(context.Background())
+
+	want := map[string]struct {
+		source ModelSource
+		owner  bool
+	}{
+		"openai-main/gpt-4o": {ModelSourceLive, true},
+		"backup/gpt-4o":      {ModelSourceCache, false},
+	}
+	models := registry.ListModelsWithProvider()
+	if len(models) != len(want) {
+		t.Fatalf("got %d models, want %d", len(models), len(want))
+	}
+	for _, model := range models {
+		expected, ok := want[model.Selector]
+		if !ok {
+			t.Fatalf("unexpected selector %q", model.Selector)
+		}
+		if model.Source != expected.source {
+			t.Errorf("%s source = %q, want %q", model.Selector, model.Source, expected.source)
+		}
+		if model.UnqualifiedOwner != expected.owner {
+			t.Errorf("%s unqualified_owner = %v, want %v", model.Selector, model.UnqualifiedOwner, expected.owner)
+		}
+	}
+}
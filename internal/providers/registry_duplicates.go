@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/enterpilot/gomodel/config"
+)
+
+// ErrDuplicateModelIDs reports a model inventory refused by the "error"
+// duplicate model policy because several providers expose the same bare ID.
+var ErrDuplicateModelIDs = errors.New("duplicate model IDs across providers")
+
+// maxReportedDuplicateModels bounds how many shared IDs an
+// ErrDuplicateModelIDs message spells out.
+const maxReportedDuplicateModels = 10
+
+// duplicateModelOwners returns, for every bare model ID exposed by two or more
+// providers, the providers' entries in resolution order.
+func duplicateModelOwners(modelsByProvider map[string]map[string]*ModelInfo, providerOrderNames []string) map[string][]*ModelInfo {
+	owners := make(map[string][]*ModelInfo)
+	for _, providerName := range inventoryProviderOrder(modelsByProvider, providerOrderNames) {
+		for modelID, info := range modelsByProvider[providerName] {
+			if info == nil {
+				continue
+			}
+			owners[modelID] = append(owners[modelID], info)
+		}
+	}
+	for modelID, infos := range owners {
+		if len(infos) < 2 {
+			delete(owners, modelID)
+		}
+	}
+	return owners
+}
+
+func duplicateOwnerNames(infos []*ModelInfo) []string {
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.ProviderName)
+	}
+	return names
+}
+
+// duplicateModelsError describes shared bare model IDs as an
+// ErrDuplicateModelIDs error, or returns nil when there are none.
+func duplicateModelsError(owners map[string][]*ModelInfo) error {
+	if len(owners) == 0 {
+		return nil
+	}
+	modelIDs := slices.Sorted(maps.Keys(owners))
+	parts := make([]string, 0, min(len(modelIDs), maxReportedDuplicateModels)+1)
+	for _, modelID := range modelIDs[:min(len(modelIDs), maxReportedDuplicateModels)] {
+		parts = append(parts, fmt.Sprintf("%s (%s)", modelID, strings.Join(duplicateOwnerNames(owners[modelID]), ", ")))
+	}
+	if extra := len(modelIDs) - maxReportedDuplicateModels; extra > 0 {
+		parts = append(parts, fmt.Sprintf("and %d more", extra))
+	}
+	return fmt.Errorf("%w: %s; restrict the providers' model lists or set models.duplicate_model_policy",
+		ErrDuplicateModelIDs, strings.Join(parts, "; "))
+}
+
+// checkDuplicateModelsLocked enforces the "error" duplicate model policy
+// against a freshly fetched inventory. Caller must hold r.mu.
+func (r *ModelRegistry) checkDuplicateModelsLocked(modelsByProvider map[string]map[string]*ModelInfo) error {
+	if r.duplicateModelPolicy != config.DuplicateModelPolicyError {
+		return nil
+	}
+	return duplicateModelsError(duplicateModelOwners(modelsByProvider, r.providerOrderNamesLocked()))
+}
+
+// rebuildModelIndexLocked recomputes the bare-ID model map from
+// modelsByProvider and, under the "all" duplicate model policy, the owner
+// lists unqualified routing rotates across. Stale providers never join a
+// rotation. Caller must hold r.mu for writing.
+func (r *ModelRegistry) rebuildModelIndexLocked() {
+	order := r.freshFirstProviderOrderLocked()
+	r.models = rebuildGlobalModelMap(r.modelsByProvider, order)
+	r.modelOwners = nil
+	if r.duplicateModelPolicy != config.DuplicateModelPolicyAll {
+		return
+	}
+	owners := duplicateModelOwners(r.modelsByProvider, order)
+	for modelID, infos := range owners {
+		fresh := slices.DeleteFunc(infos, func(info *ModelInfo) bool {
+			return r.providerRuntime[info.ProviderName].inventoryStale
+		})
+		if len(fresh) < 2 {
+			delete(owners, modelID)
+			continue
+		}
+		owners[modelID] = fresh
+	}
+	r.modelOwners = owners
+}
+
+// logDuplicateModelsLocked reports bare model IDs shared by several providers
+// and how the active policy resolves them. Caller must hold r.mu.
+func (r *ModelRegistry) logDuplicateModelsLocked() {
+	owners := duplicateModelOwners(r.modelsByProvider, r.freshFirstProviderOrderLocked())
+	if len(owners) == 0 {
+		return
+	}
+	slog.Info("bare model IDs shared by several providers",
+		"policy", string(r.duplicateModelPolicy),
+		"models", len(owners),
+	)
+	for _, modelID := range slices.Sorted(maps.Keys(owners)) {
+		slog.Debug("shared bare model ID",
+			"model", modelID,
+			"providers", duplicateOwnerNames(owners[modelID]),
+			"owner", r.models[modelID].ProviderName,
+		)
+	}
+}
+
+// SelectProviderName resolves a bare model ID to a configured provider
+// instance name. Under the "all" duplicate model policy, the registry's
+// Selector picks among the providers sharing the ID; otherwise it matches
+// GetProviderName.
+func (r *ModelRegistry) SelectProviderName(model string) string {
+	r.mu.RLock()
+	owners := r.modelOwners[model]
+	selector := r.selector
+	r.mu.RUnlock()
+	if len(owners) < 2 || selector == nil {
+		return r.GetProviderName(model)
+	}
+	info := selector.Select(model, owners)
+	if info == nil {
+		return r.GetProviderName(model)
+	}
+	return strings.TrimSpace(info.ProviderName)
+}
@@ -0,0 +1,178 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func newSharedModelRegistry(t *testing.T, policy config.DuplicateModelPolicy) (*ModelRegistry, *registryMockProvider, *registryMockProvider) {
+	t.Helper()
+	sharedModel := func(owner string) *core.ModelsResponse {
+		return &core.ModelsResponse{
+			Object: "list",
+			Data: []core.Model{
+				{ID: "shared-model", Object: "model", OwnedBy: owner},
+				{ID: owner + "-only", Object: "model", OwnedBy: owner},
+			},
+		}
+	}
+	registry := NewModelRegistry()
+	first := &registryMockProvider{name: "first", modelsResponse: sharedModel("first")}
+	second := &registryMockProvider{name: "second", modelsResponse: sharedModel("second")}
+	registry.RegisterProviderWithNameAndType(first, "first", "first")
+	registry.RegisterProviderWithNameAndType(second, "second", "second")
+	registry.SetDuplicateModelPolicy(policy)
+	return registry, first, second
+}
+
+func TestDuplicateModelPolicy_FirstWins(t *testing.T) {
+	registry, first, second := newSharedModelRegistry(t, config.DuplicateModelPolicyFirstWins)
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	if registry.GetProvider("shared-model") != first {
+		t.Fatal("bare model ID not owned by the first registered provider")
+	}
+	for range 3 {
+		if got := registry.SelectProviderName("shared-model"); got != "first" {
+			t.Fatalf("SelectProviderName() = %q, want first", got)
+		}
+	}
+	if registry.GetProvider("second/shared-model") != second {
+		t.Fatal("qualified model on the second provider must stay resolvable")
+	}
+}
+
+func TestDuplicateModelPolicy_Priority(t *testing.T) {
+	registry, first, second := newSharedModelRegistry(t, config.DuplicateModelPolicyPriority)
+	registry.SetProviderPriority("second", 10)
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	if registry.GetProvider("shared-model") != second {
+		t.Fatal("bare model ID not owned by the higher-priority provider")
+	}
+	models := registry.ListModels()
+	for _, model := range models {
+		if model.ID == "shared-model" && model.OwnedBy != "second" {
+			t.Fatalf("shared model object owned by %q, want second", model.OwnedBy)
+		}
+	}
+	if registry.GetProvider("first/shared-model") != first {
+		t.Fatal("qualified model on the lower-priority provider must stay resolvable")
+	}
+
+	// Equal priorities keep registration order.
+	registry.SetProviderPriority("second", 0)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("refresh Initialize() error = %v", err)
+	}
+	if registry.GetProvider("shared-model") != first {
+		t.Fatal("bare model ID with tied priorities not owned by the first registered provider")
+	}
+}
+
+func TestDuplicateModelPolicy_PriorityYieldsToPreferredProvider(t *testing.T) {
+	registry, first, _ := newSharedModelRegistry(t, config.DuplicateModelPolicyPriority)
+	registry.SetProviderPriority("second", 10)
+	registry.SetPreferredProvider("first")
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	if registry.GetProvider("shared-model") != first {
+		t.Fatal("bare model ID not owned by the preferred provider")
+	}
+}
+
+func TestDuplicateModelPolicy_All(t *testing.T) {
+	registry, first, second := newSharedModelRegistry(t, config.DuplicateModelPolicyAll)
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	counts := map[string]int{}
+	for range 4 {
+		counts[registry.SelectProviderName("shared-model")]++
+	}
+	if counts["first"] != 2 || counts["second"] != 2 {
+		t.Fatalf("SelectProviderName() spread = %v, want 2 each", counts)
+	}
+	if got := registry.SelectProviderName("second-only"); got != "second" {
+		t.Fatalf("SelectProviderName(unshared) = %q, want second", got)
+	}
+	if registry.GetProvider("first/shared-model") != first || registry.GetProvider("second/shared-model") != second {
+		t.Fatal("qualified models must stay pinned to their provider")
+	}
+
+	router, err := NewRouter(registry)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	seen := map[string]bool{}
+	for range 2 {
+		selector, ok := router.resolveUnqualifiedSelector(core.ModelSelector{Model: "shared-model"})
+		if !ok {
+			t.Fatal("resolveUnqualifiedSelector() failed for shared model")
+		}
+		seen[selector.Provider] = true
+	}
+	if !seen["first"] || !seen["second"] {
+		t.Fatalf("router resolved shared model to %v, want both providers", seen)
+	}
+}
+
+func TestDuplicateModelPolicy_AllSkipsStaleProviders(t *testing.T) {
+	registry, first, _ := newSharedModelRegistry(t, config.DuplicateModelPolicyAll)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	first.err = errors.New("connection refused")
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("refresh Initialize() error = %v", err)
+	}
+
+	for range 3 {
+		if got := registry.SelectProviderName("shared-model"); got != "second" {
+			t.Fatalf("SelectProviderName() = %q, want healthy provider second", got)
+		}
+	}
+}
+
+func TestDuplicateModelPolicy_Error(t *testing.T) {
+	registry, _, second := newSharedModelRegistry(t, config.DuplicateModelPolicyError)
+
+	err := registry.Initialize(context.Background())
+	if !errors.Is(err, ErrDuplicateModelIDs) {
+		t.Fatalf("Initialize() error = %v, want ErrDuplicateModelIDs", err)
+	}
+	if !strings.Contains(err.Error(), "shared-model (first, second)") {
+		t.Fatalf("Initialize() error = %q, want the shared ID and its providers", err)
+	}
+	if registry.ModelCount() != 0 || registry.IsInitialized() {
+		t.Fatal("registry accepted an inventory with duplicate model IDs")
+	}
+
+	second.modelsResponse = &core.ModelsResponse{
+		Object: "list",
+		Data:   []core.Model{{ID: "second-only", Object: "model", OwnedBy: "second"}},
+	}
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() after resolving the conflict error = %v", err)
+	}
+	if registry.GetProvider("shared-model") == nil {
+		t.Fatal("shared-model not registered once the conflict is resolved")
+	}
+}
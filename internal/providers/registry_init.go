@@ -59,6 +59,15 @@ func (r *ModelRegistry) initialize(ctx context.Context) error {
 		return fmt.Errorf("no models available: providers returned empty model lists")
 	}
 
+	r.mu.RLock()
+	err := r.checkDuplicateModelsLocked(fetched.modelsByProvider)
+	r.mu.RUnlock()
+	if err != nil {
+		// Keep serving the previous inventory until the conflict is resolved.
+		r.applyProviderRuntimeUpdates(fetched.runtimeUpdates)
+		return err
+	}
+
 	r.applyFetchedInventory(providerTypes, fetched, len(providers))
 	return nil
 }
@@ -88,6 +97,21 @@ type fetchedInventory struct {
 	failedProviders  int
 }
 
+// providerFetchContext gives one provider its own timeout of budget, started
+// when it is called. The parent's deadline stays a hard cap, so no provider
+// can run the sweep past its caller's deadline.
+func providerFetchContext(parent context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, budget)
+}
+
+// hasProviderInventory reports whether the registry holds any models for
+// providerName, live or loaded from the model cache.
+func (r *ModelRegistry) hasProviderInventory(providerName string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.modelsByProvider[providerName]) > 0
+}
+
 // fetchAllProviderModels runs ListModels (or applies a configured allowlist)
 // for every registered provider and aggregates the results. Network calls
 // happen outside any registry lock so live readers keep serving the previous
@@ -123,7 +147,12 @@ func (r *ModelRegistry) fetchAllProviderModels(
 	// slow upstream starve every provider after it — and a starved provider
 	// is recorded as failed, dropping its models from the registry.
 	// Aggregation below stays sequential in registration order so
-	// first-provider-wins dedup remains deterministic.
+	// first-provider-wins dedup remains deterministic. The configured refresh
+	// concurrency bounds how many of those calls are in flight at once. The
+	// sweep budget is then split across the waves of slot holders, and each
+	// provider's share starts when it gets a slot, so slow providers ahead of
+	// it in the queue cannot spend its time; the sweep deadline still caps
+	// every call.
 	type fetchResult struct {
 		resp             *core.ModelsResponse
 		configuredReason configuredProviderModelsApplyReason
@@ -131,13 +160,32 @@ func (r *ModelRegistry) fetchAllProviderModels(
 		err              error
 	}
 	results := make([]fetchResult, len(providers))
+	r.mu.RLock()
+	limit := r.refreshConcurrency
+	r.mu.RUnlock()
+	if limit <= 0 || limit > len(providers) {
+		limit = len(providers)
+	}
+	budget, bounded := time.Duration(0), false
+	if deadline, ok := ctx.Deadline(); ok && limit < len(providers) {
+		waves := (len(providers) + limit - 1) / limit
+		budget, bounded = time.Until(deadline)/time.Duration(waves), true
+	}
+	sem := make(chan struct{}, limit)
 	var wg sync.WaitGroup
 	for i, provider := range providers {
 		wg.Add(1)
 		go func(i int, provider core.Provider) {
 			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fetchCtx, cancel := ctx, context.CancelFunc(func() {})
+			if bounded {
+				fetchCtx, cancel = providerFetchContext(ctx, budget)
+			}
+			defer cancel()
 			resp, configuredReason, fetchAt, err := fetchProviderInventory(
-				ctx,
+				fetchCtx,
 				provider,
 				names[i],
 				providerTypes[provider],
@@ -156,7 +204,7 @@ func (r *ModelRegistry) fetchAllProviderModels(
 		configuredReason := results[i].configuredReason
 		fetchAt := results[i].fetchAt
 		err := results[i].err
-		var configuredUpstreamError string
+		var fallbackUpstreamError string
 		if configuredReason != configuredProviderModelsNotApplied {
 			attrs := []any{
 				"provider", providerName,
@@ -164,7 +212,7 @@ func (r *ModelRegistry) fetchAllProviderModels(
 				"configured_models", len(configuredModels),
 			}
 			if err != nil {
-				configuredUpstreamError = err.Error()
+				fallbackUpstreamError = err.Error()
 				attrs = append(attrs, "error", err)
 				slog.Warn("upstream ListModels failed, using configured provider models", attrs...)
 			} else if configuredReason == configuredProviderModelsAllowlist {
@@ -174,6 +222,20 @@ func (r *ModelRegistry) fetchAllProviderModels(
 			}
 			err = nil
 		}
+		// A provider may return a built-in model list along with a failed
+		// fetch. It is used only when the registry has nothing for the
+		// provider; otherwise the cached inventory is kept as for any failure.
+		builtIn := false
+		if err != nil && resp != nil && len(resp.Data) > 0 && !r.hasProviderInventory(providerName) {
+			slog.Warn("upstream ListModels failed, using the provider's built-in model list",
+				"provider", providerName,
+				"models", len(resp.Data),
+				"error", err,
+			)
+			fallbackUpstreamError = err.Error()
+			builtIn = true
+			err = nil
+		}
 		if err != nil {
 			slog.Warn("failed to fetch models from provider",
 				"provider", providerName,
@@ -222,7 +284,7 @@ func (r *ModelRegistry) fetchAllProviderModels(
 		runtimeUpdate := providerRuntimeState{
 			registered:          true,
 			lastModelFetchAt:    fetchAt,
-			lastModelFetchError: configuredUpstreamError,
+			lastModelFetchError: fallbackUpstreamError,
 		}
 		// Mark the inventory as authoritatively populated when this fetch is the
 		// last word on the provider's model list. That covers two cases:
@@ -233,11 +295,11 @@ func (r *ModelRegistry) fetchAllProviderModels(
 		// Fallback cases (configured*UpstreamError, *Nil, *Empty) keep
 		// lastModelFetchSuccessAt unset so health surfaces "live refresh failed,
 		// serving configured fallback".
-		if configuredReason == configuredProviderModelsNotApplied ||
-			configuredReason == configuredProviderModelsAllowlist {
+		if !builtIn && (configuredReason == configuredProviderModelsNotApplied ||
+			configuredReason == configuredProviderModelsAllowlist) {
 			runtimeUpdate.lastModelFetchSuccessAt = fetchAt
 		}
-		if configuredReason == configuredProviderModelsNotApplied {
+		if !builtIn && configuredReason == configuredProviderModelsNotApplied {
 			runtimeUpdate.lastAvailabilityCheckAt = fetchAt
 			runtimeUpdate.lastAvailabilityOKAt = fetchAt
 		}
@@ -247,23 +309,27 @@ func (r *ModelRegistry) fetchAllProviderModels(
 			out.modelsByProvider[providerName] = make(map[string]*ModelInfo, len(resp.Data))
 		}
 
+		source := ModelSourceLive
+		if configuredReason != configuredProviderModelsNotApplied {
+			source = ModelSourceConfigured
+		} else if builtIn {
+			source = ModelSourceBuiltIn
+		}
 		for _, model := range resp.Data {
 			info := &ModelInfo{
-				Model:        model,
-				Provider:     provider,
-				ProviderName: providerName,
-				ProviderType: providerTypes[provider],
+				Model:            model,
+				Provider:         provider,
+				ProviderName:     providerName,
+				ProviderType:     providerTypes[provider],
+				Source:           source,
+				ReportedMetadata: model.Metadata.Clone(),
 			}
 			out.modelsByProvider[providerName][model.ID] = info
 
 			if _, exists := out.models[model.ID]; exists {
-				// First provider wins for unqualified lookups; later duplicates
-				// stay reachable via modelsByProvider but lose the bare-id slot.
-				slog.Debug("model already registered, skipping",
-					"model", model.ID,
-					"provider", providerName,
-					"owner", model.OwnedBy,
-				)
+				// Later duplicates stay reachable via modelsByProvider; the
+				// bare-id owner is picked by the duplicate model policy when
+				// the global map is rebuilt.
 				continue
 			}
 
@@ -313,8 +379,9 @@ func (r *ModelRegistry) applyFetchedInventory(
 		state.inventoryStale = stale[name]
 		r.providerRuntime[name] = state
 	}
-	r.models = rebuildGlobalModelMap(r.modelsByProvider, r.freshFirstProviderOrderLocked())
+	r.rebuildModelIndexLocked()
 	r.invalidateSortedCaches()
+	r.logDuplicateModelsLocked()
 	r.mu.Unlock()
 
 	r.initMu.Lock()
@@ -432,6 +499,27 @@ func (r *ModelRegistry) Refresh(ctx context.Context) error {
 	return r.Initialize(ctx)
 }
 
+// ErrRefreshInProgress is returned by TryRefresh when another inventory sweep
+// is already running.
+var ErrRefreshInProgress = errors.New("model registry refresh already in progress")
+
+// TryRefresh is Refresh for on-demand callers that should not queue behind a
+// running sweep: it returns ErrRefreshInProgress immediately instead of
+// waiting for the registry.
+func (r *ModelRegistry) TryRefresh(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ch := r.refreshSemaphore()
+	select {
+	case ch <- struct{}{}:
+	default:
+		return ErrRefreshInProgress
+	}
+	defer func() { <-ch }()
+	return r.initialize(ctx)
+}
+
 func (r *ModelRegistry) acquireRefresh(ctx context.Context) (func(), error) {
 	if ctx == nil {
 		ctx = context.Background()
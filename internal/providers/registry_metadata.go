@@ -420,6 +420,9 @@ func (a *registryAccessor) GetProviderType(modelID string) string {
 
 func (a *registryAccessor) SetMetadata(modelID string, meta *core.ModelMetadata) {
 	if info, ok := a.models[modelID]; ok {
+		if info.ReportedMetadata != nil {
+			meta = modeldata.MergeMetadata(meta, info.ReportedMetadata)
+		}
 		if a.replacements != nil {
 			cloned := *info
 			cloned.Model.Metadata = meta
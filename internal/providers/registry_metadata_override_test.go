@@ -83,6 +83,69 @@ func TestInitialize_AppliesConfigMetadataOverrides(t *testing.T) {
 	}
 }
 
+// TestInitialize_ProviderReportedMetadataSurvivesEnrichment verifies that
+// metadata a provider returns from its own model list (e.g. Gemini token
+// limits) is merged over remote-registry enrichment instead of replaced by it,
+// including when the model list is re-applied later.
+func TestInitialize_ProviderReportedMetadataSurvivesEnrichment(t *testing.T) {
+	registry := NewModelRegistry()
+
+	provider := &registryMockProvider{
+		name: "provider-gemini",
+		modelsResponse: &core.ModelsResponse{
+			Object: "list",
+			Data: []core.Model{{
+				ID:      "gemini-2.5-flash",
+				Object:  "model",
+				OwnedBy: "google",
+				Metadata: &core.ModelMetadata{
+					ContextWindow:   new(1048576),
+					MaxOutputTokens: new(65536),
+				},
+			}},
+		},
+	}
+	registry.RegisterProviderWithNameAndType(provider, "gemini", "gemini")
+
+	raw := []byte(`{
+		"version": 1,
+		"updated_at": "2025-01-01T00:00:00Z",
+		"providers": {"gemini": {"display_name": "Gemini", "api_type": "gemini", "supported_modes": ["chat"]}},
+		"models": {"gemini-2.5-flash": {"display_name": "Gemini 2.5 Flash", "modes": ["chat"]}},
+		"provider_models": {"gemini/gemini-2.5-flash": {"model_ref": "gemini-2.5-flash", "enabled": true, "context_window": 1000}}
+	}`)
+	list, err := modeldata.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	registry.SetModelList(list, raw)
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	registry.EnrichModels()
+
+	var listed *core.Model
+	for _, model := range registry.ListModels() {
+		if model.ID == "gemini-2.5-flash" {
+			listed = &model
+		}
+	}
+	if listed == nil || listed.Metadata == nil {
+		t.Fatal("expected gemini-2.5-flash with metadata in ListModels")
+	}
+	meta := listed.Metadata
+	if meta.DisplayName != "Gemini 2.5 Flash" {
+		t.Errorf("DisplayName = %q, want Gemini 2.5 Flash (remote preserved)", meta.DisplayName)
+	}
+	if meta.ContextWindow == nil || *meta.ContextWindow != 1048576 {
+		t.Errorf("ContextWindow = %v, want provider-reported 1048576", meta.ContextWindow)
+	}
+	if meta.MaxOutputTokens == nil || *meta.MaxOutputTokens != 65536 {
+		t.Errorf("MaxOutputTokens = %v, want provider-reported 65536", meta.MaxOutputTokens)
+	}
+}
+
 // TestInitialize_OverrideMergesOnRemoteEnrichment verifies field-wise merging:
 // fields declared in config win; unmentioned fields fall back to whatever the
 // remote registry produced during enrichment.
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
 	"strings"
 
+	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/core"
 )
 
@@ -201,7 +203,7 @@ func (r *ModelRegistry) applyFetchedProviderInventory(providerTypes map[core.Pro
 		r.providerRuntime[providerName] = state
 	}
 	r.applyProviderRuntimeUpdatesLocked(fetched.runtimeUpdates)
-	r.models = rebuildGlobalModelMap(r.modelsByProvider, r.freshFirstProviderOrderLocked())
+	r.rebuildModelIndexLocked()
 	r.invalidateSortedCaches()
 	r.mu.Unlock()
 
@@ -218,15 +220,31 @@ func (r *ModelRegistry) applyFetchedProviderInventory(providerTypes map[core.Pro
 	slog.Info("provider models refreshed", attrs...)
 }
 
+// providerOrderNamesLocked returns provider names in registration order, with
+// the preferred provider (if any) moved to the front. Under the "priority"
+// duplicate model policy the rest are ordered by descending priority first.
 func (r *ModelRegistry) providerOrderNamesLocked() []string {
 	names := make([]string, 0, len(r.providers))
+	preferred := false
 	for _, provider := range r.providers {
 		providerName := strings.TrimSpace(r.providerNames[provider])
 		if providerName == "" {
 			continue
 		}
+		if providerName == r.preferredProvider {
+			preferred = true
+			continue
+		}
 		names = append(names, providerName)
 	}
+	if r.duplicateModelPolicy == config.DuplicateModelPolicyPriority {
+		sort.SliceStable(names, func(i, j int) bool {
+			return r.providerPriority[names[i]] > r.providerPriority[names[j]]
+		})
+	}
+	if preferred {
+		names = append([]string{r.preferredProvider}, names...)
+	}
 	return names
 }
 
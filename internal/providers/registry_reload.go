@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// ProviderRegistration describes one configured provider instance for
+// ReplaceProviders. The fields mirror what initializeProviders records through
+// the individual Register/Set calls at startup.
+type ProviderRegistration struct {
+	Name              string
+	Type              string
+	Provider          core.Provider
+	Models            []string
+	MetadataOverrides map[string]*core.ModelMetadata
+	Priority          int
+}
+
+// ReplaceProviders swaps the registered provider set for regs in one step, so
+// concurrent lookups see either the old set or the new one, never a mix.
+//
+// Models already fetched for a provider name that stays registered are kept
+// and re-pointed at its new instance until the next refresh replaces them;
+// a newly added provider has no models until then. Models and runtime state
+// of removed providers are dropped. Requests already dispatched keep the
+// provider instance they resolved, so in-flight calls are not interrupted.
+//
+// The swap waits for any running inventory sweep, so a sweep that snapshotted
+// the old providers cannot write their models back afterwards.
+func (r *ModelRegistry) ReplaceProviders(ctx context.Context, regs []ProviderRegistration) error {
+	release, err := r.acquireRefresh(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	providers := make([]core.Provider, 0, len(regs))
+	providerTypes := make(map[core.Provider]string, len(regs))
+	providerNames := make(map[core.Provider]string, len(regs))
+	instances := make(map[string]core.Provider, len(regs))
+	configuredModels := make(map[string][]string)
+	overrides := make(map[string]map[string]*core.ModelMetadata)
+	priority := make(map[string]int)
+	for _, reg := range regs {
+		name := strings.TrimSpace(reg.Name)
+		if name == "" || reg.Provider == nil {
+			continue
+		}
+		providerType := strings.TrimSpace(reg.Type)
+		providers = append(providers, reg.Provider)
+		providerTypes[reg.Provider] = providerType
+		providerNames[reg.Provider] = name
+		instances[name] = reg.Provider
+		if models := normalizeConfiguredProviderModels(reg.Models); len(models) > 0 {
+			configuredModels[name] = models
+		}
+		if len(reg.MetadataOverrides) > 0 {
+			clone := make(map[string]*core.ModelMetadata, len(reg.MetadataOverrides))
+			for k, v := range reg.MetadataOverrides {
+				clone[k] = v.Clone()
+			}
+			overrides[name] = clone
+		}
+		if reg.Priority != 0 {
+			priority[name] = reg.Priority
+		}
+	}
+
+	modelsByProvider := make(map[string]map[string]*ModelInfo, len(instances))
+	for name, models := range r.modelsByProvider {
+		provider, ok := instances[name]
+		if !ok {
+			continue
+		}
+		providerType := providerTypes[provider]
+		// ModelInfo values are shared with readers holding earlier snapshots,
+		// so re-point copies rather than mutating them in place.
+		rebound := make(map[string]*ModelInfo, len(models))
+		for id, info := range models {
+			if info.Provider != provider || info.ProviderType != providerType {
+				copied := *info
+				copied.Provider = provider
+				copied.ProviderType = providerType
+				info = &copied
+			}
+			rebound[id] = info
+		}
+		modelsByProvider[name] = rebound
+	}
+
+	runtime := make(map[string]providerRuntimeState, len(instances))
+	for name := range instances {
+		state := r.providerRuntime[name]
+		state.registered = true
+		runtime[name] = state
+	}
+
+	r.providers = providers
+	r.providerTypes = providerTypes
+	r.providerNames = providerNames
+	r.providerRuntime = runtime
+	r.modelsByProvider = modelsByProvider
+	r.configuredProviderModels = configuredModels
+	r.configMetadataOverrides = overrides
+	r.providerPriority = priority
+	r.rebuildModelIndexLocked()
+	r.invalidateSortedCaches()
+	return nil
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func reloadMockProvider(modelIDs ...string) *registryMockProvider {
+	data := make([]core.Model, 0, len(modelIDs))
+	for _, id := range modelIDs {
+		data = append(data, core.Model{ID: id, Object: "model"})
+	}
+	return &registryMockProvider{modelsResponse: &core.ModelsResponse{Object: "list", Data: data}}
+}
+
+func TestModelRegistry_ReplaceProviders(t *testing.T) {
+	registry := NewModelRegistry()
+	keptOld := reloadMockProvider("gpt-4o")
+	removed := reloadMockProvider("claude-sonnet")
+	registry.RegisterProviderWithNameAndType(keptOld, "openai", "openai")
+	registry.RegisterProviderWithNameAndType(removed, "anthropic", "anthropic")
+	registry.SetProviderPriority("anthropic", 5)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	oldInfo := registry.GetModel("gpt-4o")
+	if oldInfo == nil {
+		t.Fatal("GetModel(gpt-4o) = nil before replace")
+	}
+
+	keptNew := reloadMockProvider("gpt-4o", "gpt-4.1")
+	added := reloadMockProvider("llama-3")
+	err := registry.ReplaceProviders(context.Background(), []ProviderRegistration{
+		{Name: "openai", Type: "openai", Provider: keptNew},
+		{Name: "groq", Type: "groq", Provider: added, Models: []string{"llama-3"}},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceProviders() error = %v", err)
+	}
+
+	if got := registry.ProviderNames(); len(got) != 2 || got[0] != "openai" || got[1] != "groq" {
+		t.Fatalf("ProviderNames() = %v, want [openai groq]", got)
+	}
+	if got := registry.ProviderByName("anthropic"); got != nil {
+		t.Error("removed provider is still registered")
+	}
+	if registry.Supports("claude-sonnet") {
+		t.Error("models of a removed provider still resolve")
+	}
+	if got := registry.GetProvider("gpt-4o"); got != keptNew {
+		t.Error("kept model does not route to the new provider instance")
+	}
+	if oldInfo.Provider != keptOld {
+		t.Error("ReplaceProviders mutated a ModelInfo shared with earlier readers")
+	}
+	if registry.providerPriority["anthropic"] != 0 {
+		t.Error("priority of a removed provider was kept")
+	}
+
+	if err := registry.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	for _, model := range []string{"gpt-4.1", "llama-3", "groq/llama-3"} {
+		if !registry.Supports(model) {
+			t.Errorf("Supports(%q) = false after refresh", model)
+		}
+	}
+}
+
+func TestModelRegistry_ReplaceProvidersHonorsContext(t *testing.T) {
+	registry := NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(reloadMockProvider("gpt-4o"), "openai", "openai")
+
+	release, err := registry.acquireRefresh(context.Background())
+	if err != nil {
+		t.Fatalf("acquireRefresh() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = registry.ReplaceProviders(ctx, []ProviderRegistration{
+		{Name: "groq", Type: "groq", Provider: reloadMockProvider("llama-3")},
+	})
+	if err == nil {
+		t.Fatal("ReplaceProviders() error = nil while a refresh holds the registry")
+	}
+	if got := registry.ProviderNames(); len(got) != 1 || got[0] != "openai" {
+		t.Fatalf("ProviderNames() = %v, want the original provider kept", got)
+	}
+}
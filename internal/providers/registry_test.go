@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"time"
 
 	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/concurrencytest"
 	"github.com/enterpilot/gomodel/internal/core"
 	"github.com/enterpilot/gomodel/internal/modeldata"
 )
@@ -26,6 +28,7 @@ type registryMockProvider struct {
 	responsesResponse *core.ResponsesResponse
 	modelsResponse    *core.ModelsResponse
 	err               error
+	fallbackModels    *core.ModelsResponse
 	listModelsDelay   time.Duration
 	listModelsStarted chan struct{}
 	listModelsBlocked chan struct{}
@@ -70,7 +73,7 @@ func (m *registryMockProvider) ListModels(ctx context.Context) (*core.ModelsResp
 		}
 	}
 	if m.err != nil {
-		return nil, m.err
+		return m.fallbackModels, m.err
 	}
 	return m.modelsResponse, nil
 }
@@ -1152,6 +1155,70 @@ func TestInitialize_FailedRefreshKeepsPreviousInventoryAsStale(t *testing.T) {
 	}
 }
 
+func TestInitialize_UsesBuiltInModelsOnlyWhenNothingCached(t *testing.T) {
+	registry := NewModelRegistry()
+	offline := &registryMockProvider{
+		name: "offline",
+		err:  errors.New("dial tcp: connection refused"),
+		fallbackModels: &core.ModelsResponse{
+			Object: "list",
+			Data:   []core.Model{{ID: "built-in-model", Object: "model", OwnedBy: "offline"}},
+		},
+	}
+	steady := &registryMockProvider{
+		name: "steady",
+		modelsResponse: &core.ModelsResponse{
+			Object: "list",
+			Data:   []core.Model{{ID: "steady-model", Object: "model", OwnedBy: "steady"}},
+		},
+	}
+	registry.RegisterProviderWithNameAndType(offline, "offline", "offline")
+	registry.RegisterProviderWithNameAndType(steady, "steady", "steady")
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	info := registry.GetModel("offline/built-in-model")
+	if info == nil {
+		t.Fatal("built-in model not registered when nothing was cached")
+	}
+	if info.Source != ModelSourceBuiltIn {
+		t.Fatalf("Source = %q, want %q", info.Source, ModelSourceBuiltIn)
+	}
+	var snapshot ProviderRuntimeSnapshot
+	for _, s := range registry.ProviderRuntimeSnapshots() {
+		if s.Name == "offline" {
+			snapshot = s
+		}
+	}
+	if snapshot.LastModelFetchError == "" {
+		t.Fatal("LastModelFetchError empty, want the fetch failure recorded")
+	}
+	if snapshot.LastModelFetchSuccessAt != nil {
+		t.Fatal("LastModelFetchSuccessAt set, want unset for a built-in list")
+	}
+
+	offline.err = nil
+	offline.modelsResponse = &core.ModelsResponse{
+		Object: "list",
+		Data:   []core.Model{{ID: "live-model", Object: "model", OwnedBy: "offline"}},
+	}
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("live Initialize() error = %v", err)
+	}
+
+	offline.err = errors.New("dial tcp: connection refused")
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("refresh Initialize() error = %v", err)
+	}
+	if registry.GetModel("offline/live-model") == nil {
+		t.Fatal("live inventory replaced by the built-in list, want it kept")
+	}
+	if registry.GetModel("offline/built-in-model") != nil {
+		t.Fatal("built-in model registered over a cached inventory")
+	}
+}
+
 // When several providers serve the same bare model ID, a stale provider loses
 // the unqualified slot to a healthy duplicate — the same routing the old
 // inventory wipe produced — while staying reachable via its qualified name.
@@ -1191,6 +1258,44 @@ func TestInitialize_StaleProviderLosesBareModelIDToHealthyDuplicate(t *testing.T
 	}
 }
 
+func TestInitialize_PreferredProviderOwnsSharedModelID(t *testing.T) {
+	registry := NewModelRegistry()
+	sharedModel := func(owner string, contextWindow int) *core.ModelsResponse {
+		return &core.ModelsResponse{
+			Object: "list",
+			Data: []core.Model{{
+				ID:       "shared-model",
+				Object:   "model",
+				OwnedBy:  owner,
+				Metadata: &core.ModelMetadata{ContextWindow: &contextWindow},
+			}},
+		}
+	}
+	first := &registryMockProvider{name: "first", modelsResponse: sharedModel("first", 8192)}
+	second := &registryMockProvider{name: "second", modelsResponse: sharedModel("second", 128000)}
+	registry.RegisterProviderWithNameAndType(first, "first", "first")
+	registry.RegisterProviderWithNameAndType(second, "second", "second")
+	registry.SetPreferredProvider("second")
+
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	if registry.GetProvider("shared-model") != second {
+		t.Fatal("bare model ID not owned by preferred provider")
+	}
+	models := registry.ListModels()
+	if len(models) != 1 || models[0].OwnedBy != "second" {
+		t.Fatalf("ListModels() = %+v, want the preferred provider's model object", models)
+	}
+	if got := models[0].Metadata.ContextWindow; got == nil || *got != 128000 {
+		t.Fatalf("context window = %v, want preferred provider metadata", got)
+	}
+	if registry.GetProvider("first/shared-model") != first {
+		t.Fatal("qualified model on non-preferred provider must stay resolvable")
+	}
+}
+
 // The fast recheck loop re-probes only providers whose latest refresh failed,
 // so a recovered provider is picked up within the recheck interval instead of
 // waiting for the next full refresh.
@@ -1458,6 +1563,130 @@ func TestInitialize_SlowProviderDoesNotStarveOthers(t *testing.T) {
 	}
 }
 
+// inFlightListProvider records the peak number of concurrent ListModels calls
+// across every provider sharing its gauge.
+type inFlightListProvider struct {
+	*registryMockProvider
+	calls *concurrencytest.Gauge
+}
+
+func (p *inFlightListProvider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
+	defer p.calls.Enter()()
+	return p.registryMockProvider.ListModels(ctx)
+}
+
+func TestInitialize_RefreshConcurrencyBoundsListModels(t *testing.T) {
+	const providerCount = 24
+	tests := []struct {
+		name     string
+		limit    int
+		wantPeak func(peak int32) bool
+	}{
+		{name: "bounded", limit: 3, wantPeak: func(peak int32) bool { return peak >= 2 && peak <= 3 }},
+		{name: "unbounded", limit: 0, wantPeak: func(peak int32) bool { return peak > 3 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewModelRegistry()
+			registry.SetRefreshConcurrency(tt.limit)
+			var calls concurrencytest.Gauge
+			for i := range providerCount {
+				name := fmt.Sprintf("provider-%d", i)
+				registry.RegisterProviderWithNameAndType(&inFlightListProvider{
+					registryMockProvider: &registryMockProvider{
+						name:            name,
+						listModelsDelay: 20 * time.Millisecond,
+						modelsResponse: &core.ModelsResponse{
+							Object: "list",
+							Data:   []core.Model{{ID: name + "-model", Object: "model", OwnedBy: name}},
+						},
+					},
+					calls: &calls,
+				}, name, "test")
+			}
+
+			if err := registry.Initialize(context.Background()); err != nil {
+				t.Fatalf("Initialize() error = %v", err)
+			}
+			if got := registry.ModelCount(); got != providerCount {
+				t.Fatalf("ModelCount() = %d, want %d", got, providerCount)
+			}
+			if peak := calls.Peak(); !tt.wantPeak(peak) {
+				t.Fatalf("peak concurrent ListModels calls = %d with limit %d", peak, tt.limit)
+			}
+		})
+	}
+}
+
+// firstCallerBlocksProvider stalls the first ListModels call across every
+// provider sharing calls until its context expires; later calls answer at
+// once unless their context is already done. Whichever provider takes the
+// refresh slot first is therefore the slow one, whatever the scheduling order.
+type firstCallerBlocksProvider struct {
+	*registryMockProvider
+	calls *atomic.Int32
+}
+
+func (p *firstCallerBlocksProvider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
+	if p.calls.Add(1) == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.registryMockProvider.ListModels(ctx)
+}
+
+func TestInitialize_QueuedProviderGetsBudgetShareAfterSlowSlotHolder(t *testing.T) {
+	registry := NewModelRegistry()
+	registry.SetRefreshConcurrency(1)
+	var calls atomic.Int32
+	for _, name := range []string{"first", "second"} {
+		registry.RegisterProviderWithNameAndType(&firstCallerBlocksProvider{
+			registryMockProvider: &registryMockProvider{
+				name: name,
+				modelsResponse: &core.ModelsResponse{
+					Object: "list",
+					Data:   []core.Model{{ID: name + "-model", Object: "model", OwnedBy: name}},
+				},
+			},
+			calls: &calls,
+		}, name, "test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v, want nil (queued provider succeeded)", err)
+	}
+	if got := registry.ModelCount(); got != 1 {
+		t.Fatalf("ModelCount() = %d, want 1: the queued provider must not inherit the slot holder's spent budget", got)
+	}
+}
+
+func TestInitialize_SlowProvidersCannotOutlastCallerDeadline(t *testing.T) {
+	registry := NewModelRegistry()
+	registry.SetRefreshConcurrency(1)
+	for _, name := range []string{"first", "second", "third"} {
+		registry.RegisterProviderWithNameAndType(&registryMockProvider{
+			name:            name,
+			listModelsDelay: time.Hour,
+		}, name, "test")
+	}
+
+	const deadline = 150 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	start := time.Now()
+	_ = registry.Initialize(ctx)
+	if elapsed := time.Since(start); elapsed > deadline+100*time.Millisecond {
+		t.Fatalf("Initialize() took %v, want it bounded by the caller's %v deadline", elapsed, deadline)
+	}
+}
+
 func TestInitialize_LogsSingleMetadataSummaryPerCycle(t *testing.T) {
 	registry := NewModelRegistry()
 
@@ -2420,3 +2649,27 @@ func TestProviderByTypeAndNameTrimConfiguredValues(t *testing.T) {
 		t.Fatalf("GetProviderNameForType(openai) = %q, want %q", got, "padded-name")
 	}
 }
+
+type circuitReportingMockProvider struct {
+	registryMockProvider
+	state string
+}
+
+func (m *circuitReportingMockProvider) CircuitState() string { return m.state }
+
+func TestProviderRuntimeSnapshots_ReportCircuitState(t *testing.T) {
+	registry := NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(&circuitReportingMockProvider{state: "open"}, "tripped", "openai")
+	registry.RegisterProviderWithNameAndType(&registryMockProvider{}, "plain", "mock")
+
+	got := make(map[string]string)
+	for _, snapshot := range registry.ProviderRuntimeSnapshots() {
+		got[snapshot.Name] = snapshot.CircuitState
+	}
+	if got["tripped"] != "open" {
+		t.Errorf("tripped CircuitState = %q, want open", got["tripped"])
+	}
+	if state, ok := got["plain"]; !ok || state != "" {
+		t.Errorf("plain CircuitState = %q (present=%v), want empty", state, ok)
+	}
+}
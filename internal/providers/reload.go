@@ -0,0 +1,162 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+)
+
+// ProviderReload reports how Reload changed the registered provider set.
+// Each list holds configured provider instance names in sorted order.
+type ProviderReload struct {
+	Added   []string
+	Removed []string
+	Updated []string
+}
+
+// Changed reports whether the reload added, removed, or updated any provider.
+func (r ProviderReload) Changed() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0 || len(r.Updated) > 0
+}
+
+// Reload rebuilds the provider set from a freshly loaded configuration and
+// swaps it into the registry and router without a restart.
+//
+// Providers whose resolved config is unchanged keep their existing instance;
+// added and updated ones are created through the factory. A provider that
+// fails to build keeps its previous instance, if any, and is otherwise left
+// out. When no provider can be registered the current set stays in effect
+// and an error is returned.
+//
+// Only provider-level settings are reloaded: credentials, base URLs, models,
+// metadata overrides, priorities, weights, default params, and the routing
+// strategy. Other configuration, including the model cache backend and the
+// duplicate model policy, still requires a restart. The registry keeps
+// serving the previous inventory for kept providers; callers should refresh
+// it afterwards so added providers get their models.
+//
+// Replaced and removed instances are handed to Factory.Release once the new
+// set is in place. ConfiguredProviders and CredentialResolvedProviders keep
+// their startup values.
+func (r *InitResult) Reload(ctx context.Context, result *config.LoadResult) (ProviderReload, error) {
+	if result == nil || result.Config == nil {
+		return ProviderReload{}, fmt.Errorf("load result is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.ValidateProviderTypes(result.RawProviders, r.Factory.RegisteredTypes()); err != nil {
+		return ProviderReload{}, fmt.Errorf("invalid provider config: %w", err)
+	}
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	discovery := r.Factory.discoveryConfigsSnapshot()
+	providerMap, _ := resolveProviders(result.RawProviders, result.Config.Resilience, discovery)
+
+	names := make([]string, 0, len(providerMap))
+	for name := range providerMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diff ProviderReload
+	regs := make([]ProviderRegistration, 0, len(names))
+	registered := make(map[string]ProviderConfig, len(names))
+	for _, name := range names {
+		pCfg := providerMap[name]
+		prevCfg := r.providerConfigs[name]
+		current := r.Registry.ProviderByName(name)
+
+		provider := current
+		if current == nil || !reflect.DeepEqual(prevCfg, pCfg) {
+			p, err := r.Factory.Create(pCfg)
+			switch {
+			case err == nil:
+				provider = p
+			case current != nil:
+				slog.Error("failed to rebuild provider; keeping previous instance",
+					"name", name,
+					"type", pCfg.Type,
+					"error", err)
+				pCfg = prevCfg
+			default:
+				slog.Error("failed to initialize provider",
+					"name", name,
+					"type", pCfg.Type,
+					"error", err)
+				continue
+			}
+		}
+
+		switch {
+		case current == nil:
+			diff.Added = append(diff.Added, name)
+		case provider != current:
+			diff.Updated = append(diff.Updated, name)
+		}
+		registered[name] = pCfg
+		regs = append(regs, ProviderRegistration{
+			Name:              name,
+			Type:              pCfg.Type,
+			Provider:          provider,
+			Models:            pCfg.Models,
+			MetadataOverrides: pCfg.ModelMetadataOverrides,
+			Priority:          pCfg.Priority,
+		})
+	}
+	if len(regs) == 0 {
+		return ProviderReload{}, fmt.Errorf("no providers were successfully registered; keeping current providers")
+	}
+	for _, name := range r.Registry.ProviderNames() {
+		if _, ok := registered[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	sort.Strings(diff.Removed)
+
+	selector, err := NewSelector(result.Config.Routing.Strategy, providerWeights(registered))
+	if err != nil {
+		return ProviderReload{}, fmt.Errorf("routing.strategy: %w", err)
+	}
+
+	var created, retired []core.Provider
+	for _, reg := range regs {
+		if current := r.Registry.ProviderByName(reg.Name); reg.Provider != current {
+			created = append(created, reg.Provider)
+			if current != nil {
+				retired = append(retired, current)
+			}
+		}
+	}
+	for _, name := range diff.Removed {
+		retired = append(retired, r.Registry.ProviderByName(name))
+	}
+
+	// The new allow-list goes in before the swap so added providers are never
+	// served without their hosts, and the previous one comes back if the swap
+	// fails.
+	prevHosts := llmclient.AllowedUpstreamHosts()
+	llmclient.SetAllowedUpstreamHosts(llmclient.NewHostAllowList(
+		upstreamHostPatterns(result.Config.HTTP.AllowedUpstreamHosts, registered, discovery)))
+	if err := r.Registry.ReplaceProviders(ctx, regs); err != nil {
+		llmclient.SetAllowedUpstreamHosts(prevHosts)
+		for _, p := range created {
+			r.Factory.Release(p)
+		}
+		return ProviderReload{}, err
+	}
+	r.Registry.SetSelector(selector)
+	r.Router.SetDefaultParams(registered)
+	r.providerConfigs = registered
+	for _, p := range retired {
+		r.Factory.Release(p)
+	}
+	return diff, nil
+}
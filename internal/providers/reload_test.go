@@ -0,0 +1,182 @@
+package providers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/llmclient"
+)
+
+func reloadLoadResult(t *testing.T, raw map[string]config.RawProviderConfig) *config.LoadResult {
+	t.Helper()
+	return &config.LoadResult{
+		Config: &config.Config{
+			Cache: config.CacheConfig{
+				Model: config.ModelCacheConfig{
+					RefreshInterval: 3600,
+					Local:           &config.LocalCacheConfig{CacheDir: t.TempDir()},
+				},
+			},
+		},
+		RawProviders: raw,
+	}
+}
+
+func TestInitResultReload_SwapsProviderSet(t *testing.T) {
+	created := make(map[string]int)
+	factory := NewProviderFactory()
+	factory.Add(Registration{
+		Type: "test",
+		New: func(cfg ProviderConfig, _ ProviderOptions) core.Provider {
+			created[cfg.APIKey]++
+			return &initTestProvider{}
+		},
+	})
+
+	result, err := Init(context.Background(), reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"keep":   {Type: "test", APIKey: "sk-keep"},
+		"update": {Type: "test", APIKey: "sk-old"},
+		"remove": {Type: "test", APIKey: "sk-remove"},
+	}), factory)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = result.Close()
+		llmclient.SetAllowedUpstreamHosts(nil)
+	})
+	kept := result.Registry.ProviderByName("keep")
+
+	diff, err := result.Reload(context.Background(), reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"keep":   {Type: "test", APIKey: "sk-keep"},
+		"update": {Type: "test", APIKey: "sk-new"},
+		"add":    {Type: "test", APIKey: "sk-add"},
+	}))
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	want := ProviderReload{Added: []string{"add"}, Removed: []string{"remove"}, Updated: []string{"update"}}
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("Reload() = %+v, want %+v", diff, want)
+	}
+	if got := result.Registry.ProviderNames(); !reflect.DeepEqual(got, []string{"add", "keep", "update"}) {
+		t.Fatalf("ProviderNames() = %v, want [add keep update]", got)
+	}
+	if result.Registry.ProviderByName("keep") != kept {
+		t.Error("unchanged provider was rebuilt")
+	}
+	if created["sk-keep"] != 1 {
+		t.Errorf("unchanged provider created %d times, want 1", created["sk-keep"])
+	}
+	if created["sk-new"] != 1 || created["sk-add"] != 1 {
+		t.Errorf("created = %v, want updated and added providers built once", created)
+	}
+}
+
+func TestInitResultReload_KeepsProvidersWhenNoneResolve(t *testing.T) {
+	factory := NewProviderFactory()
+	factory.Add(Registration{
+		Type: "test",
+		New: func(ProviderConfig, ProviderOptions) core.Provider {
+			return &initTestProvider{}
+		},
+	})
+
+	result, err := Init(context.Background(), reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"test": {Type: "test", APIKey: "sk-test"},
+	}), factory)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = result.Close()
+		llmclient.SetAllowedUpstreamHosts(nil)
+	})
+
+	if _, err := result.Reload(context.Background(), reloadLoadResult(t, nil)); err == nil {
+		t.Fatal("Reload() error = nil, want error for an empty provider set")
+	}
+	if got := result.Registry.ProviderNames(); !reflect.DeepEqual(got, []string{"test"}) {
+		t.Fatalf("ProviderNames() = %v, want the current provider kept", got)
+	}
+}
+
+func TestInitResultReload_RestoresUpstreamHostsWhenSwapFails(t *testing.T) {
+	factory := NewProviderFactory()
+	factory.Add(Registration{
+		Type: "test",
+		New: func(ProviderConfig, ProviderOptions) core.Provider {
+			return &initTestProvider{}
+		},
+	})
+
+	result, err := Init(context.Background(), reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"test": {Type: "test", APIKey: "sk-test", BaseURL: "https://old.example.com"},
+	}), factory)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = result.Close()
+		llmclient.SetAllowedUpstreamHosts(nil)
+	})
+	before := llmclient.AllowedUpstreamHosts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = result.Reload(ctx, reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"test": {Type: "test", APIKey: "sk-test", BaseURL: "https://new.example.com"},
+	}))
+	if err == nil {
+		t.Fatal("Reload() error = nil, want the canceled swap to fail")
+	}
+	if got := llmclient.AllowedUpstreamHosts(); got != before {
+		t.Fatal("upstream host allow-list was replaced by a reload that failed")
+	}
+}
+
+func TestInitResultReload_ReleasesReplacedProviders(t *testing.T) {
+	factory := NewProviderFactory()
+	factory.Add(Registration{
+		Type: "test",
+		New: func(ProviderConfig, ProviderOptions) core.Provider {
+			return &initTestProvider{}
+		},
+	})
+
+	result, err := Init(context.Background(), reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"update": {Type: "test", APIKey: "sk-old", Proxy: "http://proxy.example.com:3128"},
+		"remove": {Type: "test", APIKey: "sk-remove", Proxy: "http://proxy.example.com:3128"},
+	}), factory)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = result.Close()
+		llmclient.SetAllowedUpstreamHosts(nil)
+	})
+	replaced := result.Registry.ProviderByName("update")
+	removed := result.Registry.ProviderByName("remove")
+
+	if _, err := result.Reload(context.Background(), reloadLoadResult(t, map[string]config.RawProviderConfig{
+		"update": {Type: "test", APIKey: "sk-new", Proxy: "http://proxy.example.com:3128"},
+	})); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	factory.mu.RLock()
+	defer factory.mu.RUnlock()
+	if _, ok := factory.proxyClients[replaced]; ok {
+		t.Error("replaced provider's proxy client was not released")
+	}
+	if _, ok := factory.proxyClients[removed]; ok {
+		t.Error("removed provider's proxy client was not released")
+	}
+	if _, ok := factory.proxyClients[result.Registry.ProviderByName("update")]; !ok {
+		t.Error("new provider's proxy client is not tracked")
+	}
+}
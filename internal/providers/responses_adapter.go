@@ -22,7 +22,8 @@ type ChatProvider interface {
 
 // ConvertResponsesRequestToChat converts a ResponsesRequest to a ChatRequest.
 // It also validates the supported Responses input shapes and returns an error
-// when the request cannot be converted safely.
+// when the request cannot be converted safely. Include is dropped: it only asks
+// for optional extra output, which Chat Completions has no way to return.
 func ConvertResponsesRequestToChat(req *core.ResponsesRequest) (*core.ChatRequest, error) {
 	if req == nil {
 		return nil, core.NewInvalidRequestError("responses request is required", nil)
@@ -83,9 +84,6 @@ func validateResponsesRequestForChatTranslation(req *core.ResponsesRequest) erro
 	if req.Conversation != nil {
 		return unsupportedResponsesChatTranslationField("conversation")
 	}
-	if len(req.Include) > 0 {
-		return unsupportedResponsesChatTranslationField("include")
-	}
 	if req.Prompt != nil {
 		return unsupportedResponsesChatTranslationField("prompt")
 	}
@@ -414,6 +414,26 @@ func TestConvertResponsesRequestToChat_MapsPortableAgentsSDKFields(t *testing.T)
 	}
 }
 
+func TestConvertResponsesRequestToChat_DropsInclude(t *testing.T) {
+	req := &core.ResponsesRequest{
+		Model:   "test-model",
+		Input:   "Hello",
+		Include: []string{"reasoning.encrypted_content", "message.output_text.logprobs"},
+	}
+
+	chatReq, err := ConvertResponsesRequestToChat(req)
+	if err != nil {
+		t.Fatalf("ConvertResponsesRequestToChat() error = %v", err)
+	}
+	body, err := json.Marshal(chatReq)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(body), "include") {
+		t.Fatalf("converted chat request must not carry include, got %s", body)
+	}
+}
+
 func TestConvertResponsesRequestToChat_NormalizesToolChoiceAliases(t *testing.T) {
 	tests := []struct {
 		name string
@@ -774,6 +794,47 @@ func TestConvertResponsesRequestToChat_PreservesOpaqueExtras(t *testing.T) {
 	}
 }
 
+func TestConvertResponsesRequestToChat_PreservesStop(t *testing.T) {
+	chatReq, err := ConvertResponsesRequestToChat(&core.ResponsesRequest{
+		Model: "test-model",
+		Input: "hi",
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"stop": json.RawMessage(`["END"]`),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("ConvertResponsesRequestToChat() error = %v", err)
+	}
+	if got := chatReq.StopSequences(); len(got) != 1 || got[0] != "END" {
+		t.Fatalf("StopSequences() = %v, want [END]", got)
+	}
+}
+
+func TestConvertResponsesRequestToChat_PreservesSamplingParams(t *testing.T) {
+	topP := 0.9
+	chatReq, err := ConvertResponsesRequestToChat(&core.ResponsesRequest{
+		Model: "test-model",
+		Input: "hi",
+		TopP:  &topP,
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{
+			"frequency_penalty": json.RawMessage("0.5"),
+			"presence_penalty":  json.RawMessage("0.25"),
+			"seed":              json.RawMessage("42"),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("ConvertResponsesRequestToChat() error = %v", err)
+	}
+	if chatReq.TopP == nil || *chatReq.TopP != 0.9 {
+		t.Fatalf("TopP = %v, want 0.9", chatReq.TopP)
+	}
+	for _, field := range []string{"frequency_penalty", "presence_penalty", "seed"} {
+		if chatReq.ExtraFields.Lookup(field) == nil {
+			t.Errorf("%s missing from chat request extras", field)
+		}
+	}
+}
+
 func TestConvertResponsesRequestToChat_PreservesUnknownMapFields(t *testing.T) {
 	req := &core.ResponsesRequest{
 		Model: "test-model",
@@ -322,3 +322,65 @@ data: [DONE]
 	}
 	t.Fatal("expected response.completed event")
 }
+
+func TestOpenAIResponsesStreamConverter_InterleavedParallelToolCalls(t *testing.T) {
+	// No finish_reason: pending calls must still be closed before response.completed.
+	mockStream := `data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_a","type":"function","function":{"name":"get_time","arguments":"{\"tz\":"}},{"index":1,"id":"call_b","type":"function","function":{"name":"get_weather","arguments":"{\"city\":"}}]},"finish_reason":null}]}
+
+data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"function":{"arguments":"\"Oslo\"}"}}]},"finish_reason":null}]}
+
+data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"UTC\"}"}}]},"finish_reason":null}]}
+
+data: [DONE]
+`
+
+	converter := NewOpenAIResponsesStreamConverter(io.NopCloser(strings.NewReader(mockStream)), "test-model", "groq")
+	raw, err := io.ReadAll(converter)
+	if err != nil {
+		t.Fatalf("failed to read from converter: %v", err)
+	}
+
+	itemIDs := map[string]float64{}
+	deltas := map[float64]string{}
+	done := map[float64]string{}
+	completedSeen := false
+	for _, event := range parseTestSSEEvents(t, string(raw)) {
+		if event.Done {
+			continue
+		}
+		switch event.Name {
+		case "response.output_item.added":
+			item, _ := event.Payload["item"].(map[string]any)
+			if item["type"] == "function_call" {
+				itemIDs[item["call_id"].(string)] = event.Payload["output_index"].(float64)
+			}
+		case "response.function_call_arguments.delta":
+			deltas[event.Payload["output_index"].(float64)] += event.Payload["delta"].(string)
+		case "response.function_call_arguments.done":
+			if completedSeen {
+				t.Fatal("function_call_arguments.done emitted after response.completed")
+			}
+			done[event.Payload["output_index"].(float64)] = event.Payload["arguments"].(string)
+		case "response.completed":
+			completedSeen = true
+		}
+	}
+
+	indexA, okA := itemIDs["call_a"]
+	indexB, okB := itemIDs["call_b"]
+	if !okA || !okB || indexA == indexB {
+		t.Fatalf("output_item.added call indexes = %#v, want distinct entries for call_a and call_b", itemIDs)
+	}
+	want := map[float64]string{indexA: `{"tz":"UTC"}`, indexB: `{"city":"Oslo"}`}
+	for index, args := range want {
+		if deltas[index] != args {
+			t.Fatalf("assembled deltas for output_index %v = %q, want %q", index, deltas[index], args)
+		}
+		if done[index] != args {
+			t.Fatalf("function_call_arguments.done for output_index %v = %q, want %q", index, done[index], args)
+		}
+	}
+	if !completedSeen {
+		t.Fatal("expected response.completed event")
+	}
+}
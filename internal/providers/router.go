@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/enterpilot/gomodel/internal/core"
 )
@@ -22,6 +23,13 @@ var ErrRegistryNotInitialized = fmt.Errorf("model registry has no models: ensure
 // by fetching available models from each provider's /models endpoint.
 type Router struct {
 	lookup core.ModelLookup
+	// defaultParams maps provider name to its default_params; see
+	// SetDefaultParams. It is swapped atomically on provider reload.
+	defaultParams atomic.Pointer[map[string]providerDefaults]
+	// samplingPolicy clamps temperature and top_p; see SetSamplingPolicy.
+	samplingPolicy core.SamplingPolicy
+	// streamingFallback serves streams for non-streaming models; see SetStreamingFallback.
+	streamingFallback bool
 }
 
 type providerTypeRegistry interface {
@@ -60,6 +68,16 @@ type qualifiedSelectorResolver interface {
 	ResolveProviderSelector(segment, modelID string) (core.ModelSelector, bool)
 }
 
+// providerNameSelector is an optional lookup extension that picks among the
+// providers sharing a bare model ID using the configured routing strategy.
+type providerNameSelector interface {
+	SelectProviderName(model string) string
+}
+
+type concreteModelLookup interface {
+	LookupModel(model string) (*core.Model, bool)
+}
+
 type providerModelRefresher interface {
 	RefreshProviderModels(ctx context.Context, providerSelector string) (int, error)
 }
@@ -124,7 +142,12 @@ func (r *Router) resolveUnqualifiedSelector(selector core.ModelSelector) (core.M
 	if selector.Provider != "" || strings.TrimSpace(selector.Model) == "" {
 		return core.ModelSelector{}, false
 	}
-	providerName := strings.TrimSpace(r.lookup.GetProviderName(selector.Model))
+	var providerName string
+	if namer, ok := r.lookup.(providerNameSelector); ok {
+		providerName = strings.TrimSpace(namer.SelectProviderName(selector.Model))
+	} else {
+		providerName = strings.TrimSpace(r.lookup.GetProviderName(selector.Model))
+	}
 	if providerName == "" {
 		return core.ModelSelector{}, false
 	}
@@ -278,8 +301,10 @@ func (r *Router) resolveProvider(ctx context.Context, model, providerHint string
 			p = r.lookup.GetProvider(lookupModel)
 		}
 	}
+	// Request resolution rejects unknown models earlier with the configured
+	// status; a model that disappears before dispatch keeps the router's 404.
 	if p == nil {
-		return nil, core.ModelSelector{}, core.NewNotFoundError("model not found: " + lookupModel)
+		return nil, core.ModelSelector{}, core.NewInvalidRequestErrorWithStatus(http.StatusNotFound, "model not found: "+lookupModel, nil).WithCode("model_not_found")
 	}
 	return p, selector, nil
 }
@@ -334,6 +359,20 @@ func (r *Router) hasRegisteredProviderSelector(providerSelector string) bool {
 	return r.providerByTypeRegistry(providerSelector) != nil
 }
 
+// modelMetadata returns the registry metadata of the model a selector
+// resolves to, or nil when the lookup cannot provide it.
+func (r *Router) modelMetadata(model string) *core.ModelMetadata {
+	lookup, ok := r.lookup.(concreteModelLookup)
+	if !ok {
+		return nil
+	}
+	m, found := lookup.LookupModel(model)
+	if !found || m == nil {
+		return nil
+	}
+	return m.Metadata
+}
+
 func (r *Router) resolveProviderType(providerType string) (core.Provider, error) {
 	if err := r.ensureProviderInventoryReady(); err != nil {
 		return nil, err
@@ -622,24 +661,31 @@ func (r *Router) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*co
 		req.Model,
 		req.Provider,
 		func(selector core.ModelSelector) *core.ChatRequest {
-			return forwardChatRequest(req, selector)
+			return r.forwardChatRequest(ctx, req, selector)
 		},
 		callChatCompletion,
 	)
 }
 
 // StreamChatCompletion routes the streaming request to the appropriate provider.
+// With SetStreamingFallback enabled, models that cannot stream are served by a
+// non-streaming call wrapped as a single-chunk SSE stream.
 // Returns ErrRegistryNotInitialized if the lookup has no models loaded.
 func (r *Router) StreamChatCompletion(ctx context.Context, req *core.ChatRequest) (io.ReadCloser, error) {
+	var fallback bool
 	stream, _, err := routeResolvedModelCall(
 		r,
 		ctx,
 		req.Model,
 		req.Provider,
 		func(selector core.ModelSelector) *core.ChatRequest {
-			return forwardChatRequest(req, selector)
+			fallback = r.NeedsStreamingFallback(selector.QualifiedModel())
+			return r.forwardChatRequest(ctx, req, selector)
 		},
 		func(ctx context.Context, provider core.Provider, forwardReq *core.ChatRequest) (io.ReadCloser, error) {
+			if fallback {
+				return streamChatCompletionFallback(ctx, provider, forwardReq)
+			}
 			return provider.StreamChatCompletion(ctx, forwardReq)
 		},
 	)
@@ -673,7 +719,7 @@ func (r *Router) Responses(ctx context.Context, req *core.ResponsesRequest) (*co
 		req.Model,
 		req.Provider,
 		func(selector core.ModelSelector) *core.ResponsesRequest {
-			return forwardResponsesRequest(req, selector)
+			return r.forwardResponsesRequest(ctx, req, selector)
 		},
 		callResponses,
 	)
@@ -688,7 +734,7 @@ func (r *Router) StreamResponses(ctx context.Context, req *core.ResponsesRequest
 		req.Model,
 		req.Provider,
 		func(selector core.ModelSelector) *core.ResponsesRequest {
-			return forwardResponsesRequest(req, selector)
+			return r.forwardResponsesRequest(ctx, req, selector)
 		},
 		func(ctx context.Context, provider core.Provider, forwardReq *core.ResponsesRequest) (io.ReadCloser, error) {
 			return provider.StreamResponses(ctx, forwardReq)
@@ -514,6 +514,9 @@ func TestRouterChatCompletion(t *testing.T) {
 				if gwErr.HTTPStatusCode() != http.StatusNotFound {
 					t.Fatalf("expected 404 status, got %d", gwErr.HTTPStatusCode())
 				}
+				if gwErr.Type != core.ErrorTypeInvalidRequest {
+					t.Fatalf("expected %s type, got %s", core.ErrorTypeInvalidRequest, gwErr.Type)
+				}
 				return
 			}
 
@@ -0,0 +1,45 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// samplingPolicyFromConfig converts sampling config, where negative bounds
+// are open, into the policy the router enforces.
+func samplingPolicyFromConfig(cfg config.SamplingConfig) core.SamplingPolicy {
+	bound := func(value float64) *float64 {
+		if value < 0 {
+			return nil
+		}
+		return &value
+	}
+	return core.SamplingPolicy{
+		Temperature: core.SamplingRange{Min: bound(cfg.MinTemperature), Max: bound(cfg.MaxTemperature)},
+		TopP:        core.SamplingRange{Min: bound(cfg.MinTopP), Max: bound(cfg.MaxTopP)},
+	}
+}
+
+// SetSamplingPolicy installs the sampling policy applied to every chat and
+// Responses request the router forwards. Call it before the router serves
+// traffic.
+func (r *Router) SetSamplingPolicy(policy core.SamplingPolicy) {
+	r.samplingPolicy = policy
+}
+
+// HasSamplingPolicy reports whether forwarded requests may have their
+// sampling parameters rewritten, which rules out verbatim body passthrough.
+func (r *Router) HasSamplingPolicy() bool {
+	return r.samplingPolicy.Enabled()
+}
+
+// applySamplingPolicy clamps the forwarded request's sampling parameters and
+// records any adjustment for the response header.
+func (r *Router) applySamplingPolicy(ctx context.Context, temperature, topP **float64) {
+	if !r.samplingPolicy.Enabled() {
+		return
+	}
+	core.RecordSamplingAdjustments(ctx, r.samplingPolicy.Apply(temperature, topP))
+}
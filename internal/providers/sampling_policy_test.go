@@ -0,0 +1,86 @@
+package providers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func newSamplingPolicyRouter(t *testing.T, provider *mockProvider, cfg config.SamplingConfig) *Router {
+	t.Helper()
+	lookup := newMockLookup()
+	lookup.addModel("openai-east/gpt-4o", provider, "openai")
+	router, err := NewRouter(lookup)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	router.SetSamplingPolicy(samplingPolicyFromConfig(cfg))
+	return router
+}
+
+func TestRouterChatCompletion_ClampsSamplingAboveMax(t *testing.T) {
+	provider := &mockProvider{name: "openai-east", chatResponse: &core.ChatResponse{ID: "east"}}
+	router := newSamplingPolicyRouter(t, provider, config.SamplingConfig{
+		MinTemperature: -1, MaxTemperature: 0.7, MinTopP: -1, MaxTopP: 0.9,
+	})
+
+	temperature, topP := 1.5, 1.0
+	req := &core.ChatRequest{
+		Model:       "gpt-4o",
+		Provider:    "openai-east",
+		Messages:    []core.Message{{Role: "user", Content: "Hello"}},
+		Temperature: &temperature,
+		TopP:        &topP,
+	}
+	ctx, recorder := core.WithSamplingAdjustmentRecorder(context.Background())
+	if _, err := router.ChatCompletion(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := provider.lastChatReq
+	if got.Temperature == nil || *got.Temperature != 0.7 {
+		t.Fatalf("Temperature = %v, want clamped 0.7", got.Temperature)
+	}
+	if got.TopP == nil || *got.TopP != 0.9 {
+		t.Fatalf("TopP = %v, want clamped 0.9", got.TopP)
+	}
+	if temperature != 1.5 || topP != 1.0 {
+		t.Fatalf("client request mutated: temperature=%v top_p=%v", temperature, topP)
+	}
+	if want := []string{"temperature=0.7", "top_p=0.9"}; !reflect.DeepEqual(recorder.Adjustments(), want) {
+		t.Fatalf("Adjustments() = %v, want %v", recorder.Adjustments(), want)
+	}
+}
+
+func TestRouterResponses_LeavesInRangeSamplingUntouched(t *testing.T) {
+	provider := &mockProvider{name: "openai-east", responsesResponse: &core.ResponsesResponse{ID: "resp"}}
+	router := newSamplingPolicyRouter(t, provider, config.SamplingConfig{
+		MinTemperature: -1, MaxTemperature: 0.7, MinTopP: -1, MaxTopP: -1,
+	})
+
+	temperature := 0.3
+	ctx, recorder := core.WithSamplingAdjustmentRecorder(context.Background())
+	_, err := router.Responses(ctx, &core.ResponsesRequest{
+		Model:       "gpt-4o",
+		Provider:    "openai-east",
+		Input:       "Hello",
+		Temperature: &temperature,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := provider.lastResponsesReq
+	if got.Temperature == nil || *got.Temperature != 0.3 {
+		t.Fatalf("Temperature = %v, want client value 0.3", got.Temperature)
+	}
+	if got.TopP != nil {
+		t.Fatalf("TopP = %v, want unset", *got.TopP)
+	}
+	if adjusted := recorder.Adjustments(); len(adjusted) != 0 {
+		t.Fatalf("Adjustments() = %v, want none", adjusted)
+	}
+}
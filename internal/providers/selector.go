@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/enterpilot/gomodel/config"
+)
+
+// Selector picks the provider that serves a bare model ID when several
+// providers are candidates for it. Candidates are non-empty and listed in
+// resolution order (preferred provider first, then priority or registration
+// order, stale providers excluded). Implementations must be safe for
+// concurrent use.
+type Selector interface {
+	Select(model string, candidates []*ModelInfo) *ModelInfo
+}
+
+// NewSelector returns the Selector implementing a routing strategy. weights
+// maps provider instance names to their round-robin share; it is ignored by
+// first_wins and may be nil.
+func NewSelector(strategy config.RoutingStrategy, weights map[string]int) (Selector, error) {
+	switch config.ResolveRoutingStrategy(strategy) {
+	case config.RoutingStrategyRoundRobin:
+		return NewWeightedRoundRobinSelector(weights), nil
+	case config.RoutingStrategyFirstWins:
+		return FirstWinsSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown routing strategy %q", strategy)
+	}
+}
+
+// FirstWinsSelector always picks the first candidate.
+type FirstWinsSelector struct{}
+
+// Select implements Selector.
+func (FirstWinsSelector) Select(_ string, candidates []*ModelInfo) *ModelInfo {
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[0]
+}
+
+// RoundRobinSelector rotates across the candidates, keeping independent
+// state per model ID. Without weights it is a plain rotation. With weights it
+// runs smooth weighted round-robin: a provider with weight 3 gets three turns
+// per cycle, interleaved with the others rather than back to back, and
+// unweighted providers count as 1.
+type RoundRobinSelector struct {
+	cursors sync.Map // model ID -> *atomic.Uint64, or *smoothWeights when weighted
+	weights map[string]int
+}
+
+// smoothWeights is the per-model running state of smooth weighted
+// round-robin, keyed by provider instance name.
+type smoothWeights struct {
+	mu      sync.Mutex
+	current map[string]int
+}
+
+// NewRoundRobinSelector creates an unweighted RoundRobinSelector.
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{}
+}
+
+// NewWeightedRoundRobinSelector creates a RoundRobinSelector that shares
+// traffic in proportion to weights, keyed by provider instance name.
+// Non-positive weights are dropped.
+func NewWeightedRoundRobinSelector(weights map[string]int) *RoundRobinSelector {
+	s := &RoundRobinSelector{}
+	for name, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		if s.weights == nil {
+			s.weights = make(map[string]int, len(weights))
+		}
+		s.weights[name] = weight
+	}
+	return s
+}
+
+// Select implements Selector.
+func (s *RoundRobinSelector) Select(model string, candidates []*ModelInfo) *ModelInfo {
+	switch len(candidates) {
+	case 0:
+		return nil
+	case 1:
+		return candidates[0]
+	}
+	if len(s.weights) > 0 {
+		return s.selectWeighted(model, candidates)
+	}
+	cursor, ok := s.cursors.Load(model)
+	if !ok {
+		cursor, _ = s.cursors.LoadOrStore(model, new(atomic.Uint64))
+	}
+	next := cursor.(*atomic.Uint64).Add(1) - 1
+	return candidates[next%uint64(len(candidates))]
+}
+
+// selectWeighted adds each candidate's weight to its running value, picks the
+// highest (earliest on ties), and takes the total back off the winner.
+func (s *RoundRobinSelector) selectWeighted(model string, candidates []*ModelInfo) *ModelInfo {
+	state, ok := s.cursors.Load(model)
+	if !ok {
+		state, _ = s.cursors.LoadOrStore(model, &smoothWeights{current: make(map[string]int)})
+	}
+	weights := state.(*smoothWeights)
+	weights.mu.Lock()
+	defer weights.mu.Unlock()
+
+	var best *ModelInfo
+	total := 0
+	for _, candidate := range candidates {
+		weight := s.weight(candidate)
+		total += weight
+		weights.current[candidate.ProviderName] += weight
+		if best == nil || weights.current[candidate.ProviderName] > weights.current[best.ProviderName] {
+			best = candidate
+		}
+	}
+	weights.current[best.ProviderName] -= total
+	return best
+}
+
+func (s *RoundRobinSelector) weight(candidate *ModelInfo) int {
+	if weight, ok := s.weights[candidate.ProviderName]; ok {
+		return weight
+	}
+	return 1
+}
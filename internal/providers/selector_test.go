@@ -0,0 +1,187 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enterpilot/gomodel/config"
+)
+
+func selectorCandidates(names ...string) []*ModelInfo {
+	candidates := make([]*ModelInfo, 0, len(names))
+	for _, name := range names {
+		candidates = append(candidates, &ModelInfo{ProviderName: name})
+	}
+	return candidates
+}
+
+func TestNewSelector(t *testing.T) {
+	tests := []struct {
+		strategy config.RoutingStrategy
+		want     string
+	}{
+		{strategy: "", want: "round_robin"},
+		{strategy: config.RoutingStrategyRoundRobin, want: "round_robin"},
+		{strategy: "Round-Robin", want: "round_robin"},
+		{strategy: config.RoutingStrategyFirstWins, want: "first_wins"},
+		{strategy: "first-wins", want: "first_wins"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			selector, err := NewSelector(tt.strategy, nil)
+			if err != nil {
+				t.Fatalf("NewSelector(%q) error = %v", tt.strategy, err)
+			}
+			var got string
+			switch selector.(type) {
+			case *RoundRobinSelector:
+				got = "round_robin"
+			case FirstWinsSelector:
+				got = "first_wins"
+			}
+			if got != tt.want {
+				t.Fatalf("NewSelector(%q) = %T, want %s", tt.strategy, selector, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewSelector("least_latency", nil); err == nil {
+		t.Fatal("NewSelector(least_latency) error = nil, want unknown strategy error")
+	}
+}
+
+func TestFirstWinsSelector(t *testing.T) {
+	candidates := selectorCandidates("a", "b", "c")
+	for range 3 {
+		if got := (FirstWinsSelector{}).Select("m", candidates); got != candidates[0] {
+			t.Fatalf("Select() = %q, want a", got.ProviderName)
+		}
+	}
+	if got := (FirstWinsSelector{}).Select("m", nil); got != nil {
+		t.Fatalf("Select(no candidates) = %v, want nil", got)
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	candidates := selectorCandidates("a", "b", "c")
+
+	var got []string
+	for range 4 {
+		got = append(got, selector.Select("m", candidates).ProviderName)
+	}
+	if want := []string{"a", "b", "c", "a"}; !equalStrings(got, want) {
+		t.Fatalf("Select() sequence = %v, want %v", got, want)
+	}
+
+	// Each model ID rotates independently.
+	if got := selector.Select("other", candidates).ProviderName; got != "a" {
+		t.Fatalf("Select(other) = %q, want a", got)
+	}
+	if got := selector.Select("m", candidates[:1]); got != candidates[0] {
+		t.Fatal("Select(single candidate) did not return it")
+	}
+	if got := selector.Select("m", nil); got != nil {
+		t.Fatalf("Select(no candidates) = %v, want nil", got)
+	}
+}
+
+func TestModelRegistry_SetSelectorSwapsStrategy(t *testing.T) {
+	registry, _, _ := newSharedModelRegistry(t, config.DuplicateModelPolicyAll)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	registry.SetSelector(FirstWinsSelector{})
+	for range 3 {
+		if got := registry.SelectProviderName("shared-model"); got != "first" {
+			t.Fatalf("first_wins SelectProviderName() = %q, want first", got)
+		}
+	}
+
+	registry.SetPreferredProvider("second")
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("refresh Initialize() error = %v", err)
+	}
+	if got := registry.SelectProviderName("shared-model"); got != "second" {
+		t.Fatalf("first_wins SelectProviderName() = %q, want preferred provider second", got)
+	}
+
+	registry.SetSelector(NewRoundRobinSelector())
+	var got []string
+	for range 4 {
+		got = append(got, registry.SelectProviderName("shared-model"))
+	}
+	if want := []string{"second", "first", "second", "first"}; !equalStrings(got, want) {
+		t.Fatalf("round_robin SelectProviderName() sequence = %v, want %v", got, want)
+	}
+
+	// nil restores the default round-robin selector.
+	registry.SetSelector(nil)
+	seen := map[string]bool{}
+	for range 2 {
+		seen[registry.SelectProviderName("shared-model")] = true
+	}
+	if !seen["first"] || !seen["second"] {
+		t.Fatalf("default SelectProviderName() = %v, want both providers", seen)
+	}
+}
+
+func TestWeightedRoundRobinSelector_DistributesByWeight(t *testing.T) {
+	selector := NewWeightedRoundRobinSelector(map[string]int{"a": 3, "c": 2, "ignored": -1})
+	candidates := selectorCandidates("a", "b", "c")
+
+	const calls = 6000
+	counts := map[string]int{}
+	for range calls {
+		counts[selector.Select("m", candidates).ProviderName]++
+	}
+
+	// Weights a=3, b=1 (unset), c=2 give shares of 3/6, 1/6 and 2/6.
+	want := map[string]int{"a": calls / 2, "b": calls / 6, "c": calls / 3}
+	for name, wantCount := range want {
+		if got := counts[name]; got != wantCount {
+			t.Fatalf("counts[%s] = %d, want %d (all counts %v)", name, got, wantCount, counts)
+		}
+	}
+}
+
+func TestWeightedRoundRobinSelector_InterleavesTurns(t *testing.T) {
+	selector := NewWeightedRoundRobinSelector(map[string]int{"a": 5})
+	candidates := selectorCandidates("a", "b", "c")
+
+	var got []string
+	for range 7 {
+		got = append(got, selector.Select("m", candidates).ProviderName)
+	}
+	if want := []string{"a", "a", "b", "a", "c", "a", "a"}; !equalStrings(got, want) {
+		t.Fatalf("Select() sequence = %v, want %v", got, want)
+	}
+}
+
+func TestModelRegistry_WeightedSelectorSplitsSharedModel(t *testing.T) {
+	registry, _, _ := newSharedModelRegistry(t, config.DuplicateModelPolicyAll)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	selector, err := NewSelector(config.RoutingStrategyRoundRobin, map[string]int{"first": 4})
+	if err != nil {
+		t.Fatalf("NewSelector() error = %v", err)
+	}
+	registry.SetSelector(selector)
+
+	const calls = 1000
+	counts := map[string]int{}
+	for range calls {
+		counts[registry.SelectProviderName("shared-model")]++
+	}
+	if counts["first"] != 800 || counts["second"] != 200 {
+		t.Fatalf("SelectProviderName() counts = %v, want first=800 second=200", counts)
+	}
+	if !registry.Supports("shared-model") {
+		t.Fatal("Supports(shared-model) = false, want true")
+	}
+	if registry.GetProvider("shared-model") == nil {
+		t.Fatal("GetProvider(shared-model) = nil, want a provider")
+	}
+}
@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// streamingCapability is the model metadata capability that marks whether a
+// model can stream. Only an explicit false triggers the fallback; models
+// without the capability are assumed to stream.
+const streamingCapability = "streaming"
+
+// SetStreamingFallback controls whether streaming chat requests for models
+// whose metadata declares no streaming support are served by a non-streaming
+// upstream call wrapped as a single-chunk SSE stream. Call it before the
+// router serves traffic.
+func (r *Router) SetStreamingFallback(enabled bool) {
+	r.streamingFallback = enabled
+}
+
+// NeedsStreamingFallback reports whether a streaming chat request for model
+// will be answered by the non-streaming fallback, which rules out verbatim
+// streaming passthrough.
+func (r *Router) NeedsStreamingFallback(model string) bool {
+	if !r.streamingFallback {
+		return false
+	}
+	meta := r.modelMetadata(model)
+	if meta == nil {
+		return false
+	}
+	supported, declared := meta.Capabilities[streamingCapability]
+	return declared && !supported
+}
+
+// streamChatCompletionFallback serves a streaming chat request with a
+// non-streaming upstream call and re-emits the completion as one SSE chunk
+// followed by data: [DONE], in the same shape EnsureChatCompletionSSE uses for
+// upstreams that ignore stream:true.
+func streamChatCompletionFallback(ctx context.Context, provider core.Provider, req *core.ChatRequest) (io.ReadCloser, error) {
+	nonStreaming := *req
+	nonStreaming.Stream = false
+	nonStreaming.StreamOptions = nil
+
+	resp, err := provider.ChatCompletion(ctx, &nonStreaming)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return nil, core.NewProviderError("", http.StatusInternalServerError, "failed to encode non-streaming fallback response", err)
+	}
+	return io.NopCloser(bytes.NewReader(bufferedCompletionToSSE(body))), nil
+}
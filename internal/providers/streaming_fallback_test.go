@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// nonStreamingProvider fails the test if its streaming method is used.
+type nonStreamingProvider struct {
+	mockProvider
+	streamCalls int
+}
+
+func (p *nonStreamingProvider) StreamChatCompletion(_ context.Context, _ *core.ChatRequest) (io.ReadCloser, error) {
+	p.streamCalls++
+	return nil, core.NewInvalidRequestError("streaming is not supported", nil)
+}
+
+func newStreamingFallbackRouter(t *testing.T, provider core.Provider, streaming bool) *Router {
+	t.Helper()
+	registry := newTestRegistryWithModels(registryModelEntry{
+		provider:     provider,
+		providerName: "batch-only",
+		providerType: "openai",
+		modelID:      "o1-batch",
+	})
+	registry.models["o1-batch"].Model.Metadata = &core.ModelMetadata{
+		Capabilities: map[string]bool{streamingCapability: streaming},
+	}
+	router, err := NewRouter(registry)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	return router
+}
+
+func TestRouterStreamChatCompletion_FallsBackForNonStreamingModel(t *testing.T) {
+	provider := &nonStreamingProvider{mockProvider: mockProvider{
+		name: "batch-only",
+		chatResponse: &core.ChatResponse{
+			ID:      "chatcmpl-1",
+			Object:  "chat.completion",
+			Created: 1700000000,
+			Model:   "o1-batch",
+			Choices: []core.Choice{{
+				Message:      core.ResponseMessage{Role: "assistant", Content: "Hello there"},
+				FinishReason: "stop",
+			}},
+			Usage: core.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+		},
+	}}
+	router := newStreamingFallbackRouter(t, provider, false)
+	router.SetStreamingFallback(true)
+
+	stream, err := router.StreamChatCompletion(context.Background(), &core.ChatRequest{
+		Model:         "o1-batch",
+		Messages:      []core.Message{{Role: "user", Content: "Hi"}},
+		Stream:        true,
+		StreamOptions: &core.StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		t.Fatalf("StreamChatCompletion() error = %v", err)
+	}
+	body := readAndCloseBody(t, stream)
+
+	if provider.streamCalls != 0 {
+		t.Fatalf("stream calls = %d, want 0", provider.streamCalls)
+	}
+	if got := provider.lastChatReq; got == nil || got.Stream || got.StreamOptions != nil {
+		t.Fatalf("upstream request = %+v, want non-streaming", got)
+	}
+
+	events := strings.Split(strings.TrimSuffix(body, "\n\n"), "\n\n")
+	if len(events) != 2 || events[1] != "data: [DONE]" {
+		t.Fatalf("events = %q, want one chunk followed by [DONE]", events)
+	}
+	var chunk struct {
+		Object  string `json:"object"`
+		Choices []struct {
+			Delta struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"delta"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+		Usage core.Usage `json:"usage"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(events[0], "data: ")), &chunk); err != nil {
+		t.Fatalf("decode chunk: %v (event %q)", err, events[0])
+	}
+	if chunk.Object != "chat.completion.chunk" || len(chunk.Choices) != 1 {
+		t.Fatalf("chunk = %+v, want one chat.completion.chunk choice", chunk)
+	}
+	if delta := chunk.Choices[0].Delta; delta.Role != "assistant" || delta.Content != "Hello there" {
+		t.Fatalf("delta = %+v, want assistant content", delta)
+	}
+	if chunk.Choices[0].FinishReason != "stop" || chunk.Usage.TotalTokens != 5 {
+		t.Fatalf("finish_reason = %q usage = %+v", chunk.Choices[0].FinishReason, chunk.Usage)
+	}
+}
+
+func TestRouterStreamChatCompletion_StreamsWhenFallbackNotApplicable(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		streaming bool
+	}{
+		{name: "fallback disabled", enabled: false, streaming: false},
+		{name: "model streams", enabled: true, streaming: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &nonStreamingProvider{mockProvider: mockProvider{name: "batch-only"}}
+			router := newStreamingFallbackRouter(t, provider, tt.streaming)
+			router.SetStreamingFallback(tt.enabled)
+
+			_, _ = router.StreamChatCompletion(context.Background(), &core.ChatRequest{
+				Model:    "o1-batch",
+				Messages: []core.Message{{Role: "user", Content: "Hi"}},
+				Stream:   true,
+			})
+			if provider.streamCalls != 1 || provider.lastChatReq != nil {
+				t.Fatalf("stream calls = %d, chat request = %+v; want the streaming path", provider.streamCalls, provider.lastChatReq)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package providers
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// cloudUpstreamHostPatterns covers providers whose hosts are derived from a
+// region or location rather than a fixed default base URL (Bedrock, Bedrock
+// Mantle, Vertex AI, Gemini).
+var cloudUpstreamHostPatterns = []string{
+	"*.amazonaws.com",
+	"*.api.aws",
+	"*.googleapis.com",
+}
+
+// upstreamHostPatterns builds the upstream host allow-list. Hosts of configured
+// provider base URLs are always included; configured patterns replace the
+// built-in known provider hosts (registered default base URLs plus the cloud
+// patterns) when set.
+func upstreamHostPatterns(configured []string, providerMap map[string]ProviderConfig, discovery map[string]DiscoveryConfig) []string {
+	seen := make(map[string]struct{})
+	add := func(pattern string) {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" {
+			seen[pattern] = struct{}{}
+		}
+	}
+
+	for _, cfg := range providerMap {
+		add(baseURLHost(cfg.BaseURL))
+	}
+	if len(configured) > 0 {
+		for _, pattern := range configured {
+			add(pattern)
+		}
+	} else {
+		for _, d := range discovery {
+			add(baseURLHost(d.DefaultBaseURL))
+		}
+		for _, pattern := range cloudUpstreamHostPatterns {
+			add(pattern)
+		}
+	}
+
+	patterns := make([]string, 0, len(seen))
+	for pattern := range seen {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+func baseURLHost(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
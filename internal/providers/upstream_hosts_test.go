@@ -0,0 +1,34 @@
+package providers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUpstreamHostPatterns(t *testing.T) {
+	providerMap := map[string]ProviderConfig{
+		"local":  {Type: "ollama", BaseURL: "http://ollama.internal:11434/v1"},
+		"openai": {Type: "openai"},
+	}
+	discovery := map[string]DiscoveryConfig{
+		"openai":    {DefaultBaseURL: "https://api.openai.com/v1"},
+		"anthropic": {DefaultBaseURL: "https://api.anthropic.com/v1"},
+		"azure":     {RequireBaseURL: true},
+	}
+
+	t.Run("defaults to known provider hosts", func(t *testing.T) {
+		got := upstreamHostPatterns(nil, providerMap, discovery)
+		want := []string{"*.amazonaws.com", "*.api.aws", "*.googleapis.com", "api.anthropic.com", "api.openai.com", "ollama.internal"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("upstreamHostPatterns() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("configured list replaces known hosts", func(t *testing.T) {
+		got := upstreamHostPatterns([]string{" API.OpenAI.com ", "*.example.com"}, providerMap, discovery)
+		want := []string{"*.example.com", "api.openai.com", "ollama.internal"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("upstreamHostPatterns() = %v, want %v", got, want)
+		}
+	})
+}
@@ -56,7 +56,7 @@ func newProvider(providerCfg providers.ProviderConfig, opts providers.ProviderOp
 
 	authClient := baseHTTPClient
 	if authClient == nil {
-		authClient = p.authHTTPClient(providerCfg, nil)
+		authClient = p.authHTTPClient(providerCfg, opts.HTTPClient)
 	}
 	p.gemini = gemini.NewVertexWithHTTPClient(providerCfg, opts, authClient)
 	nativeBaseURL := vertexNativeBaseURL(providerCfg)
@@ -66,6 +66,9 @@ func newProvider(providerCfg providers.ProviderConfig, opts providers.ProviderOp
 		Retry:          opts.Resilience.Retry,
 		Hooks:          opts.Hooks,
 		CircuitBreaker: opts.Resilience.CircuitBreaker,
+		Queue:          opts.Queue,
+		TokenPacer:     opts.TokenPacer,
+		HTTPClient:     opts.HTTPClient,
 	}
 	if authClient != nil {
 		p.nativeClient = llmclient.NewWithHTTPClient(authClient, nativeCfg, p.setHeaders)
@@ -149,6 +152,34 @@ func (p *Provider) ready() error {
 	return core.NewProviderError("vertex", http.StatusBadGateway, "invalid Vertex AI provider configuration: "+p.configErr.Error(), p.configErr)
 }
 
+// ResetCircuit force-closes the circuit breakers of the Gemini clients and the
+// native Vertex client.
+func (p *Provider) ResetCircuit() string {
+	state := ""
+	if p.gemini != nil {
+		state = p.gemini.ResetCircuit()
+	}
+	if p.nativeClient != nil {
+		if s := p.nativeClient.ResetCircuit(); state == "" {
+			state = s
+		}
+	}
+	return state
+}
+
+// CircuitState reports the worst circuit breaker state across the Gemini
+// clients and the native Vertex client.
+func (p *Provider) CircuitState() string {
+	var states []string
+	if p.gemini != nil {
+		states = append(states, p.gemini.CircuitState())
+	}
+	if p.nativeClient != nil {
+		states = append(states, p.nativeClient.CircuitState())
+	}
+	return llmclient.WorstCircuitState(states...)
+}
+
 func (p *Provider) setHeaders(req *http.Request) {
 	if requestID := core.GetRequestID(req.Context()); requestID != "" {
 		req.Header.Set("X-Request-Id", requestID)
@@ -48,6 +48,9 @@ func New(cfg providers.ProviderConfig, opts providers.ProviderOptions) core.Prov
 			Retry:          opts.Resilience.Retry,
 			Hooks:          opts.Hooks,
 			CircuitBreaker: opts.Resilience.CircuitBreaker,
+			Queue:          opts.Queue,
+			TokenPacer:     opts.TokenPacer,
+			HTTPClient:     opts.HTTPClient,
 		}, func(req *http.Request) {
 			setHeaders(req, cfg.APIKey)
 		}),
@@ -78,6 +81,22 @@ func (p *Provider) SetBaseURL(url string) {
 	p.rootClient.SetBaseURL(passthroughBaseURL(url))
 }
 
+// ResetCircuit force-closes the circuit breakers of the OpenAI-compatible and
+// passthrough clients.
+func (p *Provider) ResetCircuit() string {
+	state := p.compatible.ResetCircuit()
+	if s := p.rootClient.ResetCircuit(); state == "" {
+		state = s
+	}
+	return state
+}
+
+// CircuitState reports the worst circuit breaker state across the
+// OpenAI-compatible and passthrough clients.
+func (p *Provider) CircuitState() string {
+	return llmclient.WorstCircuitState(p.compatible.CircuitState(), p.rootClient.CircuitState())
+}
+
 func setHeaders(req *http.Request, apiKey string) {
 	providers.SetAuthHeaders(req, apiKey, providers.AuthHeaderConfig{
 		AuthScheme:      "Bearer ",
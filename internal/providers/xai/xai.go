@@ -113,6 +113,16 @@ func (p *Provider) SetBaseURL(url string) {
 	p.compat.SetBaseURL(url)
 }
 
+// ResetCircuit force-closes the provider client's circuit breaker.
+func (p *Provider) ResetCircuit() string {
+	return p.compat.ResetCircuit()
+}
+
+// CircuitState reports the provider client's circuit breaker state.
+func (p *Provider) CircuitState() string {
+	return p.compat.CircuitState()
+}
+
 // setHeaders sets the required headers for xAI API requests
 func setHeaders(req *http.Request, apiKey string) {
 	providers.SetAuthHeaders(req, apiKey, providers.AuthHeaderConfig{
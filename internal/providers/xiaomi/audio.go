@@ -41,15 +41,6 @@ func (p *Provider) CreateSpeech(ctx context.Context, req *core.AudioSpeechReques
 		return nil, err
 	}
 
-	audioParams := map[string]string{"format": format}
-	if voice := strings.TrimSpace(req.Voice); voice != "" {
-		audioParams["voice"] = voice
-	}
-	rawAudio, err := json.Marshal(audioParams)
-	if err != nil {
-		return nil, core.NewInvalidRequestError("failed to encode audio parameters", err)
-	}
-
 	var messages []core.Message
 	if instructions := strings.TrimSpace(req.Instructions); instructions != "" {
 		messages = append(messages, core.Message{Role: "user", Content: instructions})
@@ -57,9 +48,9 @@ func (p *Provider) CreateSpeech(ctx context.Context, req *core.AudioSpeechReques
 	messages = append(messages, core.Message{Role: "assistant", Content: req.Input})
 
 	resp, err := p.ChatCompletion(ctx, &core.ChatRequest{
-		Model:       req.Model,
-		Messages:    messages,
-		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{"audio": rawAudio}),
+		Model:    req.Model,
+		Messages: messages,
+		Audio:    &core.ChatAudioConfig{Voice: strings.TrimSpace(req.Voice), Format: format},
 	})
 	if err != nil {
 		return nil, err
@@ -89,15 +80,12 @@ func decodeSpeechAudio(resp *core.ChatResponse) ([]byte, error) {
 	if resp == nil || len(resp.Choices) == 0 {
 		return nil, core.NewProviderError("xiaomi", 502, "speech response contains no choices", nil)
 	}
-	rawAudio := resp.Choices[0].Message.ExtraFields.Lookup("audio")
-	if len(rawAudio) == 0 {
+	audio := resp.Choices[0].Message.Audio
+	if audio == nil {
 		return nil, core.NewProviderError("xiaomi", 502, "speech response contains no audio", nil)
 	}
-	var audio struct {
-		Data string `json:"data"`
-	}
-	if err := json.Unmarshal(rawAudio, &audio); err != nil || audio.Data == "" {
-		return nil, core.NewProviderError("xiaomi", 502, "speech response audio is malformed", err)
+	if audio.Data == "" {
+		return nil, core.NewProviderError("xiaomi", 502, "speech response audio is malformed", nil)
 	}
 	decoded, err := base64.StdEncoding.DecodeString(audio.Data)
 	if err != nil {
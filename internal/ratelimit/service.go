@@ -19,6 +19,9 @@ type Service struct {
 
 	mu    sync.RWMutex
 	rules []Rule
+	// keyRules are the in-memory rules declared on auth key file keys. A
+	// persisted rule for the same subject and period takes precedence.
+	keyRules []Rule
 }
 
 func NewService(ctx context.Context, store Store) (*Service, error) {
@@ -102,6 +105,18 @@ func (s *Service) ReplaceConfigRules(ctx context.Context, rules []Rule) error {
 	return s.Refresh(ctx)
 }
 
+// SetKeyRules replaces the rules declared on auth key file keys. They are
+// not persisted and do not appear in Rules; only admission and token
+// accounting see them.
+func (s *Service) SetKeyRules(rules []Rule) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyRules = append([]Rule(nil), rules...)
+}
+
 // HasTokenRules reports whether any rule limits tokens. Used at startup to
 // warn when token limits cannot be enforced because usage tracking is off.
 func (s *Service) HasTokenRules() bool {
@@ -115,6 +130,11 @@ func (s *Service) HasTokenRules() bool {
 			return true
 		}
 	}
+	for _, rule := range s.keyRules {
+		if rule.MaxTokens != nil {
+			return true
+		}
+	}
 	return false
 }
 
@@ -283,9 +303,25 @@ func (s *Service) matchingRules(subjects Subjects) []Rule {
 			matching = append(matching, rule)
 		}
 	}
+	for _, rule := range s.keyRules {
+		if rule.appliesTo(subjects) && !s.hasPersistedRule(keyForRule(rule)) {
+			matching = append(matching, rule)
+		}
+	}
 	return matching
 }
 
+// hasPersistedRule reports whether a persisted rule shares key. The caller
+// must hold s.mu.
+func (s *Service) hasPersistedRule(key ruleKey) bool {
+	for _, rule := range s.rules {
+		if keyForRule(rule) == key {
+			return true
+		}
+	}
+	return false
+}
+
 // normalizeSubjects canonicalizes request subjects before matching. The user
 // path arrives pre-normalized from ingress; this guards direct service use.
 func normalizeSubjects(subjects Subjects) (Subjects, error) {
@@ -115,6 +115,40 @@ func TestAcquireEnforcesRequestLimit(t *testing.T) {
 	}
 }
 
+func TestKeyRulesEnforcedBehindPersistedRules(t *testing.T) {
+	service := newTestService(t, Rule{
+		Subject:       "/team",
+		PeriodSeconds: PeriodMinuteSeconds,
+		MaxRequests:   new(int64(5)),
+	})
+	service.SetKeyRules([]Rule{
+		{Scope: ScopeUserPath, Subject: "/keys/ci", PeriodSeconds: PeriodMinuteSeconds, MaxRequests: new(int64(1)), Source: SourceKeyFile},
+		// Shadowed by the persisted /team rule for the same period.
+		{Scope: ScopeUserPath, Subject: "/team", PeriodSeconds: PeriodMinuteSeconds, MaxRequests: new(int64(1)), Source: SourceKeyFile},
+	})
+	if len(service.Rules()) != 1 {
+		t.Fatalf("Rules() = %d, want only the persisted rule", len(service.Rules()))
+	}
+
+	if _, err := service.Acquire(onPath("/keys/ci"), windowBase); err != nil {
+		t.Fatalf("Acquire(/keys/ci) failed: %v", err)
+	}
+	var exceeded *ExceededError
+	if _, err := service.Acquire(onPath("/keys/ci"), windowBase); !errors.As(err, &exceeded) {
+		t.Fatalf("second Acquire(/keys/ci) error = %v, want ExceededError", err)
+	}
+	for i := range 2 {
+		if _, err := service.Acquire(onPath("/team"), windowBase); err != nil {
+			t.Fatalf("Acquire(/team) %d failed: %v; the persisted limit must win", i, err)
+		}
+	}
+
+	service.SetKeyRules(nil)
+	if _, err := service.Acquire(onPath("/keys/ci"), windowBase); err != nil {
+		t.Fatalf("Acquire(/keys/ci) after clearing key rules failed: %v", err)
+	}
+}
+
 // TestRetryAfterReflectsSlidingWindowRecovery pins Retry-After to the exact
 // first second a retry would be admitted, not the next bucket boundary.
 func TestRetryAfterReflectsSlidingWindowRecovery(t *testing.T) {
@@ -26,6 +26,9 @@ const (
 	SourceConfig = "config"
 	// SourceManual marks rules created or changed through admin APIs.
 	SourceManual = "manual"
+	// SourceKeyFile marks rules declared on keys in an auth key file. They
+	// are held in memory only and replaced on every key file reload.
+	SourceKeyFile = "key_file"
 )
 
 // RuleScope names what a rule limits: a consumer user-path subtree, a
@@ -165,6 +165,95 @@ func TestHandleRequest_DifferentBodyDifferentKey(t *testing.T) {
 	}
 }
 
+func TestHandleRequest_DeterministicOnly(t *testing.T) {
+	workflow := resolvedWorkflow("openai", "gpt-4")
+	newMiddleware := func(ttl time.Duration) *ResponseCacheMiddleware {
+		mw := NewResponseCacheMiddlewareWithStore(cache.NewLRUStore(10), ttl)
+		mw.deterministicOnly = true
+		return mw
+	}
+	run := func(t *testing.T, mw *ResponseCacheMiddleware, body string) (string, *int) {
+		t.Helper()
+		calls := 0
+		rec := driveHandleRequest(t, mw, workflow, []byte(body), nil, func(c *echo.Context) error {
+			calls++
+			return c.JSON(http.StatusOK, map[string]string{"result": "fresh"})
+		})
+		mw.simple.wg.Wait()
+		return rec.Header().Get("X-Cache"), &calls
+	}
+
+	t.Run("temperature 0 hits", func(t *testing.T) {
+		mw := newMiddleware(time.Hour)
+		defer mw.Close()
+		body := `{"model":"gpt-4","temperature":0,"messages":[{"role":"user","content":"hi"}]}`
+		run(t, mw, body)
+		if got, calls := run(t, mw, body); got != "HIT (exact)" || *calls != 0 {
+			t.Fatalf("X-Cache = %q, calls = %d; want exact hit without calling next", got, *calls)
+		}
+	})
+
+	t.Run("different params miss", func(t *testing.T) {
+		mw := newMiddleware(time.Hour)
+		defer mw.Close()
+		run(t, mw, `{"model":"gpt-4","temperature":0,"max_tokens":10,"messages":[{"role":"user","content":"hi"}]}`)
+		if got, _ := run(t, mw, `{"model":"gpt-4","temperature":0,"max_tokens":20,"messages":[{"role":"user","content":"hi"}]}`); got != "" {
+			t.Fatalf("X-Cache = %q, want miss for different max_tokens", got)
+		}
+	})
+
+	t.Run("sampled and unset temperature are not cached", func(t *testing.T) {
+		for _, body := range []string{
+			`{"model":"gpt-4","temperature":0.7,"messages":[{"role":"user","content":"hi"}]}`,
+			`{"model":"gpt-4","messages":[{"role":"user","content":"hi"}]}`,
+		} {
+			mw := newMiddleware(time.Hour)
+			run(t, mw, body)
+			if got, _ := run(t, mw, body); got != "" {
+				t.Fatalf("X-Cache = %q for %s, want no caching", got, body)
+			}
+			_ = mw.Close()
+		}
+	})
+
+	t.Run("streaming is not cached", func(t *testing.T) {
+		mw := newMiddleware(time.Hour)
+		defer mw.Close()
+		body := `{"model":"gpt-4","temperature":0,"stream":true,"messages":[{"role":"user","content":"hi"}]}`
+		run(t, mw, body)
+		if got, calls := run(t, mw, body); got != "" || *calls != 1 {
+			t.Fatalf("X-Cache = %q, calls = %d; want streaming request generated fresh", got, *calls)
+		}
+	})
+
+	t.Run("semantic layer is gated too", func(t *testing.T) {
+		mw := newMiddleware(time.Hour)
+		defer mw.Close()
+		semantic, store, emb := newTestSemanticMiddleware(0.90, 10, false)
+		mw.semantic = semantic
+		body := `{"model":"gpt-4","temperature":0.7,"messages":[{"role":"user","content":"hi"}]}`
+		run(t, mw, body)
+		if got, calls := run(t, mw, body); got != "" || *calls != 1 {
+			t.Fatalf("X-Cache = %q, calls = %d; want sampled request generated fresh", got, *calls)
+		}
+		semantic.wg.Wait()
+		if store.Len() != 0 || emb.calls != 0 {
+			t.Fatalf("semantic entries = %d, embed calls = %d; want the semantic layer skipped", store.Len(), emb.calls)
+		}
+	})
+
+	t.Run("entries expire after ttl", func(t *testing.T) {
+		mw := newMiddleware(20 * time.Millisecond)
+		defer mw.Close()
+		body := `{"model":"gpt-4","temperature":0,"messages":[{"role":"user","content":"hi"}]}`
+		run(t, mw, body)
+		time.Sleep(40 * time.Millisecond)
+		if got, calls := run(t, mw, body); got != "" || *calls != 1 {
+			t.Fatalf("X-Cache = %q, calls = %d; want expired entry to miss", got, *calls)
+		}
+	})
+}
+
 func TestHashRequest_ResolvedModelChangesKey(t *testing.T) {
 	body := []byte(`{"model":"anthropic/claude-opus-4-6","messages":[{"role":"user","content":"hi"}]}`)
 
@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/cache"
+	"github.com/enterpilot/gomodel/internal/cache/cachetest"
 )
 
 // pingableStore is a cache.Store that also implements cache.Pinger.
@@ -59,3 +61,66 @@ func TestUsesRedisAndPing(t *testing.T) {
 		}
 	})
 }
+
+func TestNewResponseCacheMiddleware_MemoryBackend(t *testing.T) {
+	m, err := NewResponseCacheMiddleware(config.ResponseCacheConfig{
+		Simple: &config.SimpleCacheConfig{
+			Memory: &config.MemoryResponseConfig{MaxEntries: 5},
+		},
+		DeterministicOnly: true,
+	}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewResponseCacheMiddleware() error = %v", err)
+	}
+	defer m.Close()
+
+	if m.simple == nil {
+		t.Fatal("simple cache = nil, want memory-backed exact cache")
+	}
+	if _, ok := m.simple.store.(*cache.LRUStore); !ok {
+		t.Fatalf("store = %T, want *cache.LRUStore", m.simple.store)
+	}
+	if m.simple.ttl != time.Hour || !m.deterministicOnly {
+		t.Fatalf("ttl = %v, deterministicOnly = %v; want 1h and true", m.simple.ttl, m.deterministicOnly)
+	}
+	if m.UsesRedis() {
+		t.Error("UsesRedis() = true for memory backend, want false")
+	}
+}
+
+func TestNewResponseCacheMiddleware_UnreachableRedis(t *testing.T) {
+	newConfig := func(fallback *bool) config.ResponseCacheConfig {
+		return config.ResponseCacheConfig{
+			Simple: &config.SimpleCacheConfig{
+				Redis:            &config.RedisResponseConfig{URL: cachetest.UnreachableRedisURL(t)},
+				FallbackToMemory: fallback,
+			},
+		}
+	}
+
+	t.Run("falls back to memory by default", func(t *testing.T) {
+		m, err := NewResponseCacheMiddleware(newConfig(nil), nil, nil, nil)
+		if err != nil {
+			t.Fatalf("NewResponseCacheMiddleware() error = %v, want in-memory fallback", err)
+		}
+		defer m.Close()
+		if m.simple == nil {
+			t.Fatal("simple cache = nil, want in-memory fallback")
+		}
+		if _, ok := m.simple.store.(*cache.LRUStore); !ok {
+			t.Fatalf("store = %T, want *cache.LRUStore", m.simple.store)
+		}
+		if m.UsesRedis() {
+			t.Error("UsesRedis() = true for the in-memory fallback, want false")
+		}
+	})
+
+	t.Run("fails when fallback disabled", func(t *testing.T) {
+		disabled := false
+		m, err := NewResponseCacheMiddleware(newConfig(&disabled), nil, nil, nil)
+		if err == nil {
+			m.Close()
+			t.Fatal("NewResponseCacheMiddleware() error = nil, want redis connection error")
+		}
+	})
+}
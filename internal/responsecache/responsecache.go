@@ -17,7 +17,12 @@ import (
 	"github.com/enterpilot/gomodel/internal/usage"
 )
 
-const responseCachePrefix = "gomodel:response:"
+const (
+	responseCachePrefix = "gomodel:response:"
+	// defaultMemoryMaxEntries bounds the in-memory exact cache when
+	// max_entries is unset.
+	defaultMemoryMaxEntries = 1000
+)
 
 var internalRequestHeaderAllowlist = map[string]struct{}{
 	http.CanonicalHeaderKey("Accept"):                     {},
@@ -39,6 +44,9 @@ var internalRequestHeaderAllowlist = map[string]struct{}{
 type ResponseCacheMiddleware struct {
 	simple   *simpleCacheMiddleware
 	semantic *semanticCacheMiddleware
+	// deterministicOnly keeps requests that fail isDeterministicRequest out of
+	// both cache layers.
+	deterministicOnly bool
 }
 
 // InternalHandleResult is the buffered result of running the cache middleware
@@ -54,6 +62,8 @@ type InternalHandleResult struct {
 // If neither simple nor semantic cache is configured, returns a no-op middleware.
 // resolvedProviders must be the credential/env-resolved map from providers.InitResult
 // (same keys as live routing). Semantic embedder.provider names a key in this map.
+// An unreachable exact-cache Redis falls back to process memory, with a
+// warning, unless cache.response.simple.fallback_to_memory is false.
 func NewResponseCacheMiddleware(
 	cfg config.ResponseCacheConfig,
 	resolvedProviders map[string]config.RawProviderConfig,
@@ -67,6 +77,21 @@ func NewResponseCacheMiddleware(
 	case cfg.Simple == nil:
 	case !config.SimpleCacheEnabled(cfg.Simple):
 		slog.Info("response cache (simple/exact) disabled by config")
+	case cfg.Simple.Memory != nil:
+		ttl := time.Duration(cfg.Simple.Memory.TTL) * time.Second
+		if ttl == 0 {
+			ttl = time.Hour
+		}
+		maxEntries := cfg.Simple.Memory.MaxEntries
+		if maxEntries == 0 {
+			maxEntries = defaultMemoryMaxEntries
+		}
+		m.simple = newSimpleCacheMiddleware(cache.NewLRUStore(maxEntries), ttl, hitRecorder)
+		slog.Info("response cache (simple/exact) enabled in memory",
+			"ttl_seconds", int(ttl/time.Second),
+			"max_entries", maxEntries,
+			"deterministic_only", cfg.DeterministicOnly,
+		)
 	case cfg.Simple.Redis == nil || cfg.Simple.Redis.URL == "":
 		slog.Warn("response cache (simple/exact) enabled in config but redis URL is missing; set cache.response.simple.redis.url or REDIS_URL")
 	default:
@@ -84,12 +109,26 @@ func NewResponseCacheMiddleware(
 			TTL:    ttl,
 		})
 		if err != nil {
-			return nil, err
+			if !config.SimpleCacheFallbackToMemory(cfg.Simple) {
+				return nil, err
+			}
+			slog.Warn("response cache redis unavailable, falling back to in-memory exact cache",
+				"max_entries", defaultMemoryMaxEntries,
+				"error", err,
+			)
+			m.simple = newSimpleCacheMiddleware(cache.NewLRUStore(defaultMemoryMaxEntries), ttl, hitRecorder)
+			break
 		}
 		m.simple = newSimpleCacheMiddleware(store, ttl, hitRecorder)
-		slog.Info("response cache (simple/exact) enabled", "ttl_seconds", cfg.Simple.Redis.TTL, "prefix", prefix)
+		slog.Info("response cache (simple/exact) enabled",
+			"ttl_seconds", cfg.Simple.Redis.TTL,
+			"prefix", prefix,
+			"deterministic_only", cfg.DeterministicOnly,
+		)
 	}
 
+	m.deterministicOnly = cfg.DeterministicOnly
+
 	sem := cfg.Semantic
 	if sem != nil && config.SemanticCacheActive(sem) {
 		emb, err := embedding.NewEmbedder(sem.Embedder, resolvedProviders)
@@ -142,11 +181,16 @@ func (m *ResponseCacheMiddleware) handle(ex exchange, body []byte, next func() e
 	if shouldSkipAllCacheHeaders(ex.RequestHeader) {
 		return next()
 	}
+	if m.deterministicOnly && !isDeterministicRequest(ex.Path(), body) {
+		return next()
+	}
 
 	skipExact := strings.EqualFold(ex.RequestHeader("X-Cache-Type"), CacheTypeSemantic)
 	skipSemantic := m.semantic == nil || strings.EqualFold(ex.RequestHeader("X-Cache-Type"), CacheTypeExact)
 
-	if !skipExact && m.simple != nil {
+	skipExact = skipExact || m.simple == nil
+
+	if !skipExact {
 		hit, err := m.simple.TryHit(ex, body)
 		if err != nil || hit {
 			return err
@@ -156,7 +200,7 @@ func (m *ResponseCacheMiddleware) handle(ex exchange, body []byte, next func() e
 	// innerNext is what actually calls the LLM. When exact caching is active we
 	// wrap next inside StoreAfter so both cache layers write on a full miss.
 	innerNext := next
-	if !skipExact && m.simple != nil {
+	if !skipExact {
 		innerNext = func() error { return m.simple.StoreAfter(ex, body, next) }
 	}
 
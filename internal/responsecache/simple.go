@@ -161,6 +161,21 @@ func (m *simpleCacheMiddleware) enqueueWrite(job cacheWriteJob) {
 	}
 }
 
+// isDeterministicRequest reports whether a request's output is reproducible
+// enough to serve from cache under deterministic_only: embeddings always are;
+// chat and Responses requests only when they do not stream and set
+// temperature explicitly to 0.
+func isDeterministicRequest(path string, body []byte) bool {
+	if path == "/v1/embeddings" {
+		return true
+	}
+	if isStreamingRequest(path, body) {
+		return false
+	}
+	temperature := gjson.GetBytes(body, "temperature")
+	return temperature.Type == gjson.Number && temperature.Float() == 0
+}
+
 func shouldSkipCacheControl(cc string) bool {
 	if cc == "" {
 		return false
@@ -2,6 +2,7 @@ package responsecache
 
 import (
 	"bytes"
+	"io"
 	"net/http"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/enterpilot/gomodel/internal/auditlog"
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/streaming"
 )
 
 var (
@@ -70,7 +72,15 @@ func writeCachedResponse(c *echo.Context, path string, requestBody, cached []byt
 	if isStreamingRequest(path, requestBody) {
 		auditlog.EnrichEntryWithStream(c, true)
 		auditlog.EnrichEntryWithCachedStreamResponse(c, path, cached)
-		c.Response().Header().Set("Content-Type", "text/event-stream")
+		contentType := "text/event-stream"
+		if streaming.AcceptsNDJSON(c.Request().Header.Get("Accept")) {
+			framed, err := io.ReadAll(streaming.NewNDJSONStream(io.NopCloser(bytes.NewReader(cached))))
+			if err == nil {
+				cached = framed
+				contentType = streaming.NDJSONContentType
+			}
+		}
+		c.Response().Header().Set("Content-Type", contentType)
 		c.Response().Header().Set("Cache-Control", "no-cache")
 		c.Response().Header().Set("Connection", "keep-alive")
 		c.Response().Header().Set("X-Cache", cacheHeader)
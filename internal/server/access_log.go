@@ -0,0 +1,112 @@
+package server
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// AccessLogConfig configures AccessLogMiddleware.
+type AccessLogConfig struct {
+	// OnlyModelInteractions limits access logging to AI model endpoints.
+	OnlyModelInteractions bool
+}
+
+// AccessLogMiddleware emits one structured "REQUEST" log line per request with
+// the request ID, method, URI, path, host, status, latency, request and
+// response sizes, resolved provider and model, and the authenticated key id. Streaming responses are logged once when the
+// stream is established (stream=true, latency is time to first byte) rather
+// than when the stream closes. Handler errors are forwarded to the global
+// error handler first so the logged status matches what the client receives.
+// Nothing is captured when info logging is disabled.
+func AccessLogMiddleware(cfg AccessLogConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if cfg.OnlyModelInteractions && !core.IsModelInteractionPath(c.Request().URL.Path) {
+				return next(c)
+			}
+			if !slog.Default().Enabled(c.Request().Context(), slog.LevelInfo) {
+				return next(c)
+			}
+
+			start := time.Now()
+			logged := false
+			// resp is nil when the writer does not unwrap to *echo.Response.
+			resp, _ := echo.UnwrapResponse(c.Response())
+			if resp != nil {
+				resp.Before(func() {
+					if logged || !isEventStreamContentType(resp.Header().Get("Content-Type")) {
+						return
+					}
+					logged = true
+					logAccess(c, resp.Status, time.Since(start), 0, true, nil)
+				})
+			}
+
+			err := next(c)
+			if err != nil {
+				c.Echo().HTTPErrorHandler(c, err)
+			}
+			if logged {
+				return nil
+			}
+			_, status := echo.ResolveResponseStatus(c.Response(), err)
+			var bytesOut int64
+			if resp != nil {
+				bytesOut = resp.Size
+			}
+			logAccess(c, status, time.Since(start), bytesOut, false, err)
+			return nil
+		}
+	}
+}
+
+func isEventStreamContentType(contentType string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(contentType)), "text/event-stream")
+}
+
+func logAccess(c *echo.Context, status int, latency time.Duration, bytesOut int64, stream bool, err error) {
+	req := c.Request()
+	ctx := req.Context()
+
+	requestID := strings.TrimSpace(core.GetRequestID(ctx))
+	if requestID == "" {
+		requestID = c.Response().Header().Get("X-Request-ID")
+	}
+	var provider, model string
+	if workflow := core.GetWorkflow(ctx); workflow != nil {
+		provider = workflow.ProviderType
+		if workflow.Resolution != nil {
+			if workflow.Resolution.ProviderName != "" {
+				provider = workflow.Resolution.ProviderName
+			}
+			model = workflow.Resolution.ResolvedSelector.Model
+		}
+	}
+
+	attrs := []any{
+		"request_id", requestID,
+		"method", req.Method,
+		"uri", req.RequestURI,
+		"path", req.URL.Path,
+		"host", req.Host,
+		"status", status,
+		"latency", latency.String(),
+		"bytes_in", req.Header.Get(echo.HeaderContentLength),
+		"bytes_out", bytesOut,
+		"stream", stream,
+		"provider", provider,
+		"model", model,
+		"key_id", core.GetAuthKeyID(ctx),
+		"remote_ip", c.RealIP(),
+		"user_agent", req.UserAgent(),
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err.Error())
+	}
+	slog.Info("REQUEST", attrs...)
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func captureAccessLogs(t *testing.T) *syncBuffer {
+	t.Helper()
+	buf := &syncBuffer{}
+	original := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, nil)))
+	t.Cleanup(func() {
+		slog.SetDefault(original)
+	})
+	return buf
+}
+
+func accessLogEntries(t *testing.T, logs string) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["msg"] == "REQUEST" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestServerAccessLog_RequestIDRoundTrip(t *testing.T) {
+	logs := captureAccessLogs(t)
+	srv := New(&mockProvider{}, &Config{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/models?limit=5", nil)
+	req.Header.Set("X-Request-ID", "client-req-123")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "client-req-123", rec.Header().Get("X-Request-ID"))
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	generated := rec.Header().Get("X-Request-ID")
+	assert.NotEmpty(t, generated, "a request ID is generated when none is sent")
+
+	entries := accessLogEntries(t, logs.String())
+	require.Len(t, entries, 2)
+	assert.Equal(t, "client-req-123", entries[0]["request_id"])
+	assert.Equal(t, "GET", entries[0]["method"])
+	assert.Equal(t, "/v1/models", entries[0]["path"])
+	assert.Equal(t, "/v1/models?limit=5", entries[0]["uri"])
+	assert.Equal(t, "example.com", entries[0]["host"])
+	assert.Equal(t, float64(http.StatusOK), entries[0]["status"])
+	assert.Contains(t, entries[0], "bytes_in")
+	assert.Positive(t, entries[0]["bytes_out"])
+	assert.Equal(t, false, entries[0]["stream"])
+	assert.Equal(t, generated, entries[1]["request_id"])
+	assert.Equal(t, "/health", entries[1]["path"])
+}
+
+func TestAccessLogMiddleware_LogsProviderModelAndKey(t *testing.T) {
+	logs := captureAccessLogs(t)
+	e := echo.New()
+	e.Use(AccessLogMiddleware(AccessLogConfig{}))
+	e.POST("/v1/chat/completions", func(c *echo.Context) error {
+		ctx := core.WithAuthKeyID(c.Request().Context(), "team-a")
+		ctx = core.WithWorkflow(ctx, &core.Workflow{
+			ProviderType: "openai",
+			Resolution: &core.RequestModelResolution{
+				ResolvedSelector: core.ModelSelector{Provider: "openai-eu", Model: "gpt-4o"},
+				ProviderType:     "openai",
+				ProviderName:     "openai-eu",
+			},
+		})
+		c.SetRequest(c.Request().WithContext(ctx))
+		return core.NewInvalidRequestError("bad input", nil)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil))
+
+	entries := accessLogEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "openai-eu", entries[0]["provider"])
+	assert.Equal(t, "gpt-4o", entries[0]["model"])
+	assert.Equal(t, "team-a", entries[0]["key_id"])
+	assert.Equal(t, float64(rec.Code), entries[0]["status"])
+	assert.NotEmpty(t, entries[0]["error"])
+}
+
+func TestAccessLogMiddleware_LogsStreamAtEstablishment(t *testing.T) {
+	logs := captureAccessLogs(t)
+	e := echo.New()
+	e.Use(AccessLogMiddleware(AccessLogConfig{}))
+	var loggedBeforeStreamEnd []map[string]any
+	e.POST("/v1/chat/completions", func(c *echo.Context) error {
+		c.Response().Header().Set("Content-Type", "text/event-stream")
+		c.Response().WriteHeader(http.StatusOK)
+		loggedBeforeStreamEnd = accessLogEntries(t, logs.String())
+		_, err := c.Response().Write([]byte("data: [DONE]\n\n"))
+		return err
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil))
+
+	require.Len(t, loggedBeforeStreamEnd, 1, "stream should be logged once headers are written")
+	assert.Equal(t, true, loggedBeforeStreamEnd[0]["stream"])
+	assert.Equal(t, float64(http.StatusOK), loggedBeforeStreamEnd[0]["status"])
+	assert.Len(t, accessLogEntries(t, logs.String()), 1, "stream must not be logged again at completion")
+}
+
+func TestAccessLogMiddleware_OnlyModelInteractions(t *testing.T) {
+	logs := captureAccessLogs(t)
+	e := echo.New()
+	e.Use(AccessLogMiddleware(AccessLogConfig{OnlyModelInteractions: true}))
+	e.GET("/health", func(c *echo.Context) error { return c.String(http.StatusOK, "ok") })
+	e.POST("/v1/chat/completions", func(c *echo.Context) error { return errors.New("boom") })
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil))
+
+	entries := accessLogEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/v1/chat/completions", entries[0]["path"])
+	assert.Equal(t, float64(http.StatusInternalServerError), entries[0]["status"])
+}
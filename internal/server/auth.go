@@ -21,16 +21,30 @@ type BearerTokenAuthenticator interface {
 	Authenticate(ctx context.Context, token string) (authkeys.AuthenticationResult, error)
 }
 
+// StaticAPIKey is a bearer key accepted from server.api_keys. Name is the
+// identity recorded for requests made with Key.
+type StaticAPIKey struct {
+	Name string
+	Key  string
+}
+
 // AuthMiddlewareWithAuthenticator creates an Echo middleware that validates
 // the legacy master key and, when configured, managed auth keys from the auth
 // key service. If no auth mechanism is configured, no authentication is
 // required. skipPaths is a list of paths that should bypass authentication.
 func AuthMiddlewareWithAuthenticator(masterKey string, authenticator BearerTokenAuthenticator, skipPaths []string, userPathHeader ...string) echo.MiddlewareFunc {
+	return AuthMiddlewareWithAPIKeys(masterKey, nil, authenticator, skipPaths, userPathHeader...)
+}
+
+// AuthMiddlewareWithAPIKeys is AuthMiddlewareWithAuthenticator with static
+// API keys checked after the master key and before managed keys. A matching
+// static key's name becomes the request's auth key id.
+func AuthMiddlewareWithAPIKeys(masterKey string, apiKeys []StaticAPIKey, authenticator BearerTokenAuthenticator, skipPaths []string, userPathHeader ...string) echo.MiddlewareFunc {
 	userPathHeaderName := configuredUserPathHeaderName(userPathHeader...)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
 			// If no auth mechanism is configured, allow all requests.
-			if masterKey == "" && (authenticator == nil || !authenticator.Enabled()) {
+			if masterKey == "" && len(apiKeys) == 0 && (authenticator == nil || !authenticator.Enabled()) {
 				auditlog.EnrichEntryWithAuthMethod(c, auditlog.AuthMethodNoKey)
 				return next(c)
 			}
@@ -58,6 +72,13 @@ func AuthMiddlewareWithAuthenticator(masterKey string, authenticator BearerToken
 			}
 			if masterKey != "" && subtle.ConstantTimeCompare([]byte(token), []byte(masterKey)) == 1 {
 				auditlog.EnrichEntryWithAuthMethod(c, auditlog.AuthMethodMasterKey)
+				c.SetRequest(c.Request().WithContext(core.WithMasterKeyAuth(c.Request().Context())))
+				applyProviderOverride(c)
+				return next(c)
+			}
+			if name, ok := matchStaticAPIKey(token, apiKeys); ok {
+				auditlog.EnrichEntryWithAuthMethod(c, auditlog.AuthMethodAPIKey)
+				applyAuthKeyResult(c, authkeys.AuthenticationResult{ID: name}, userPathHeaderName)
 				return next(c)
 			}
 
@@ -73,12 +94,45 @@ func AuthMiddlewareWithAuthenticator(masterKey string, authenticator BearerToken
 				return writeGatewayError(c, authErr)
 			}
 
-			authErr := authenticationError(c, "invalid master key")
+			message := "invalid master key"
+			if len(apiKeys) > 0 {
+				message = "invalid API key"
+			}
+			authErr := authenticationError(c, message)
 			return writeGatewayError(c, authErr)
 		}
 	}
 }
 
+// adminMasterKeyOnlyMiddleware refuses admin API requests authenticated with
+// a static or managed API key. Those keys are issued to API consumers; only
+// the master key, or an admin API left open by the auth configuration, may
+// manage the gateway.
+func adminMasterKeyOnlyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		ctx := c.Request().Context()
+		if !core.IsMasterKeyAuth(ctx) && core.GetAuthKeyID(ctx) != "" {
+			auditlog.EnrichEntryWithError(c, string(core.ErrorTypeInvalidRequest), "admin API requires the master key")
+			return writeGatewayError(c, core.NewInvalidRequestErrorWithStatus(http.StatusForbidden, "admin API requires the master key", nil).
+				WithCode("forbidden"))
+		}
+		return next(c)
+	}
+}
+
+// matchStaticAPIKey returns the name of the static key equal to token. Every
+// key is compared in constant time so the response time does not reveal
+// which entry, if any, matched.
+func matchStaticAPIKey(token string, apiKeys []StaticAPIKey) (string, bool) {
+	matched := ""
+	for _, key := range apiKeys {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(key.Key)) == 1 {
+			matched = key.Name
+		}
+	}
+	return matched, matched != ""
+}
+
 // requestAuthToken extracts the caller's credential from the request. The
 // primary scheme is "Authorization: Bearer <token>"; the Anthropic-native
 // "x-api-key: <token>" header is accepted as a fallback so Anthropic SDK
@@ -99,9 +153,13 @@ func requestAuthToken(r *http.Request) (token, errMessage string) {
 }
 
 // applyAuthKeyResult enriches the request context and audit entry with the
-// authenticated managed key's identity, labels, and bound user path.
+// authenticated managed key's identity, labels, allowed models, and bound
+// user path.
 func applyAuthKeyResult(c *echo.Context, authResult authkeys.AuthenticationResult, userPathHeaderName string) {
 	ctx := core.WithAuthKeyID(c.Request().Context(), authResult.ID)
+	if len(authResult.AllowedModels) > 0 {
+		ctx = core.WithAuthKeyAllowedModels(ctx, authResult.AllowedModels)
+	}
 	if len(authResult.Labels) > 0 {
 		// Key labels join any labels the tagging middleware already
 		// extracted from request headers; duplicates collapse.
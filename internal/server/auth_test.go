@@ -245,6 +245,111 @@ func TestAuthMiddlewareWithAuthenticator_ManagedKeyEnrichesContextAndAudit(t *te
 	assert.Equal(t, "ok", rec.Body.String())
 }
 
+func TestAuthMiddlewareWithAPIKeys(t *testing.T) {
+	apiKeys := []StaticAPIKey{
+		{Name: "team-a", Key: "sk-team-a"},
+		{Name: "team-b", Key: "sk-team-b"},
+	}
+	tests := []struct {
+		name       string
+		masterKey  string
+		apiKeys    []StaticAPIKey
+		token      string
+		wantStatus int
+		wantKeyID  string
+		wantMethod string
+	}{
+		{name: "first static key", apiKeys: apiKeys, token: "sk-team-a", wantStatus: http.StatusOK, wantKeyID: "team-a", wantMethod: auditlog.AuthMethodAPIKey},
+		{name: "second static key", apiKeys: apiKeys, token: "sk-team-b", wantStatus: http.StatusOK, wantKeyID: "team-b", wantMethod: auditlog.AuthMethodAPIKey},
+		{name: "unknown key", apiKeys: apiKeys, token: "sk-team-c", wantStatus: http.StatusUnauthorized},
+		{name: "revoked key removed from list", apiKeys: apiKeys[1:], token: "sk-team-a", wantStatus: http.StatusUnauthorized},
+		{name: "master key alongside static keys", masterKey: "master", apiKeys: apiKeys, token: "master", wantStatus: http.StatusOK, wantMethod: auditlog.AuthMethodMasterKey},
+		{name: "static key alongside master key", masterKey: "master", apiKeys: apiKeys, token: "sk-team-b", wantStatus: http.StatusOK, wantKeyID: "team-b", wantMethod: auditlog.AuthMethodAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			var gotKeyID string
+			handler := AuthMiddlewareWithAPIKeys(tt.masterKey, tt.apiKeys, nil, nil)(func(c *echo.Context) error {
+				gotKeyID = core.GetAuthKeyID(c.Request().Context())
+				return c.String(http.StatusOK, "ok")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			entry := &auditlog.LogEntry{Data: &auditlog.LogData{}}
+			c.Set(string(auditlog.LogEntryKey), entry)
+
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Contains(t, rec.Body.String(), "invalid API key")
+				return
+			}
+			assert.Equal(t, tt.wantKeyID, gotKeyID)
+			assert.Equal(t, tt.wantKeyID, entry.AuthKeyID)
+			assert.Equal(t, tt.wantMethod, entry.AuthMethod)
+		})
+	}
+}
+
+func TestAuthMiddlewareWithAPIKeys_FallsThroughToManagedKeys(t *testing.T) {
+	e := echo.New()
+	var gotKeyID string
+	handler := AuthMiddlewareWithAPIKeys("", []StaticAPIKey{{Name: "team-a", Key: "sk-team-a"}}, mockAuthenticator{
+		enabled:   true,
+		tokenToID: map[string]string{"sk_gom_token": "key-123"},
+	}, nil)(func(c *echo.Context) error {
+		gotKeyID = core.GetAuthKeyID(c.Request().Context())
+		return c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer sk_gom_token")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, handler(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "key-123", gotKeyID)
+}
+
+func TestAuthMiddleware_ProviderOverrideHeaderRequiresMasterKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "master key forces provider", token: "master-secret", want: "groq"},
+		{name: "managed key is ignored", token: "sk_gom_token", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := AuthMiddlewareWithAuthenticator("master-secret", mockAuthenticator{
+				enabled:   true,
+				tokenToID: map[string]string{"sk_gom_token": "key-123"},
+			}, nil)(func(c *echo.Context) error {
+				got = core.GetProviderOverride(c.Request().Context())
+				return c.String(http.StatusOK, "ok")
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			req.Header.Set(ProviderOverrideHeader, " groq ")
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.Set(string(auditlog.LogEntryKey), &auditlog.LogEntry{Data: &auditlog.LogData{}})
+
+			require.NoError(t, handler(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestAuthMiddlewareWithAuthenticator_ManagedKeyLabelsMergeWithHeaderLabels(t *testing.T) {
 	e := echo.New()
 	testHandler := func(c *echo.Context) error {
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/goccy/go-json"
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/auditlog"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// Completions handles POST /v1/completions.
+//
+// It accepts the legacy OpenAI text completions request, translates the
+// prompt to a single-user-message chat request, and runs it through the
+// standard chat-completions pipeline so older clients can reach any
+// configured provider with full workflow, budget, failover, cache, usage, and
+// audit support.
+//
+// @Summary      Create a text completion (legacy)
+// @Tags         chat
+// @Accept       json
+// @Produce      json
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        request  body      core.CompletionRequest  true  "Completion request"
+// @Success      200      {object}  core.CompletionResponse  "JSON response or SSE stream when stream=true"
+// @Failure      400      {object}  core.OpenAIErrorEnvelope
+// @Failure      401      {object}  core.OpenAIErrorEnvelope
+// @Failure      429      {object}  core.OpenAIErrorEnvelope
+// @Failure      502      {object}  core.OpenAIErrorEnvelope
+// @Router       /v1/completions [post]
+func (h *Handler) Completions(c *echo.Context) error {
+	return h.translatedInference().Completions(c)
+}
+
+// Completions translates a legacy completion request and dispatches it
+// through the shared chat-completions pipeline (workflow resolution, response
+// cache).
+func (s *translatedInferenceService) Completions(c *echo.Context) error {
+	completionReq, req, err := decodeCompletionChatRequest(c)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	ctx, prepared, workflow, err := prepareChatCompletionRequest(s, c.Request().Context(), req, translatedRequestMeta(c))
+	if err != nil {
+		return handleError(c, err)
+	}
+	attachPreparedWorkflow(c, ctx, workflow)
+
+	// The echoed prompt is applied after translation and is not part of the
+	// chat body the response cache keys on, so echo requests bypass the cache.
+	if completionReq.Echo {
+		return s.dispatchCompletions(c, prepared, workflow, completionReq.Prompt)
+	}
+	return handleWithCache(s, c, prepared, workflow, func(c *echo.Context, req *core.ChatRequest, workflow *core.Workflow) error {
+		return s.dispatchCompletions(c, req, workflow, "")
+	})
+}
+
+func (s *translatedInferenceService) dispatchCompletions(c *echo.Context, req *core.ChatRequest, workflow *core.Workflow, echoPrompt string) error {
+	s.observeLiveProviderAttempts(c, workflow)
+	ctx := c.Request().Context()
+	requestID := requestIDFromContextOrHeader(c.Request())
+
+	adm, err := enforceAdmission(c, s.rateLimiter, s.budgetChecker,
+		rateLimitRouteFromWorkflow(workflow).withFailovers(len(s.inference().FailoverSelectors(workflow))))
+	if err != nil {
+		return handleError(c, err)
+	}
+	defer adm.release()
+	ctx = adm.dispatchContext(ctx)
+
+	if req.Stream {
+		result, err := s.inference().StreamChatCompletion(ctx, workflow, req)
+		if err != nil {
+			return handleStreamingDispatchError(c, err)
+		}
+		if result.Meta.UsedFailover {
+			markRequestFailoverUsed(c)
+		}
+		return s.handleStreamingReadCloser(
+			c,
+			workflow,
+			result.Meta.Model,
+			result.Meta.ProviderType,
+			result.Meta.ProviderName,
+			result.Meta.FailoverModel,
+			result.Stream,
+			func(stream io.ReadCloser) io.ReadCloser {
+				return newCompletionStreamConverter(stream, echoPrompt)
+			},
+		)
+	}
+
+	result, err := s.inference().ExecuteChatCompletion(ctx, workflow, req, requestID, "/v1/completions")
+	if err != nil {
+		return handleError(c, err)
+	}
+	enrichAuditEntryWithProviderAttempts(c)
+	if result.Meta.UsedFailover {
+		markRequestFailoverUsed(c)
+		auditlog.EnrichEntryWithFailover(c, result.Meta.FailoverModel)
+	}
+	auditlog.EnrichEntryWithResolvedRoute(
+		c,
+		qualifyExecutedModel(workflow, result.Response.Model, result.Meta.ProviderName),
+		result.Meta.ProviderType,
+		result.Meta.ProviderName,
+	)
+
+	return c.JSON(http.StatusOK, core.CompletionResponseFromChat(result.Response, echoPrompt))
+}
+
+// decodeCompletionChatRequest reads the request body, decodes the legacy
+// completion request, and translates it to the canonical chat request.
+func decodeCompletionChatRequest(c *echo.Context) (*core.CompletionRequest, *core.ChatRequest, error) {
+	body, err := requestBodyBytes(c)
+	if err != nil {
+		return nil, nil, core.NewInvalidRequestError("invalid request body: "+err.Error(), err)
+	}
+	var req core.CompletionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		var gatewayErr *core.GatewayError
+		if errors.As(err, &gatewayErr) {
+			return nil, nil, gatewayErr
+		}
+		return nil, nil, core.NewInvalidRequestError("invalid request body: "+err.Error(), err)
+	}
+	chatReq, err := req.ToChatRequest()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &req, chatReq, nil
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestCompletions_NonStreaming(t *testing.T) {
+	provider := &capturingProvider{
+		mockProvider: mockProvider{
+			supportedModels: []string{"gpt-4o-mini"},
+			response: &core.ChatResponse{
+				ID:     "chatcmpl-1",
+				Object: "chat.completion",
+				Model:  "gpt-4o-mini",
+				Choices: []core.Choice{{
+					Index:        0,
+					Message:      core.ResponseMessage{Role: "assistant", Content: " world"},
+					FinishReason: "stop",
+				}},
+				Usage: core.Usage{PromptTokens: 2, CompletionTokens: 1, TotalTokens: 3},
+			},
+		},
+	}
+
+	e := echo.New()
+	handler := NewHandler(provider, nil, nil, nil)
+
+	reqBody := `{"model":"gpt-4o-mini","prompt":"Hello","max_tokens":8,"echo":true,"stop":["\n"]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/completions", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	if err := handler.Completions(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("Completions: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	var resp core.CompletionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Object != "text_completion" || resp.ID != "cmpl-1" {
+		t.Errorf("envelope = %+v", resp)
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].Text != "Hello world" {
+		t.Fatalf("choices = %+v", resp.Choices)
+	}
+	if resp.Usage.TotalTokens != 3 {
+		t.Errorf("usage = %+v", resp.Usage)
+	}
+
+	if provider.capturedChatReq == nil {
+		t.Fatal("provider did not receive a chat request")
+	}
+	msgs := provider.capturedChatReq.Messages
+	if len(msgs) != 1 || msgs[0].Role != "user" || msgs[0].Content != "Hello" {
+		t.Fatalf("translated messages = %+v", msgs)
+	}
+	if provider.capturedChatReq.ExtraFields.Lookup("stop") == nil {
+		t.Error("stop was not forwarded to the chat request")
+	}
+}
+
+func TestCompletions_Streaming(t *testing.T) {
+	chatSSE := strings.Join([]string{
+		`data: {"id":"chatcmpl-2","created":1,"model":"gpt-4o-mini","choices":[{"index":0,"delta":{"role":"assistant"},"finish_reason":null}]}`,
+		`data: {"id":"chatcmpl-2","created":1,"model":"gpt-4o-mini","choices":[{"index":0,"delta":{"content":"Hi!"},"finish_reason":null}]}`,
+		`data: {"id":"chatcmpl-2","created":1,"model":"gpt-4o-mini","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`,
+		`data: [DONE]`,
+		"",
+	}, "\n\n")
+
+	provider := &capturingProvider{
+		mockProvider: mockProvider{
+			supportedModels: []string{"gpt-4o-mini"},
+			streamData:      chatSSE,
+		},
+	}
+
+	e := echo.New()
+	handler := NewHandler(provider, nil, nil, nil)
+
+	reqBody := `{"model":"gpt-4o-mini","prompt":"Hi","stream":true}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/completions", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	if err := handler.Completions(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("Completions: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`"object":"text_completion"`,
+		`"id":"cmpl-2"`,
+		`"text":"Hi!"`,
+		`"finish_reason":"stop"`,
+		"data: [DONE]",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("stream missing %q\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "chat.completion.chunk") || strings.Contains(body, `"delta"`) {
+		t.Errorf("stream leaked chat chunk shape\n%s", body)
+	}
+}
+
+func TestCompletions_InvalidRequestReturnsOpenAIError(t *testing.T) {
+	provider := &mockProvider{supportedModels: []string{"gpt-4o-mini"}}
+	e := echo.New()
+	handler := NewHandler(provider, nil, nil, nil)
+
+	reqBody := `{"model":"gpt-4o-mini","prompt":"Hi","suffix":"bye"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/completions", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	if err := handler.Completions(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("Completions: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var resp core.OpenAIErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error.Param == nil || *resp.Error.Param != "suffix" {
+		t.Errorf("error = %+v", resp.Error)
+	}
+}
+
+func TestCompletionStreamConverter_EchoesPromptOnce(t *testing.T) {
+	upstream := strings.Join([]string{
+		`data: {"id":"chatcmpl-3","choices":[{"index":0,"delta":{"content":" a"},"finish_reason":null}]}`,
+		`data: {"id":"chatcmpl-3","choices":[{"index":0,"delta":{"content":" b"},"finish_reason":null}]}`,
+		`data: {"id":"chatcmpl-3","choices":[],"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}`,
+		`data: {"error":{"message":"boom"}}`,
+		`data: [DONE]`,
+		"",
+	}, "\n\n")
+
+	converter := newCompletionStreamConverter(io.NopCloser(strings.NewReader(upstream)), "Start")
+	out, err := io.ReadAll(converter)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if err := converter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	body := string(out)
+	if strings.Count(body, "Start") != 1 || !strings.Contains(body, `"text":"Start a"`) {
+		t.Errorf("echo prompt should prefix only the first text chunk\n%s", body)
+	}
+	if !strings.Contains(body, `"text":" b"`) {
+		t.Errorf("second chunk missing\n%s", body)
+	}
+	if !strings.Contains(body, `"total_tokens":3`) {
+		t.Errorf("usage chunk missing\n%s", body)
+	}
+	if !strings.Contains(body, `data: {"error":{"message":"boom"}}`) {
+		t.Errorf("error event should pass through unchanged\n%s", body)
+	}
+	if !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Errorf("stream should end with [DONE]\n%s", body)
+	}
+}
+
+func TestServerCompletions_RoutesThroughChatPipeline(t *testing.T) {
+	provider := &mockProvider{
+		supportedModels: []string{"gpt-4o-mini"},
+		response: &core.ChatResponse{
+			ID:    "chatcmpl-4",
+			Model: "gpt-4o-mini",
+			Choices: []core.Choice{{
+				Message:      core.ResponseMessage{Role: "assistant", Content: "ok"},
+				FinishReason: "stop",
+			}},
+		},
+	}
+	srv := New(provider, &Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/completions", strings.NewReader(`{"model":"gpt-4o-mini","prompt":"ping"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"text":"ok"`) {
+		t.Errorf("body = %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/streaming"
+)
+
+// completionChatChunk is the subset of a chat.completion.chunk consumed by
+// the legacy completions stream converter.
+type completionChatChunk struct {
+	ID                string          `json:"id"`
+	Created           int64           `json:"created"`
+	Model             string          `json:"model"`
+	SystemFingerprint string          `json:"system_fingerprint"`
+	Error             json.RawMessage `json:"error"`
+	Choices           []struct {
+		Index int `json:"index"`
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage json.RawMessage `json:"usage"`
+}
+
+type completionStreamChoice struct {
+	Text         string          `json:"text"`
+	Index        int             `json:"index"`
+	Logprobs     json.RawMessage `json:"logprobs"`
+	FinishReason *string         `json:"finish_reason"`
+}
+
+type completionStreamChunk struct {
+	ID                string                   `json:"id"`
+	Object            string                   `json:"object"`
+	Created           int64                    `json:"created"`
+	Model             string                   `json:"model"`
+	SystemFingerprint string                   `json:"system_fingerprint,omitempty"`
+	Choices           []completionStreamChoice `json:"choices"`
+	Usage             json.RawMessage          `json:"usage,omitempty"`
+}
+
+var completionNullLogprobs = json.RawMessage("null")
+
+// newCompletionStreamConverter wraps a chat completion SSE stream and emits
+// legacy text_completion chunks. echoPrompt, when non-empty, is prepended to
+// the first text of every choice. Upstream error events and the [DONE]
+// sentinel pass through unchanged. The returned reader owns body and closes
+// it on Close.
+func newCompletionStreamConverter(body io.ReadCloser, echoPrompt string) io.ReadCloser {
+	return &completionStreamConverter{
+		reader:     bufio.NewReader(body),
+		body:       body,
+		buffer:     streaming.NewStreamBuffer(1024),
+		echoPrompt: echoPrompt,
+	}
+}
+
+// completionStreamConverter is single-reader and must not be shared.
+type completionStreamConverter struct {
+	reader     *bufio.Reader
+	body       io.ReadCloser
+	buffer     streaming.StreamBuffer
+	echoPrompt string
+	echoed     map[int]bool
+	done       bool
+	closed     bool
+}
+
+func (sc *completionStreamConverter) Read(p []byte) (int, error) {
+	for {
+		if sc.buffer.Len() > 0 {
+			return sc.buffer.Read(p), nil
+		}
+		if sc.done || sc.closed {
+			return 0, io.EOF
+		}
+		line, err := streaming.ReadLine(sc.reader)
+		if len(bytes.TrimSpace(line)) > 0 {
+			sc.consumeLine(line)
+		}
+		if err != nil {
+			sc.done = true
+			if err != io.EOF {
+				sc.buffer.Release()
+				return 0, err
+			}
+		}
+	}
+}
+
+func (sc *completionStreamConverter) Close() error {
+	if sc.closed {
+		return nil
+	}
+	sc.closed = true
+	sc.buffer.Release()
+	return sc.body.Close()
+}
+
+func (sc *completionStreamConverter) consumeLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return
+	}
+	payload := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data:")))
+	if bytes.Equal(payload, []byte("[DONE]")) {
+		sc.done = true
+		sc.emit(payload)
+		return
+	}
+	var chunk completionChatChunk
+	if err := json.Unmarshal(payload, &chunk); err != nil || len(chunk.Error) > 0 {
+		sc.emit(payload)
+		return
+	}
+
+	out := completionStreamChunk{
+		ID:                core.CompletionIDFromChat(chunk.ID),
+		Object:            "text_completion",
+		Created:           chunk.Created,
+		Model:             chunk.Model,
+		SystemFingerprint: chunk.SystemFingerprint,
+		Choices:           make([]completionStreamChoice, 0, len(chunk.Choices)),
+	}
+	if !core.IsJSONNull(bytes.TrimSpace(chunk.Usage)) {
+		out.Usage = chunk.Usage
+	}
+	for _, choice := range chunk.Choices {
+		text := choice.Delta.Content
+		if sc.echoPrompt != "" && (text != "" || choice.FinishReason != nil) && !sc.echoed[choice.Index] {
+			if sc.echoed == nil {
+				sc.echoed = make(map[int]bool)
+			}
+			sc.echoed[choice.Index] = true
+			text = sc.echoPrompt + text
+		}
+		// Role-only deltas carry nothing a legacy client can use.
+		if text == "" && choice.FinishReason == nil {
+			continue
+		}
+		out.Choices = append(out.Choices, completionStreamChoice{
+			Text:         text,
+			Index:        choice.Index,
+			Logprobs:     completionNullLogprobs,
+			FinishReason: choice.FinishReason,
+		})
+	}
+	if len(out.Choices) == 0 && out.Usage == nil {
+		return
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return
+	}
+	sc.emit(data)
+}
+
+func (sc *completionStreamConverter) emit(payload []byte) {
+	sc.buffer.AppendString("data: ")
+	sc.buffer.AppendBytes(payload)
+	sc.buffer.AppendString("\n\n")
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+)
+
+// CORSConfig configures cross-origin access to /v1 routes. CORS is disabled
+// when AllowedOrigins is empty.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+}
+
+// CORSMiddleware answers CORS preflights and sets CORS headers on /v1 routes
+// for allowed origins. Preflight OPTIONS requests are answered here without
+// reaching auth, since browsers send them without credentials; every other
+// request continues down the chain, so auth still applies. Origins must be
+// valid (config.ValidateCORSConfig) or this panics.
+func CORSMiddleware(cfg CORSConfig) echo.MiddlewareFunc {
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		Skipper: func(c *echo.Context) bool {
+			path := c.Request().URL.Path
+			return path != "/v1" && !strings.HasPrefix(path, "/v1/")
+		},
+		AllowOrigins:     cfg.AllowedOrigins,
+		AllowMethods:     cfg.AllowedMethods,
+		AllowHeaders:     cfg.AllowedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+	})
+}
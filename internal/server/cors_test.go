@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerCORS(t *testing.T) {
+	srv := New(&mockProvider{}, &Config{
+		MasterKey: "test-secret-key",
+		CORS: CORSConfig{
+			AllowedOrigins:   []string{"https://app.example.com"},
+			AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+			AllowedHeaders:   []string{"Authorization", "Content-Type"},
+			AllowCredentials: true,
+		},
+	})
+
+	send := func(method, path, origin string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("preflight from allowed origin skips auth", func(t *testing.T) {
+		rec := send(http.MethodOptions, "/v1/chat/completions", "https://app.example.com", map[string]string{
+			"Access-Control-Request-Method":  http.MethodPost,
+			"Access-Control-Request-Headers": "authorization,content-type",
+		})
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET,POST", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization,Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("allowed origin gets headers and auth still applies", func(t *testing.T) {
+		rec := send(http.MethodGet, "/v1/models", "https://app.example.com", nil)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+
+		rec = send(http.MethodGet, "/v1/models", "https://app.example.com", map[string]string{"Authorization": "Bearer test-secret-key"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("disallowed origin gets no CORS headers", func(t *testing.T) {
+		rec := send(http.MethodOptions, "/v1/chat/completions", "https://evil.example.com", map[string]string{
+			"Access-Control-Request-Method": http.MethodPost,
+		})
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+
+		rec = send(http.MethodGet, "/v1/models", "https://evil.example.com", map[string]string{"Authorization": "Bearer test-secret-key"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("non-v1 routes are untouched", func(t *testing.T) {
+		rec := send(http.MethodGet, "/health", "https://app.example.com", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestServerCORS_DisabledByDefault(t *testing.T) {
+	srv := New(&mockProvider{}, &Config{})
+
+	req := httptest.NewRequest(http.MethodOptions, "/v1/chat/completions", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v5"
 
@@ -24,9 +25,72 @@ func handleError(c *echo.Context, err error) error {
 	enrichAuditEntryWithProviderAttempts(c)
 	auditlog.EnrichEntryWithError(c, string(gatewayErr.Type), gatewayErr.Message, gatewayErrorCode(gatewayErr))
 	applyErrorResponseHeaders(c, err)
+	applyUpstreamRetryAfter(c, gatewayErr)
 	return writeGatewayError(c, gatewayErr)
 }
 
+// applyUpstreamRetryAfter relays an upstream provider's Retry-After hint on a
+// 429 so clients can back off. A header already set by the gateway's own rate
+// limit or budget checks takes precedence.
+func applyUpstreamRetryAfter(c *echo.Context, gatewayErr *core.GatewayError) {
+	if gatewayErr.RetryAfter <= 0 || gatewayErr.HTTPStatusCode() != http.StatusTooManyRequests {
+		return
+	}
+	header := c.Response().Header()
+	if header.Get("Retry-After") != "" {
+		return
+	}
+	header.Set("Retry-After", strconv.FormatInt(retryAfterSeconds(gatewayErr.RetryAfter), 10))
+}
+
+// handleHTTPError is the server's echo HTTPErrorHandler. Handlers render their
+// own failures through handleError; this catches what escapes them (middleware
+// errors, echo's own 405/413 responses, plain Go errors) so every error reaches
+// the client in the same envelope as a handler-rendered one.
+func handleHTTPError(c *echo.Context, err error) {
+	if resp, _ := echo.UnwrapResponse(c.Response()); resp != nil && resp.Committed {
+		return
+	}
+	gatewayErr, ok := errors.AsType[*core.GatewayError](err)
+	if !ok {
+		if status := echo.StatusCode(err); status != 0 {
+			gatewayErr = gatewayErrorFromStatus(status, err)
+		} else {
+			gatewayErr = core.NewProviderError("", http.StatusInternalServerError, "an unexpected error occurred", err)
+		}
+		err = gatewayErr
+	}
+	if c.Request().Method == http.MethodHead {
+		logHandledError(c, gatewayErr)
+		_ = c.NoContent(gatewayErr.HTTPStatusCode())
+		return
+	}
+	if writeErr := handleError(c, err); writeErr != nil {
+		slog.Error("failed to write error response", "error", writeErr)
+	}
+}
+
+// gatewayErrorFromStatus maps an error carrying only an HTTP status (such as
+// *echo.HTTPError) to the gateway error type clients expect for that status.
+func gatewayErrorFromStatus(status int, err error) *core.GatewayError {
+	message := http.StatusText(status)
+	if httpErr, ok := errors.AsType[*echo.HTTPError](err); ok && httpErr.Message != "" {
+		message = httpErr.Message
+	}
+	switch {
+	case status == http.StatusUnauthorized:
+		return core.NewAuthenticationError("", message)
+	case status == http.StatusNotFound:
+		return core.NewNotFoundError(message)
+	case status == http.StatusTooManyRequests:
+		return core.NewRateLimitError("", message)
+	case status >= 400 && status < 500:
+		return core.NewInvalidRequestErrorWithStatus(status, message, err)
+	default:
+		return core.NewProviderError("", status, message, err)
+	}
+}
+
 // writeGatewayError renders a gateway error in the request's wire dialect
 // without logging or audit enrichment, for callers that already recorded it.
 func writeGatewayError(c *echo.Context, gatewayErr *core.GatewayError) error {
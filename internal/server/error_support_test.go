@@ -9,11 +9,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v5"
 
 	"github.com/enterpilot/gomodel/internal/auditlog"
 	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/providers"
 )
 
 func TestHandleError_RendersDialectSpecificEnvelope(t *testing.T) {
@@ -221,3 +223,218 @@ func TestHandleRouteNotFound_OpenAIDialect(t *testing.T) {
 		t.Errorf("envelope = %s, want OpenAI error envelope with not_found_error", rec.Body.String())
 	}
 }
+
+func TestHandleHTTPError_RendersGatewayEnvelope(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+	}{
+		{
+			name:       "echo method not allowed",
+			err:        echo.ErrMethodNotAllowed,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantType:   "invalid_request_error",
+		},
+		{
+			name:       "echo body too large",
+			err:        echo.ErrStatusRequestEntityTooLarge,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantType:   "invalid_request_error",
+		},
+		{
+			name:       "echo unauthorized",
+			err:        echo.ErrUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+			wantType:   "authentication_error",
+		},
+		{
+			name:       "plain error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "provider_error",
+		},
+		{
+			name:       "gateway error",
+			err:        core.NewRateLimitError("openai", "slow down"),
+			wantStatus: http.StatusTooManyRequests,
+			wantType:   "rate_limit_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handleHTTPError(c, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body map[string]map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unmarshal %q: %v", rec.Body.String(), err)
+			}
+			envelope, ok := body["error"]
+			if !ok {
+				t.Fatalf("body = %s, want error envelope", rec.Body.String())
+			}
+			if envelope["type"] != tt.wantType {
+				t.Errorf("type = %v, want %s", envelope["type"], tt.wantType)
+			}
+			for _, field := range []string{"message", "param", "code"} {
+				if _, ok := envelope[field]; !ok {
+					t.Errorf("envelope missing %q: %s", field, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestHandleHTTPError_HeadRequestHasNoBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodHead, "/v1/models", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handleHTTPError(c, echo.ErrMethodNotAllowed)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want empty", rec.Body.String())
+	}
+}
+
+func TestHandleHTTPError_SkipsCommittedResponse(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if err := c.String(http.StatusOK, "done"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	handleHTTPError(c, errors.New("late failure"))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "done" {
+		t.Errorf("response = %d %q, want untouched 200 done", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleError_GatewayRetryAfterTakesPrecedence(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	upstream := core.NewRateLimitError("openai", "rate limit exceeded")
+	upstream.RetryAfter = 5 * time.Second
+	err := &gatewayErrorWithResponseHeaders{
+		GatewayError: upstream,
+		headers:      http.Header{"Retry-After": []string{"30"}},
+	}
+	if writeErr := handleError(c, err); writeErr != nil {
+		t.Fatalf("handleError returned error: %v", writeErr)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want the gateway's 30", got)
+	}
+}
+
+// newRegistryRouterServer serves the gateway over a real model registry and
+// router, so errors reach the client through the same path as in production.
+func newRegistryRouterServer(t *testing.T, initialize bool) *Server {
+	t.Helper()
+	registry := providers.NewModelRegistry()
+	registry.RegisterProviderWithNameAndType(&mockProvider{
+		modelsResponse: &core.ModelsResponse{
+			Object: "list",
+			Data:   []core.Model{{ID: "gpt-4o-mini", Object: "model", OwnedBy: "openai"}},
+		},
+	}, "openai", "openai")
+	if initialize {
+		if err := registry.Initialize(t.Context()); err != nil {
+			t.Fatalf("Initialize() error = %v", err)
+		}
+	}
+	router, err := providers.NewRouter(registry)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	return New(router, nil)
+}
+
+func TestServer_ModelErrorsEndToEnd(t *testing.T) {
+	tests := []struct {
+		name           string
+		initialize     bool
+		model          string
+		notFoundStatus int
+		wantStatus     int
+		wantType       string
+		wantCode       string
+	}{
+		{
+			name:       "registry not initialized",
+			model:      "gpt-4o-mini",
+			wantStatus: http.StatusServiceUnavailable,
+			wantType:   "provider_error",
+		},
+		{
+			name:       "unknown model",
+			initialize: true,
+			model:      "no-such-model",
+			wantStatus: http.StatusBadRequest,
+			wantType:   "invalid_request_error",
+			wantCode:   "model_not_found",
+		},
+		{
+			name:           "unknown model with 404 status configured",
+			initialize:     true,
+			model:          "no-such-model",
+			notFoundStatus: http.StatusNotFound,
+			wantStatus:     http.StatusNotFound,
+			wantType:       "invalid_request_error",
+			wantCode:       "model_not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core.SetModelNotFoundStatus(tt.notFoundStatus)
+			t.Cleanup(func() { core.SetModelNotFoundStatus(http.StatusBadRequest) })
+			srv := newRegistryRouterServer(t, tt.initialize)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions",
+				strings.NewReader(`{"model":"`+tt.model+`","messages":[{"role":"user","content":"hi"}]}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			var body struct {
+				Error struct {
+					Type string  `json:"type"`
+					Code *string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unmarshal %q: %v", rec.Body.String(), err)
+			}
+			if body.Error.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", body.Error.Type, tt.wantType)
+			}
+			if tt.wantCode != "" && (body.Error.Code == nil || *body.Error.Code != tt.wantCode) {
+				t.Errorf("code = %v, want %q", body.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/goccy/go-json"
 	"github.com/labstack/echo/v5"
 
+	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/anthropicapi"
 	"github.com/enterpilot/gomodel/internal/auditlog"
 	batchstore "github.com/enterpilot/gomodel/internal/batch"
@@ -20,6 +23,7 @@ import (
 	"github.com/enterpilot/gomodel/internal/responsecache"
 	"github.com/enterpilot/gomodel/internal/responsestore"
 	"github.com/enterpilot/gomodel/internal/usage"
+	"github.com/enterpilot/gomodel/internal/version"
 )
 
 // Handler holds the HTTP handlers
@@ -33,6 +37,12 @@ type Handler struct {
 	batchRequestPreparer            BatchRequestPreparer
 	exposedModelLister              ExposedModelLister
 	keepOnlyAliasesAtModelsEndpoint bool
+	modelListSortOrder              config.ModelListSortOrder
+	healthStatusCode                int
+	healthBody                      string
+	healthIncludeBuildInfo          bool
+	startedAt                       time.Time
+	providerTypes                   []string
 	logger                          auditlog.LoggerInterface
 	usageLogger                     usage.LoggerInterface
 	budgetChecker                   BudgetChecker
@@ -55,6 +65,9 @@ type Handler struct {
 	realtimeHTTPClient           *http.Client
 	responseCache                *responsecache.ResponseCacheMiddleware
 	guardrailsHash               string
+	maxToolsPerRequest           int
+	streamKeepaliveInterval      time.Duration
+	outputPacing                 config.OutputPacingConfig
 	storageProbe                 ReadinessProbe
 	cacheProbe                   ReadinessProbe
 
@@ -95,6 +108,7 @@ func newHandlerWithAuthorizer(
 		enabledPassthroughProviders:  normalizeEnabledPassthroughProviders(defaultEnabledPassthroughProviders),
 		realtimeCalls:                realtime.NewCallRegistry(),
 		realtimeHTTPClient:           httpclient.NewDefaultHTTPClient(),
+		startedAt:                    time.Now(),
 	}
 }
 
@@ -161,6 +175,9 @@ func (h *Handler) translatedInference() *translatedInferenceService {
 			pricingResolver:          h.pricingResolver,
 			responseCache:            h.responseCache,
 			guardrailsHash:           h.guardrailsHash,
+			maxToolsPerRequest:       h.maxToolsPerRequest,
+			streamKeepaliveInterval:  h.streamKeepaliveInterval,
+			outputPacing:             h.outputPacing,
 			responseStore:            h.currentResponseStore(),
 		}
 		s.initHandlers()
@@ -280,6 +297,7 @@ func (h *Handler) passthrough() *passthroughService {
 		pricingResolver:              h.pricingResolver,
 		normalizePassthroughV1Prefix: h.normalizePassthroughV1Prefix,
 		enabledPassthroughProviders:  h.enabledPassthroughProviders,
+		streamKeepaliveInterval:      h.streamKeepaliveInterval,
 	}
 }
 
@@ -467,13 +485,35 @@ func (h *Handler) ChatCompletion(c *echo.Context) error {
 
 // Health handles GET /health
 //
+// The status code and body are configurable for load balancers with strict
+// health-check contracts; by default it returns 200 {"status":"ok"}.
+//
 // @Summary      Health check
 // @Tags         system
 // @Produce      json
 // @Success      200  {object}  map[string]string
 // @Router       /health [get]
 func (h *Handler) Health(c *echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	status := h.healthStatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if status == http.StatusNoContent {
+		return c.NoContent(status)
+	}
+	if h.healthBody != "" {
+		if json.Valid([]byte(h.healthBody)) {
+			return c.JSONBlob(status, []byte(h.healthBody))
+		}
+		return c.String(status, h.healthBody)
+	}
+	body := map[string]string{"status": "ok"}
+	if h.healthIncludeBuildInfo {
+		body["version"] = version.Version
+		body["commit"] = version.Commit
+		body["uptime"] = time.Since(h.startedAt).Round(time.Second).String()
+	}
+	return c.JSON(status, body)
 }
 
 // ListModels handles GET /v1/models
@@ -550,9 +590,12 @@ func (h *Handler) ListModels(c *echo.Context) error {
 		if resp != nil {
 			models = resp.Data
 		}
-		return c.JSON(http.StatusOK, anthropicapi.FromModels(models))
+		return c.JSON(http.StatusOK, anthropicapi.FromModels(sortModels(models, h.modelListSortOrder)))
 	}
 
+	if resp != nil {
+		resp = &core.ModelsResponse{Object: resp.Object, Data: sortModels(resp.Data, h.modelListSortOrder)}
+	}
 	return c.JSON(http.StatusOK, resp)
 }
 
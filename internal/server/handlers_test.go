@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/auditlog"
 	batchstore "github.com/enterpilot/gomodel/internal/batch"
 	"github.com/enterpilot/gomodel/internal/core"
@@ -2144,6 +2146,46 @@ data: [DONE]
 	}
 }
 
+func TestChatCompletionStreaming_NDJSONWhenRequested(t *testing.T) {
+	streamData := "data: {\"id\":\"chatcmpl-123\",\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\ndata: [DONE]\n\n"
+	mock := &mockProvider{
+		supportedModels: []string{"gpt-4o-mini"},
+		providerTypes: map[string]string{
+			"gpt-4o-mini": "openai",
+		},
+		streamData: streamData,
+		passthroughResponse: &core.PassthroughResponse{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(streamData)),
+		},
+	}
+
+	e := echo.New()
+	handler := NewHandler(mock, nil, nil, nil)
+
+	reqBody := `{"model":"gpt-4o-mini","stream":true,"messages":[{"role":"user","content":"Hi"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := handler.ChatCompletion(c); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Fatalf("Content-Type = %q, want application/x-ndjson", got)
+	}
+	want := "{\"id\":\"chatcmpl-123\",\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n{\"done\":true}\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+	if mock.lastPassthroughReq != nil {
+		t.Fatal("NDJSON requests must not take the SSE passthrough fast path")
+	}
+}
+
 func TestChatCompletionStreaming_FastPathUsesPassthroughForOpenAICompatibleProviders(t *testing.T) {
 	streamData := "data: {\"id\":\"chatcmpl-123\",\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\ndata: [DONE]\n\n"
 	reqBody := `{"model":"gpt-4o-mini","stream":true,"messages":[{"role":"user","content":"Hi"}]}`
@@ -2397,6 +2439,98 @@ func TestFlushStream_ReturnsWriteError(t *testing.T) {
 	}
 }
 
+func TestFlushStreamWithKeepalive_WritesCommentsWhileUpstreamIsSlow(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("data: {\"id\":\"1\"}\n\n"))
+		time.Sleep(120 * time.Millisecond)
+		_, _ = pw.Write([]byte("data: [DONE]\n\n"))
+		// Idle after [DONE]: no keepalive may follow the sentinel.
+		time.Sleep(80 * time.Millisecond)
+		_ = pw.Close()
+	}()
+
+	var out bytes.Buffer
+	if err := flushStreamWithKeepalive(&out, pr, 20*time.Millisecond); err != nil {
+		t.Fatalf("flushStreamWithKeepalive() error = %v", err)
+	}
+
+	got := out.String()
+	if !strings.HasPrefix(got, "data: {\"id\":\"1\"}\n\n: keepalive\n\n") {
+		t.Fatalf("expected keepalive after first event, got %q", got)
+	}
+	if n := strings.Count(got, ": keepalive\n\n"); n < 2 {
+		t.Fatalf("expected periodic keepalives, got %d in %q", n, got)
+	}
+	if !strings.HasSuffix(got, "data: [DONE]\n\n") {
+		t.Fatalf("expected stream to end with [DONE], got %q", got)
+	}
+}
+
+func TestFlushStreamWithKeepalive_WaitsForEventBoundary(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("data: {\"id\":"))
+		time.Sleep(80 * time.Millisecond)
+		_, _ = pw.Write([]byte("\"1\"}\n\n"))
+		_ = pw.Close()
+	}()
+
+	var out bytes.Buffer
+	if err := flushStreamWithKeepalive(&out, pr, 10*time.Millisecond); err != nil {
+		t.Fatalf("flushStreamWithKeepalive() error = %v", err)
+	}
+	if got := out.String(); got != "data: {\"id\":\"1\"}\n\n" {
+		t.Fatalf("keepalive split an event: %q", got)
+	}
+}
+
+// blockingCloseTrackingStream blocks in Read until release is closed and
+// mutates shared state in both Read and Close, the way ObservedSSEStream does,
+// so a Close that overlaps a Read is both recorded and visible to -race.
+type blockingCloseTrackingStream struct {
+	release    chan struct{}
+	reading    atomic.Bool
+	overlapped atomic.Bool
+	pending    []byte
+}
+
+func (s *blockingCloseTrackingStream) Read([]byte) (int, error) {
+	s.reading.Store(true)
+	defer s.reading.Store(false)
+	<-s.release
+	s.pending = append(s.pending, "tail"...)
+	return 0, io.EOF
+}
+
+func (s *blockingCloseTrackingStream) Close() error {
+	if s.reading.Load() {
+		s.overlapped.Store(true)
+	}
+	s.pending = nil
+	return nil
+}
+
+func TestFlushStreamWithKeepalive_WriteFailureWaitsForPendingRead(t *testing.T) {
+	expectedErr := errors.New("client write failed")
+	stream := &blockingCloseTrackingStream{release: make(chan struct{})}
+	go func() {
+		// Stands in for the request context ending the upstream read once
+		// the client is gone.
+		time.Sleep(50 * time.Millisecond)
+		close(stream.release)
+	}()
+
+	err := flushStreamWithKeepalive(&erroringWriter{err: expectedErr}, stream, 5*time.Millisecond)
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected write error %v, got %v", expectedErr, err)
+	}
+	_ = stream.Close()
+	if stream.overlapped.Load() {
+		t.Fatal("flushStreamWithKeepalive returned while Read was still in flight")
+	}
+}
+
 func TestRequestIDFromContextOrHeader(t *testing.T) {
 	t.Run("prefers context request id", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
@@ -2749,6 +2883,103 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestHealth_CustomResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		body            string
+		includeBuild    bool
+		wantStatus      int
+		wantContentType string
+		check           func(*testing.T, string)
+	}{
+		{
+			name:            "custom status keeps default body",
+			statusCode:      http.StatusAccepted,
+			wantStatus:      http.StatusAccepted,
+			wantContentType: "application/json",
+			check: func(t *testing.T, body string) {
+				if body != `{"status":"ok"}` {
+					t.Fatalf("body = %q, want default body", body)
+				}
+			},
+		},
+		{
+			name:       "no content",
+			statusCode: http.StatusNoContent,
+			body:       "ignored",
+			wantStatus: http.StatusNoContent,
+			check: func(t *testing.T, body string) {
+				if body != "" {
+					t.Fatalf("body = %q, want empty", body)
+				}
+			},
+		},
+		{
+			name:            "json body",
+			body:            `{"healthy":true}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			check: func(t *testing.T, body string) {
+				if body != `{"healthy":true}` {
+					t.Fatalf("body = %q, want custom JSON", body)
+				}
+			},
+		},
+		{
+			name:            "plain text body",
+			body:            "OK",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain",
+			check: func(t *testing.T, body string) {
+				if body != "OK" {
+					t.Fatalf("body = %q, want OK", body)
+				}
+			},
+		},
+		{
+			name:            "build info",
+			includeBuild:    true,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			check: func(t *testing.T, body string) {
+				var payload map[string]string
+				if err := json.Unmarshal([]byte(body), &payload); err != nil {
+					t.Fatalf("failed to decode body: %v", err)
+				}
+				if payload["status"] != "ok" || payload["version"] == "" || payload["commit"] == "" || payload["uptime"] == "" {
+					t.Fatalf("payload = %#v, want status, version, commit, and uptime", payload)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			handler := NewHandler(&mockProvider{}, nil, nil, nil)
+			handler.healthStatusCode = tt.statusCode
+			handler.healthBody = tt.body
+			handler.healthIncludeBuildInfo = tt.includeBuild
+
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			rec := httptest.NewRecorder()
+			if err := handler.Health(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.wantContentType) {
+				t.Fatalf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if tt.check != nil {
+				tt.check(t, strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
 func TestListModels(t *testing.T) {
 	mock := &mockProvider{
 		modelsResponse: &core.ModelsResponse{
@@ -2798,6 +3029,46 @@ func TestListModels(t *testing.T) {
 	}
 }
 
+func TestListModels_SortOrder(t *testing.T) {
+	models := []core.Model{
+		// Listed by ID, as the registry returns them.
+		{ID: "anthropic/claude-sonnet", Object: "model", Created: 300, OwnedBy: "anthropic"},
+		{ID: "groq/llama", Object: "model", Created: 100, OwnedBy: "groq"},
+		{ID: "openai/gpt-4.1", Object: "model", Created: 300, OwnedBy: "openai"},
+		{ID: "openai/gpt-4o", Object: "model", Created: 200, OwnedBy: "openai"},
+		{ID: "smart", Object: "model", OwnedBy: "gomodel"},
+	}
+
+	tests := []struct {
+		order config.ModelListSortOrder
+		want  []string
+	}{
+		{order: "", want: []string{"anthropic/claude-sonnet", "groq/llama", "openai/gpt-4.1", "openai/gpt-4o", "smart"}},
+		{order: config.ModelListSortByID, want: []string{"anthropic/claude-sonnet", "groq/llama", "openai/gpt-4.1", "openai/gpt-4o", "smart"}},
+		{order: config.ModelListSortByCreated, want: []string{"anthropic/claude-sonnet", "openai/gpt-4.1", "openai/gpt-4o", "groq/llama", "smart"}},
+		{order: config.ModelListSortByProvider, want: []string{"anthropic/claude-sonnet", "smart", "groq/llama", "openai/gpt-4.1", "openai/gpt-4o"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			mock := &mockProvider{modelsResponse: &core.ModelsResponse{Object: "list", Data: slices.Clone(models)}}
+			handler := NewHandler(mock, nil, nil, nil)
+			handler.modelListSortOrder = tt.order
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+			rec := httptest.NewRecorder()
+			require.NoError(t, handler.ListModels(echo.New().NewContext(req, rec)))
+
+			var resp core.ModelsResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			got := make([]string, 0, len(resp.Data))
+			for _, model := range resp.Data {
+				got = append(got, model.ID)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestListModels_AnthropicDialect(t *testing.T) {
 	mock := &mockProvider{
 		modelsResponse: &core.ModelsResponse{
@@ -3099,6 +3370,35 @@ func TestHandleError_RateLimitError(t *testing.T) {
 	}
 }
 
+func TestHandleError_RateLimitErrorRelaysUpstreamRetryAfter(t *testing.T) {
+	rateLimited := core.NewRateLimitError("openai", "rate limit exceeded")
+	rateLimited.RetryAfter = 5 * time.Second
+	mock := &mockProvider{
+		supportedModels: []string{"gpt-4o-mini"},
+		err:             rateLimited,
+	}
+
+	e := echo.New()
+	handler := NewHandler(mock, nil, nil, nil)
+
+	reqBody := `{"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "Hi"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := handler.ChatCompletion(c); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("Retry-After = %q, want 5", got)
+	}
+}
+
 func TestHandleError_InvalidRequestError(t *testing.T) {
 	param := "model"
 	code := "model_not_found"
@@ -4257,7 +4557,7 @@ func TestBatches_InputFileRejectsUnsupportedExplicitProviderSelector(t *testing.
 
 	err = handler.Batches(c)
 	require.NoError(t, err)
-	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
 	require.Contains(t, rec.Body.String(), "unsupported model: openai/smart")
 	require.Contains(t, rec.Body.String(), `"code":"model_not_found"`)
 	require.Nil(t, mock.capturedBatchReq)
@@ -4359,7 +4659,7 @@ func TestBatches_InputFileRejectsDisabledAlias(t *testing.T) {
 
 	err = handler.Batches(c)
 	require.NoError(t, err)
-	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
 	require.Contains(t, rec.Body.String(), "unsupported model: smart")
 	require.Contains(t, rec.Body.String(), `"code":"model_not_found"`)
 	require.Nil(t, inner.capturedBatchReq)
@@ -6933,6 +7233,41 @@ func TestProviderPassthrough_AnthropicStream(t *testing.T) {
 	}
 }
 
+func TestProviderPassthrough_StreamWritesKeepalivesWhileUpstreamIsSlow(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("data: {\"type\":\"message_start\"}\n\n"))
+		time.Sleep(60 * time.Millisecond)
+		_ = pw.Close()
+	}()
+	provider := &mockProvider{
+		passthroughResponse: &core.PassthroughResponse{
+			StatusCode: http.StatusOK,
+			Headers: map[string][]string{
+				"Content-Type": {"text/event-stream"},
+			},
+			Body: pr,
+		},
+	}
+
+	e := echo.New()
+	handler := NewHandler(provider, nil, nil, nil)
+	handler.streamKeepaliveInterval = 10 * time.Millisecond
+	e.POST("/p/:provider/*", handler.ProviderPassthrough)
+
+	req := httptest.NewRequest(http.MethodPost, "/p/anthropic/messages", strings.NewReader(`{"model":"claude-sonnet-4-5"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Body.String(); !strings.Contains(got, "message_start\"}\n\n: keepalive\n\n") {
+		t.Fatalf("expected keepalive after the first passthrough event, got %q", got)
+	}
+}
+
 func TestProviderPassthrough_StreamWithoutObserversClosesUpstreamBodyOnce(t *testing.T) {
 	body := &closeCountingReadCloser{
 		ReadCloser: &chunkedReadCloser{
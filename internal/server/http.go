@@ -8,6 +8,7 @@ import (
 	"net/http"
 	httppprof "net/http/pprof"
 	"path"
+	"slices"
 	"strings"
 	"time"
 
@@ -39,18 +40,21 @@ type Server struct {
 	responseCacheMiddleware *responsecache.ResponseCacheMiddleware
 	responseStore           responsestore.Store
 	conversationStore       conversationstore.Store
+	timeouts                config.ServerTimeoutsConfig
 }
 
 const (
 	inboundServerReadTimeout       = 30 * time.Second
 	inboundServerReadHeaderTimeout = 10 * time.Second
 	inboundServerWriteTimeout      = 30 * time.Second
+	inboundServerIdleTimeout       = 120 * time.Second
 )
 
 // Config holds server configuration options
 type Config struct {
 	BasePath                        string                                 // URL path prefix where the app is mounted (default: /)
 	MasterKey                       string                                 // Optional: Master key for authentication
+	APIKeys                         []StaticAPIKey                         // Optional: static API keys from server.api_keys
 	Authenticator                   BearerTokenAuthenticator               // Optional: managed API key authenticator
 	MetricsEnabled                  bool                                   // Whether to expose Prometheus metrics endpoint
 	MetricsEndpoint                 string                                 // HTTP path for metrics endpoint (default: /metrics)
@@ -60,6 +64,8 @@ type Config struct {
 	UsageLogger                     usage.LoggerInterface                  // Optional: Usage logger for token tracking
 	BudgetChecker                   BudgetChecker                          // Optional: per-user-path budget checker
 	RateLimiter                     RateLimiter                            // Optional: per-user-path rate limiter
+	KeyRateLimit                    KeyRateLimitConfig                     // Optional: per-caller token bucket on /v1 and passthrough routes
+	CORS                            CORSConfig                             // Optional: CORS for /v1 routes; disabled without allowed origins
 	UsageSummarizer                 UsageSummarizer                        // Optional: usage aggregates for the self-service GET /v1/usage endpoint
 	PricingResolver                 usage.PricingResolver                  // Optional: Resolves pricing for cost calculation
 	ModelResolver                   RequestModelResolver                   // Optional: explicit model resolver used during workflow resolution
@@ -70,6 +76,13 @@ type Config struct {
 	BatchRequestPreparer            BatchRequestPreparer                   // Optional: batch request preparer before native provider submission
 	ExposedModelLister              ExposedModelLister                     // Optional: additional public models to merge into GET /v1/models
 	KeepOnlyAliasesAtModelsEndpoint bool                                   // Whether GET /v1/models should hide concrete provider models
+	ModelListSortOrder              config.ModelListSortOrder              // Order of GET /v1/models entries (default: by ID)
+	HealthStatusCode                int                                    // Status returned by GET /health (default: 200)
+	HealthBody                      string                                 // Optional: custom GET /health body replacing {"status":"ok"}
+	HealthIncludeBuildInfo          bool                                   // Whether the default GET /health body reports version, commit, and uptime
+	MaxToolsPerRequest              int                                    // Max tools per chat/Responses/Messages request; 0 disables the cap
+	StreamKeepaliveInterval         time.Duration                          // Idle time before an SSE keepalive comment is written; 0 disables
+	ProviderTypes                   []string                               // Provider types compiled into the binary, reported by GET /version
 	PassthroughSemanticEnrichers    []core.PassthroughSemanticEnricher     // Optional: provider-owned passthrough semantic enrichers before workflow resolution
 	BatchStore                      batchstore.Store                       // Optional: Batch lifecycle persistence store
 	FileStore                       filestore.Store                        // Optional: File provider mapping persistence store
@@ -77,6 +90,8 @@ type Config struct {
 	ConversationStore               conversationstore.Store                // Optional: Conversations lifecycle persistence store
 	LogOnlyModelInteractions        bool                                   // Only log AI model endpoints (default: true)
 	DisablePassthroughRoutes        bool                                   // Disable /p/{provider}/{endpoint} route registration
+	DisableResponsesRoutes          bool                                   // Disable /v1/responses route registration; those requests get 404
+	LenientRequestShape             bool                                   // Reroute chat/Responses bodies posted to the other endpoint by their messages/input shape; off installs no middleware
 	RealtimeEnabled                 bool                                   // Enable realtime websocket route /v1/realtime and passthrough upgrades
 	MCPEnabled                      bool                                   // Enable the MCP gateway routes /mcp and /mcp/{server}
 	MCPGateway                      *mcpgateway.Service                    // MCP gateway service (nil if disabled or not wired)
@@ -98,6 +113,9 @@ type Config struct {
 	ExtraRoutes                     []func(*echo.Echo)                     // Optional: extension route registration callbacks invoked after core routes
 	ExtraAuthSkipPaths              []string                               // Optional: extension paths appended to the auth skip list ("/*" suffix matches a prefix)
 	Tagging                         *tagging.Service                       // Optional: request labelling based on configured tagging headers
+	SamplingPolicyEnabled           bool                                   // Whether a sampling policy may clamp temperature/top_p; reported in X-GoModel-Sampling-Adjusted
+	OutputPacing                    config.OutputPacingConfig              // Optional: tokens-per-second pacing of streamed chat/Responses output
+	Timeouts                        config.ServerTimeoutsConfig            // Inbound http.Server timeouts; zero values use the built-in defaults
 }
 
 // ReadinessProbe verifies that a dependency the gateway owns is reachable.
@@ -121,10 +139,14 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 		}),
 	})
 	e.Logger = slog.Default()
+	e.HTTPErrorHandler = handleHTTPError
 	basePath := configuredBasePath(cfg)
 	if basePath != "/" {
 		e.Pre(stripBasePathMiddleware(basePath))
 	}
+	if cfg != nil && cfg.LenientRequestShape {
+		e.Pre(requestShapeRoutingMiddleware(parseBodySizeLimitBytes(cfg.BodySizeLimit), !cfg.DisableResponsesRoutes))
+	}
 	// Keep client IP handling explicit after Echo v5.1.0 changed RealIP defaults.
 	// Direct extraction is the safe baseline unless a caller opts into trusted
 	// proxy header handling via Config.IPExtractor.
@@ -168,8 +190,16 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 		handler.batchRequestPreparer = cfg.BatchRequestPreparer
 		handler.exposedModelLister = cfg.ExposedModelLister
 		handler.keepOnlyAliasesAtModelsEndpoint = cfg.KeepOnlyAliasesAtModelsEndpoint
+		handler.modelListSortOrder = cfg.ModelListSortOrder
+		handler.healthStatusCode = cfg.HealthStatusCode
+		handler.healthBody = cfg.HealthBody
+		handler.healthIncludeBuildInfo = cfg.HealthIncludeBuildInfo
+		handler.providerTypes = slices.Sorted(slices.Values(cfg.ProviderTypes))
 		handler.responseCache = cfg.ResponseCacheMiddleware
 		handler.guardrailsHash = cfg.GuardrailsHash
+		handler.maxToolsPerRequest = cfg.MaxToolsPerRequest
+		handler.streamKeepaliveInterval = cfg.StreamKeepaliveInterval
+		handler.outputPacing = cfg.OutputPacing
 		handler.storageProbe = cfg.StorageProbe
 		handler.cacheProbe = cfg.CacheProbe
 	}
@@ -200,7 +230,7 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	}
 
 	// Build list of paths that skip authentication
-	authSkipPaths := []string{"/health", "/health/ready"}
+	authSkipPaths := []string{"/health", "/health/ready", "/version"}
 
 	// Determine metrics path
 	metricsPath := "/metrics"
@@ -226,7 +256,8 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	}
 	// When no bootstrap master key is configured, keep admin APIs reachable so
 	// the dashboard can recover managed-key access instead of locking itself out.
-	if cfg != nil && cfg.MasterKey == "" && cfg.AdminEndpointsEnabled && cfg.AdminHandler != nil {
+	// Static server.api_keys turn this off: they never grant admin access.
+	if cfg != nil && cfg.MasterKey == "" && len(cfg.APIKeys) == 0 && cfg.AdminEndpointsEnabled && cfg.AdminHandler != nil {
 		authSkipPaths = append(authSkipPaths, "/admin/*")
 	}
 	if cfg != nil && cfg.SwaggerEnabled && SwaggerAvailable() {
@@ -240,43 +271,11 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	}
 
 	// Global middleware stack (order matters)
-	// Request logger with optional filtering for model-only interactions
-	if cfg != nil && cfg.LogOnlyModelInteractions {
-		e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
-			Skipper: func(c *echo.Context) bool {
-				return !core.IsModelInteractionPath(c.Request().URL.Path)
-			},
-			LogStatus:        true,
-			LogURI:           true,
-			LogMethod:        true,
-			LogLatency:       true,
-			LogProtocol:      true,
-			LogRemoteIP:      true,
-			LogHost:          true,
-			LogURIPath:       true,
-			LogUserAgent:     true,
-			LogRequestID:     true,
-			LogContentLength: true,
-			LogResponseSize:  true,
-			LogValuesFunc: func(c *echo.Context, v middleware.RequestLoggerValues) error {
-				slog.Info("REQUEST",
-					"method", v.Method,
-					"uri", v.URI,
-					"status", v.Status,
-					"latency", v.Latency.String(),
-					"host", v.Host,
-					"bytes_in", v.ContentLength,
-					"bytes_out", v.ResponseSize,
-					"user_agent", v.UserAgent,
-					"remote_ip", v.RemoteIP,
-					"request_id", v.RequestID,
-				)
-				return nil
-			},
-		}))
-	} else {
-		e.Use(middleware.RequestLogger())
-	}
+	// Access logger with optional filtering for model-only interactions. It runs
+	// outermost so the logged status includes recovered panics and handler errors.
+	e.Use(AccessLogMiddleware(AccessLogConfig{
+		OnlyModelInteractions: cfg != nil && cfg.LogOnlyModelInteractions,
+	}))
 	e.Use(middleware.Recover())
 
 	// Body size limit (default: 10MB)
@@ -296,6 +295,10 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 			return next(c)
 		}
 	})
+	e.Use(UpstreamRequestIDCapture())
+	if cfg != nil && cfg.SamplingPolicyEnabled {
+		e.Use(SamplingAdjustmentCapture())
+	}
 	e.Use(modelInteractionWriteDeadlineMiddleware())
 
 	// Ingress capture (before auth/audit/model validation so they can consume shared raw request state)
@@ -314,6 +317,12 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 		e.Use(PassthroughSemanticEnrichment(provider, cfg.PassthroughSemanticEnrichers, passthroughV1PrefixNormalizationEnabled(cfg)))
 	}
 
+	// CORS runs ahead of audit logging and auth: preflights are answered here
+	// and never logged or authenticated, while actual requests pass through.
+	if cfg != nil && len(cfg.CORS.AllowedOrigins) > 0 {
+		e.Use(CORSMiddleware(cfg.CORS))
+	}
+
 	// Audit logging runs before workflow resolution so early workflow resolution/validation
 	// failures are still logged. The middleware defers request capture and
 	// dynamically gates response capture on the final resolved workflow, so
@@ -331,8 +340,17 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	}
 
 	// Authentication (skips public paths)
-	if cfg != nil && (cfg.MasterKey != "" || cfg.Authenticator != nil) {
-		e.Use(AuthMiddlewareWithAuthenticator(cfg.MasterKey, cfg.Authenticator, authSkipPaths, userPathHeaderName))
+	authEnabled := cfg != nil && (cfg.MasterKey != "" || len(cfg.APIKeys) > 0 || cfg.Authenticator != nil)
+	if authEnabled {
+		e.Use(AuthMiddlewareWithAPIKeys(cfg.MasterKey, cfg.APIKeys, cfg.Authenticator, authSkipPaths, userPathHeaderName))
+	}
+
+	// Per-caller throttling runs after auth so callers are keyed by their
+	// auth key id; /health, /metrics, and admin routes are never limited.
+	if cfg != nil && cfg.KeyRateLimit.RequestsPerMinute > 0 {
+		keyRateLimit := cfg.KeyRateLimit
+		keyRateLimit.AuthEnabled = authEnabled
+		e.Use(RateLimitMiddleware(keyRateLimit))
 	}
 
 	// Request rewriters run post-auth (rewriters only see authenticated
@@ -351,6 +369,7 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	// Public routes
 	e.GET("/health", handler.Health)
 	e.GET("/health/ready", handler.Ready)
+	e.GET("/version", handler.Version)
 	registerSwagger(e, cfg)
 	if cfg != nil && cfg.MetricsEnabled {
 		e.GET(metricsPath, echo.WrapHandler(promhttp.Handler()))
@@ -381,6 +400,7 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	e.GET("/v1/models", handler.ListModels)
 	e.GET("/v1/usage", handler.UsageStatus)
 	e.POST("/v1/chat/completions", handler.ChatCompletion)
+	e.POST("/v1/completions", handler.Completions)
 	e.POST("/v1/messages", handler.Messages)
 	e.POST("/v1/messages/count_tokens", handler.CountMessageTokens)
 	e.POST("/v1/messages/batches", handler.MessagesBatches)
@@ -389,13 +409,15 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 	e.POST("/v1/messages/batches/:id/cancel", handler.CancelMessagesBatch)
 	e.DELETE("/v1/messages/batches/:id", handler.DeleteMessagesBatch)
 	e.GET("/v1/messages/batches/:id/results", handler.MessagesBatchResults)
-	e.POST("/v1/responses/input_tokens", handler.ResponseInputTokens)
-	e.POST("/v1/responses/compact", handler.CompactResponse)
-	e.GET("/v1/responses/:id/input_items", handler.ListResponseInputItems)
-	e.POST("/v1/responses/:id/cancel", handler.CancelResponse)
-	e.GET("/v1/responses/:id", handler.GetResponse)
-	e.DELETE("/v1/responses/:id", handler.DeleteResponse)
-	e.POST("/v1/responses", handler.Responses)
+	if cfg == nil || !cfg.DisableResponsesRoutes {
+		e.POST("/v1/responses/input_tokens", handler.ResponseInputTokens)
+		e.POST("/v1/responses/compact", handler.CompactResponse)
+		e.GET("/v1/responses/:id/input_items", handler.ListResponseInputItems)
+		e.POST("/v1/responses/:id/cancel", handler.CancelResponse)
+		e.GET("/v1/responses/:id", handler.GetResponse)
+		e.DELETE("/v1/responses/:id", handler.DeleteResponse)
+		e.POST("/v1/responses", handler.Responses)
+	}
 	e.POST("/v1/conversations", handler.CreateConversation)
 	e.GET("/v1/conversations/:id", handler.GetConversation)
 	e.POST("/v1/conversations/:id", handler.UpdateConversation)
@@ -429,12 +451,12 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 
 	// Admin API routes (behind ADMIN_ENDPOINTS_ENABLED flag)
 	if cfg != nil && cfg.AdminEndpointsEnabled && cfg.AdminHandler != nil {
-		cfg.AdminHandler.RegisterRoutes(e.Group("/admin"))
+		cfg.AdminHandler.RegisterRoutes(e.Group("/admin", adminMasterKeyOnlyMiddleware))
 
 		// Legacy alias under /admin/api/v1/* — accepted until adminLegacySunset
 		// to give operators a window to migrate. Responses carry Deprecation,
 		// Sunset, and Link headers per RFC 8594 / draft-ietf-httpapi-deprecation-header.
-		legacy := e.Group("/admin/api/v1", adminLegacyDeprecationMiddleware)
+		legacy := e.Group("/admin/api/v1", adminMasterKeyOnlyMiddleware, adminLegacyDeprecationMiddleware)
 		cfg.AdminHandler.RegisterRoutes(legacy)
 		// DashboardConfig moved within /admin from /dashboard/config to
 		// /runtime/config; preserve the historical legacy path explicitly.
@@ -465,6 +487,7 @@ func New(provider core.RoutableProvider, cfg *Config) *Server {
 		responseCacheMiddleware: rcm,
 		responseStore:           handler.currentResponseStore(),
 		conversationStore:       handler.conversationStore,
+		timeouts:                configuredServerTimeouts(cfg),
 	}
 }
 
@@ -493,16 +516,14 @@ func passthroughV1PrefixNormalizationEnabled(cfg *Config) bool {
 
 // Start starts the HTTP server on the given address and exits when ctx is canceled.
 func (s *Server) Start(ctx context.Context, addr string) error {
-	return newGatewayStartConfig(addr).Start(ctx, s.echo)
+	return newGatewayStartConfig(addr, s.timeouts).Start(ctx, s.echo)
 }
 
 // StartWithListener starts the HTTP server using a pre-bound listener.
 // This is useful in tests that need an already-reserved loopback port.
 func (s *Server) StartWithListener(ctx context.Context, listener net.Listener) error {
-	sc := echo.StartConfig{
-		HideBanner: true,
-		Listener:   listener,
-	}
+	sc := newGatewayStartConfig("", s.timeouts)
+	sc.Listener = listener
 	return sc.Start(ctx, s.echo)
 }
 
@@ -543,26 +564,48 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.echo.ServeHTTP(w, r)
 }
 
-func newGatewayStartConfig(addr string) echo.StartConfig {
+func newGatewayStartConfig(addr string, timeouts config.ServerTimeoutsConfig) echo.StartConfig {
 	return echo.StartConfig{
 		Address:    addr,
 		HideBanner: true,
 		BeforeServeFunc: func(server *http.Server) error {
-			return configureGatewayHTTPServer(server)
+			return configureGatewayHTTPServer(server, timeouts)
 		},
 	}
 }
 
-func configureGatewayHTTPServer(server *http.Server) error {
+// configuredServerTimeouts fills unset timeouts with the built-in defaults.
+func configuredServerTimeouts(cfg *Config) config.ServerTimeoutsConfig {
+	var timeouts config.ServerTimeoutsConfig
+	if cfg != nil {
+		timeouts = cfg.Timeouts
+	}
+	if timeouts.ReadHeader <= 0 {
+		timeouts.ReadHeader = inboundServerReadHeaderTimeout
+	}
+	if timeouts.Read <= 0 {
+		timeouts.Read = inboundServerReadTimeout
+	}
+	if timeouts.Write <= 0 {
+		timeouts.Write = inboundServerWriteTimeout
+	}
+	if timeouts.Idle <= 0 {
+		timeouts.Idle = inboundServerIdleTimeout
+	}
+	return timeouts
+}
+
+func configureGatewayHTTPServer(server *http.Server, timeouts config.ServerTimeoutsConfig) error {
 	if server == nil {
 		return nil
 	}
 
 	// Keep an explicit server-wide write timeout for ordinary routes. Long-lived
 	// model interaction routes clear it per request before provider work begins.
-	server.ReadTimeout = inboundServerReadTimeout
-	server.ReadHeaderTimeout = inboundServerReadHeaderTimeout
-	server.WriteTimeout = inboundServerWriteTimeout
+	server.ReadTimeout = timeouts.Read
+	server.ReadHeaderTimeout = timeouts.ReadHeader
+	server.WriteTimeout = timeouts.Write
+	server.IdleTimeout = timeouts.Idle
 	return nil
 }
 
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"context"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/config"
 )
 
 func TestConfigureGatewayHTTPServer_PreservesServerWriteTimeoutDefault(t *testing.T) {
@@ -15,7 +20,7 @@ func TestConfigureGatewayHTTPServer_PreservesServerWriteTimeoutDefault(t *testin
 		WriteTimeout: 30 * time.Second,
 	}
 
-	if err := configureGatewayHTTPServer(server); err != nil {
+	if err := configureGatewayHTTPServer(server, configuredServerTimeouts(nil)); err != nil {
 		t.Fatalf("configureGatewayHTTPServer() error = %v", err)
 	}
 
@@ -31,7 +36,7 @@ func TestConfigureGatewayHTTPServer_PreservesServerWriteTimeoutDefault(t *testin
 }
 
 func TestNewGatewayStartConfig_AppliesTimeoutOverrides(t *testing.T) {
-	cfg := newGatewayStartConfig(":0")
+	cfg := newGatewayStartConfig(":0", configuredServerTimeouts(nil))
 	if cfg.BeforeServeFunc == nil {
 		t.Fatal("BeforeServeFunc = nil, want configured server overrides")
 	}
@@ -50,6 +55,72 @@ func TestNewGatewayStartConfig_AppliesTimeoutOverrides(t *testing.T) {
 	if got := server.WriteTimeout; got != inboundServerWriteTimeout {
 		t.Fatalf("WriteTimeout = %v, want %v", got, inboundServerWriteTimeout)
 	}
+	if got := server.IdleTimeout; got != inboundServerIdleTimeout {
+		t.Fatalf("IdleTimeout = %v, want %v", got, inboundServerIdleTimeout)
+	}
+}
+
+func TestConfiguredServerTimeouts_AppliesOverrides(t *testing.T) {
+	timeouts := configuredServerTimeouts(&Config{Timeouts: config.ServerTimeoutsConfig{
+		ReadHeader: 2 * time.Second,
+		Idle:       time.Minute,
+	}})
+
+	server := &http.Server{}
+	if err := configureGatewayHTTPServer(server, timeouts); err != nil {
+		t.Fatalf("configureGatewayHTTPServer() error = %v", err)
+	}
+	if got := server.ReadHeaderTimeout; got != 2*time.Second {
+		t.Fatalf("ReadHeaderTimeout = %v, want 2s", got)
+	}
+	if got := server.IdleTimeout; got != time.Minute {
+		t.Fatalf("IdleTimeout = %v, want 1m", got)
+	}
+	if got := server.ReadTimeout; got != inboundServerReadTimeout {
+		t.Fatalf("ReadTimeout = %v, want default %v", got, inboundServerReadTimeout)
+	}
+	if got := server.WriteTimeout; got != inboundServerWriteTimeout {
+		t.Fatalf("WriteTimeout = %v, want default %v", got, inboundServerWriteTimeout)
+	}
+}
+
+func TestStartWithListener_ClosesSlowHeaderConnections(t *testing.T) {
+	srv := New(&mockProvider{}, &Config{Timeouts: config.ServerTimeoutsConfig{ReadHeader: 100 * time.Millisecond}})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.StartWithListener(ctx, listener)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Send a request line but never finish the headers.
+	if _, err := conn.Write([]byte("GET /health HTTP/1.1\r\nHost: localhost\r\n")); err != nil {
+		t.Fatalf("conn.Write() error = %v", err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("SetReadDeadline() error = %v", err)
+	}
+	started := time.Now()
+	if _, err := io.ReadAll(conn); err != nil {
+		t.Fatalf("connection was not closed by the server: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Fatalf("server closed the slow connection after %v, want about the 100ms header timeout", elapsed)
+	}
 }
 
 func TestModelInteractionWriteDeadlineMiddleware_ClearsDeadlineForModelRoutes(t *testing.T) {
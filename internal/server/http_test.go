@@ -152,6 +152,96 @@ func TestStartWithListener(t *testing.T) {
 	t.Fatalf("health check never succeeded, last error: %v", lastErr)
 }
 
+// blockingModelsProvider holds ListModels until released, so a request can be
+// kept in flight while the server shuts down.
+type blockingModelsProvider struct {
+	*mockProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingModelsProvider) ListModels(ctx context.Context) (*core.ModelsResponse, error) {
+	close(p.started)
+	<-p.release
+	return p.mockProvider.ListModels(ctx)
+}
+
+func TestStartWithListener_DrainsInFlightRequestsOnShutdown(t *testing.T) {
+	provider := &blockingModelsProvider{
+		mockProvider: &mockProvider{modelsResponse: &core.ModelsResponse{Object: "list"}},
+		started:      make(chan struct{}),
+		release:      make(chan struct{}),
+	}
+	srv := New(provider, nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	addr := listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.StartWithListener(ctx, listener)
+	}()
+
+	type result struct {
+		status int
+		err    error
+	}
+	inFlight := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/v1/models")
+		if err != nil {
+			inFlight <- result{err: err}
+			return
+		}
+		_ = resp.Body.Close()
+		inFlight <- result{status: resp.StatusCode}
+	}()
+
+	select {
+	case <-provider.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight request never reached the provider")
+	}
+
+	cancel()
+	// Shutdown closes the listener first; wait until new connections fail.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err != nil {
+			break
+		}
+		_ = conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("server still accepting connections after shutdown started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("server stopped before in-flight request finished: %v", err)
+	default:
+	}
+
+	close(provider.release)
+	got := <-inFlight
+	if got.err != nil {
+		t.Fatalf("in-flight request error = %v", got.err)
+	}
+	if got.status != http.StatusOK {
+		t.Fatalf("in-flight request status = %d, want %d", got.status, http.StatusOK)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("StartWithListener() error = %v", err)
+	}
+}
+
 func TestMetricsEndpoint(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -630,6 +720,7 @@ func TestAdminWorkflowEndpoints_AreRegistered(t *testing.T) {
 		{method: http.MethodGet, path: "/admin/runtime/config"},
 		{method: http.MethodGet, path: "/admin/providers/status"},
 		{method: http.MethodPost, path: "/admin/runtime/refresh"},
+		{method: http.MethodPost, path: "/admin/models/refresh"},
 		{method: http.MethodGet, path: "/admin/auth-keys"},
 		{method: http.MethodPost, path: "/admin/auth-keys"},
 		{method: http.MethodPost, path: "/admin/auth-keys/test-key/deactivate"},
@@ -819,6 +910,75 @@ func TestAdminAPI_RequiresAuth(t *testing.T) {
 	}
 }
 
+func TestAdminModelRefresh_RequiresMasterKey(t *testing.T) {
+	mock := &mockProvider{}
+	adminHandler := admin.NewHandler(nil, providers.NewModelRegistry())
+	srv := New(mock, &Config{
+		MasterKey:             "test-secret-key",
+		APIKeys:               []StaticAPIKey{{Name: "team-a", Key: "team-a-key"}},
+		AdminEndpointsEnabled: true,
+		AdminHandler:          adminHandler,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/models/refresh", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without auth, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	apiKeyReq := httptest.NewRequest(http.MethodPost, "/admin/models/refresh", nil)
+	apiKeyReq.Header.Set("Authorization", "Bearer team-a-key")
+	apiKeyRec := httptest.NewRecorder()
+	srv.ServeHTTP(apiKeyRec, apiKeyReq)
+	if apiKeyRec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-master key, got %d body=%s", apiKeyRec.Code, apiKeyRec.Body.String())
+	}
+
+	authReq := httptest.NewRequest(http.MethodPost, "/admin/models/refresh", nil)
+	authReq.Header.Set("Authorization", "Bearer test-secret-key")
+	authRec := httptest.NewRecorder()
+	srv.ServeHTTP(authRec, authReq)
+	if authRec.Code != http.StatusOK {
+		t.Fatalf("expected authorized refresh 200, got %d body=%s", authRec.Code, authRec.Body.String())
+	}
+}
+
+func TestAdminAPI_RefusesStaticAPIKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		masterKey  string
+		token      string
+		wantStatus int
+	}{
+		{name: "api keys only, no credentials", wantStatus: http.StatusUnauthorized},
+		{name: "api keys only, api key", token: "team-a-key", wantStatus: http.StatusForbidden},
+		{name: "master key set, api key", masterKey: "test-secret-key", token: "team-a-key", wantStatus: http.StatusForbidden},
+		{name: "master key set, master key", masterKey: "test-secret-key", token: "test-secret-key", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := New(&mockProvider{}, &Config{
+				MasterKey:             tt.masterKey,
+				APIKeys:               []StaticAPIKey{{Name: "team-a", Key: "team-a-key"}},
+				AdminEndpointsEnabled: true,
+				AdminHandler:          admin.NewHandler(nil, nil),
+			})
+			for _, path := range []string{"/admin/models", "/admin/api/v1/models"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				if tt.token != "" {
+					req.Header.Set("Authorization", "Bearer "+tt.token)
+				}
+				rec := httptest.NewRecorder()
+				srv.ServeHTTP(rec, req)
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("%s status = %d, want %d body=%s", path, rec.Code, tt.wantStatus, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
 func TestAdminAPI_SkipsAuthWithoutMasterKey(t *testing.T) {
 	mock := &mockProvider{}
 	adminHandler := admin.NewHandler(nil, nil)
@@ -1123,6 +1283,52 @@ func TestProviderPassthroughRoute_EnabledByDefault(t *testing.T) {
 	}
 }
 
+func TestResponsesRoutes_DisabledReturn404(t *testing.T) {
+	newProvider := func() *mockProvider {
+		return &mockProvider{
+			supportedModels:   []string{"gpt-5-mini"},
+			providerTypes:     map[string]string{"gpt-5-mini": "mock"},
+			response:          &core.ChatResponse{ID: "chatcmpl-1", Object: "chat.completion", Model: "gpt-5-mini"},
+			responsesResponse: &core.ResponsesResponse{ID: "resp_1", Object: "response", Model: "gpt-5-mini", Status: "completed"},
+			streamData:        "data: {\"type\":\"response.completed\"}\n\ndata: [DONE]\n\n",
+		}
+	}
+	bodies := map[string]string{
+		"non-streaming": `{"model":"gpt-5-mini","input":"hi"}`,
+		"streaming":     `{"model":"gpt-5-mini","input":"hi","stream":true}`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			enabled := postResponses(t, New(newProvider(), &Config{}), body)
+			if enabled.Code != http.StatusOK {
+				t.Fatalf("enabled status = %d, want 200 body=%s", enabled.Code, enabled.Body.String())
+			}
+
+			disabled := postResponses(t, New(newProvider(), &Config{DisableResponsesRoutes: true}), body)
+			if disabled.Code != http.StatusNotFound {
+				t.Fatalf("disabled status = %d, want 404 body=%s", disabled.Code, disabled.Body.String())
+			}
+		})
+	}
+
+	srv := New(newProvider(), &Config{DisableResponsesRoutes: true})
+	for _, path := range []string{"/v1/responses/resp_1", "/v1/responses/resp_1/input_items"} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("GET %s status = %d, want 404", path, rec.Code)
+		}
+	}
+	chat := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"gpt-5-mini","messages":[{"role":"user","content":"hi"}]}`))
+	chat.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, chat)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("chat completions status = %d, want 200 with the Responses API disabled", rec.Code)
+	}
+}
+
 func TestProviderPassthroughRoute_DisabledRequiresAuthBefore404(t *testing.T) {
 	mock := &mockProvider{}
 	srv := New(mock, &Config{
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// sortModels returns a GET /v1/models listing in the configured order without
+// mutating the input. The registry already lists models by ID, so the default
+// order keeps the listing as is; the other orders break ties by ID so
+// responses stay deterministic.
+func sortModels(models []core.Model, order config.ModelListSortOrder) []core.Model {
+	switch config.ResolveModelListSortOrder(order) {
+	case config.ModelListSortByCreated:
+		models = slices.Clone(models)
+		slices.SortStableFunc(models, func(a, b core.Model) int {
+			if a.Created != b.Created {
+				if a.Created > b.Created {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+	case config.ModelListSortByProvider:
+		models = slices.Clone(models)
+		slices.SortStableFunc(models, func(a, b core.Model) int {
+			if c := strings.Compare(a.OwnedBy, b.OwnedBy); c != 0 {
+				return c
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+	}
+	return models
+}
@@ -144,11 +144,11 @@ func TestModelValidation(t *testing.T) {
 			handlerCalled:  false,
 		},
 		{
-			name:           "unsupported model returns 404 model_not_found",
+			name:           "unsupported model returns 400 model_not_found",
 			method:         http.MethodPost,
 			path:           "/v1/chat/completions",
 			body:           `{"model":"unsupported-model","messages":[{"role":"user","content":"hi"}]}`,
-			expectedStatus: http.StatusNotFound,
+			expectedStatus: http.StatusBadRequest,
 			expectedBody:   "model_not_found",
 			handlerCalled:  false,
 		},
@@ -709,10 +709,39 @@ func TestModelValidation_RegistryNotInitializedReturnsGatewayError(t *testing.T)
 	require.NoError(t, err)
 
 	assert.False(t, handlerCalled)
-	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
 	assert.Contains(t, rec.Body.String(), "model registry not initialized")
 }
 
+func TestModelValidation_ModelNotFoundStatusIsConfigurable(t *testing.T) {
+	t.Cleanup(func() { core.SetModelNotFoundStatus(http.StatusBadRequest) })
+	provider := &mockProvider{supportedModels: []string{"gpt-4o-mini"}}
+
+	tests := []struct {
+		status   int
+		wantType string
+	}{
+		{status: http.StatusBadRequest, wantType: "invalid_request_error"},
+		{status: http.StatusNotFound, wantType: "invalid_request_error"},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			core.SetModelNotFoundStatus(tt.status)
+			handler := WorkflowResolution(provider)(func(c *echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"unsupported-model","messages":[{"role":"user","content":"hi"}]}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			require.NoError(t, handler(echo.New().NewContext(req, rec)))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.JSONEq(t, `{"error":{"message":"unsupported model: unsupported-model","type":"`+tt.wantType+`","param":null,"code":"model_not_found"}}`, rec.Body.String())
+		})
+	}
+}
+
 func TestModelValidation_EnrichesAuditEntryWithRequestedModelOnResolutionError(t *testing.T) {
 	store := newAliasesTestStore(redirectVM("smart", "gpt-4o", "openai", false))
 	catalog := &aliasesTestCatalog{
@@ -756,12 +785,12 @@ func TestModelValidation_EnrichesAuditEntryWithRequestedModelOnResolutionError(t
 	require.NoError(t, err)
 
 	assert.False(t, handlerCalled)
-	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	assert.Contains(t, rec.Body.String(), "unsupported model: smart")
 	assert.Equal(t, "smart", entry.RequestedModel)
 	assert.Equal(t, "", entry.ResolvedModel)
 	assert.Equal(t, "", entry.Provider)
-	assert.Equal(t, "not_found_error", entry.ErrorType)
+	assert.Equal(t, "invalid_request_error", entry.ErrorType)
 }
 
 func TestModelValidation_DefersOversizedLiveBodyResolutionToHandler(t *testing.T) {
@@ -0,0 +1,18 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PartialOnCancelHeader opts a non-streaming chat completion into partial
+// responses: the gateway streams from the upstream internally and, if the
+// request is cancelled mid-flight, returns the content generated so far with
+// finish_reason "cancelled" instead of an error.
+const PartialOnCancelHeader = "X-GoModel-Partial-On-Cancel"
+
+func wantsPartialOnCancel(req *http.Request) bool {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(req.Header.Get(PartialOnCancelHeader)))
+	return err == nil && enabled
+}
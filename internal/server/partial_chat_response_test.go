@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/cache"
+	"github.com/enterpilot/gomodel/internal/core"
+	"github.com/enterpilot/gomodel/internal/gateway"
+	"github.com/enterpilot/gomodel/internal/responsecache"
+)
+
+// stallingStreamProvider emits a partial stream, then blocks until the
+// request context is cancelled.
+type stallingStreamProvider struct {
+	*mockProvider
+	emitted chan struct{}
+}
+
+func (p *stallingStreamProvider) StreamChatCompletion(ctx context.Context, _ *core.ChatRequest) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("data: {\"id\":\"chatcmpl-1\",\"model\":\"gpt-4o-mini\",\"system_fingerprint\":\"fp_abc123\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hel\"}}]}\n\n"))
+		_, _ = pw.Write([]byte("data: {\"id\":\"chatcmpl-1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n"))
+		close(p.emitted)
+		<-ctx.Done()
+		_ = pw.CloseWithError(ctx.Err())
+	}()
+	return pr, nil
+}
+
+func TestChatCompletion_PartialOnCancelReturnsCollectedContent(t *testing.T) {
+	provider := &stallingStreamProvider{
+		mockProvider: &mockProvider{supportedModels: []string{"gpt-4o-mini"}},
+		emitted:      make(chan struct{}),
+	}
+	handler := NewHandler(provider, nil, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-provider.emitted
+		cancel()
+	}()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"gpt-4o-mini","messages":[{"role":"user","content":"Hi"}]}`)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(PartialOnCancelHeader, "true")
+	rec := httptest.NewRecorder()
+
+	if err := handler.ChatCompletion(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp core.ChatResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Choices) != 1 {
+		t.Fatalf("choices = %d, want 1", len(resp.Choices))
+	}
+	if got := resp.Choices[0].Message.Content; got != "Hello" {
+		t.Fatalf("content = %v, want Hello", got)
+	}
+	if got := resp.Choices[0].FinishReason; got != gateway.FinishReasonCancelled {
+		t.Fatalf("finish_reason = %q, want %q", got, gateway.FinishReasonCancelled)
+	}
+	if resp.ID != "chatcmpl-1" || resp.Object != "chat.completion" {
+		t.Fatalf("unexpected id/object: %q %q", resp.ID, resp.Object)
+	}
+	if resp.SystemFingerprint != "fp_abc123" {
+		t.Fatalf("system_fingerprint = %q, want fp_abc123", resp.SystemFingerprint)
+	}
+}
+
+func TestChatCompletion_PartialOnCancelCompletedStreamKeepsFinishReason(t *testing.T) {
+	mock := &mockProvider{
+		supportedModels: []string{"gpt-4o-mini"},
+		streamData: "data: {\"id\":\"chatcmpl-2\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n" +
+			"data: {\"id\":\"chatcmpl-2\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":1,\"total_tokens\":4}}\n\n" +
+			"data: [DONE]\n\n",
+	}
+	handler := NewHandler(mock, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"gpt-4o-mini","messages":[{"role":"user","content":"Hi"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(PartialOnCancelHeader, "true")
+	rec := httptest.NewRecorder()
+
+	if err := handler.ChatCompletion(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	var resp core.ChatResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].FinishReason != "stop" || resp.Choices[0].Message.Content != "Hi" {
+		t.Fatalf("unexpected choices: %+v", resp.Choices)
+	}
+	if resp.Usage.TotalTokens != 4 {
+		t.Fatalf("usage total = %d, want 4", resp.Usage.TotalTokens)
+	}
+}
+
+func TestChatCompletion_PartialOnCancelBypassesResponseCache(t *testing.T) {
+	const body = `{"model":"gpt-4o-mini","messages":[{"role":"user","content":"Hi"}]}`
+	store := cache.NewMapStore()
+	provider := &stallingStreamProvider{
+		mockProvider: &mockProvider{
+			supportedModels: []string{"gpt-4o-mini"},
+			response: &core.ChatResponse{
+				ID:      "chatcmpl-full",
+				Object:  "chat.completion",
+				Model:   "gpt-4o-mini",
+				Choices: []core.Choice{{FinishReason: "stop", Message: core.ResponseMessage{Role: "assistant", Content: "Hello there"}}},
+			},
+		},
+		emitted: make(chan struct{}),
+	}
+
+	partialCache := responsecache.NewResponseCacheMiddlewareWithStore(store, time.Hour)
+	handler := NewHandler(provider, nil, nil, nil)
+	handler.responseCache = partialCache
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-provider.emitted
+		cancel()
+	}()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(PartialOnCancelHeader, "true")
+	rec := httptest.NewRecorder()
+	if err := handler.ChatCompletion(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if !strings.Contains(rec.Body.String(), gateway.FinishReasonCancelled) {
+		t.Fatalf("body = %s, want a cancelled partial completion", rec.Body.String())
+	}
+	// Close flushes pending cache writes; the map store survives it.
+	if err := partialCache.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	handler = NewHandler(provider, nil, nil, nil)
+	handler.responseCache = responsecache.NewResponseCacheMiddlewareWithStore(store, time.Hour)
+	req = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	if err := handler.ChatCompletion(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if got := rec.Header().Get("X-Cache"); got != "" {
+		t.Fatalf("X-Cache = %q, want a cache miss", got)
+	}
+	if !strings.Contains(rec.Body.String(), "Hello there") {
+		t.Fatalf("body = %s, want the full provider response", rec.Body.String())
+	}
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v5"
 
 	"github.com/enterpilot/gomodel/internal/auditlog"
@@ -18,6 +20,7 @@ type passthroughService struct {
 	pricingResolver              usage.PricingResolver
 	normalizePassthroughV1Prefix bool
 	enabledPassthroughProviders  map[string]struct{}
+	streamKeepaliveInterval      time.Duration
 }
 
 func (s *passthroughService) ProviderPassthrough(c *echo.Context) error {
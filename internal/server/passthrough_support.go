@@ -299,6 +299,7 @@ func (s *passthroughService) proxyPassthroughResponse(c *echo.Context, providerT
 				observer.SetProviderName(providerName)
 				observer.SetLabels(core.RequestLabelsFromContext(c.Request().Context()))
 				observer.SetRewriteTokensSaved(core.RewriteTokensSavedFromContext(c.Request().Context()))
+				observer.SetUpstreamRequestID(core.GetUpstreamRequestID(c.Request().Context()))
 				observers = append(observers, observer)
 			}
 		}
@@ -310,7 +311,7 @@ func (s *passthroughService) proxyPassthroughResponse(c *echo.Context, providerT
 		}
 
 		c.Response().WriteHeader(resp.StatusCode)
-		if err := flushStream(c.Response(), wrappedStream); err != nil {
+		if err := flushStreamWithKeepalive(c.Response(), wrappedStream, s.streamKeepaliveInterval); err != nil {
 			recordStreamingError(streamEntry, model, providerType, c.Request().URL.Path, requestID, c.Request().Context(), err)
 			return err
 		}
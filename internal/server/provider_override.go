@@ -0,0 +1,38 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// ProviderOverrideHeader lets a master-key caller force the provider for a
+// single request, bypassing normal routing. It is ignored for managed API keys
+// and unauthenticated deployments so ordinary clients cannot steer traffic.
+const ProviderOverrideHeader = "X-GoModel-Provider"
+
+// applyProviderOverride records the requested provider override on the request
+// context. Callers must only invoke it for master-key requests.
+func applyProviderOverride(c *echo.Context) {
+	provider := strings.TrimSpace(c.Request().Header.Get(ProviderOverrideHeader))
+	if provider == "" {
+		return
+	}
+	c.SetRequest(c.Request().WithContext(core.WithProviderOverride(c.Request().Context(), provider)))
+}
+
+// overrideProviderHint swaps the caller's provider hint for a forced provider
+// when one is set. A "provider/model" selector is reduced to its raw model ID
+// so the same request body can be replayed against different providers.
+func overrideProviderHint(c *echo.Context, model, providerHint string) (string, string) {
+	override := core.GetProviderOverride(c.Request().Context())
+	if override == "" {
+		return model, providerHint
+	}
+	if selector, err := core.ParseModelSelector(model, providerHint); err == nil {
+		model = selector.Model
+	}
+	return model, override
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// keyRateLimitSweepInterval is how often idle buckets are dropped. A bucket
+// that has refilled to capacity carries no state worth keeping.
+const keyRateLimitSweepInterval = time.Minute
+
+// KeyRateLimitConfig configures RateLimitMiddleware.
+type KeyRateLimitConfig struct {
+	// RequestsPerMinute is the refill rate of each caller's bucket.
+	// Zero disables the middleware.
+	RequestsPerMinute int
+	// Burst is the bucket capacity; zero defaults to RequestsPerMinute.
+	Burst int
+	// AuthEnabled keys unlabelled credentials (the master key) by token.
+	// Without auth, tokens are caller-chosen, so callers are keyed by IP.
+	AuthEnabled bool
+}
+
+// RateLimitMiddleware throttles each caller with an in-memory token bucket on
+// /v1 and passthrough routes. Callers are identified by auth key id, then by
+// credential when auth is enabled, then by client IP. Requests over the limit
+// get a 429 in the gateway error format with a Retry-After header. It must
+// run after authentication so the auth key id is known.
+func RateLimitMiddleware(cfg KeyRateLimitConfig) echo.MiddlewareFunc {
+	limiter := newKeyRateLimiter(cfg.RequestsPerMinute, cfg.Burst, time.Now)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if limiter == nil {
+			return next
+		}
+		return func(c *echo.Context) error {
+			if !keyRateLimitedPath(c.Request().URL.Path) {
+				return next(c)
+			}
+			allowed, retryAfter := limiter.take(keyRateLimitKey(c, cfg.AuthEnabled))
+			if allowed {
+				return next(c)
+			}
+			c.Response().Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(retryAfter), 10))
+			return writeGatewayError(c, core.NewRateLimitError("", "rate limit exceeded for this API key").WithCode("rate_limit_exceeded"))
+		}
+	}
+}
+
+func keyRateLimitedPath(path string) bool {
+	return path == "/v1" || strings.HasPrefix(path, "/v1/") || strings.HasPrefix(path, "/p/")
+}
+
+// keyRateLimitKey names the bucket for a request. Credentials are hashed so
+// raw secrets are never held as map keys.
+func keyRateLimitKey(c *echo.Context, authEnabled bool) string {
+	if id := core.GetAuthKeyID(c.Request().Context()); id != "" {
+		return "key:" + id
+	}
+	if authEnabled {
+		if token, errMessage := requestAuthToken(c.Request()); errMessage == "" && token != "" {
+			sum := sha256.Sum256([]byte(token))
+			return "token:" + hex.EncodeToString(sum[:])
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// keyRateLimiter holds one token bucket per caller key.
+type keyRateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	perSecond float64
+	burst     float64
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func newKeyRateLimiter(requestsPerMinute, burst int, now func() time.Time) *keyRateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = requestsPerMinute
+	}
+	return &keyRateLimiter{
+		buckets:   make(map[string]*tokenBucket),
+		perSecond: float64(requestsPerMinute) / 60,
+		burst:     float64(burst),
+		now:       now,
+		lastSweep: now(),
+	}
+}
+
+// take spends one token from key's bucket. When the bucket is empty it
+// returns false and how long until a token is available.
+func (l *keyRateLimiter) take(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = l.refilled(bucket, now)
+	bucket.last = now
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := (1 - bucket.tokens) / l.perSecond
+	return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+}
+
+func (l *keyRateLimiter) refilled(bucket *tokenBucket, now time.Time) float64 {
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed <= 0 {
+		return bucket.tokens
+	}
+	return math.Min(l.burst, bucket.tokens+elapsed*l.perSecond)
+}
+
+func (l *keyRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < keyRateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if l.refilled(bucket, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestKeyRateLimiter_ExhaustsAndRefills(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	limiter := newKeyRateLimiter(60, 3, func() time.Time { return now })
+
+	for i := range 3 {
+		allowed, _ := limiter.take("key:a")
+		require.True(t, allowed, "request %d within burst", i+1)
+	}
+	allowed, retryAfter := limiter.take("key:a")
+	assert.False(t, allowed, "bucket should be exhausted after the burst")
+	assert.Equal(t, time.Second, retryAfter)
+
+	otherAllowed, _ := limiter.take("key:b")
+	assert.True(t, otherAllowed, "other keys have their own bucket")
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, retryAfter = limiter.take("key:a")
+	assert.False(t, allowed, "half a token is not enough")
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.take("key:a")
+	assert.True(t, allowed, "one token refills per second at 60 rpm")
+
+	now = now.Add(time.Hour)
+	for i := range 3 {
+		allowed, _ := limiter.take("key:a")
+		require.True(t, allowed, "refill is capped at burst; request %d", i+1)
+	}
+	allowed, _ = limiter.take("key:a")
+	assert.False(t, allowed, "refill must not exceed the burst")
+}
+
+func TestKeyRateLimiter_SweepsIdleBuckets(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	limiter := newKeyRateLimiter(60, 1, func() time.Time { return now })
+
+	limiter.take("ip:192.0.2.1")
+	require.Len(t, limiter.buckets, 1)
+
+	now = now.Add(2 * keyRateLimitSweepInterval)
+	limiter.take("ip:192.0.2.2")
+	assert.Len(t, limiter.buckets, 1, "the refilled bucket should be dropped")
+}
+
+func TestNewKeyRateLimiter_DisabledAndDefaultBurst(t *testing.T) {
+	assert.Nil(t, newKeyRateLimiter(0, 10, time.Now))
+
+	limiter := newKeyRateLimiter(120, 0, time.Now)
+	require.NotNil(t, limiter)
+	assert.Equal(t, float64(120), limiter.burst)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if id := c.Request().Header.Get("X-Test-Key-ID"); id != "" {
+				c.SetRequest(c.Request().WithContext(core.WithAuthKeyID(c.Request().Context(), id)))
+			}
+			return next(c)
+		}
+	})
+	e.Use(RateLimitMiddleware(KeyRateLimitConfig{RequestsPerMinute: 1, Burst: 1, AuthEnabled: true}))
+	ok := func(c *echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.POST("/v1/chat/completions", ok)
+	e.GET("/health", ok)
+
+	send := func(method, path, keyID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if keyID != "" {
+			req.Header.Set("X-Test-Key-ID", keyID)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/v1/chat/completions", "team-a").Code)
+
+	rec := send(http.MethodPost, "/v1/chat/completions", "team-a")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":{"message":"rate limit exceeded for this API key","type":"rate_limit_error","param":null,"code":"rate_limit_exceeded"}}`, rec.Body.String())
+
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/v1/chat/completions", "team-b").Code, "keys are limited independently")
+
+	for range 3 {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, "/health", "team-a").Code, "/health is never limited")
+	}
+}
+
+func TestKeyRateLimitKey(t *testing.T) {
+	e := echo.New()
+	newContext := func(authorization string) *echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+		req.RemoteAddr = "192.0.2.10:4321"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	c := newContext("Bearer master")
+	c.SetRequest(c.Request().WithContext(core.WithAuthKeyID(c.Request().Context(), "team-a")))
+	assert.Equal(t, "key:team-a", keyRateLimitKey(c, true))
+
+	tokenKey := keyRateLimitKey(newContext("Bearer master"), true)
+	assert.Contains(t, tokenKey, "token:")
+	assert.NotContains(t, tokenKey, "master", "raw credentials must not be used as keys")
+
+	assert.Equal(t, "ip:192.0.2.10", keyRateLimitKey(newContext("Bearer anything"), false))
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// RawResponseHeader opts a non-streaming chat completion into returning the
+// upstream provider's JSON body unmodified instead of the translated
+// core.ChatResponse, for inspecting fields the translation drops. Only
+// requests authenticated with the master key may use it, so it is ignored when
+// auth is disabled and for API keys; raw requests bypass the response cache.
+const RawResponseHeader = "X-GoModel-Raw"
+
+// rawResponseHeaderKey is looked up directly so the hot path does not
+// canonicalize the mixed-case header name on every request.
+var rawResponseHeaderKey = http.CanonicalHeaderKey(RawResponseHeader)
+
+func wantsRawResponse(req *http.Request) bool {
+	values := req.Header[rawResponseHeaderKey]
+	if len(values) == 0 || !core.IsMasterKeyAuth(req.Context()) {
+		return false
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(values[0]))
+	return err == nil && enabled
+}
+
+// rawResponseContext installs a raw response recorder when the caller asked
+// for the provider-native body, or returns nil.
+func rawResponseContext(c *echo.Context) *core.RawResponseRecorder {
+	req := c.Request()
+	if !wantsRawResponse(req) {
+		return nil
+	}
+	ctx, recorder := core.WithRawResponseRecorder(req.Context())
+	c.SetRequest(req.WithContext(ctx))
+	return recorder
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// rawRecordingProvider mimics a provider client that records the upstream
+// body before translating it.
+type rawRecordingProvider struct {
+	*mockProvider
+	raw string
+}
+
+func (p *rawRecordingProvider) ChatCompletion(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	core.RecordRawResponse(ctx, "application/json", []byte(p.raw))
+	return p.mockProvider.ChatCompletion(ctx, req)
+}
+
+func TestChatCompletion_RawResponseHeader(t *testing.T) {
+	const upstream = `{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-5-mini","service_tier":"flex","choices":[]}`
+	provider := &rawRecordingProvider{
+		mockProvider: &mockProvider{
+			supportedModels: []string{"gpt-5-mini"},
+			providerTypes:   map[string]string{"gpt-5-mini": "mock"},
+			response:        &core.ChatResponse{ID: "chatcmpl-1", Object: "chat.completion", Model: "gpt-5-mini"},
+		},
+		raw: upstream,
+	}
+
+	post := func(ctx context.Context, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"gpt-5-mini","messages":[{"role":"user","content":"hi"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			req.Header.Set(RawResponseHeader, header)
+		}
+		rec := httptest.NewRecorder()
+		New(provider, &Config{}).ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	masterKey := core.WithMasterKeyAuth(context.Background())
+	raw := post(masterKey, "true")
+	if raw.Code != http.StatusOK {
+		t.Fatalf("raw status = %d, want 200 body=%s", raw.Code, raw.Body.String())
+	}
+	if raw.Body.String() != upstream {
+		t.Fatalf("raw body = %s, want upstream body verbatim", raw.Body.String())
+	}
+
+	translated := post(masterKey, "")
+	if translated.Code != http.StatusOK || strings.Contains(translated.Body.String(), "service_tier") {
+		t.Fatalf("translated response = %d %s, want 200 without upstream-only fields", translated.Code, translated.Body.String())
+	}
+
+	managed := post(core.WithAuthKeyID(context.Background(), "key-1"), "true")
+	if managed.Code != http.StatusOK || strings.Contains(managed.Body.String(), "service_tier") {
+		t.Fatalf("managed key response = %d %s, want the translated response", managed.Code, managed.Body.String())
+	}
+
+	unauthenticated := post(context.Background(), "true")
+	if unauthenticated.Code != http.StatusOK || strings.Contains(unauthenticated.Body.String(), "service_tier") {
+		t.Fatalf("unauthenticated response = %d %s, want the translated response", unauthenticated.Code, unauthenticated.Body.String())
+	}
+}
+
+func TestChatCompletion_RawResponseHeaderRequiresMasterKey(t *testing.T) {
+	const upstream = `{"id":"chatcmpl-1","object":"chat.completion","model":"gpt-5-mini","service_tier":"flex","choices":[]}`
+	provider := &rawRecordingProvider{
+		mockProvider: &mockProvider{
+			supportedModels: []string{"gpt-5-mini"},
+			providerTypes:   map[string]string{"gpt-5-mini": "mock"},
+			response:        &core.ChatResponse{ID: "chatcmpl-1", Object: "chat.completion", Model: "gpt-5-mini"},
+		},
+		raw: upstream,
+	}
+	srv := New(provider, &Config{
+		MasterKey: "master-key",
+		APIKeys:   []StaticAPIKey{{Name: "team-a", Key: "team-a-key"}},
+	})
+
+	for _, tt := range []struct {
+		bearer  string
+		wantRaw bool
+	}{
+		{bearer: "master-key", wantRaw: true},
+		{bearer: "team-a-key", wantRaw: false},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model":"gpt-5-mini","messages":[{"role":"user","content":"hi"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tt.bearer)
+		req.Header.Set(RawResponseHeader, "true")
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, want 200 body=%s", tt.bearer, rec.Code, rec.Body.String())
+		}
+		if gotRaw := rec.Body.String() == upstream; gotRaw != tt.wantRaw {
+			t.Errorf("%s: raw body = %v, want %v (body=%s)", tt.bearer, gotRaw, tt.wantRaw, rec.Body.String())
+		}
+	}
+}
@@ -116,6 +116,7 @@ func resolveAndStoreRequestModelResolution(
 	authorizer RequestModelAuthorizer,
 	model, providerHint string,
 ) (*core.RequestModelResolution, error) {
+	model, providerHint = overrideProviderHint(c, model, providerHint)
 	requested := core.NewRequestedModelSelector(model, providerHint)
 	enrichAuditEntryWithRequestedModel(c, requested)
 
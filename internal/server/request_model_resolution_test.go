@@ -280,3 +280,48 @@ func (l *requestModelLiveLogger) PublishLiveEvent(eventType string, entry *audit
 	}
 	l.events = append(l.events, event)
 }
+
+func TestResolveAndStoreRequestModelResolution_ProviderOverrideForcesProvider(t *testing.T) {
+	provider := &mockProvider{
+		supportedModels: []string{"gpt-4o-mini"},
+		providerTypes: map[string]string{
+			"openai/gpt-4o-mini": "openai",
+			"groq/gpt-4o-mini":   "groq",
+		},
+		providerNames: map[string]string{
+			"openai/gpt-4o-mini": "openai",
+			"groq/gpt-4o-mini":   "groq",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		model         string
+		providerHint  string
+		override      string
+		wantProvider  string
+		wantModelName string
+	}{
+		{name: "no override keeps selector", model: "openai/gpt-4o-mini", wantProvider: "openai", wantModelName: "gpt-4o-mini"},
+		{name: "override replaces model prefix", model: "openai/gpt-4o-mini", override: "groq", wantProvider: "groq", wantModelName: "gpt-4o-mini"},
+		{name: "override replaces provider field", model: "gpt-4o-mini", providerHint: "openai", override: "groq", wantProvider: "groq", wantModelName: "gpt-4o-mini"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			req = req.WithContext(core.WithProviderOverride(req.Context(), tt.override))
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			resolution, err := resolveAndStoreRequestModelResolution(c, provider, nil, nil, tt.model, tt.providerHint)
+			if err != nil {
+				t.Fatalf("resolveAndStoreRequestModelResolution() error = %v", err)
+			}
+			if got := resolution.ProviderName; got != tt.wantProvider {
+				t.Fatalf("ProviderName = %q, want %q", got, tt.wantProvider)
+			}
+			if got := resolution.ResolvedSelector.Model; got != tt.wantModelName {
+				t.Fatalf("ResolvedSelector.Model = %q, want %q", got, tt.wantModelName)
+			}
+		})
+	}
+}
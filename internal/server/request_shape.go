@@ -0,0 +1,79 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	chatCompletionsPath = "/v1/chat/completions"
+	responsesPath       = "/v1/responses"
+)
+
+// requestShapeRoutingMiddleware reroutes JSON bodies posted to the wrong
+// translated inference endpoint: a Responses-shaped body ("input" without
+// "messages") sent to /v1/chat/completions is served by /v1/responses, and a
+// chat-shaped body ("messages" without "input") sent to /v1/responses is served
+// by /v1/chat/completions. It runs before routing so snapshots, workflow
+// resolution, and audit entries all see the endpoint that matches the body.
+// Bodies larger than bodyLimit are left alone for the body limit to reject, and
+// nothing is rerouted to /v1/responses when those routes are disabled. It is
+// installed only when Config.LenientRequestShape is set, because it buffers
+// and scans every body posted to either endpoint.
+func requestShapeRoutingMiddleware(bodyLimit int64, responsesEnabled bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodPost || req.Body == nil ||
+				(req.URL.Path != chatCompletionsPath && req.URL.Path != responsesPath) {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(io.LimitReader(req.Body, bodyLimit+1))
+			req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), req.Body), Closer: req.Body}
+			if err != nil || int64(len(body)) > bodyLimit {
+				return next(c)
+			}
+
+			target := detectRequestShapePath(req.URL.Path, body)
+			if target == req.URL.Path || (target == responsesPath && !responsesEnabled) {
+				return next(c)
+			}
+			rerouted := req.Clone(req.Context())
+			urlCopy := *req.URL
+			urlCopy.Path = target
+			urlCopy.RawPath = ""
+			rerouted.URL = &urlCopy
+			rerouted.RequestURI = target
+			if urlCopy.RawQuery != "" {
+				rerouted.RequestURI += "?" + urlCopy.RawQuery
+			}
+			c.SetRequest(rerouted)
+			return next(c)
+		}
+	}
+}
+
+// detectRequestShapePath returns the endpoint path matching the body shape,
+// or path unchanged when the body is ambiguous or already matches.
+func detectRequestShapePath(path string, body []byte) string {
+	fields := gjson.GetManyBytes(body, "messages", "input")
+	hasMessages, hasInput := fields[0].Exists(), fields[1].Exists()
+	switch {
+	case path == chatCompletionsPath && hasInput && !hasMessages:
+		return responsesPath
+	case path == responsesPath && hasMessages && !hasInput:
+		return chatCompletionsPath
+	default:
+		return path
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestRequestShapeRouting(t *testing.T) {
+	newProvider := func() *mockProvider {
+		return &mockProvider{
+			supportedModels:   []string{"gpt-5-mini"},
+			providerTypes:     map[string]string{"gpt-5-mini": "mock"},
+			response:          &core.ChatResponse{ID: "chatcmpl-1", Object: "chat.completion", Model: "gpt-5-mini"},
+			responsesResponse: &core.ResponsesResponse{ID: "resp_1", Object: "response", Model: "gpt-5-mini", Status: "completed"},
+		}
+	}
+	const (
+		responsesBody = `{"model":"gpt-5-mini","input":"hi"}`
+		chatBody      = `{"model":"gpt-5-mini","messages":[{"role":"user","content":"hi"}]}`
+	)
+
+	tests := []struct {
+		name       string
+		cfg        *Config
+		path       string
+		body       string
+		wantStatus int
+		wantObject string
+	}{
+		{name: "responses body on chat endpoint", cfg: &Config{LenientRequestShape: true}, path: "/v1/chat/completions", body: responsesBody, wantStatus: http.StatusOK, wantObject: `"object":"response"`},
+		{name: "chat body on responses endpoint", cfg: &Config{LenientRequestShape: true}, path: "/v1/responses", body: chatBody, wantStatus: http.StatusOK, wantObject: `"object":"chat.completion"`},
+		{name: "matching body is not rerouted", cfg: &Config{LenientRequestShape: true}, path: "/v1/chat/completions", body: chatBody, wantStatus: http.StatusOK, wantObject: `"object":"chat.completion"`},
+		{name: "base path is stripped first", cfg: &Config{LenientRequestShape: true, BasePath: "/gateway"}, path: "/gateway/v1/chat/completions", body: responsesBody, wantStatus: http.StatusOK, wantObject: `"object":"response"`},
+		{name: "default keeps the posted endpoint", cfg: &Config{}, path: "/v1/chat/completions", body: responsesBody, wantStatus: http.StatusBadRequest, wantObject: `"param":"messages"`},
+		{name: "responses routes disabled", cfg: &Config{LenientRequestShape: true, DisableResponsesRoutes: true}, path: "/v1/chat/completions", body: responsesBody, wantStatus: http.StatusBadRequest, wantObject: `"param":"messages"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			New(newProvider(), tt.cfg).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d body=%s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantObject != "" && !strings.Contains(rec.Body.String(), tt.wantObject) {
+				t.Fatalf("body = %s, want %s", rec.Body.String(), tt.wantObject)
+			}
+		})
+	}
+}
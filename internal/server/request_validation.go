@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// chatMessageRoles lists the message roles accepted by /v1/chat/completions.
+var chatMessageRoles = []string{"system", "developer", "user", "assistant", "tool", "function"}
+
+// validateChatRequestBody checks the chat completion fields every provider
+// needs, so clients get a field-specific 400 before any routing or upstream
+// call instead of a provider-specific failure.
+func validateChatRequestBody(req *core.ChatRequest) error {
+	if len(req.Messages) == 0 {
+		return core.NewInvalidRequestError("messages must be a non-empty array", nil).
+			WithParam("messages").
+			WithCode("missing_required_parameter")
+	}
+	for i, msg := range req.Messages {
+		if isChatMessageRole(msg.Role) {
+			if msg.Role == "tool" && strings.TrimSpace(msg.ToolCallID) == "" {
+				param := fmt.Sprintf("messages[%d].tool_call_id", i)
+				return core.NewInvalidRequestError(param+" is required for tool messages", nil).
+					WithParam(param).
+					WithCode("missing_required_parameter")
+			}
+			continue
+		}
+		param := fmt.Sprintf("messages[%d].role", i)
+		if strings.TrimSpace(msg.Role) == "" {
+			return core.NewInvalidRequestError(param+" is required", nil).
+				WithParam(param).
+				WithCode("missing_required_parameter")
+		}
+		return core.NewInvalidRequestError(
+			fmt.Sprintf("invalid value %q for %s: must be one of %s", msg.Role, param, strings.Join(chatMessageRoles, ", ")), nil).
+			WithParam(param).
+			WithCode("invalid_value")
+	}
+	return nil
+}
+
+func isChatMessageRole(role string) bool {
+	return slices.Contains(chatMessageRoles, role)
+}
+
+// validateResponsesRequestBody checks that a Responses request carries input.
+// A stored prompt template may stand in for input, as on OpenAI.
+func validateResponsesRequestBody(req *core.ResponsesRequest) error {
+	switch input := req.Input.(type) {
+	case nil:
+		if req.Prompt != nil {
+			return nil
+		}
+		return core.NewInvalidRequestError("input is required", nil).
+			WithParam("input").
+			WithCode("missing_required_parameter")
+	case string:
+		if strings.TrimSpace(input) == "" {
+			return core.NewInvalidRequestError("input must not be empty", nil).
+				WithParam("input").
+				WithCode("invalid_value")
+		}
+	case []core.ResponsesInputElement:
+		if len(input) == 0 {
+			return core.NewInvalidRequestError("input must not be an empty array", nil).
+				WithParam("input").
+				WithCode("invalid_value")
+		}
+	default:
+		return core.NewInvalidRequestError("input must be a string or an array of input items", nil).
+			WithParam("input").
+			WithCode("invalid_type")
+	}
+	return nil
+}
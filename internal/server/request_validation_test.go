@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestTranslatedRequestBodyValidation(t *testing.T) {
+	provider := &mockProvider{
+		supportedModels:   []string{"gpt-4o-mini"},
+		providerTypes:     map[string]string{"gpt-4o-mini": "mock"},
+		response:          &core.ChatResponse{ID: "chatcmpl-1", Object: "chat.completion", Model: "gpt-4o-mini"},
+		responsesResponse: &core.ResponsesResponse{ID: "resp_1", Object: "response", Model: "gpt-4o-mini", Status: "completed"},
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		body      string
+		wantParam string
+		wantCode  string
+	}{
+		{name: "chat missing messages", path: "/v1/chat/completions", body: `{"model":"gpt-4o-mini"}`, wantParam: "messages", wantCode: "missing_required_parameter"},
+		{name: "chat empty messages", path: "/v1/chat/completions", body: `{"model":"gpt-4o-mini","messages":[]}`, wantParam: "messages", wantCode: "missing_required_parameter"},
+		{name: "chat missing role", path: "/v1/chat/completions", body: `{"model":"gpt-4o-mini","messages":[{"content":"hi"}]}`, wantParam: "messages[0].role", wantCode: "missing_required_parameter"},
+		{name: "chat unknown role", path: "/v1/chat/completions", body: `{"model":"gpt-4o-mini","messages":[{"role":"user","content":"hi"},{"role":"robot","content":"hi"}]}`, wantParam: "messages[1].role", wantCode: "invalid_value"},
+		{name: "chat tool message without tool_call_id", path: "/v1/chat/completions", body: `{"model":"gpt-4o-mini","messages":[{"role":"tool","content":"42"}]}`, wantParam: "messages[0].tool_call_id", wantCode: "missing_required_parameter"},
+		{name: "responses missing input", path: "/v1/responses", body: `{"model":"gpt-4o-mini"}`, wantParam: "input", wantCode: "missing_required_parameter"},
+		{name: "responses empty string input", path: "/v1/responses", body: `{"model":"gpt-4o-mini","input":"  "}`, wantParam: "input", wantCode: "invalid_value"},
+		{name: "responses empty array input", path: "/v1/responses", body: `{"model":"gpt-4o-mini","input":[]}`, wantParam: "input", wantCode: "invalid_value"},
+		{name: "responses non-string input", path: "/v1/responses", body: `{"model":"gpt-4o-mini","input":42}`, wantParam: "input", wantCode: "invalid_type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			New(provider, &Config{}).ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			var body struct {
+				Error struct {
+					Type  string `json:"type"`
+					Param string `json:"param"`
+					Code  string `json:"code"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, "invalid_request_error", body.Error.Type)
+			assert.Equal(t, tt.wantParam, body.Error.Param)
+			assert.Equal(t, tt.wantCode, body.Error.Code)
+		})
+	}
+}
+
+func TestTranslatedRequestBodyValidation_AcceptsValidBodies(t *testing.T) {
+	provider := &mockProvider{
+		supportedModels:   []string{"gpt-4o-mini"},
+		providerTypes:     map[string]string{"gpt-4o-mini": "mock"},
+		response:          &core.ChatResponse{ID: "chatcmpl-1", Object: "chat.completion", Model: "gpt-4o-mini"},
+		responsesResponse: &core.ResponsesResponse{ID: "resp_1", Object: "response", Model: "gpt-4o-mini", Status: "completed"},
+	}
+
+	for _, tt := range []struct{ path, body string }{
+		{path: "/v1/chat/completions", body: `{"model":"gpt-4o-mini","messages":[{"role":"developer","content":"be brief"},{"role":"user","content":"hi"},{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function","function":{"name":"f","arguments":"{}"}}]},{"role":"tool","tool_call_id":"call_1","content":"42"}]}`},
+		{path: "/v1/responses", body: `{"model":"gpt-4o-mini","input":[{"role":"user","content":"hi"}]}`},
+		{path: "/v1/responses", body: `{"model":"gpt-4o-mini","prompt":{"id":"pmpt_1"}}`},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		New(provider, &Config{}).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, "%s %s: %s", tt.path, tt.body, rec.Body.String())
+	}
+}
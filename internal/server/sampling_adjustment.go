@@ -0,0 +1,34 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// SamplingAdjustedHeader lists the sampling parameters the gateway changed to
+// satisfy the configured sampling policy, as "name=value" pairs.
+const SamplingAdjustedHeader = "X-GoModel-Sampling-Adjusted"
+
+// SamplingAdjustmentCapture installs a recorder on the request context that
+// the provider router fills when the sampling policy clamps temperature or
+// top_p, and reports the adjustments in the X-GoModel-Sampling-Adjusted
+// response header before it is written.
+func SamplingAdjustmentCapture() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			ctx, recorder := core.WithSamplingAdjustmentRecorder(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+			if resp, err := echo.UnwrapResponse(c.Response()); err == nil && resp != nil {
+				resp.Before(func() {
+					if adjusted := recorder.Adjustments(); len(adjusted) > 0 {
+						resp.Header().Set(SamplingAdjustedHeader, strings.Join(adjusted, ", "))
+					}
+				})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestSamplingAdjustmentCapture(t *testing.T) {
+	tests := []struct {
+		name     string
+		adjusted []string
+		want     string
+	}{
+		{name: "clamped", adjusted: []string{"temperature=0.7", "top_p=0.9"}, want: "temperature=0.7, top_p=0.9"},
+		{name: "untouched", adjusted: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(SamplingAdjustmentCapture())
+			e.POST("/v1/chat/completions", func(c *echo.Context) error {
+				core.RecordSamplingAdjustments(c.Request().Context(), tt.adjusted)
+				return c.JSON(http.StatusOK, map[string]string{"ok": "true"})
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(SamplingAdjustedHeader); got != tt.want {
+				t.Fatalf("%s = %q, want %q", SamplingAdjustedHeader, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package server
 
 import (
+	"bytes"
 	"io"
 	"net/http"
 	"sync"
+	"time"
 )
 
+// sseKeepalive is an SSE comment line; conforming clients ignore it.
+var sseKeepalive = []byte(": keepalive\n\n")
+
 // streamCopyBufferPool reuses 32KB copy buffers across streaming responses so
 // each concurrent stream does not allocate (and later garbage-collect) its own
 // buffer. Buffers are pooled by pointer to avoid an allocation on Put.
@@ -43,3 +48,100 @@ func flushStream(w io.Writer, stream io.Reader) error {
 		}
 	}
 }
+
+type streamReadResult struct {
+	n   int
+	err error
+}
+
+// flushStreamWithKeepalive is flushStream for SSE responses that writes an
+// sseKeepalive comment whenever the stream has been silent for interval.
+// Keepalives only go out between complete events, never inside a partially
+// written one, and stop once the [DONE] sentinel has been written. A
+// non-positive interval falls back to flushStream.
+//
+// It does not return until the reader goroutine has exited, so the caller
+// may Close the stream afterwards without overlapping a Read. After a write
+// failure that wait lasts until the pending Read returns, which upstream
+// streams bound to the request context do once the client is gone.
+func flushStreamWithKeepalive(w io.Writer, stream io.Reader, interval time.Duration) error {
+	if interval <= 0 {
+		return flushStream(w, stream)
+	}
+	flusher, canFlush := w.(http.Flusher)
+	if canFlush {
+		flusher.Flush()
+	}
+
+	bufPtr := streamCopyBufferPool.Get().(*[]byte)
+	buf := *bufPtr
+	results := make(chan streamReadResult)
+	next := make(chan struct{})
+	done := make(chan struct{})
+	var reader sync.WaitGroup
+	defer func() {
+		close(done)
+		reader.Wait()
+		streamCopyBufferPool.Put(bufPtr)
+	}()
+
+	// Reads run on their own goroutine so the ticker can fire while Read
+	// blocks. The buffer is handed back and forth: the reader does not touch
+	// it again until the writer signals next.
+	reader.Go(func() {
+		for {
+			n, err := stream.Read(buf)
+			select {
+			case results <- streamReadResult{n: n, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+			select {
+			case <-next:
+			case <-done:
+				return
+			}
+		}
+	})
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	atBoundary, sentDone := true, false
+	for {
+		select {
+		case <-ticker.C:
+			if !atBoundary || sentDone {
+				continue
+			}
+			if _, err := w.Write(sseKeepalive); err != nil {
+				return err
+			}
+			if canFlush {
+				flusher.Flush()
+			}
+		case res := <-results:
+			if res.n > 0 {
+				chunk := buf[:res.n]
+				if _, writeErr := w.Write(chunk); writeErr != nil {
+					return writeErr
+				}
+				if canFlush {
+					flusher.Flush()
+				}
+				atBoundary = bytes.HasSuffix(chunk, []byte("\n\n"))
+				sentDone = sentDone || bytes.Contains(chunk, []byte("data: [DONE]"))
+				ticker.Reset(interval)
+			}
+			if res.err != nil {
+				if res.err == io.EOF {
+					return nil
+				}
+				return res.err
+			}
+			next <- struct{}{}
+		}
+	}
+}
@@ -9,11 +9,13 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/goccy/go-json"
 
 	"github.com/labstack/echo/v5"
 
+	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/internal/auditlog"
 	"github.com/enterpilot/gomodel/internal/conversationstore"
 	"github.com/enterpilot/gomodel/internal/core"
@@ -41,6 +43,9 @@ type translatedInferenceService struct {
 	pricingResolver          usage.PricingResolver
 	responseCache            *responsecache.ResponseCacheMiddleware
 	guardrailsHash           string
+	maxToolsPerRequest       int
+	streamKeepaliveInterval  time.Duration
+	outputPacing             config.OutputPacingConfig
 	responseStore            responsestore.Store
 	responseStoreMu          sync.RWMutex
 	conversationStore        conversationstore.Store
@@ -73,6 +78,7 @@ func (s *translatedInferenceService) newInferenceOrchestrator() *gateway.Inferen
 		UsageLogger:              s.usageLogger,
 		PricingResolver:          s.pricingResolver,
 		GuardrailsHash:           s.guardrailsHash,
+		MaxToolsPerRequest:       s.maxToolsPerRequest,
 	}
 	// Guarded assignment keeps the gate nil when rate limits are off (a nil
 	// RateLimiter assigned unconditionally would arrive as a typed non-nil
@@ -88,11 +94,12 @@ func (s *translatedInferenceService) ChatCompletion(c *echo.Context) error {
 }
 
 func (s *translatedInferenceService) handleChatCompletion(c *echo.Context) error {
-	return handleTranslatedJSON(s, c, core.DecodeChatRequest, prepareChatCompletionRequest, s.dispatchChatCompletion)
+	return handleTranslatedJSON(s, c, core.DecodeChatRequest, validateChatRequestBody, prepareChatCompletionRequest, s.dispatchChatCompletion)
 }
 
 func (s *translatedInferenceService) dispatchChatCompletion(c *echo.Context, req *core.ChatRequest, workflow *core.Workflow) error {
 	s.observeLiveProviderAttempts(c, workflow)
+	rawRecorder := rawResponseContext(c)
 	ctx := c.Request().Context()
 	requestID := requestIDFromContextOrHeader(c.Request())
 
@@ -105,7 +112,8 @@ func (s *translatedInferenceService) dispatchChatCompletion(c *echo.Context, req
 	ctx = adm.dispatchContext(ctx)
 
 	if req.Stream {
-		if len(s.inference().FailoverSelectors(workflow)) == 0 {
+		if len(s.inference().FailoverSelectors(workflow)) == 0 && !streaming.AcceptsNDJSON(c.Request().Header.Get("Accept")) &&
+			s.outputPacingRate(ctx) == 0 {
 			if handled, err := s.tryFastPathStreamingChatPassthrough(c, workflow, req); handled {
 				return err
 			}
@@ -129,6 +137,10 @@ func (s *translatedInferenceService) dispatchChatCompletion(c *echo.Context, req
 		)
 	}
 
+	if rawRecorder == nil && wantsPartialOnCancel(c.Request()) {
+		ctx = core.WithPartialOnCancel(ctx, true)
+	}
+
 	result, err := s.inference().ExecuteChatCompletion(ctx, workflow, req, requestID, "/v1/chat/completions")
 	if err != nil {
 		return handleError(c, err)
@@ -145,6 +157,9 @@ func (s *translatedInferenceService) dispatchChatCompletion(c *echo.Context, req
 		result.Meta.ProviderName,
 	)
 
+	if body, contentType := rawRecorder.Response(); body != nil {
+		return c.Blob(http.StatusOK, contentType, body)
+	}
 	return c.JSON(http.StatusOK, result.Response)
 }
 
@@ -153,13 +168,14 @@ func (s *translatedInferenceService) Responses(c *echo.Context) error {
 }
 
 func (s *translatedInferenceService) handleResponses(c *echo.Context) error {
-	return handleTranslatedJSON(s, c, core.DecodeResponsesRequest, prepareResponsesRequest, s.dispatchResponses)
+	return handleTranslatedJSON(s, c, core.DecodeResponsesRequest, validateResponsesRequestBody, prepareResponsesRequest, s.dispatchResponses)
 }
 
 func handleTranslatedJSON[Req any](
 	s *translatedInferenceService,
 	c *echo.Context,
 	decode func([]byte, *core.WhiteBoxPrompt) (Req, error),
+	validate func(Req) error,
 	prepare func(*translatedInferenceService, context.Context, Req, gateway.RequestMeta) (context.Context, Req, *core.Workflow, error),
 	dispatch func(*echo.Context, Req, *core.Workflow) error,
 ) error {
@@ -167,6 +183,9 @@ func handleTranslatedJSON[Req any](
 	if err != nil {
 		return handleError(c, core.NewInvalidRequestError("invalid request body: "+err.Error(), err))
 	}
+	if err := validate(req); err != nil {
+		return handleError(c, err)
+	}
 
 	ctx, preparedReq, workflow, err := prepare(s, c.Request().Context(), req, translatedRequestMeta(c))
 	if err != nil {
@@ -241,7 +260,10 @@ func handleWithCache[R any](
 ) error {
 	// Conversation turns are stateful: the same input means something different
 	// as the conversation grows, and a cache hit would skip the history append.
-	if conversationTurnFromContext(c.Request().Context()) != nil {
+	// Raw responses are provider-native and must neither be served from nor
+	// stored in the cache of translated responses. Partial-on-cancel requests
+	// may end in a truncated answer that must not be replayed.
+	if conversationTurnFromContext(c.Request().Context()) != nil || wantsRawResponse(c.Request()) || wantsPartialOnCancel(c.Request()) {
 		return dispatch(c, req, workflow)
 	}
 
@@ -433,10 +455,11 @@ func (s *translatedInferenceService) tryFastPathStreamingChatPassthrough(c *echo
 		Model:       resolvedModelFromWorkflow(workflow, req.Model),
 	}
 	passthrough := passthroughService{
-		provider:        s.provider,
-		logger:          s.logger,
-		usageLogger:     s.usageLogger,
-		pricingResolver: s.pricingResolver,
+		provider:                s.provider,
+		logger:                  s.logger,
+		usageLogger:             s.usageLogger,
+		pricingResolver:         s.pricingResolver,
+		streamKeepaliveInterval: s.streamKeepaliveInterval,
 	}
 	return true, passthrough.proxyPassthroughResponse(c, providerType, providerNameFromWorkflow(workflow), endpoint, info, resp)
 }
@@ -519,7 +542,9 @@ func cacheWorkflowResolutionHints(c *echo.Context, workflow *core.Workflow) {
 // fanning audit and usage observers off the canonical (OpenAI-shaped) stream.
 // outerWrap, when non-nil, wraps the observed stream as the outermost layer —
 // used by the Anthropic /v1/messages dialect to re-encode the SSE events after
-// the observers have already seen the canonical form.
+// the observers have already seen the canonical form. Without an outerWrap,
+// clients that send Accept: application/x-ndjson get the same events re-framed
+// as newline-delimited JSON.
 func (s *translatedInferenceService) handleStreamingReadCloser(
 	c *echo.Context,
 	workflow *core.Workflow,
@@ -550,25 +575,26 @@ func (s *translatedInferenceService) handleStreamingReadCloser(
 	if auditEnabled && streamEntry != nil {
 		observers = append(observers, auditlog.NewStreamLogObserver(s.logger, streamEntry, endpoint))
 	}
-	if s.usageLogger != nil && s.usageLogger.Config().Enabled && (workflow == nil || workflow.UsageEnabled()) {
-		usageObserver := usage.NewStreamUsageObserver(s.usageLogger, model, provider, requestID, endpoint, s.pricingResolver, core.UserPathFromContext(c.Request().Context()))
-		if usageObserver != nil {
-			usageObserver.SetProviderName(providerName)
-			usageObserver.SetLabels(core.RequestLabelsFromContext(c.Request().Context()))
-			usageObserver.SetRewriteTokensSaved(core.RewriteTokensSavedFromContext(c.Request().Context()))
-			observers = append(observers, usageObserver)
-		}
+	if usageObserver := s.streamUsageObserver(c, workflow, model, provider, providerName, requestID, endpoint); usageObserver != nil {
+		observers = append(observers, usageObserver)
+	}
+	var wrappedStream io.ReadCloser = streaming.NewObservedSSEStream(stream, observers...)
+	if rate := s.outputPacingRate(c.Request().Context()); rate > 0 {
+		wrappedStream = streaming.NewPacedSSEStream(c.Request().Context(), wrappedStream, rate)
 	}
-	wrappedStream := streaming.NewObservedSSEStream(stream, observers...)
+	contentType := "text/event-stream"
 	if outerWrap != nil {
 		wrappedStream = outerWrap(wrappedStream)
+	} else if streaming.AcceptsNDJSON(c.Request().Header.Get("Accept")) {
+		wrappedStream = streaming.NewNDJSONStream(wrappedStream)
+		contentType = streaming.NDJSONContentType
 	}
 
 	defer func() {
 		_ = wrappedStream.Close() //nolint:errcheck
 	}()
 
-	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Content-Type", contentType)
 	c.Response().Header().Set("Cache-Control", "no-cache")
 	c.Response().Header().Set("Connection", "keep-alive")
 
@@ -577,12 +603,48 @@ func (s *translatedInferenceService) handleStreamingReadCloser(
 	}
 
 	c.Response().WriteHeader(http.StatusOK)
-	if err := flushStream(c.Response(), wrappedStream); err != nil {
+	keepalive := s.streamKeepaliveInterval
+	if contentType != "text/event-stream" {
+		// Comment lines are not valid NDJSON records.
+		keepalive = 0
+	}
+	if err := flushStreamWithKeepalive(c.Response(), wrappedStream, keepalive); err != nil {
 		recordStreamingError(streamEntry, model, provider, c.Request().URL.Path, requestID, c.Request().Context(), err)
 	}
 	return nil
 }
 
+// outputPacingRate returns the streamed output rate, in tokens per second,
+// for the request's user path; 0 means the stream is not paced.
+func (s *translatedInferenceService) outputPacingRate(ctx context.Context) float64 {
+	if !s.outputPacing.Enabled() {
+		return 0
+	}
+	return s.outputPacing.TokensPerSecondFor(core.UserPathFromContext(ctx))
+}
+
+// streamUsageObserver returns the usage observer for a streamed response, or
+// nil when usage tracking is disabled for the request.
+func (s *translatedInferenceService) streamUsageObserver(
+	c *echo.Context,
+	workflow *core.Workflow,
+	model, provider, providerName, requestID, endpoint string,
+) streaming.Observer {
+	if s.usageLogger == nil || !s.usageLogger.Config().Enabled || (workflow != nil && !workflow.UsageEnabled()) {
+		return nil
+	}
+	ctx := c.Request().Context()
+	usageObserver := usage.NewStreamUsageObserver(s.usageLogger, model, provider, requestID, endpoint, s.pricingResolver, core.UserPathFromContext(ctx))
+	if usageObserver == nil {
+		return nil
+	}
+	usageObserver.SetProviderName(providerName)
+	usageObserver.SetLabels(core.RequestLabelsFromContext(ctx))
+	usageObserver.SetRewriteTokensSaved(core.RewriteTokensSavedFromContext(ctx))
+	usageObserver.SetUpstreamRequestID(core.GetUpstreamRequestID(ctx))
+	return usageObserver
+}
+
 // handleStreamingDispatchError records audit context for a streaming request
 // that failed before any chunks could be flushed. It marks the entry as
 // streaming and distinguishes client cancellations from upstream failures so
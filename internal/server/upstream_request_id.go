@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// UpstreamRequestIDHeader carries the request ID reported by the provider that
+// served the request, so callers can quote it in provider support tickets.
+const UpstreamRequestIDHeader = "X-GoModel-Upstream-Request-ID"
+
+// UpstreamRequestIDCapture installs a recorder on the request context that the
+// provider client fills with the upstream request ID, and copies the last
+// recorded ID onto the response headers before they are written.
+func UpstreamRequestIDCapture() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			ctx, recorder := core.WithUpstreamRequestIDRecorder(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+			if resp, err := echo.UnwrapResponse(c.Response()); err == nil && resp != nil {
+				resp.Before(func() {
+					if id := recorder.ID(); id != "" {
+						resp.Header().Set(UpstreamRequestIDHeader, id)
+					}
+				})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+func TestUpstreamRequestIDCapture(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "recorded", id: "req_abc"},
+		{name: "not recorded", id: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(UpstreamRequestIDCapture())
+			e.POST("/v1/chat/completions", func(c *echo.Context) error {
+				core.RecordUpstreamRequestID(c.Request().Context(), tt.id)
+				return c.JSON(http.StatusOK, map[string]string{"ok": "true"})
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(UpstreamRequestIDHeader); got != tt.id {
+				t.Fatalf("%s = %q, want %q", UpstreamRequestIDHeader, got, tt.id)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/enterpilot/gomodel/internal/version"
+)
+
+// versionResponse is the JSON body returned by GET /version.
+type versionResponse struct {
+	Version       string   `json:"version"`
+	Commit        string   `json:"commit"`
+	BuildDate     string   `json:"build_date"`
+	ProviderTypes []string `json:"provider_types"`
+}
+
+// Version handles GET /version
+//
+// Version reports the build identity injected via ldflags and the provider
+// types compiled into this binary, so operators can confirm what is deployed.
+//
+// @Summary      Build information
+// @Tags         system
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /version [get]
+func (h *Handler) Version(c *echo.Context) error {
+	providerTypes := h.providerTypes
+	if providerTypes == nil {
+		providerTypes = []string{}
+	}
+	return c.JSON(http.StatusOK, versionResponse{
+		Version:       version.Version,
+		Commit:        version.Commit,
+		BuildDate:     version.Date,
+		ProviderTypes: providerTypes,
+	})
+}
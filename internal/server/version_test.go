@@ -0,0 +1,36 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/version"
+)
+
+func TestVersionEndpoint(t *testing.T) {
+	srv := New(&mockProvider{}, &Config{
+		MasterKey:     "secret",
+		ProviderTypes: []string{"openai", "anthropic", "gemini"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("version without auth = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	var body versionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if body.Version != version.Version || body.Commit != version.Commit || body.BuildDate != version.Date {
+		t.Fatalf("build info = %+v, want %s/%s/%s", body, version.Version, version.Commit, version.Date)
+	}
+	if want := []string{"anthropic", "gemini", "openai"}; !slices.Equal(body.ProviderTypes, want) {
+		t.Fatalf("provider_types = %v, want sorted %v", body.ProviderTypes, want)
+	}
+}
@@ -0,0 +1,62 @@
+package streaming
+
+import (
+	"bufio"
+	"errors"
+	"sync/atomic"
+)
+
+// DefaultMaxEventBytes bounds a single upstream SSE line when no limit is
+// configured. Real events are a few KiB; a line this long means a broken or
+// hostile upstream.
+const DefaultMaxEventBytes = 1024 * 1024
+
+// ErrEventTooLarge is returned by ReadLine when an upstream SSE line exceeds
+// the configured limit. Converters end the stream with it instead of buffering
+// the line.
+var ErrEventTooLarge = errors.New("stream event exceeds maximum size")
+
+var maxEventBytes atomic.Int64
+
+func init() {
+	maxEventBytes.Store(DefaultMaxEventBytes)
+}
+
+// SetMaxEventBytes installs the process-wide limit ReadLine enforces. A value
+// of zero or less restores DefaultMaxEventBytes.
+func SetMaxEventBytes(n int64) {
+	if n <= 0 {
+		n = DefaultMaxEventBytes
+	}
+	maxEventBytes.Store(n)
+}
+
+// MaxEventBytes returns the limit ReadLine currently enforces.
+func MaxEventBytes() int64 {
+	return maxEventBytes.Load()
+}
+
+// ReadLine is bufio.Reader.ReadBytes('\n') with a size cap: it returns the
+// next line including its delimiter, or ErrEventTooLarge once the line grows
+// past MaxEventBytes without one. Like ReadBytes, a final unterminated line is
+// returned together with io.EOF.
+func ReadLine(r *bufio.Reader) ([]byte, error) {
+	return appendLine(r, nil)
+}
+
+// appendLine appends the next line from r to dst, enforcing MaxEventBytes on
+// the line alone.
+func appendLine(r *bufio.Reader, dst []byte) ([]byte, error) {
+	limit := MaxEventBytes()
+	start := len(dst)
+	for {
+		frag, err := r.ReadSlice('\n')
+		if int64(len(dst)-start)+int64(len(frag)) > limit {
+			return dst[:start], ErrEventTooLarge
+		}
+		dst = append(dst, frag...)
+		if err != bufio.ErrBufferFull {
+			return dst, err
+		}
+	}
+}
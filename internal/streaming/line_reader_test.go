@@ -0,0 +1,65 @@
+package streaming
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// endlessReader yields the same byte forever, like an upstream that never
+// sends a newline.
+type endlessReader struct{ read int64 }
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+	}
+	r.read += int64(len(p))
+	return len(p), nil
+}
+
+func TestReadLine_ReturnsLinesAndFinalFragment(t *testing.T) {
+	r := bufio.NewReaderSize(strings.NewReader("data: one\n"+strings.Repeat("x", 40)+"\ntail"), 16)
+
+	want := []string{"data: one\n", strings.Repeat("x", 40) + "\n"}
+	for _, w := range want {
+		line, err := ReadLine(r)
+		if err != nil {
+			t.Fatalf("ReadLine() error = %v", err)
+		}
+		if string(line) != w {
+			t.Fatalf("ReadLine() = %q, want %q", line, w)
+		}
+	}
+
+	line, err := ReadLine(r)
+	if err != io.EOF || string(line) != "tail" {
+		t.Fatalf("ReadLine() = %q, %v; want %q, io.EOF", line, err, "tail")
+	}
+}
+
+func TestReadLine_StopsOversizedEvent(t *testing.T) {
+	SetMaxEventBytes(64 * 1024)
+	t.Cleanup(func() { SetMaxEventBytes(0) })
+
+	src := &endlessReader{}
+	_, err := ReadLine(bufio.NewReader(src))
+	if !errors.Is(err, ErrEventTooLarge) {
+		t.Fatalf("ReadLine() error = %v, want ErrEventTooLarge", err)
+	}
+	// The reader stops one bufio buffer past the limit rather than draining
+	// the endless source.
+	if src.read > 64*1024+2*4096 {
+		t.Fatalf("read %d bytes before failing, want about 64KiB", src.read)
+	}
+}
+
+func TestSetMaxEventBytes_NonPositiveRestoresDefault(t *testing.T) {
+	SetMaxEventBytes(1024)
+	SetMaxEventBytes(0)
+	if got := MaxEventBytes(); got != DefaultMaxEventBytes {
+		t.Fatalf("MaxEventBytes() = %d, want %d", got, DefaultMaxEventBytes)
+	}
+}
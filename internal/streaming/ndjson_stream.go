@@ -0,0 +1,137 @@
+package streaming
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// NDJSONContentType is the media type of newline-delimited JSON streams.
+const NDJSONContentType = "application/x-ndjson"
+
+// maxNDJSONEventBytes bounds how much of a single SSE event is buffered while
+// waiting for its boundary. Unlike observers, the framer cannot skip an
+// oversized event without corrupting the client's stream, so it fails instead.
+const maxNDJSONEventBytes = 8 * 1024 * 1024
+
+// ndjsonDoneLine terminates an NDJSON stream in place of the SSE [DONE] sentinel.
+var ndjsonDoneLine = []byte("{\"done\":true}\n")
+
+// ErrNDJSONEventTooLarge is returned when an upstream SSE event exceeds the
+// framer's buffering limit.
+var ErrNDJSONEventTooLarge = errors.New("stream event exceeds ndjson buffering limit")
+
+// AcceptsNDJSON reports whether an Accept header explicitly asks for
+// newline-delimited JSON. Wildcards do not count, so clients keep receiving SSE
+// unless they opt in.
+func AcceptsNDJSON(accept string) bool {
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mediaType == NDJSONContentType || mediaType == "application/jsonl" {
+			return true
+		}
+	}
+	return false
+}
+
+// NDJSONStream re-frames an SSE stream as newline-delimited JSON: each JSON
+// data payload becomes one compact line and the [DONE] sentinel becomes a
+// final {"done":true} line. Event names, comments, and non-JSON payloads are
+// dropped.
+type NDJSONStream struct {
+	src     io.ReadCloser
+	buf     []byte
+	pending []byte
+	out     []byte
+	err     error
+}
+
+// NewNDJSONStream wraps an SSE stream so reads yield NDJSON.
+func NewNDJSONStream(src io.ReadCloser) *NDJSONStream {
+	return &NDJSONStream{src: src, buf: make([]byte, 32*1024)}
+}
+
+func (s *NDJSONStream) Read(p []byte) (int, error) {
+	for len(s.out) == 0 && s.err == nil {
+		n, err := s.src.Read(s.buf)
+		if n > 0 {
+			s.pending = append(s.pending, s.buf[:n]...)
+			s.drainEvents()
+			if len(s.pending) > maxNDJSONEventBytes {
+				s.pending = nil
+				s.err = ErrNDJSONEventTooLarge
+			}
+		}
+		if err != nil {
+			if err == io.EOF && len(s.pending) > 0 {
+				s.writeEvent(s.pending)
+				s.pending = nil
+			}
+			s.err = err
+		}
+	}
+
+	if len(s.out) > 0 {
+		n := copy(p, s.out)
+		s.out = s.out[n:]
+		return n, nil
+	}
+	return 0, s.err
+}
+
+func (s *NDJSONStream) Close() error {
+	return s.src.Close()
+}
+
+func (s *NDJSONStream) drainEvents() {
+	consumed := 0
+	for {
+		idx, sepLen := nextEventBoundary(s.pending[consumed:])
+		if idx == -1 {
+			break
+		}
+		s.writeEvent(s.pending[consumed : consumed+idx])
+		consumed += idx + sepLen
+	}
+	if consumed > 0 {
+		s.pending = append(s.pending[:0], s.pending[consumed:]...)
+	}
+}
+
+func (s *NDJSONStream) writeEvent(event []byte) {
+	var payload []byte
+	hasData := false
+	for line := range bytes.SplitSeq(event, []byte("\n")) {
+		data, ok := parseDataLine(line)
+		if !ok {
+			continue
+		}
+		if hasData {
+			payload = append(payload, '\n')
+		}
+		payload = append(payload, data...)
+		hasData = true
+	}
+	if !hasData {
+		return
+	}
+
+	payload = bytes.TrimSpace(payload)
+	if bytes.Equal(payload, donePayload) {
+		s.out = append(s.out, ndjsonDoneLine...)
+		return
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, payload); err != nil {
+		return
+	}
+	s.out = append(s.out, compact.Bytes()...)
+	s.out = append(s.out, '\n')
+}
@@ -0,0 +1,63 @@
+package streaming
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAcceptsNDJSON(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"text/event-stream", false},
+		{"application/x-ndjson", true},
+		{"text/event-stream;q=0.5, application/x-ndjson", true},
+		{"application/jsonl", true},
+	}
+	for _, tt := range tests {
+		if got := AcceptsNDJSON(tt.accept); got != tt.want {
+			t.Errorf("AcceptsNDJSON(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestNDJSONStream_ReframesSSEEvents(t *testing.T) {
+	src := "event: message\ndata: {\"a\": 1}\n\n" +
+		": keep-alive\n\n" +
+		"data: {\"b\":\ndata: 2}\r\n\r\n" +
+		"data: not-json\n\n" +
+		"data: [DONE]\n\n"
+
+	got, err := io.ReadAll(NewNDJSONStream(io.NopCloser(iotest.OneByteReader(strings.NewReader(src)))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "{\"a\":1}\n{\"b\":2}\n{\"done\":true}\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestNDJSONStream_FlushesUnterminatedFinalEvent(t *testing.T) {
+	got, err := io.ReadAll(NewNDJSONStream(io.NopCloser(strings.NewReader("data: {\"a\":1}"))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "{\"a\":1}\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestNDJSONStream_RejectsOversizedEvent(t *testing.T) {
+	src := "data: \"" + strings.Repeat("x", maxNDJSONEventBytes+1)
+	_, err := io.ReadAll(NewNDJSONStream(io.NopCloser(strings.NewReader(src))))
+	if !errors.Is(err, ErrNDJSONEventTooLarge) {
+		t.Fatalf("expected ErrNDJSONEventTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// maxPacedEventBytes bounds how much of a single SSE event is buffered while
+// waiting for its boundary. Past it the buffered bytes are released unpaced,
+// which keeps the client stream intact.
+const maxPacedEventBytes = 8 * 1024 * 1024
+
+// PacedSSEStream releases an SSE stream to its reader no faster than a target
+// rate of output tokens per second, so bursty upstream output reaches the
+// client as a steady flow. Events are released whole; tokens are estimated
+// from the text deltas of chat completion chunks and Responses events at
+// roughly four bytes per token, and events without text pass through at once.
+type PacedSSEStream struct {
+	ctx    context.Context
+	src    io.ReadCloser
+	rate   float64
+	buf    []byte
+	pend   []byte
+	out    []byte
+	err    error
+	start  time.Time
+	tokens float64
+}
+
+// NewPacedSSEStream wraps src so reads are paced to tokensPerSecond. Waiting
+// stops with ctx's error when ctx ends.
+func NewPacedSSEStream(ctx context.Context, src io.ReadCloser, tokensPerSecond float64) *PacedSSEStream {
+	return &PacedSSEStream{ctx: ctx, src: src, rate: tokensPerSecond, buf: make([]byte, 32*1024)}
+}
+
+func (s *PacedSSEStream) Read(p []byte) (int, error) {
+	for len(s.out) == 0 {
+		event, ok := s.nextEvent()
+		if !ok {
+			return 0, s.err
+		}
+		if err := s.wait(eventOutputTokens(event)); err != nil {
+			return 0, err
+		}
+		s.out = event
+	}
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+func (s *PacedSSEStream) Close() error {
+	return s.src.Close()
+}
+
+// nextEvent returns the next complete event including its boundary, or the
+// remaining bytes once the source ends or an event outgrows the buffer limit.
+func (s *PacedSSEStream) nextEvent() ([]byte, bool) {
+	for {
+		if idx, sepLen := nextEventBoundary(s.pend); idx != -1 {
+			return s.take(idx + sepLen), true
+		}
+		if len(s.pend) > maxPacedEventBytes || (s.err != nil && len(s.pend) > 0) {
+			return s.take(len(s.pend)), true
+		}
+		if s.err != nil {
+			return nil, false
+		}
+		n, err := s.src.Read(s.buf)
+		s.pend = append(s.pend, s.buf[:n]...)
+		if err != nil {
+			s.err = err
+		}
+	}
+}
+
+func (s *PacedSSEStream) take(n int) []byte {
+	event := bytes.Clone(s.pend[:n])
+	s.pend = append(s.pend[:0], s.pend[n:]...)
+	return event
+}
+
+// wait blocks until the tokens already released fit the target rate, then
+// accounts for the next event's tokens.
+func (s *PacedSSEStream) wait(tokens int) error {
+	if tokens <= 0 {
+		return nil
+	}
+	if s.start.IsZero() {
+		s.start = time.Now()
+	}
+	due := s.start.Add(time.Duration(s.tokens / s.rate * float64(time.Second)))
+	if delay := time.Until(due); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+	s.tokens += float64(tokens)
+	return nil
+}
+
+// eventOutputTokens estimates the output tokens carried by one SSE event.
+func eventOutputTokens(event []byte) int {
+	size := 0
+	for line := range bytes.SplitSeq(event, []byte("\n")) {
+		payload, ok := parseDataLine(line)
+		if !ok || bytes.Equal(bytes.TrimSpace(payload), donePayload) {
+			continue
+		}
+		fields := gjson.GetManyBytes(payload, "choices.0.delta.content", "choices.0.delta.reasoning_content", "delta")
+		size += len(fields[0].String()) + len(fields[1].String())
+		if fields[2].Type == gjson.String {
+			size += len(fields[2].Str)
+		}
+	}
+	return (size + 3) / 4
+}
@@ -0,0 +1,90 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func chatContentChunk(content string) string {
+	return `data: {"choices":[{"index":0,"delta":{"content":"` + content + `"}}]}` + "\n\n"
+}
+
+func TestPacedSSEStream_PacesContentToRate(t *testing.T) {
+	var src strings.Builder
+	for range 10 {
+		src.WriteString(chatContentChunk("abcdefgh")) // 2 tokens each
+	}
+	src.WriteString("data: [DONE]\n\n")
+
+	start := time.Now()
+	got, err := io.ReadAll(NewPacedSSEStream(context.Background(), io.NopCloser(strings.NewReader(src.String())), 200))
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != src.String() {
+		t.Fatalf("paced stream altered the output:\n%s", got)
+	}
+	// 20 tokens at 200 tokens/s: the last chunk is due after the first 18
+	// tokens, i.e. 90ms after the first chunk.
+	if elapsed < 80*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("elapsed = %v, want roughly 90ms", elapsed)
+	}
+}
+
+func TestPacedSSEStream_EventsWithoutContentAreNotDelayed(t *testing.T) {
+	src := `data: {"choices":[{"index":0,"delta":{"role":"assistant"}}]}` + "\n\n" +
+		": keep-alive\n\n" +
+		chatContentChunk("hello") +
+		`data: {"choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	start := time.Now()
+	got, err := io.ReadAll(NewPacedSSEStream(context.Background(), io.NopCloser(strings.NewReader(src)), 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != src {
+		t.Fatalf("paced stream altered the output:\n%s", got)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("elapsed = %v, want no pacing delay for a single content chunk", elapsed)
+	}
+}
+
+func TestPacedSSEStream_StopsWaitingWhenContextEnds(t *testing.T) {
+	src := chatContentChunk("abcdefgh") + chatContentChunk("abcdefgh")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := io.ReadAll(NewPacedSSEStream(ctx, io.NopCloser(strings.NewReader(src)), 0.01))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestEventOutputTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  int
+	}{
+		{"chat content", chatContentChunk("abcdefgh"), 2},
+		{"chat role only", `data: {"choices":[{"delta":{"role":"assistant"}}]}` + "\n\n", 0},
+		{"responses text delta", `data: {"type":"response.output_text.delta","delta":"abcde"}` + "\n\n", 2},
+		{"responses completed", `data: {"type":"response.completed","response":{}}` + "\n\n", 0},
+		{"done", "data: [DONE]\n\n", 0},
+		{"comment", ": keep-alive\n\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventOutputTokens([]byte(tt.event)); got != tt.want {
+				t.Fatalf("eventOutputTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
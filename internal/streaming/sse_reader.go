@@ -0,0 +1,98 @@
+package streaming
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// SSEEvent is one dispatched server-sent event. Data holds the event's data
+// lines joined with "\n" and is only valid until the next SSEReader.Next call.
+type SSEEvent struct {
+	Event string
+	Data  []byte
+}
+
+// SSEReader frames an upstream text/event-stream into events: consecutive
+// data lines accumulate until a blank line, "\r\n" line endings are accepted,
+// and comment lines (":" heartbeats) are skipped. Lines and events are bounded
+// by MaxEventBytes. It is single-reader and must not be shared.
+type SSEReader struct {
+	r       *bufio.Reader
+	line    []byte
+	data    []byte
+	event   string
+	hasData bool
+	eof     bool
+}
+
+// NewSSEReader returns an SSEReader reading from r.
+func NewSSEReader(r io.Reader) *SSEReader {
+	return &SSEReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event carrying at least one data line. Events without
+// data (a bare "event:" line, or keep-alive comments) are skipped. An event
+// still pending when the upstream ends is dispatched before io.EOF, matching
+// how the converters treated a final unterminated data line.
+func (s *SSEReader) Next() (SSEEvent, error) {
+	if s.eof {
+		return SSEEvent{}, io.EOF
+	}
+	for {
+		line, err := appendLine(s.r, s.line[:0])
+		s.line = line
+		if err != nil && err != io.EOF {
+			return SSEEvent{}, err
+		}
+
+		line = bytes.TrimSuffix(line, []byte("\n"))
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) == 0 {
+			if s.hasData {
+				return s.dispatch(), nil
+			}
+			s.event = ""
+		} else if ferr := s.field(line); ferr != nil {
+			return SSEEvent{}, ferr
+		}
+
+		if err == io.EOF {
+			s.eof = true
+			if s.hasData {
+				return s.dispatch(), nil
+			}
+			return SSEEvent{}, io.EOF
+		}
+	}
+}
+
+func (s *SSEReader) field(line []byte) error {
+	if line[0] == ':' {
+		return nil
+	}
+	name, value, _ := bytes.Cut(line, []byte(":"))
+	value = bytes.TrimPrefix(value, []byte(" "))
+	switch string(name) {
+	case "data":
+		if s.hasData {
+			s.data = append(s.data, '\n')
+		}
+		s.data = append(s.data, value...)
+		s.hasData = true
+		if int64(len(s.data)) > MaxEventBytes() {
+			return ErrEventTooLarge
+		}
+	case "event":
+		s.event = string(value)
+	}
+	return nil
+}
+
+func (s *SSEReader) dispatch() SSEEvent {
+	event := SSEEvent{Event: s.event, Data: s.data}
+	s.data = s.data[:0]
+	s.event = ""
+	s.hasData = false
+	return event
+}
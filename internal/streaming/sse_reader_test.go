@@ -0,0 +1,92 @@
+package streaming
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readAllSSEEvents(t *testing.T, input string) []SSEEvent {
+	t.Helper()
+	r := NewSSEReader(strings.NewReader(input))
+	var events []SSEEvent
+	for {
+		event, err := r.Next()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		events = append(events, SSEEvent{Event: event.Event, Data: append([]byte(nil), event.Data...)})
+	}
+}
+
+func TestSSEReader_Framing(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []SSEEvent
+	}{
+		{
+			name:  "lf endings",
+			input: "event: ping\ndata: {\"a\":1}\n\ndata: {\"b\":2}\n\n",
+			want:  []SSEEvent{{Event: "ping", Data: []byte(`{"a":1}`)}, {Data: []byte(`{"b":2}`)}},
+		},
+		{
+			name:  "crlf endings",
+			input: "event: ping\r\ndata: {\"a\":1}\r\n\r\ndata: {\"b\":2}\r\n\r\n",
+			want:  []SSEEvent{{Event: "ping", Data: []byte(`{"a":1}`)}, {Data: []byte(`{"b":2}`)}},
+		},
+		{
+			name:  "multi-line data is joined",
+			input: "data: {\"a\":\ndata: 1}\n\n",
+			want:  []SSEEvent{{Data: []byte("{\"a\":\n1}")}},
+		},
+		{
+			name:  "comments and heartbeats are skipped",
+			input: ": keep-alive\n\n:\ndata: x\n: mid-event comment\ndata: y\n\n",
+			want:  []SSEEvent{{Data: []byte("x\ny")}},
+		},
+		{
+			name:  "event without data is dropped",
+			input: "event: ping\n\ndata: z\n\n",
+			want:  []SSEEvent{{Data: []byte("z")}},
+		},
+		{
+			name:  "no space after colon",
+			input: "data:z\n\n",
+			want:  []SSEEvent{{Data: []byte("z")}},
+		},
+		{
+			name:  "pending event is dispatched at eof",
+			input: "data: tail",
+			want:  []SSEEvent{{Data: []byte("tail")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAllSSEEvents(t, tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events %q, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i].Event != tt.want[i].Event || string(got[i].Data) != string(tt.want[i].Data) {
+					t.Errorf("event %d = {%q %q}, want {%q %q}", i, got[i].Event, got[i].Data, tt.want[i].Event, tt.want[i].Data)
+				}
+			}
+		})
+	}
+}
+
+func TestSSEReader_BoundsMultiLineEvent(t *testing.T) {
+	SetMaxEventBytes(1024)
+	t.Cleanup(func() { SetMaxEventBytes(0) })
+
+	line := "data: " + strings.Repeat("a", 100) + "\n"
+	r := NewSSEReader(strings.NewReader(strings.Repeat(line, 20) + "\n"))
+	if _, err := r.Next(); !errors.Is(err, ErrEventTooLarge) {
+		t.Fatalf("Next() error = %v, want ErrEventTooLarge", err)
+	}
+}
@@ -0,0 +1,104 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// SystemPrompt prepends an organization-wide system prompt, the gateway-wide
+// form of a provider's system_prompt_prefix default param. Both go through
+// core.ChatRequest.WithSystemPromptPrefix and
+// core.ResponsesRequest.WithInstructionsPrefix, so they combine the same way.
+type SystemPrompt struct {
+	Content string
+}
+
+// TransformChatRequest prepends the prompt to the system prompt.
+func (t SystemPrompt) TransformChatRequest(_ context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	return req.WithSystemPromptPrefix(t.Content), nil
+}
+
+// TransformResponsesRequest prepends the prompt to the instructions.
+func (t SystemPrompt) TransformResponsesRequest(_ context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error) {
+	return req.WithInstructionsPrefix(t.Content), nil
+}
+
+// StripFields removes top-level request fields, typed or passthrough, before
+// the request is forwarded.
+type StripFields struct {
+	Fields []string
+}
+
+// TransformChatRequest removes the configured fields from a chat request.
+func (t StripFields) TransformChatRequest(_ context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	return stripFields(req, t.Fields)
+}
+
+// TransformResponsesRequest removes the configured fields from a Responses request.
+func (t StripFields) TransformResponsesRequest(_ context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error) {
+	return stripFields(req, t.Fields)
+}
+
+// stripFields round-trips the request through JSON so typed fields and
+// passthrough extras are removed alike. On failure the request is forwarded
+// unchanged.
+func stripFields[T any](req *T, fields []string) (*T, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		slog.Warn("failed to strip request fields", "error", err)
+		return req, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		slog.Warn("failed to strip request fields", "error", err)
+		return req, nil
+	}
+	stripped := false
+	for _, field := range fields {
+		if _, ok := object[field]; ok {
+			delete(object, field)
+			stripped = true
+		}
+	}
+	if !stripped {
+		return req, nil
+	}
+	body, err = json.Marshal(object)
+	if err != nil {
+		slog.Warn("failed to strip request fields", "error", err)
+		return req, nil
+	}
+	var out T
+	if err := json.Unmarshal(body, &out); err != nil {
+		slog.Warn("failed to strip request fields", "error", err)
+		return req, nil
+	}
+	return &out, nil
+}
+
+// FromConfig builds the request transformers declared in config, in order.
+func FromConfig(transformers []config.TransformerConfig) ([]RequestTransformer, error) {
+	out := make([]RequestTransformer, 0, len(transformers))
+	for i, t := range transformers {
+		switch strings.TrimSpace(t.Type) {
+		case config.TransformerTypeSystemPrompt:
+			out = append(out, SystemPrompt{Content: t.Content})
+		case config.TransformerTypeStripFields:
+			fields := make([]string, 0, len(t.Fields))
+			for _, field := range t.Fields {
+				fields = append(fields, strings.TrimSpace(field))
+			}
+			out = append(out, StripFields{Fields: fields})
+		default:
+			return nil, fmt.Errorf("transformers[%d]: unknown type %q", i, t.Type)
+		}
+	}
+	return out, nil
+}
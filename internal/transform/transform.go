@@ -0,0 +1,89 @@
+// Package transform provides an ordered chain of request transformers applied
+// to translated chat and Responses traffic, plus the built-in transformers
+// operators enable through config.
+package transform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// RequestTransformer rewrites a translated request before it is routed to a
+// provider. Implementations must not mutate the request they receive; they
+// return a modified copy, or the same request when nothing changes.
+type RequestTransformer interface {
+	TransformChatRequest(ctx context.Context, req *core.ChatRequest) (*core.ChatRequest, error)
+	TransformResponsesRequest(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error)
+}
+
+// Chain applies request transformers in the order they were added. It
+// satisfies gateway.TranslatedRequestPatcher.
+type Chain struct {
+	requests []RequestTransformer
+}
+
+// NewChain creates an empty chain.
+func NewChain() *Chain {
+	return &Chain{}
+}
+
+// AddRequest appends request transformers to the chain.
+func (c *Chain) AddRequest(transformers ...RequestTransformer) *Chain {
+	c.requests = append(c.requests, transformers...)
+	return c
+}
+
+// HasRequestTransformers reports whether the chain rewrites requests.
+func (c *Chain) HasRequestTransformers() bool {
+	return c != nil && len(c.requests) > 0
+}
+
+// PatchChatRequest runs the request transformers over a chat request.
+func (c *Chain) PatchChatRequest(ctx context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	return applyAll(ctx, c.requests, req, RequestTransformer.TransformChatRequest)
+}
+
+// PatchResponsesRequest runs the request transformers over a Responses request.
+func (c *Chain) PatchResponsesRequest(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error) {
+	return applyAll(ctx, c.requests, req, RequestTransformer.TransformResponsesRequest)
+}
+
+func applyAll[T any, V any](ctx context.Context, transformers []T, value *V, apply func(T, context.Context, *V) (*V, error)) (*V, error) {
+	for i, transformer := range transformers {
+		next, err := apply(transformer, ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return nil, fmt.Errorf("transformer %d returned nil", i)
+		}
+		value = next
+	}
+	return value, nil
+}
+
+// Patcher is the request-patching shape shared with the gateway, implemented
+// for example by the guardrails workflow patcher.
+type Patcher interface {
+	PatchChatRequest(ctx context.Context, req *core.ChatRequest) (*core.ChatRequest, error)
+	PatchResponsesRequest(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error)
+}
+
+// FromPatcher adapts a request patcher so it can run as a step of a chain.
+func FromPatcher(p Patcher) RequestTransformer {
+	return patcherTransformer{patcher: p}
+}
+
+type patcherTransformer struct {
+	patcher Patcher
+}
+
+func (t patcherTransformer) TransformChatRequest(ctx context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	return t.patcher.PatchChatRequest(ctx, req)
+}
+
+func (t patcherTransformer) TransformResponsesRequest(ctx context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error) {
+	return t.patcher.PatchResponsesRequest(ctx, req)
+}
@@ -0,0 +1,134 @@
+package transform
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/enterpilot/gomodel/config"
+	"github.com/enterpilot/gomodel/internal/core"
+)
+
+// renameModel records its position in the chain and rewrites the model.
+type renameModel struct {
+	to    string
+	calls *[]string
+}
+
+func (t renameModel) TransformChatRequest(_ context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	*t.calls = append(*t.calls, "rename:"+req.Model)
+	out := *req
+	out.Model = t.to
+	return &out, nil
+}
+
+func (t renameModel) TransformResponsesRequest(_ context.Context, req *core.ResponsesRequest) (*core.ResponsesRequest, error) {
+	out := *req
+	out.Model = t.to
+	return &out, nil
+}
+
+type failingTransformer struct{}
+
+func (failingTransformer) TransformChatRequest(context.Context, *core.ChatRequest) (*core.ChatRequest, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingTransformer) TransformResponsesRequest(context.Context, *core.ResponsesRequest) (*core.ResponsesRequest, error) {
+	return nil, errors.New("boom")
+}
+
+func TestChain_AppliesRequestTransformersInOrder(t *testing.T) {
+	var calls []string
+	chain := NewChain().AddRequest(
+		renameModel{to: "gpt-4o-mini", calls: &calls},
+		renameModel{to: "gpt-4.1", calls: &calls},
+	)
+
+	req := &core.ChatRequest{Model: "gpt-4o", Messages: []core.Message{{Role: "user", Content: "hi"}}}
+	got, err := chain.PatchChatRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("PatchChatRequest() error = %v", err)
+	}
+	if want := []string{"rename:gpt-4o", "rename:gpt-4o-mini"}; !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	if got.Model != "gpt-4.1" || req.Model != "gpt-4o" {
+		t.Fatalf("model = %q (original %q), want gpt-4.1 with the original untouched", got.Model, req.Model)
+	}
+}
+
+func TestChain_AppliesBuiltinsInOrder(t *testing.T) {
+	chain := NewChain().AddRequest(
+		StripFields{Fields: []string{"user", "metadata"}},
+		SystemPrompt{Content: "Answer in English."},
+	)
+
+	req := &core.ChatRequest{
+		Model:       "gpt-4o",
+		User:        "alice",
+		Messages:    []core.Message{{Role: "user", Content: "hi"}},
+		ExtraFields: core.UnknownJSONFieldsFromMap(map[string]json.RawMessage{"metadata": json.RawMessage(`{"team":"a"}`), "seed": json.RawMessage(`7`)}),
+	}
+	got, err := chain.PatchChatRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("PatchChatRequest() error = %v", err)
+	}
+	if got.User != "" || got.ExtraFields.Lookup("metadata") != nil {
+		t.Fatalf("stripped fields survived: user=%q metadata=%s", got.User, got.ExtraFields.Lookup("metadata"))
+	}
+	if string(got.ExtraFields.Lookup("seed")) != "7" {
+		t.Fatalf("seed = %s, want 7 kept", got.ExtraFields.Lookup("seed"))
+	}
+	if len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.Messages[0].Content != "Answer in English." {
+		t.Fatalf("messages = %+v, want the system prompt first", got.Messages)
+	}
+	if req.User != "alice" || len(req.Messages) != 1 {
+		t.Fatal("original request was mutated")
+	}
+}
+
+func TestChain_SystemPromptPrependsResponsesInstructions(t *testing.T) {
+	chain := NewChain().AddRequest(SystemPrompt{Content: "Be brief."})
+
+	got, err := chain.PatchResponsesRequest(context.Background(), &core.ResponsesRequest{
+		Model:        "gpt-4o",
+		Input:        "hi",
+		Instructions: "Use bullet points.",
+	})
+	if err != nil {
+		t.Fatalf("PatchResponsesRequest() error = %v", err)
+	}
+	if got.Instructions != "Be brief.\nUse bullet points." {
+		t.Fatalf("Instructions = %q", got.Instructions)
+	}
+}
+
+func TestChain_StopsOnError(t *testing.T) {
+	var calls []string
+	chain := NewChain().AddRequest(failingTransformer{}, renameModel{to: "never", calls: &calls})
+
+	if _, err := chain.PatchChatRequest(context.Background(), &core.ChatRequest{Model: "gpt-4o"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(calls) != 0 {
+		t.Fatalf("calls = %v, want the chain to stop at the failure", calls)
+	}
+}
+
+func TestFromConfig_BuildsTransformersInOrder(t *testing.T) {
+	got, err := FromConfig([]config.TransformerConfig{
+		{Type: config.TransformerTypeStripFields, Fields: []string{" user "}},
+		{Type: config.TransformerTypeSystemPrompt, Content: "Be brief."},
+	})
+	if err != nil {
+		t.Fatalf("FromConfig() error = %v", err)
+	}
+	want := []RequestTransformer{StripFields{Fields: []string{"user"}}, SystemPrompt{Content: "Be brief."}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FromConfig() = %#v, want %#v", got, want)
+	}
+}
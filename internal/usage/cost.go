@@ -85,6 +85,10 @@ var providerMappings = map[string][]tokenCostMapping{
 	"openrouter": openAICompatibleTokenCostMappings,
 	"anthropic": {
 		{rawDataKey: "cache_read_input_tokens", pricingField: func(p *core.ModelPricing) *float64 { return p.CachedInputPerMtok }, side: sideInput, unit: unitPerMtok},
+		// The gateway mirrors cache reads into prompt_tokens_details.cached_tokens
+		// for OpenAI-shaped clients. It is the same count as
+		// cache_read_input_tokens, so the shared pricing field is applied once.
+		{rawDataKey: "prompt_cached_tokens", pricingField: func(p *core.ModelPricing) *float64 { return p.CachedInputPerMtok }, side: sideInput, unit: unitPerMtok},
 		{rawDataKey: "cache_creation_input_tokens", pricingField: func(p *core.ModelPricing) *float64 { return p.CacheWritePerMtok }, side: sideInput, unit: unitPerMtok},
 	},
 	"gemini": {
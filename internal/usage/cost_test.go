@@ -93,6 +93,28 @@ func TestCalculateGranularCost_Anthropic_CacheTokens(t *testing.T) {
 	assertCostNear(t, "OutputCost", result.OutputCost, 1.5)
 }
 
+// The translated Anthropic usage mirrors cache reads into
+// prompt_tokens_details.cached_tokens; the duplicate key must not price the
+// cached tokens a second time.
+func TestCalculateGranularCost_Anthropic_MirroredCachedTokensPricedOnce(t *testing.T) {
+	pricing := &core.ModelPricing{
+		InputPerMtok:       new(3.0),
+		OutputPerMtok:      new(15.0),
+		CachedInputPerMtok: new(0.30),
+	}
+	rawData := map[string]any{
+		"cache_read_input_tokens": 100_000,
+		"prompt_cached_tokens":    100_000,
+	}
+	result := CalculateGranularCost(200_000, 0, rawData, "anthropic", pricing)
+
+	// Input: 200k * 3.0/1M + 100k * 0.30/1M = 0.60 + 0.03 = 0.63
+	assertCostNear(t, "InputCost", result.InputCost, 0.63)
+	if result.Caveat != "" {
+		t.Fatalf("Caveat = %q, want none", result.Caveat)
+	}
+}
+
 func TestCalculateGranularCost_Gemini_ThoughtTokens(t *testing.T) {
 	pricing := &core.ModelPricing{
 		InputPerMtok:           new(1.25),
@@ -342,6 +342,33 @@ func TestExtractFromResponsesResponse_WithDetails(t *testing.T) {
 	}
 }
 
+func TestExtractFromChatResponse_PricesCachedPromptTokens(t *testing.T) {
+	pricing := &core.ModelPricing{
+		InputPerMtok:       new(2.50),
+		OutputPerMtok:      new(10.0),
+		CachedInputPerMtok: new(1.25),
+	}
+	resp := &core.ChatResponse{
+		ID:    "chatcmpl-cached",
+		Model: "gpt-4o",
+		Usage: core.Usage{
+			PromptTokens:        1_000_000,
+			CompletionTokens:    0,
+			TotalTokens:         1_000_000,
+			PromptTokensDetails: &core.PromptTokensDetails{CachedTokens: 400_000},
+		},
+	}
+
+	entry := ExtractFromChatResponse(resp, "req-cached", "openai", "/v1/chat/completions", pricing)
+	if entry == nil || entry.InputCost == nil {
+		t.Fatalf("expected a priced entry, got %+v", entry)
+	}
+	// 600k uncached at $2.50/M + 400k cached at $1.25/M = 1.50 + 0.50
+	if math.Abs(*entry.InputCost-2.0) > 1e-9 {
+		t.Errorf("InputCost = %v, want 2.0", *entry.InputCost)
+	}
+}
+
 func TestExtractFromChatResponse_WithPricing(t *testing.T) {
 	pricing := &core.ModelPricing{
 		InputPerMtok:  new(3.0),  // $3 per million input tokens
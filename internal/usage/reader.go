@@ -310,6 +310,10 @@ type UsageLogResult struct {
 // InputTokens and TotalTokens are normalized prompt/total counts across providers:
 // cached prompt reads and cache writes are included even when the upstream provider
 // reports them outside the base input token count.
+//
+// EstimatedCostUSD is the billable cost priced from each entry's token counts
+// and the pricing table. Local response-cache hits cost nothing, so they are
+// excluded; nil means a provider-served entry had no pricing.
 type RequestUsageSummary struct {
 	Entries                   int      `json:"entries"`
 	InputTokens               int64    `json:"input_tokens"`
@@ -322,6 +326,9 @@ type RequestUsageSummary struct {
 	EstimatedCachedCharacters int64    `json:"estimated_cached_characters"`
 	RewriteTokensSaved        int64    `json:"rewrite_tokens_saved,omitempty"`
 	RewriteCostSaved          *float64 `json:"rewrite_cost_saved,omitempty"`
+	EstimatedCostUSD          *float64 `json:"estimated_cost_usd"`
+	ServedFromCache           bool     `json:"served_from_cache"`
+	CacheType                 string   `json:"cache_type,omitempty"`
 }
 
 // CacheOverviewSummary holds cached-only aggregate statistics over a time period.
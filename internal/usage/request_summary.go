@@ -54,7 +54,17 @@ func SummarizeRequestUsage(entries []UsageLogEntry) *RequestUsageSummary {
 	}
 
 	summary := &RequestUsageSummary{}
+	estimatedCost, costKnown := 0.0, true
 	for _, entry := range entries {
+		if cacheType := normalizeCacheType(entry.CacheType); cacheType != "" {
+			summary.ServedFromCache = true
+			summary.CacheType = cacheType
+		} else if entry.TotalCost != nil {
+			estimatedCost += *entry.TotalCost
+		} else {
+			costKnown = false
+		}
+
 		uncachedInput, cachedInput, cacheWriteInput := EntryInputSegments(entry)
 		totalInput := uncachedInput + cachedInput + cacheWriteInput
 
@@ -79,6 +89,9 @@ func SummarizeRequestUsage(entries []UsageLogEntry) *RequestUsageSummary {
 		summary.CachedInputRatio = float64(summary.CachedInputTokens) / float64(summary.InputTokens)
 	}
 	summary.EstimatedCachedCharacters = summary.CachedInputTokens * estimatedCharactersPerToken
+	if costKnown {
+		summary.EstimatedCostUSD = &estimatedCost
+	}
 
 	return summary
 }
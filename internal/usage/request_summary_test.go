@@ -1,6 +1,11 @@
 package usage
 
-import "testing"
+import (
+	"math"
+	"testing"
+
+	"github.com/enterpilot/gomodel/internal/core"
+)
 
 func TestSummarizeRequestUsage_OpenAICompatibleCachedTokens(t *testing.T) {
 	summary := SummarizeRequestUsage([]UsageLogEntry{
@@ -170,3 +175,56 @@ func TestSummarizeUsageByRequestID(t *testing.T) {
 		t.Fatalf("summaries[req-2].TotalTokens = %d, want 30", summaries["req-2"].TotalTokens)
 	}
 }
+
+func TestSummarizeRequestUsage_EstimatedCostFromPricing(t *testing.T) {
+	inputRate, outputRate := 2.5, 10.0
+	pricing := &core.ModelPricing{Currency: "USD", InputPerMtok: &inputRate, OutputPerMtok: &outputRate}
+	resp := &core.ChatResponse{
+		Model: "gpt-4o",
+		Usage: core.Usage{PromptTokens: 1200, CompletionTokens: 300, TotalTokens: 1500},
+	}
+
+	logEntry := func(entry *UsageEntry) UsageLogEntry {
+		return UsageLogEntry{
+			Provider:     entry.Provider,
+			CacheType:    entry.CacheType,
+			InputTokens:  entry.InputTokens,
+			OutputTokens: entry.OutputTokens,
+			TotalCost:    entry.TotalCost,
+			RawData:      entry.RawData,
+		}
+	}
+	served := logEntry(ExtractFromChatResponse(resp, "req-1", "openai", "/v1/chat/completions", pricing))
+	// 1200 input tokens at $2.50/Mtok plus 300 output tokens at $10/Mtok.
+	wantCost := 1200*inputRate/1_000_000 + 300*outputRate/1_000_000
+
+	t.Run("provider served", func(t *testing.T) {
+		summary := SummarizeRequestUsage([]UsageLogEntry{served})
+		if summary.EstimatedCostUSD == nil || math.Abs(*summary.EstimatedCostUSD-wantCost) > 1e-12 {
+			t.Fatalf("EstimatedCostUSD = %v, want %v", summary.EstimatedCostUSD, wantCost)
+		}
+		if summary.ServedFromCache || summary.CacheType != "" {
+			t.Fatalf("ServedFromCache = %v, CacheType = %q, want uncached", summary.ServedFromCache, summary.CacheType)
+		}
+	})
+
+	t.Run("cache hit costs nothing", func(t *testing.T) {
+		body := []byte(`{"model":"gpt-4o","usage":{"prompt_tokens":1200,"completion_tokens":300,"total_tokens":1500}}`)
+		hit := logEntry(ExtractFromCachedResponseBody(body, "req-2", "gpt-4o", "openai", "/v1/chat/completions", CacheTypeSemantic, pricing))
+		summary := SummarizeRequestUsage([]UsageLogEntry{hit})
+		if summary.EstimatedCostUSD == nil || *summary.EstimatedCostUSD != 0 {
+			t.Fatalf("EstimatedCostUSD = %v, want 0", summary.EstimatedCostUSD)
+		}
+		if !summary.ServedFromCache || summary.CacheType != CacheTypeSemantic {
+			t.Fatalf("ServedFromCache = %v, CacheType = %q, want semantic hit", summary.ServedFromCache, summary.CacheType)
+		}
+	})
+
+	t.Run("unpriced entry leaves cost unknown", func(t *testing.T) {
+		unpriced := served
+		unpriced.TotalCost = nil
+		if summary := SummarizeRequestUsage([]UsageLogEntry{served, unpriced}); summary.EstimatedCostUSD != nil {
+			t.Fatalf("EstimatedCostUSD = %v, want nil", *summary.EstimatedCostUSD)
+		}
+	})
+}
@@ -21,6 +21,7 @@ type StreamUsageObserver struct {
 	userPath        string
 	labels          []string
 	rewriteSaved    int
+	upstreamID      string
 	closed          bool
 }
 
@@ -75,6 +76,15 @@ func (o *StreamUsageObserver) SetRewriteTokensSaved(tokensSaved int) {
 	o.rewriteSaved = tokensSaved
 }
 
+// SetUpstreamRequestID attaches the request ID reported by the provider so
+// the usage entry can be correlated with the provider's own logs.
+func (o *StreamUsageObserver) SetUpstreamRequestID(id string) {
+	if o == nil {
+		return
+	}
+	o.upstreamID = strings.TrimSpace(id)
+}
+
 // usageKeyLiteral gates WantsJSONEvent: extractUsageFromEvent can only produce
 // an entry from payloads carrying a "usage" member (top-level or nested under
 // "response"), so events without the literal never matter.
@@ -192,6 +202,7 @@ func (o *StreamUsageObserver) extractUsageFromEvent(chunk map[string]any) *Usage
 			pricing = pricingArgs[0]
 		}
 		ApplyRewriteSavings(entry, o.rewriteSaved, pricing)
+		ApplyUpstreamRequestID(entry, o.upstreamID)
 	}
 	return entry
 }
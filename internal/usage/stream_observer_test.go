@@ -713,3 +713,30 @@ data: [DONE]
 		t.Errorf("RewriteCostSaved = %v, want nil without pricing", *entries[0].RewriteCostSaved)
 	}
 }
+
+func TestStreamUsageObserverRecordsUpstreamRequestID(t *testing.T) {
+	streamData := `data: {"id":"chatcmpl-1","model":"gpt-4","choices":[],"usage":{"prompt_tokens":10,"completion_tokens":2,"total_tokens":12}}
+
+data: [DONE]
+
+`
+	logger := &trackingLogger{enabled: true}
+	observer := NewStreamUsageObserver(logger, "gpt-4", "openai", "req-1", "/v1/chat/completions", nil)
+	observer.SetUpstreamRequestID("req_upstream_1")
+
+	stream := streaming.NewObservedSSEStream(io.NopCloser(strings.NewReader(streamData)), observer)
+	if _, err := io.ReadAll(stream); err != nil {
+		t.Fatalf("ReadAll error: %v", err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	entries := logger.getEntries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if got := entries[0].RawData[RawKeyUpstreamRequestID]; got != "req_upstream_1" {
+		t.Errorf("RawData[%q] = %v, want req_upstream_1", RawKeyUpstreamRequestID, got)
+	}
+}
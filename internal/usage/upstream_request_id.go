@@ -0,0 +1,17 @@
+package usage
+
+// RawKeyUpstreamRequestID is the RawData key holding the request ID the
+// provider reported for the upstream call.
+const RawKeyUpstreamRequestID = "upstream_request_id"
+
+// ApplyUpstreamRequestID records the provider-reported request ID on entry.
+// Empty IDs leave the entry untouched.
+func ApplyUpstreamRequestID(entry *UsageEntry, id string) {
+	if entry == nil || id == "" {
+		return
+	}
+	if entry.RawData == nil {
+		entry.RawData = make(map[string]any, 1)
+	}
+	entry.RawData[RawKeyUpstreamRequestID] = id
+}
@@ -21,8 +21,12 @@ func (s *Service) EffectiveState(selector core.ModelSelector) EffectiveState {
 	return s.snapshot().effectiveState(selector)
 }
 
-// AllowsModel reports whether selector is available for the effective request user path.
+// AllowsModel reports whether selector is available for the effective request
+// user path and the authenticated auth key.
 func (s *Service) AllowsModel(ctx context.Context, selector core.ModelSelector) bool {
+	if !core.AuthKeyAllowsModel(ctx, selector) {
+		return false
+	}
 	state := s.EffectiveState(selector)
 	if !state.Enabled {
 		return false
@@ -35,6 +39,13 @@ func (s *Service) AllowsModel(ctx context.Context, selector core.ModelSelector)
 
 // ValidateModelAccess returns a typed request error when selector is not available.
 func (s *Service) ValidateModelAccess(ctx context.Context, selector core.ModelSelector) error {
+	if !core.AuthKeyAllowsModel(ctx, selector) {
+		return core.NewInvalidRequestErrorWithStatus(
+			http.StatusBadRequest,
+			"requested model is not available for this API key",
+			nil,
+		).WithCode("model_access_denied")
+	}
 	state := s.EffectiveState(selector)
 	if !state.Enabled {
 		return core.NewInvalidRequestErrorWithStatus(
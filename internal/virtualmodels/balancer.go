@@ -1,6 +1,7 @@
 package virtualmodels
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 
@@ -63,6 +64,11 @@ func (s *Service) balancedResolution(entry redirectEntry) (core.ModelSelector, b
 	switch normalizeStrategy(entry.strategy) {
 	case StrategyCost:
 		return s.cheapestTarget(pool).selector, true
+	case StrategyRace:
+		// The gateway fans raced requests out to every target (see RaceTargets);
+		// the resolved selector is the first available one, which also serves
+		// requests that cannot be raced.
+		return pool[0].selector, true
 	default: // StrategyRoundRobin
 		index := weightedIndex(pool, s.balancer.next(entry.vm.Source))
 		return pool[index].selector, true
@@ -162,3 +168,26 @@ func (s *Service) targetCost(target resolvedTarget) (float64, bool) {
 	}
 	return cost, true
 }
+
+// RaceTargets returns the concrete targets a race redirect fans a request out
+// to, honoring user_paths scoping and rate-limit capacity. It returns nil when
+// the requested model is not a race redirect or fewer than two targets are
+// currently available, in which case the request runs on its resolved target.
+func (s *Service) RaceTargets(ctx context.Context, requested core.RequestedModelSelector) []core.ModelSelector {
+	if s == nil || requested.ExplicitProvider {
+		return nil
+	}
+	entry, ok := s.snapshot().findRedirect(requested.Model, core.UserPathFromContext(ctx), true)
+	if !ok || normalizeStrategy(entry.strategy) != StrategyRace {
+		return nil
+	}
+	pool := s.targetsWithCapacity(entry.supportedTargets(s.catalog))
+	if len(pool) < 2 {
+		return nil
+	}
+	selectors := make([]core.ModelSelector, len(pool))
+	for i, target := range pool {
+		selectors[i] = target.selector
+	}
+	return selectors
+}
@@ -355,3 +355,60 @@ func TestBalancer_CostSkipsSaturatedCheapestTarget(t *testing.T) {
 		}
 	}
 }
+
+func TestBalancer_RaceResolvesFirstTargetAndExpandsAllTargets(t *testing.T) {
+	t.Parallel()
+	svc := newBalancingService(t)
+	svc.SetTargetCapacity(func(qualified string) bool { return qualified != "groq/llama" })
+	if err := svc.Upsert(context.Background(), VirtualModel{
+		Source:   "council",
+		Strategy: StrategyRace,
+		Targets: []Target{
+			{Provider: "openai", Model: "gpt-4o"},
+			{Provider: "anthropic", Model: "claude"},
+			{Provider: "groq", Model: "llama"},
+		},
+		Enabled: true,
+	}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	for i, got := range resolvedModels(t, svc, "council", 3) {
+		if got != "openai/gpt-4o" {
+			t.Fatalf("resolution[%d] = %q, want first race target (openai/gpt-4o)", i, got)
+		}
+	}
+
+	targets := svc.RaceTargets(context.Background(), core.NewRequestedModelSelector("council", ""))
+	got := make([]string, 0, len(targets))
+	for _, target := range targets {
+		got = append(got, target.QualifiedModel())
+	}
+	if len(got) != 2 || got[0] != "openai/gpt-4o" || got[1] != "anthropic/claude" {
+		t.Fatalf("RaceTargets() = %v, want the targets with capacity in declared order", got)
+	}
+
+	if targets := svc.RaceTargets(context.Background(), core.NewRequestedModelSelector("openai/gpt-4o", "")); targets != nil {
+		t.Fatalf("RaceTargets() for a concrete model = %v, want nil", targets)
+	}
+}
+
+func TestBalancer_RaceTargetsIgnoresOtherStrategies(t *testing.T) {
+	t.Parallel()
+	svc := newBalancingService(t)
+	if err := svc.Upsert(context.Background(), VirtualModel{
+		Source:   "smart",
+		Strategy: StrategyRoundRobin,
+		Targets: []Target{
+			{Provider: "openai", Model: "gpt-4o"},
+			{Provider: "anthropic", Model: "claude"},
+		},
+		Enabled: true,
+	}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	if targets := svc.RaceTargets(context.Background(), core.NewRequestedModelSelector("smart", "")); targets != nil {
+		t.Fatalf("RaceTargets() for a round-robin redirect = %v, want nil", targets)
+	}
+}
@@ -114,3 +114,36 @@ func TestRewriteChatRequest_PreservesResolvedProvider(t *testing.T) {
 		t.Fatal("rewriteChatRequest(missing model) error = nil, want error")
 	}
 }
+
+// Pinning a stable name to a dated snapshot: the redirect source shadows the
+// concrete model of the same name, and the snapshot ID is what goes upstream.
+func TestChatExecutor_RedirectPinsStableNameToSnapshot(t *testing.T) {
+	t.Parallel()
+	catalog := testCatalog()
+	catalog.supported["openai/gpt-4o-2024-11-20"] = core.Model{ID: "openai/gpt-4o-2024-11-20", Object: "model", OwnedBy: "openai"}
+	svc, err := NewService(newSQLiteVMStore(t), catalog, true)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := svc.Upsert(ctx, VirtualModel{
+		Source:  "gpt-4o",
+		Targets: []Target{{Provider: "openai", Model: "gpt-4o-2024-11-20"}},
+		Enabled: true,
+	}); err != nil {
+		t.Fatalf("Upsert(redirect) error = %v", err)
+	}
+
+	inner := &chatExecMock{
+		supported: map[string]bool{"openai/gpt-4o": true, "openai/gpt-4o-2024-11-20": true},
+		resp:      &core.ChatResponse{ID: "chatcmpl_3"},
+	}
+	executor := NewChatExecutor(inner, svc)
+
+	if _, err := executor.ChatCompletion(ctx, &core.ChatRequest{Model: "gpt-4o"}); err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+	if inner.captured == nil || inner.captured.Provider != "openai" || inner.captured.Model != "gpt-4o-2024-11-20" {
+		t.Fatalf("forwarded request = %+v, want openai/gpt-4o-2024-11-20", inner.captured)
+	}
+}
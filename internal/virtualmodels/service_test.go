@@ -110,6 +110,23 @@ func TestService_EnabledPolicyEmptyUserPathsAllowsAll(t *testing.T) {
 	}
 }
 
+func TestService_AuthKeyAllowedModelsGateAccess(t *testing.T) {
+	t.Parallel()
+	svc := newTestService(t)
+	ctx := core.WithAuthKeyAllowedModels(context.Background(), []string{"openai/gpt-4o"})
+
+	if err := svc.ValidateModelAccess(ctx, core.ModelSelector{Provider: "openai", Model: "gpt-4o"}); err != nil {
+		t.Fatalf("ValidateModelAccess(allowed) error = %v, want allowed", err)
+	}
+	denied := core.ModelSelector{Provider: "openai", Model: "gpt-4o-mini"}
+	if svc.AllowsModel(ctx, denied) {
+		t.Fatalf("AllowsModel(not listed) = true, want false")
+	}
+	if err := svc.ValidateModelAccess(ctx, denied); err == nil {
+		t.Fatalf("ValidateModelAccess(not listed) error = nil, want denied")
+	}
+}
+
 func TestService_RejectsCrossKindClobber(t *testing.T) {
 	t.Parallel()
 	svc := newTestService(t)
@@ -66,6 +66,11 @@ const (
 	// StrategyCost always routes to the cheapest currently-available target, ranked
 	// by the model registry's per-token pricing.
 	StrategyCost = "cost"
+	// StrategyRace sends each chat completion or Responses request to every
+	// available target concurrently and returns the first successful response,
+	// cancelling the rest. Requests the gateway cannot race (streaming, other
+	// operations) run on the first available target.
+	StrategyRace = "race"
 )
 
 // normalizeStrategy lower-cases and defaults a strategy string. An empty value
@@ -81,7 +86,7 @@ func normalizeStrategy(strategy string) string {
 // validStrategy reports whether strategy names a supported load-balancing mode.
 func validStrategy(strategy string) bool {
 	switch normalizeStrategy(strategy) {
-	case StrategyRoundRobin, StrategyCost:
+	case StrategyRoundRobin, StrategyCost, StrategyRace:
 		return true
 	default:
 		return false
@@ -0,0 +1,55 @@
+package run
+
+import (
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// dotenvFile loads a .env file into the process environment without
+// overriding variables the process was started with, and can re-apply the
+// file after it changes so config reloads see edited values.
+type dotenvFile struct {
+	path string
+	// external holds the variables present before the file was first
+	// applied; they always win over the file.
+	external map[string]struct{}
+	// applied holds the variables last set from the file, so keys deleted
+	// from it can be unset on reload.
+	applied map[string]struct{}
+}
+
+func loadDotenv(path string) *dotenvFile {
+	f := &dotenvFile{path: path, external: make(map[string]struct{})}
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+		f.external[key] = struct{}{}
+	}
+	f.reload()
+	return f
+}
+
+// reload re-reads the file and syncs the variables it owns. A missing or
+// malformed file is treated as empty, matching the startup behaviour of
+// ignoring an unusable .env.
+func (f *dotenvFile) reload() {
+	values, err := godotenv.Read(f.path)
+	if err != nil {
+		values = nil
+	}
+	applied := make(map[string]struct{}, len(values))
+	for key, value := range values {
+		if _, ok := f.external[key]; ok {
+			continue
+		}
+		_ = os.Setenv(key, value)
+		applied[key] = struct{}{}
+	}
+	for key := range f.applied {
+		if _, ok := applied[key]; !ok {
+			_ = os.Unsetenv(key)
+		}
+	}
+	f.applied = applied
+}
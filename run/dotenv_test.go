@@ -0,0 +1,41 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDotenvFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	writeDotenv := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write .env: %v", err)
+		}
+	}
+	t.Setenv("GOMODEL_TEST_DOTENV_EXTERNAL", "from-process")
+	t.Setenv("GOMODEL_TEST_DOTENV_KEPT", "")
+	t.Setenv("GOMODEL_TEST_DOTENV_DROPPED", "")
+	_ = os.Unsetenv("GOMODEL_TEST_DOTENV_KEPT")
+	_ = os.Unsetenv("GOMODEL_TEST_DOTENV_DROPPED")
+
+	writeDotenv("GOMODEL_TEST_DOTENV_EXTERNAL=from-file\nGOMODEL_TEST_DOTENV_KEPT=one\nGOMODEL_TEST_DOTENV_DROPPED=x\n")
+	f := loadDotenv(path)
+	if got := os.Getenv("GOMODEL_TEST_DOTENV_KEPT"); got != "one" {
+		t.Fatalf("KEPT = %q after load, want one", got)
+	}
+
+	writeDotenv("GOMODEL_TEST_DOTENV_EXTERNAL=changed\nGOMODEL_TEST_DOTENV_KEPT=two\n")
+	f.reload()
+
+	if got := os.Getenv("GOMODEL_TEST_DOTENV_KEPT"); got != "two" {
+		t.Errorf("KEPT = %q after reload, want two", got)
+	}
+	if _, ok := os.LookupEnv("GOMODEL_TEST_DOTENV_DROPPED"); ok {
+		t.Error("DROPPED is still set after it was removed from .env")
+	}
+	if got := os.Getenv("GOMODEL_TEST_DOTENV_EXTERNAL"); got != "from-process" {
+		t.Errorf("EXTERNAL = %q, want the process value to win over .env", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/enterpilot/gomodel/internal/providers/bailian"
 	"github.com/enterpilot/gomodel/internal/providers/bedrock"
 	"github.com/enterpilot/gomodel/internal/providers/bedrockmantle"
+	"github.com/enterpilot/gomodel/internal/providers/cohere"
 	"github.com/enterpilot/gomodel/internal/providers/deepseek"
 	"github.com/enterpilot/gomodel/internal/providers/fireworks"
 	"github.com/enterpilot/gomodel/internal/providers/gemini"
@@ -17,6 +18,7 @@ import (
 	"github.com/enterpilot/gomodel/internal/providers/kimicode"
 	"github.com/enterpilot/gomodel/internal/providers/meta"
 	"github.com/enterpilot/gomodel/internal/providers/minimax"
+	"github.com/enterpilot/gomodel/internal/providers/mistral"
 	"github.com/enterpilot/gomodel/internal/providers/ollama"
 	"github.com/enterpilot/gomodel/internal/providers/openai"
 	"github.com/enterpilot/gomodel/internal/providers/opencodego"
@@ -46,6 +48,7 @@ func defaultProviderFactory(cfg *config.Config) *providers.ProviderFactory {
 	factory.Add(anthropic.Registration)
 	factory.Add(bedrock.Registration)
 	factory.Add(bedrockmantle.Registration)
+	factory.Add(cohere.Registration)
 	factory.Add(deepseek.Registration)
 	factory.Add(fireworks.Registration)
 	factory.Add(gemini.Registration)
@@ -55,6 +58,7 @@ func defaultProviderFactory(cfg *config.Config) *providers.ProviderFactory {
 	factory.Add(kimicode.Registration)
 	factory.Add(meta.Registration)
 	factory.Add(minimax.Registration)
+	factory.Add(mistral.Registration)
 	factory.Add(ollama.Registration)
 	factory.Add(opencodego.Registration)
 	factory.Add(vllm.Registration)
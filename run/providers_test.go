@@ -9,8 +9,8 @@ import (
 
 func TestDefaultProviderFactoryRegistersAllProviderTypes(t *testing.T) {
 	expected := []string{
-		"anthropic", "azure", "bailian", "bedrock", "bedrock-mantle", "deepseek", "fireworks",
-		"gemini", "groq", "kilo", "kimicode", "meta", "minimax", "ollama", "openai", "opencode_go",
+		"anthropic", "azure", "bailian", "bedrock", "bedrock-mantle", "cohere", "deepseek", "fireworks",
+		"gemini", "groq", "kilo", "kimicode", "meta", "minimax", "mistral", "ollama", "openai", "opencode_go",
 		"openrouter", "oracle", "vertex", "vllm", "xai", "xiaomi", "zai",
 	}
 
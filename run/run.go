@@ -24,8 +24,6 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/joho/godotenv"
-
 	"github.com/enterpilot/gomodel/config"
 	"github.com/enterpilot/gomodel/ext"
 	"github.com/enterpilot/gomodel/internal/app"
@@ -122,7 +120,7 @@ func Run(ctx context.Context, opts Options) error {
 		return nil
 	}
 
-	_ = godotenv.Load()
+	dotenv := loadDotenv(".env")
 
 	if cliOpts.Health {
 		if err := runHealthProbe(cliOpts.HealthTimeout); err != nil {
@@ -165,6 +163,12 @@ func Run(ctx context.Context, opts Options) error {
 	}
 	opts.ConfigureSwaggerDocs(result.Config.Server.BasePath)
 
+	watchInterval, err := config.ResolveWatchInterval()
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		return err
+	}
+
 	application, err := app.New(ctx, app.Config{
 		AppConfig:  result,
 		Factory:    defaultProviderFactory(result.Config),
@@ -187,6 +191,20 @@ func Run(ctx context.Context, opts Options) error {
 		}
 	}()
 
+	if watchInterval > 0 {
+		slog.Info("watching config for provider changes", "interval", watchInterval.String())
+		stopWatch := config.Watch(signalCtx, config.WatchOptions{
+			Interval:   watchInterval,
+			ExtraPaths: []string{dotenv.path},
+			BeforeLoad: dotenv.reload,
+		}, func(result *config.LoadResult) {
+			if err := application.ReloadProviders(signalCtx, result); err != nil {
+				slog.Error("provider reload failed; keeping current providers", "error", err)
+			}
+		})
+		defer stopWatch()
+	}
+
 	addr := ":" + result.Config.Server.Port
 	if err := startApplication(application, addr); err != nil {
 		slog.Error("application failed", "error", err)
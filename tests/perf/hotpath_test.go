@@ -201,6 +201,30 @@ func BenchmarkGatewayHotPathChatCompletion(b *testing.B) {
 	}
 }
 
+// BenchmarkGatewayHotPathChatCompletionLenientShape runs the bare hot path with
+// LenientRequestShape on, so the shape middleware buffers and scans each body
+// before routing. The default (off) installs no middleware and is covered by
+// BenchmarkGatewayHotPathChatCompletion.
+func BenchmarkGatewayHotPathChatCompletionLenientShape(b *testing.B) {
+	srv := server.New(benchProvider{}, &server.Config{LogOnlyModelInteractions: true, LenientRequestShape: true})
+	body := []byte(sampleChatRequest)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			b.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	}
+}
+
 // routedCatalogSize is a representative multi-provider catalog size. Real
 // deployments aggregating several upstreams routinely exceed this.
 const routedCatalogSize = 256
@@ -389,8 +413,16 @@ func TestHotPathPerfGuard(t *testing.T) {
 		{
 			name:      "gateway_chat_completion_hot_path",
 			bench:     BenchmarkGatewayHotPathChatCompletion,
-			maxAllocs: 112,   // baseline 110 (incl. +1 strings.Clone that unpins the body from RouteHints)
-			maxBytes:  14080, // baseline ~13.5 KB (incl. per-attempt response body/header capture fields)
+			maxAllocs: 114,   // baseline 112 (incl. strings.Clone that unpins the body from RouteHints, upstream request ID capture)
+			maxBytes:  14592, // baseline ~14.1 KB (incl. per-attempt response body/header capture fields)
+		},
+		{
+			// Opt-in body-shape rerouting: the only extra cost over the bare
+			// path is buffering the body and one gjson scan for messages/input.
+			name:      "gateway_chat_completion_hot_path_lenient_shape",
+			bench:     BenchmarkGatewayHotPathChatCompletionLenientShape,
+			maxAllocs: 122,   // baseline 120 (bare path +8 for the buffered body and rerouting check)
+			maxBytes:  16000, // baseline ~14.9 KB
 		},
 		{
 			// Production-shaped path: request resolves through a real Router +
@@ -400,8 +432,8 @@ func TestHotPathPerfGuard(t *testing.T) {
 			// full catalog several times per request) would blow these limits.
 			name:      "gateway_chat_completion_hot_path_routed",
 			bench:     BenchmarkGatewayHotPathChatCompletionRouted,
-			maxAllocs: 130,   // baseline 128 (incl. +1 strings.Clone that unpins the body from RouteHints)
-			maxBytes:  14656, // baseline ~14.0 KB
+			maxAllocs: 134,   // baseline 132 (incl. strings.Clone that unpins the body from RouteHints, upstream request ID capture)
+			maxBytes:  15104, // baseline ~14.7 KB
 		},
 		{
 			// Typed chunk decoding + reused read buffer keep this converter at a